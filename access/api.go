@@ -28,6 +28,8 @@ type API interface {
 	SendTransaction(ctx context.Context, tx *flow.TransactionBody) error
 	GetTransaction(ctx context.Context, id flow.Identifier) (*flow.TransactionBody, error)
 	GetTransactionResult(ctx context.Context, id flow.Identifier) (*TransactionResult, error)
+	SubscribeTransactionStatus(ctx context.Context, id flow.Identifier, handler TransactionResultHandler) error
+	GetTransactionsByAccount(ctx context.Context, address flow.Address, startHeight, endHeight uint64, pagination Pagination) ([]AccountTransaction, error)
 
 	GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error)
 	GetAccountAtLatestBlock(ctx context.Context, address flow.Address) (*flow.Account, error)
@@ -39,14 +41,34 @@ type API interface {
 
 	GetEventsForHeightRange(ctx context.Context, eventType string, startHeight, endHeight uint64) ([]flow.BlockEvents, error)
 	GetEventsForBlockIDs(ctx context.Context, eventType string, blockIDs []flow.Identifier) ([]flow.BlockEvents, error)
+
+	SubscribeBlockHeaders(ctx context.Context, startHeight uint64, handler HeaderHandler) error
+	SubscribeEvents(ctx context.Context, eventType string, startHeight uint64, handler BlockEventsHandler) error
+
+	GetSlashingEvidence(ctx context.Context, offenderID flow.Identifier) ([]*flow.SlashingEvidence, error)
 }
 
+// HeaderHandler is called by a block header subscription for each finalized
+// block header, in order of increasing height. Returning an error terminates
+// the subscription.
+type HeaderHandler func(header *flow.Header) error
+
+// BlockEventsHandler is called by an event subscription with the matching
+// events of each sealed block, in order of increasing height. Returning an
+// error terminates the subscription.
+type BlockEventsHandler func(events flow.BlockEvents) error
+
+// TransactionResultHandler is called by a transaction status subscription every time the status of
+// the transaction changes. Returning an error terminates the subscription.
+type TransactionResultHandler func(result *TransactionResult) error
+
 // TODO: Combine this with flow.TransactionResult?
 type TransactionResult struct {
-	Status       flow.TransactionStatus
-	StatusCode   uint
-	Events       []flow.Event
-	ErrorMessage string
+	Status          flow.TransactionStatus
+	StatusCode      uint
+	Events          []flow.Event
+	ErrorMessage    string
+	ComputationUsed uint64
 }
 
 func TransactionResultToMessage(result *TransactionResult) *access.TransactionResultResponse {
@@ -58,6 +80,19 @@ func TransactionResultToMessage(result *TransactionResult) *access.TransactionRe
 	}
 }
 
+// Pagination selects a page of the results of a query.
+type Pagination struct {
+	Offset uint // number of results to skip
+	Limit  uint // maximum number of results to return, 0 for the default page size
+}
+
+// AccountTransaction is a transaction that involves an account as payer, proposer or authorizer,
+// together with the height of the block that included it.
+type AccountTransaction struct {
+	Transaction *flow.TransactionBody
+	BlockHeight uint64
+}
+
 // NetworkParameters contains the network-wide parameters for the Flow blockchain.
 type NetworkParameters struct {
 	ChainID flow.ChainID
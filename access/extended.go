@@ -0,0 +1,586 @@
+package access
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// The endpoints below are not (yet) part of the Flow protobuf definitions, so the gRPC service and its
+// messages are declared by hand. The messages carry protobuf struct tags, so they are encoded just like
+// generated messages, and other clients can generate compatible code from the following definition:
+//
+//   service ExtendedAccessAPI {
+//     rpc GetExtendedTransactionResult(GetTransactionRequest) returns (ExtendedTransactionResultResponse);
+//     rpc GetSlashingEvidence(GetSlashingEvidenceRequest) returns (GetSlashingEvidenceResponse);
+//     rpc GetTransactionsByAccount(GetTransactionsByAccountRequest) returns (GetTransactionsByAccountResponse);
+//   }
+//
+//   message ExtendedTransactionResultResponse {
+//     entities.TransactionStatus status = 1;
+//     uint32 status_code = 2;
+//     string error_message = 3;
+//     repeated entities.Event events = 4;
+//     uint64 computation_used = 5;
+//   }
+//
+//   message GetSlashingEvidenceRequest {
+//     bytes offender_id = 1;
+//   }
+//
+//   message GetSlashingEvidenceResponse {
+//     repeated SlashingEvidence evidence = 1;
+//   }
+//
+//   message SlashingEvidence {
+//     uint32 offence = 1;
+//     bytes offender_id = 2;
+//     uint64 view = 3;
+//     repeated SignedVote votes = 4;
+//     repeated SignedHeader proposals = 5;
+//   }
+//
+//   message SignedVote {
+//     uint64 view = 1;
+//     bytes block_id = 2;
+//     bytes signer_id = 3;
+//     bytes sig_data = 4;
+//   }
+//
+//   message SignedHeader {
+//     string chain_id = 1;
+//     bytes parent_id = 2;
+//     uint64 height = 3;
+//     bytes payload_hash = 4;
+//     uint64 timestamp = 5;
+//     uint64 view = 6;
+//     repeated bytes parent_voter_ids = 7;
+//     bytes parent_voter_sig = 8;
+//     bytes proposer_id = 9;
+//     bytes proposer_sig = 10;
+//   }
+//
+//   message GetTransactionsByAccountRequest {
+//     bytes address = 1;
+//     uint64 start_height = 2;
+//     uint64 end_height = 3;
+//     uint64 offset = 4;
+//     uint64 limit = 5;
+//   }
+//
+//   message GetTransactionsByAccountResponse {
+//     repeated AccountTransaction transactions = 1;
+//   }
+//
+//   message AccountTransaction {
+//     entities.Transaction transaction = 1;
+//     uint64 block_height = 2;
+//   }
+//
+// The first fields of ExtendedTransactionResultResponse match TransactionResultResponse, so clients that
+// only know the latter can decode the response as well. SignedHeader holds all fields of a block header,
+// including the signatures, as the evidence has to be verifiable; its timestamp is in Unix nanoseconds.
+//
+// The slashing evidence served by a node is the evidence it detected itself. Access nodes only follow
+// the finalized chain, so they detect double proposals, while double votes are only detected by the
+// consensus nodes collecting the votes. The evidence of a consensus node is exported from its database
+// with the export-slashing-evidence util command.
+
+const extendedServiceName = "flow.access.ExtendedAccessAPI"
+
+// ExtendedTransactionResultResponse is the result of a transaction, including the computation it used.
+type ExtendedTransactionResultResponse struct {
+	Status          entities.TransactionStatus `protobuf:"varint,1,opt,name=status,proto3,enum=flow.entities.TransactionStatus" json:"status,omitempty"`
+	StatusCode      uint32                     `protobuf:"varint,2,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	ErrorMessage    string                     `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	Events          []*entities.Event          `protobuf:"bytes,4,rep,name=events,proto3" json:"events,omitempty"`
+	ComputationUsed uint64                     `protobuf:"varint,5,opt,name=computation_used,json=computationUsed,proto3" json:"computation_used,omitempty"`
+}
+
+func (m *ExtendedTransactionResultResponse) Reset()         { *m = ExtendedTransactionResultResponse{} }
+func (m *ExtendedTransactionResultResponse) String() string { return proto.CompactTextString(m) }
+func (*ExtendedTransactionResultResponse) ProtoMessage()    {}
+
+func (m *ExtendedTransactionResultResponse) GetComputationUsed() uint64 {
+	if m != nil {
+		return m.ComputationUsed
+	}
+	return 0
+}
+
+// TransactionResultToExtendedMessage converts a transaction result to the extended response message.
+func TransactionResultToExtendedMessage(result *TransactionResult) *ExtendedTransactionResultResponse {
+	return &ExtendedTransactionResultResponse{
+		Status:          entities.TransactionStatus(result.Status),
+		StatusCode:      uint32(result.StatusCode),
+		ErrorMessage:    result.ErrorMessage,
+		Events:          convert.EventsToMessages(result.Events),
+		ComputationUsed: result.ComputationUsed,
+	}
+}
+
+// GetSlashingEvidenceRequest requests the slashing evidence against a node, or against all nodes if the
+// offender ID is empty.
+type GetSlashingEvidenceRequest struct {
+	OffenderId []byte `protobuf:"bytes,1,opt,name=offender_id,json=offenderId,proto3" json:"offender_id,omitempty"`
+}
+
+func (m *GetSlashingEvidenceRequest) Reset()         { *m = GetSlashingEvidenceRequest{} }
+func (m *GetSlashingEvidenceRequest) String() string { return proto.CompactTextString(m) }
+func (*GetSlashingEvidenceRequest) ProtoMessage()    {}
+
+func (m *GetSlashingEvidenceRequest) GetOffenderId() []byte {
+	if m != nil {
+		return m.OffenderId
+	}
+	return nil
+}
+
+// GetSlashingEvidenceResponse holds the requested slashing evidence, ordered by view.
+type GetSlashingEvidenceResponse struct {
+	Evidence []*SlashingEvidence `protobuf:"bytes,1,rep,name=evidence,proto3" json:"evidence,omitempty"`
+}
+
+func (m *GetSlashingEvidenceResponse) Reset()         { *m = GetSlashingEvidenceResponse{} }
+func (m *GetSlashingEvidenceResponse) String() string { return proto.CompactTextString(m) }
+func (*GetSlashingEvidenceResponse) ProtoMessage()    {}
+
+func (m *GetSlashingEvidenceResponse) GetEvidence() []*SlashingEvidence {
+	if m != nil {
+		return m.Evidence
+	}
+	return nil
+}
+
+// SlashingEvidence is the evidence of a slashable offence.
+type SlashingEvidence struct {
+	Offence    uint32          `protobuf:"varint,1,opt,name=offence,proto3" json:"offence,omitempty"`
+	OffenderId []byte          `protobuf:"bytes,2,opt,name=offender_id,json=offenderId,proto3" json:"offender_id,omitempty"`
+	View       uint64          `protobuf:"varint,3,opt,name=view,proto3" json:"view,omitempty"`
+	Votes      []*SignedVote   `protobuf:"bytes,4,rep,name=votes,proto3" json:"votes,omitempty"`
+	Proposals  []*SignedHeader `protobuf:"bytes,5,rep,name=proposals,proto3" json:"proposals,omitempty"`
+}
+
+func (m *SlashingEvidence) Reset()         { *m = SlashingEvidence{} }
+func (m *SlashingEvidence) String() string { return proto.CompactTextString(m) }
+func (*SlashingEvidence) ProtoMessage()    {}
+
+// SignedVote is a HotStuff vote as sent by the voter.
+type SignedVote struct {
+	View     uint64 `protobuf:"varint,1,opt,name=view,proto3" json:"view,omitempty"`
+	BlockId  []byte `protobuf:"bytes,2,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	SignerId []byte `protobuf:"bytes,3,opt,name=signer_id,json=signerId,proto3" json:"signer_id,omitempty"`
+	SigData  []byte `protobuf:"bytes,4,opt,name=sig_data,json=sigData,proto3" json:"sig_data,omitempty"`
+}
+
+func (m *SignedVote) Reset()         { *m = SignedVote{} }
+func (m *SignedVote) String() string { return proto.CompactTextString(m) }
+func (*SignedVote) ProtoMessage()    {}
+
+// SignedHeader is a full block header, including the signatures of the proposer and the parent voters.
+type SignedHeader struct {
+	ChainId        string   `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	ParentId       []byte   `protobuf:"bytes,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	Height         uint64   `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	PayloadHash    []byte   `protobuf:"bytes,4,opt,name=payload_hash,json=payloadHash,proto3" json:"payload_hash,omitempty"`
+	Timestamp      uint64   `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	View           uint64   `protobuf:"varint,6,opt,name=view,proto3" json:"view,omitempty"`
+	ParentVoterIds [][]byte `protobuf:"bytes,7,rep,name=parent_voter_ids,json=parentVoterIds,proto3" json:"parent_voter_ids,omitempty"`
+	ParentVoterSig []byte   `protobuf:"bytes,8,opt,name=parent_voter_sig,json=parentVoterSig,proto3" json:"parent_voter_sig,omitempty"`
+	ProposerId     []byte   `protobuf:"bytes,9,opt,name=proposer_id,json=proposerId,proto3" json:"proposer_id,omitempty"`
+	ProposerSig    []byte   `protobuf:"bytes,10,opt,name=proposer_sig,json=proposerSig,proto3" json:"proposer_sig,omitempty"`
+}
+
+func (m *SignedHeader) Reset()         { *m = SignedHeader{} }
+func (m *SignedHeader) String() string { return proto.CompactTextString(m) }
+func (*SignedHeader) ProtoMessage()    {}
+
+// SlashingEvidenceToMessage converts slashing evidence to its message.
+func SlashingEvidenceToMessage(e *flow.SlashingEvidence) *SlashingEvidence {
+	votes := make([]*SignedVote, 0, len(e.Votes))
+	for _, vote := range e.Votes {
+		votes = append(votes, &SignedVote{
+			View:     vote.View,
+			BlockId:  convert.IdentifierToMessage(vote.BlockID),
+			SignerId: convert.IdentifierToMessage(vote.SignerID),
+			SigData:  vote.SigData,
+		})
+	}
+
+	proposals := make([]*SignedHeader, 0, len(e.Proposals))
+	for _, header := range e.Proposals {
+		proposals = append(proposals, &SignedHeader{
+			ChainId:        string(header.ChainID),
+			ParentId:       convert.IdentifierToMessage(header.ParentID),
+			Height:         header.Height,
+			PayloadHash:    convert.IdentifierToMessage(header.PayloadHash),
+			Timestamp:      uint64(header.Timestamp.UnixNano()),
+			View:           header.View,
+			ParentVoterIds: convert.IdentifiersToMessages(header.ParentVoterIDs),
+			ParentVoterSig: header.ParentVoterSig,
+			ProposerId:     convert.IdentifierToMessage(header.ProposerID),
+			ProposerSig:    header.ProposerSig,
+		})
+	}
+
+	return &SlashingEvidence{
+		Offence:    uint32(e.Offence),
+		OffenderId: convert.IdentifierToMessage(e.OffenderID),
+		View:       e.View,
+		Votes:      votes,
+		Proposals:  proposals,
+	}
+}
+
+// MessageToSlashingEvidence converts a message back to slashing evidence.
+func MessageToSlashingEvidence(m *SlashingEvidence) *flow.SlashingEvidence {
+	var votes []*flow.SignedVote
+	for _, vote := range m.Votes {
+		votes = append(votes, &flow.SignedVote{
+			View:     vote.View,
+			BlockID:  convert.MessageToIdentifier(vote.BlockId),
+			SignerID: convert.MessageToIdentifier(vote.SignerId),
+			SigData:  vote.SigData,
+		})
+	}
+
+	var proposals []*flow.Header
+	for _, header := range m.Proposals {
+		proposals = append(proposals, &flow.Header{
+			ChainID:        flow.ChainID(header.ChainId),
+			ParentID:       convert.MessageToIdentifier(header.ParentId),
+			Height:         header.Height,
+			PayloadHash:    convert.MessageToIdentifier(header.PayloadHash),
+			Timestamp:      time.Unix(0, int64(header.Timestamp)).UTC(),
+			View:           header.View,
+			ParentVoterIDs: convert.MessagesToIdentifiers(header.ParentVoterIds),
+			ParentVoterSig: header.ParentVoterSig,
+			ProposerID:     convert.MessageToIdentifier(header.ProposerId),
+			ProposerSig:    header.ProposerSig,
+		})
+	}
+
+	return &flow.SlashingEvidence{
+		Offence:    flow.Offence(m.Offence),
+		OffenderID: convert.MessageToIdentifier(m.OffenderId),
+		View:       m.View,
+		Votes:      votes,
+		Proposals:  proposals,
+	}
+}
+
+// GetTransactionsByAccountRequest requests a page of the transactions that involve an account within a
+// range of block heights (inclusive). A limit of 0 requests the default page size.
+type GetTransactionsByAccountRequest struct {
+	Address     []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	StartHeight uint64 `protobuf:"varint,2,opt,name=start_height,json=startHeight,proto3" json:"start_height,omitempty"`
+	EndHeight   uint64 `protobuf:"varint,3,opt,name=end_height,json=endHeight,proto3" json:"end_height,omitempty"`
+	Offset      uint64 `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit       uint64 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (m *GetTransactionsByAccountRequest) Reset()         { *m = GetTransactionsByAccountRequest{} }
+func (m *GetTransactionsByAccountRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTransactionsByAccountRequest) ProtoMessage()    {}
+
+func (m *GetTransactionsByAccountRequest) GetAddress() []byte {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
+func (m *GetTransactionsByAccountRequest) GetStartHeight() uint64 {
+	if m != nil {
+		return m.StartHeight
+	}
+	return 0
+}
+
+func (m *GetTransactionsByAccountRequest) GetEndHeight() uint64 {
+	if m != nil {
+		return m.EndHeight
+	}
+	return 0
+}
+
+func (m *GetTransactionsByAccountRequest) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *GetTransactionsByAccountRequest) GetLimit() uint64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+// GetTransactionsByAccountResponse holds a page of the transactions that involve an account, ordered by
+// block height.
+type GetTransactionsByAccountResponse struct {
+	Transactions []*AccountTransactionMessage `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+}
+
+func (m *GetTransactionsByAccountResponse) Reset()         { *m = GetTransactionsByAccountResponse{} }
+func (m *GetTransactionsByAccountResponse) String() string { return proto.CompactTextString(m) }
+func (*GetTransactionsByAccountResponse) ProtoMessage()    {}
+
+func (m *GetTransactionsByAccountResponse) GetTransactions() []*AccountTransactionMessage {
+	if m != nil {
+		return m.Transactions
+	}
+	return nil
+}
+
+// AccountTransactionMessage is the AccountTransaction message, a transaction together with the height of
+// the block that included it.
+type AccountTransactionMessage struct {
+	Transaction *entities.Transaction `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	BlockHeight uint64                `protobuf:"varint,2,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+}
+
+func (m *AccountTransactionMessage) Reset()         { *m = AccountTransactionMessage{} }
+func (m *AccountTransactionMessage) String() string { return proto.CompactTextString(m) }
+func (*AccountTransactionMessage) ProtoMessage()    {}
+
+// ExtendedAccessAPIServer is the server API for the extension of the Access API.
+type ExtendedAccessAPIServer interface {
+	GetExtendedTransactionResult(context.Context, *access.GetTransactionRequest) (*ExtendedTransactionResultResponse, error)
+	GetSlashingEvidence(context.Context, *GetSlashingEvidenceRequest) (*GetSlashingEvidenceResponse, error)
+	GetTransactionsByAccount(context.Context, *GetTransactionsByAccountRequest) (*GetTransactionsByAccountResponse, error)
+}
+
+// RegisterExtendedAccessAPIServer registers the extension of the Access API on the given gRPC server.
+func RegisterExtendedAccessAPIServer(s *grpc.Server, srv ExtendedAccessAPIServer) {
+	s.RegisterService(&extendedServiceDesc, srv)
+}
+
+var extendedServiceDesc = grpc.ServiceDesc{
+	ServiceName: extendedServiceName,
+	HandlerType: (*ExtendedAccessAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetExtendedTransactionResult",
+			Handler:    getExtendedTransactionResultHandler,
+		},
+		{
+			MethodName: "GetSlashingEvidence",
+			Handler:    getSlashingEvidenceHandler,
+		},
+		{
+			MethodName: "GetTransactionsByAccount",
+			Handler:    getTransactionsByAccountHandler,
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+func getExtendedTransactionResultHandler(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	in := new(access.GetTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedAccessAPIServer).GetExtendedTransactionResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/" + extendedServiceName + "/GetExtendedTransactionResult",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedAccessAPIServer).GetExtendedTransactionResult(ctx, req.(*access.GetTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func getSlashingEvidenceHandler(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	in := new(GetSlashingEvidenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedAccessAPIServer).GetSlashingEvidence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/" + extendedServiceName + "/GetSlashingEvidence",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedAccessAPIServer).GetSlashingEvidence(ctx, req.(*GetSlashingEvidenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func getTransactionsByAccountHandler(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	in := new(GetTransactionsByAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedAccessAPIServer).GetTransactionsByAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/" + extendedServiceName + "/GetTransactionsByAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedAccessAPIServer).GetTransactionsByAccount(ctx, req.(*GetTransactionsByAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ExtendedAccessAPIClient is the client API for the extension of the Access API.
+type ExtendedAccessAPIClient interface {
+	GetExtendedTransactionResult(ctx context.Context, in *access.GetTransactionRequest, opts ...grpc.CallOption) (*ExtendedTransactionResultResponse, error)
+	GetSlashingEvidence(ctx context.Context, in *GetSlashingEvidenceRequest, opts ...grpc.CallOption) (*GetSlashingEvidenceResponse, error)
+	GetTransactionsByAccount(ctx context.Context, in *GetTransactionsByAccountRequest, opts ...grpc.CallOption) (*GetTransactionsByAccountResponse, error)
+}
+
+type extendedAccessAPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewExtendedAccessAPIClient creates a client for the extension of the Access API.
+func NewExtendedAccessAPIClient(cc grpc.ClientConnInterface) ExtendedAccessAPIClient {
+	return &extendedAccessAPIClient{cc: cc}
+}
+
+func (c *extendedAccessAPIClient) GetExtendedTransactionResult(
+	ctx context.Context,
+	in *access.GetTransactionRequest,
+	opts ...grpc.CallOption,
+) (*ExtendedTransactionResultResponse, error) {
+	out := new(ExtendedTransactionResultResponse)
+	err := c.cc.Invoke(ctx, "/"+extendedServiceName+"/GetExtendedTransactionResult", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedAccessAPIClient) GetSlashingEvidence(
+	ctx context.Context,
+	in *GetSlashingEvidenceRequest,
+	opts ...grpc.CallOption,
+) (*GetSlashingEvidenceResponse, error) {
+	out := new(GetSlashingEvidenceResponse)
+	err := c.cc.Invoke(ctx, "/"+extendedServiceName+"/GetSlashingEvidence", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extendedAccessAPIClient) GetTransactionsByAccount(
+	ctx context.Context,
+	in *GetTransactionsByAccountRequest,
+	opts ...grpc.CallOption,
+) (*GetTransactionsByAccountResponse, error) {
+	out := new(GetTransactionsByAccountResponse)
+	err := c.cc.Invoke(ctx, "/"+extendedServiceName+"/GetTransactionsByAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetExtendedTransactionResult gets the result of a transaction by ID, including the computation it used.
+func (h *Handler) GetExtendedTransactionResult(
+	ctx context.Context,
+	req *access.GetTransactionRequest,
+) (*ExtendedTransactionResultResponse, error) {
+	id, err := convert.TransactionID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := h.api.GetTransactionResult(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return TransactionResultToExtendedMessage(result), nil
+}
+
+// GetSlashingEvidence gets the slashing evidence against a node, or against all nodes if no node
+// is requested.
+func (h *Handler) GetSlashingEvidence(
+	ctx context.Context,
+	req *GetSlashingEvidenceRequest,
+) (*GetSlashingEvidenceResponse, error) {
+	// an empty offender ID converts to the zero ID, which requests the evidence against all nodes
+	offenderID := convert.MessageToIdentifier(req.GetOffenderId())
+
+	evidence, err := h.api.GetSlashingEvidence(ctx, offenderID)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]*SlashingEvidence, 0, len(evidence))
+	for _, e := range evidence {
+		messages = append(messages, SlashingEvidenceToMessage(e))
+	}
+
+	return &GetSlashingEvidenceResponse{
+		Evidence: messages,
+	}, nil
+}
+
+// GetTransactionsByAccount gets a page of the transactions that involve an account as payer, proposer
+// or authorizer within a range of block heights.
+func (h *Handler) GetTransactionsByAccount(
+	ctx context.Context,
+	req *GetTransactionsByAccountRequest,
+) (*GetTransactionsByAccountResponse, error) {
+	address, err := convert.Address(req.GetAddress(), h.chain)
+	if err != nil {
+		return nil, err
+	}
+
+	pagination := Pagination{
+		Offset: uint(req.GetOffset()),
+		Limit:  uint(req.GetLimit()),
+	}
+
+	transactions, err := h.api.GetTransactionsByAccount(ctx, address, req.GetStartHeight(), req.GetEndHeight(), pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]*AccountTransactionMessage, 0, len(transactions))
+	for _, tx := range transactions {
+		messages = append(messages, &AccountTransactionMessage{
+			Transaction: convert.TransactionToMessage(*tx.Transaction),
+			BlockHeight: tx.BlockHeight,
+		})
+	}
+
+	return &GetTransactionsByAccountResponse{
+		Transactions: messages,
+	}, nil
+}
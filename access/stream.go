@@ -0,0 +1,300 @@
+package access
+
+import (
+	"context"
+
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// The streaming endpoints are not (yet) part of the Flow protobuf definitions, so the gRPC service is
+// declared by hand below. It reuses the existing Access API messages, so any client that speaks the
+// Access API can decode the streamed responses:
+//
+//   SubscribeBlockHeaders:      GetBlockHeaderByHeightRequest (start height) -> stream BlockHeaderResponse
+//   SubscribeEvents:            GetEventsForHeightRangeRequest (type, start height) -> stream EventsResponse
+//   SubscribeTransactionStatus: GetTransactionRequest -> stream TransactionResultResponse
+//
+// The end height of the events request is ignored, each streamed EventsResponse contains the result
+// of exactly one block.
+
+const streamingServiceName = "flow.access.StreamingAccessAPI"
+
+// StreamingAccessAPIServer is the server API for the streaming extension of the Access API.
+type StreamingAccessAPIServer interface {
+	SubscribeBlockHeaders(*access.GetBlockHeaderByHeightRequest, BlockHeadersStream) error
+	SubscribeEvents(*access.GetEventsForHeightRangeRequest, EventsStream) error
+	SubscribeTransactionStatus(*access.GetTransactionRequest, TransactionResultsStream) error
+}
+
+// BlockHeadersStream is the server side stream of a block header subscription.
+type BlockHeadersStream interface {
+	Send(*access.BlockHeaderResponse) error
+	grpc.ServerStream
+}
+
+// EventsStream is the server side stream of an event subscription.
+type EventsStream interface {
+	Send(*access.EventsResponse) error
+	grpc.ServerStream
+}
+
+// TransactionResultsStream is the server side stream of a transaction status subscription.
+type TransactionResultsStream interface {
+	Send(*access.TransactionResultResponse) error
+	grpc.ServerStream
+}
+
+// RegisterStreamingAccessAPIServer registers the streaming Access API on the given gRPC server.
+func RegisterStreamingAccessAPIServer(s *grpc.Server, srv StreamingAccessAPIServer) {
+	s.RegisterService(&streamingServiceDesc, srv)
+}
+
+var streamingServiceDesc = grpc.ServiceDesc{
+	ServiceName: streamingServiceName,
+	HandlerType: (*StreamingAccessAPIServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeBlockHeaders",
+			Handler:       subscribeBlockHeadersHandler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeEvents",
+			Handler:       subscribeEventsHandler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeTransactionStatus",
+			Handler:       subscribeTransactionStatusHandler,
+			ServerStreams: true,
+		},
+	},
+}
+
+func subscribeBlockHeadersHandler(srv interface{}, stream grpc.ServerStream) error {
+	req := new(access.GetBlockHeaderByHeightRequest)
+	if err := stream.RecvMsg(req); err != nil {
+		return err
+	}
+	return srv.(StreamingAccessAPIServer).SubscribeBlockHeaders(req, &blockHeadersStream{stream})
+}
+
+func subscribeEventsHandler(srv interface{}, stream grpc.ServerStream) error {
+	req := new(access.GetEventsForHeightRangeRequest)
+	if err := stream.RecvMsg(req); err != nil {
+		return err
+	}
+	return srv.(StreamingAccessAPIServer).SubscribeEvents(req, &eventsStream{stream})
+}
+
+func subscribeTransactionStatusHandler(srv interface{}, stream grpc.ServerStream) error {
+	req := new(access.GetTransactionRequest)
+	if err := stream.RecvMsg(req); err != nil {
+		return err
+	}
+	return srv.(StreamingAccessAPIServer).SubscribeTransactionStatus(req, &transactionResultsStream{stream})
+}
+
+type blockHeadersStream struct {
+	grpc.ServerStream
+}
+
+func (s *blockHeadersStream) Send(m *access.BlockHeaderResponse) error {
+	return s.ServerStream.SendMsg(m)
+}
+
+type eventsStream struct {
+	grpc.ServerStream
+}
+
+func (s *eventsStream) Send(m *access.EventsResponse) error {
+	return s.ServerStream.SendMsg(m)
+}
+
+type transactionResultsStream struct {
+	grpc.ServerStream
+}
+
+func (s *transactionResultsStream) Send(m *access.TransactionResultResponse) error {
+	return s.ServerStream.SendMsg(m)
+}
+
+// StreamingAccessAPIClient is the client API for the streaming extension of the Access API.
+type StreamingAccessAPIClient interface {
+	SubscribeBlockHeaders(ctx context.Context, in *access.GetBlockHeaderByHeightRequest, opts ...grpc.CallOption) (BlockHeadersClientStream, error)
+	SubscribeEvents(ctx context.Context, in *access.GetEventsForHeightRangeRequest, opts ...grpc.CallOption) (EventsClientStream, error)
+	SubscribeTransactionStatus(ctx context.Context, in *access.GetTransactionRequest, opts ...grpc.CallOption) (TransactionResultsClientStream, error)
+}
+
+// BlockHeadersClientStream is the client side stream of a block header subscription.
+type BlockHeadersClientStream interface {
+	Recv() (*access.BlockHeaderResponse, error)
+	grpc.ClientStream
+}
+
+// EventsClientStream is the client side stream of an event subscription.
+type EventsClientStream interface {
+	Recv() (*access.EventsResponse, error)
+	grpc.ClientStream
+}
+
+// TransactionResultsClientStream is the client side stream of a transaction status subscription.
+type TransactionResultsClientStream interface {
+	Recv() (*access.TransactionResultResponse, error)
+	grpc.ClientStream
+}
+
+type streamingAccessAPIClient struct {
+	cc *grpc.ClientConn
+}
+
+// NewStreamingAccessAPIClient creates a client for the streaming Access API.
+func NewStreamingAccessAPIClient(cc *grpc.ClientConn) StreamingAccessAPIClient {
+	return &streamingAccessAPIClient{cc: cc}
+}
+
+func (c *streamingAccessAPIClient) SubscribeBlockHeaders(
+	ctx context.Context,
+	in *access.GetBlockHeaderByHeightRequest,
+	opts ...grpc.CallOption,
+) (BlockHeadersClientStream, error) {
+	stream, err := c.newServerStream(ctx, 0, "SubscribeBlockHeaders", in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &blockHeadersClientStream{stream}, nil
+}
+
+func (c *streamingAccessAPIClient) SubscribeEvents(
+	ctx context.Context,
+	in *access.GetEventsForHeightRangeRequest,
+	opts ...grpc.CallOption,
+) (EventsClientStream, error) {
+	stream, err := c.newServerStream(ctx, 1, "SubscribeEvents", in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &eventsClientStream{stream}, nil
+}
+
+func (c *streamingAccessAPIClient) SubscribeTransactionStatus(
+	ctx context.Context,
+	in *access.GetTransactionRequest,
+	opts ...grpc.CallOption,
+) (TransactionResultsClientStream, error) {
+	stream, err := c.newServerStream(ctx, 2, "SubscribeTransactionStatus", in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &transactionResultsClientStream{stream}, nil
+}
+
+// newServerStream opens a server streaming call and sends the single request message.
+func (c *streamingAccessAPIClient) newServerStream(
+	ctx context.Context,
+	index int,
+	method string,
+	in interface{},
+	opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	stream, err := c.cc.NewStream(ctx, &streamingServiceDesc.Streams[index], "/"+streamingServiceName+"/"+method, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+type blockHeadersClientStream struct {
+	grpc.ClientStream
+}
+
+func (s *blockHeadersClientStream) Recv() (*access.BlockHeaderResponse, error) {
+	m := new(access.BlockHeaderResponse)
+	if err := s.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+type eventsClientStream struct {
+	grpc.ClientStream
+}
+
+func (s *eventsClientStream) Recv() (*access.EventsResponse, error) {
+	m := new(access.EventsResponse)
+	if err := s.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+type transactionResultsClientStream struct {
+	grpc.ClientStream
+}
+
+func (s *transactionResultsClientStream) Recv() (*access.TransactionResultResponse, error) {
+	m := new(access.TransactionResultResponse)
+	if err := s.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SubscribeBlockHeaders streams all finalized block headers starting at the requested height.
+func (h *Handler) SubscribeBlockHeaders(
+	req *access.GetBlockHeaderByHeightRequest,
+	stream BlockHeadersStream,
+) error {
+	return h.api.SubscribeBlockHeaders(stream.Context(), req.GetHeight(), func(header *flow.Header) error {
+		resp, err := blockHeaderResponse(header)
+		if err != nil {
+			return err
+		}
+		return stream.Send(resp)
+	})
+}
+
+// SubscribeEvents streams the events of the requested type for all sealed blocks starting at the
+// requested start height.
+func (h *Handler) SubscribeEvents(
+	req *access.GetEventsForHeightRangeRequest,
+	stream EventsStream,
+) error {
+	eventType, err := convert.EventType(req.GetType())
+	if err != nil {
+		return err
+	}
+
+	return h.api.SubscribeEvents(stream.Context(), eventType, req.GetStartHeight(), func(events flow.BlockEvents) error {
+		return stream.Send(&access.EventsResponse{
+			Results: blockEventsToMessages([]flow.BlockEvents{events}),
+		})
+	})
+}
+
+// SubscribeTransactionStatus streams the result of a transaction every time its status changes,
+// until the transaction is sealed or expired.
+func (h *Handler) SubscribeTransactionStatus(
+	req *access.GetTransactionRequest,
+	stream TransactionResultsStream,
+) error {
+	id, err := convert.TransactionID(req.GetId())
+	if err != nil {
+		return err
+	}
+
+	return h.api.SubscribeTransactionStatus(stream.Context(), id, func(result *TransactionResult) error {
+		return stream.Send(TransactionResultToMessage(result))
+	})
+}
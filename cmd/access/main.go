@@ -14,15 +14,19 @@ import (
 	"github.com/onflow/flow-go/consensus"
 	"github.com/onflow/flow-go/consensus/hotstuff/committee"
 	"github.com/onflow/flow-go/consensus/hotstuff/committee/leader"
+	"github.com/onflow/flow-go/consensus/hotstuff/notifications"
+	"github.com/onflow/flow-go/consensus/hotstuff/notifications/pubsub"
 	"github.com/onflow/flow-go/consensus/hotstuff/verification"
 	recovery "github.com/onflow/flow-go/consensus/recovery/protocol"
 	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/engine/access/ingestion"
 	pingeng "github.com/onflow/flow-go/engine/access/ping"
 	"github.com/onflow/flow-go/engine/access/rpc"
+	"github.com/onflow/flow-go/engine/access/rpc/backend"
 	followereng "github.com/onflow/flow-go/engine/common/follower"
 	"github.com/onflow/flow-go/engine/common/requester"
 	synceng "github.com/onflow/flow-go/engine/common/synchronization"
+	extended "github.com/onflow/flow-go/engine/execution/rpc/protobuf"
 	"github.com/onflow/flow-go/model/encoding"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/filter"
@@ -33,6 +37,7 @@ import (
 	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/module/signature"
 	"github.com/onflow/flow-go/module/synchronization"
+	flowstorage "github.com/onflow/flow-go/storage"
 	storage "github.com/onflow/flow-go/storage/badger"
 	grpcutils "github.com/onflow/flow-go/utils/grpc"
 )
@@ -53,6 +58,7 @@ func main() {
 		rpcEng                       *rpc.Engine
 		collectionRPC                access.AccessAPIClient
 		executionRPC                 execution.ExecutionAPIClient
+		extendedExecutionRPC         extended.ExtendedExecutionAPIClient
 		err                          error
 		conCache                     *buffer.PendingBlocks // pending block cache for follower
 		transactionTimings           *stdmap.TransactionTimings
@@ -61,10 +67,17 @@ func main() {
 		blocksToMarkExecuted         *stdmap.Times
 		transactionMetrics           module.TransactionMetrics
 		pingMetrics                  module.PingMetrics
+		accessMetrics                module.AccessMetrics
 		logTxTimeToFinalized         bool
 		logTxTimeToExecuted          bool
 		logTxTimeToFinalizedExecuted bool
 		retryEnabled                 bool
+		eventQueryMode               string
+		eventIndex                   flowstorage.EventIndex
+		indexAccountTransactions     bool
+		accountTransactions          flowstorage.AccountTransactions
+		slashingEvidence             flowstorage.SlashingEvidence
+		receipts                     flowstorage.ExecutionReceipts
 	)
 
 	cmd.FlowNode(flow.RoleAccess.String()).
@@ -82,6 +95,11 @@ func main() {
 			flags.BoolVar(&logTxTimeToFinalizedExecuted, "log-tx-time-to-finalized-executed", false, "log transaction time to finalized and executed")
 			flags.BoolVar(&pingEnabled, "ping-enabled", false, "whether to enable the ping process that pings all other peers and report the connectivity to metrics")
 			flags.BoolVar(&retryEnabled, "retry-enabled", false, "whether to enable the retry mechanism at the access node level")
+			flags.StringVar(&eventQueryMode, "event-query-mode", "execution-node", "where to look up events: execution-node or local-index")
+			flags.UintVar(&rpcConf.MaxHeightRange, "rpc-max-height-range", backend.DefaultMaxHeightRange, "maximum number of blocks a single event query may span")
+			flags.UintVar(&rpcConf.ExecutionGRPCPort, "execution-grpc-port", 9000, "the grpc port for all execution nodes")
+			flags.BoolVar(&indexAccountTransactions, "index-account-transactions", false, "whether to index transactions by the accounts involved in them")
+			flags.UintVar(&rpcConf.ExecutionQuorum, "execution-quorum", 0, "number of execution nodes that must return identical responses, 0 to trust the execution node at script-addr")
 		}).
 		Module("collection node client", func(node *cmd.FlowNodeBuilder) error {
 			// collection node address is optional (if not specified, collection nodes will be chosen at random)
@@ -111,6 +129,32 @@ func main() {
 				return err
 			}
 			executionRPC = execution.NewExecutionAPIClient(executionRPCConn)
+			extendedExecutionRPC = extended.NewExtendedExecutionAPIClient(executionRPCConn)
+			return nil
+		}).
+		Module("event index", func(node *cmd.FlowNodeBuilder) error {
+			rpcConf.EventQueryMode, err = backend.ParseEventQueryMode(eventQueryMode)
+			if err != nil {
+				return err
+			}
+			// events are only indexed locally if they are also queried locally
+			if rpcConf.EventQueryMode == backend.EventQueryModeLocalIndex {
+				eventIndex = storage.NewEventIndex(node.DB)
+			}
+			return nil
+		}).
+		Module("account transaction index", func(node *cmd.FlowNodeBuilder) error {
+			if indexAccountTransactions {
+				accountTransactions = storage.NewAccountTransactions(node.DB)
+			}
+			return nil
+		}).
+		Module("slashing evidence", func(node *cmd.FlowNodeBuilder) error {
+			slashingEvidence = storage.NewSlashingEvidence(node.DB)
+			return nil
+		}).
+		Module("execution receipts storage", func(node *cmd.FlowNodeBuilder) error {
+			receipts = storage.NewExecutionReceipts(node.DB, storage.NewExecutionResults(node.DB))
 			return nil
 		}).
 		Module("block cache", func(node *cmd.FlowNodeBuilder) error {
@@ -149,6 +193,10 @@ func main() {
 			pingMetrics = metrics.NewPingCollector()
 			return nil
 		}).
+		Module("access metrics", func(node *cmd.FlowNodeBuilder) error {
+			accessMetrics = metrics.NewAccessCollector()
+			return nil
+		}).
 		Component("RPC engine", func(node *cmd.FlowNodeBuilder) (module.ReadyDoneAware, error) {
 			rpcEng = rpc.New(
 				node.Logger,
@@ -164,6 +212,12 @@ func main() {
 				transactionMetrics,
 				collectionGRPCPort,
 				retryEnabled,
+				eventIndex,
+				accessMetrics,
+				accountTransactions,
+				slashingEvidence,
+				receipts,
+				node.Storage.Seals,
 			)
 			return rpcEng, nil
 		}).
@@ -182,7 +236,7 @@ func main() {
 				return nil, fmt.Errorf("could not create requester engine: %w", err)
 			}
 			ingestEng, err = ingestion.New(node.Logger, node.Network, node.State, node.Me, requestEng, node.Storage.Blocks, node.Storage.Headers, node.Storage.Collections, node.Storage.Transactions, transactionMetrics,
-				collectionsToMarkFinalized, collectionsToMarkExecuted, blocksToMarkExecuted, rpcEng)
+				collectionsToMarkFinalized, collectionsToMarkExecuted, blocksToMarkExecuted, rpcEng, eventIndex, extendedExecutionRPC, accountTransactions, receipts, node.Storage.Seals)
 			requestEng.WithHandle(ingestEng.OnCollection)
 			return ingestEng, err
 		}).
@@ -211,11 +265,15 @@ func main() {
 			if err != nil {
 				return nil, fmt.Errorf("could not create leader selection for main consensus: %w", err)
 			}
+			leaders, err := leader.NewReputationSelectionForConsensus(selection, node.BaseConfig.LeaderReputationWindow, node.RootBlock.Header, node.State, node.Storage.Headers)
+			if err != nil {
+				return nil, fmt.Errorf("could not create leader reputation for main consensus: %w", err)
+			}
 
 			// initialize consensus committee's membership state
 			// This committee state is for the HotStuff follower, which follows the MAIN CONSENSUS Committee
 			// Note: node.Me.NodeID() is not part of the consensus committee
-			mainConsensusCommittee, err := committee.NewMainConsensusCommitteeState(node.State, node.Me.NodeID(), selection)
+			mainConsensusCommittee, err := committee.NewMainConsensusCommitteeState(node.State, node.Me.NodeID(), leaders)
 			if err != nil {
 				return nil, fmt.Errorf("could not create Committee state for main consensus: %w", err)
 			}
@@ -228,9 +286,13 @@ func main() {
 				return nil, fmt.Errorf("could not find latest finalized block and pending blocks to recover consensus follower: %w", err)
 			}
 
-			// creates a consensus follower with ingestEngine as the notifier
-			// so that it gets notified upon each new finalized block
-			followerCore, err := consensus.NewFollower(node.Logger, mainConsensusCommittee, node.Storage.Headers, final, verifier, ingestEng, node.RootBlock.Header, node.RootQC, finalized, pending)
+			// creates a consensus follower with ingestEngine as a consumer of its notifications
+			// so that it gets notified upon each new finalized block, and records evidence of
+			// double proposals observed by the follower
+			notifier := pubsub.NewFinalizationDistributor()
+			notifier.AddConsumer(ingestEng)
+			notifier.AddConsumer(notifications.NewSlashingViolationsConsumer(node.Logger, node.Storage.Headers, slashingEvidence))
+			followerCore, err := consensus.NewFollower(node.Logger, mainConsensusCommittee, node.Storage.Headers, final, verifier, notifier, node.RootBlock.Header, node.RootQC, finalized, pending)
 			if err != nil {
 				return nil, fmt.Errorf("could not initialize follower core: %w", err)
 			}
@@ -102,7 +102,7 @@ func createValidators(participantData *ParticipantData) ([]hotstuff.Validator, [
 		stakingSigner := signature.NewAggregationProvider(encoding.ConsensusVoteTag, local)
 		beaconSigner := signature.NewThresholdProvider(encoding.RandomBeaconTag, participant.RandomBeaconPrivKey)
 		merger := signature.NewCombiner()
-		signer := verification.NewCombinedSigner(committee, stakingSigner, beaconSigner, merger, participant.NodeID)
+		signer := verification.NewCombinedSigner(committee, stakingSigner, beaconSigner, signature.NewStaticThresholdSignerStore(beaconSigner), merger, participant.NodeID)
 		signers[i] = signer
 
 		// create validator
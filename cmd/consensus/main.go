@@ -24,6 +24,7 @@ import (
 	"github.com/onflow/flow-go/engine/common/requester"
 	synceng "github.com/onflow/flow-go/engine/common/synchronization"
 	"github.com/onflow/flow-go/engine/consensus/compliance"
+	dkgeng "github.com/onflow/flow-go/engine/consensus/dkg"
 	"github.com/onflow/flow-go/engine/consensus/ingestion"
 	"github.com/onflow/flow-go/engine/consensus/matching"
 	"github.com/onflow/flow-go/engine/consensus/provider"
@@ -41,6 +42,7 @@ import (
 	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/module/signature"
 	"github.com/onflow/flow-go/module/synchronization"
+	"github.com/onflow/flow-go/storage"
 	bstorage "github.com/onflow/flow-go/storage/badger"
 	"github.com/onflow/flow-go/utils/io"
 )
@@ -61,9 +63,11 @@ func main() {
 		hotstuffTimeoutDecreaseFactor          float64
 		hotstuffTimeoutVoteAggregationFraction float64
 		blockRateDelay                         time.Duration
+		dkgPhaseViews                          uint64
 
 		err            error
 		privateDKGData *bootstrap.DKGParticipantPriv
+		dkgResults     storage.DKGResults
 		guarantees     mempool.Guarantees
 		results        mempool.Results
 		receipts       mempool.Receipts
@@ -92,11 +96,16 @@ func main() {
 			flags.Float64Var(&hotstuffTimeoutDecreaseFactor, "hotstuff-timeout-decrease-factor", timeout.DefaultConfig.TimeoutDecrease, "multiplicative decrease of timeout value in case of progress")
 			flags.Float64Var(&hotstuffTimeoutVoteAggregationFraction, "hotstuff-timeout-vote-aggregation-fraction", 0.6, "additional fraction of replica timeout that the primary will wait for votes")
 			flags.DurationVar(&blockRateDelay, "block-rate-delay", 500*time.Millisecond, "the delay to broadcast block proposal in order to control block production rate")
+			flags.Uint64Var(&dkgPhaseViews, "dkg-phase-views", dkgeng.DefaultPhaseViews, "the number of views of each of the three phases of the dkg for the next epoch")
 		}).
 		Module("random beacon key", func(node *cmd.FlowNodeBuilder) error {
 			privateDKGData, err = loadDKGPrivateData(node.BaseConfig.BootstrapDir, node.NodeID)
 			return err
 		}).
+		Module("dkg results storage", func(node *cmd.FlowNodeBuilder) error {
+			dkgResults = bstorage.NewDKGResults(node.DB)
+			return nil
+		}).
 		Module("collection guarantees mempool", func(node *cmd.FlowNodeBuilder) error {
 			guarantees, err = stdmap.NewGuarantees(guaranteeLimit)
 			return err
@@ -270,8 +279,21 @@ func main() {
 			// initialize the aggregating signature module for staking signatures
 			staking := signature.NewAggregationProvider(encoding.ConsensusVoteTag, node.Me)
 
-			// initialize the threshold signature module for random beacon signatures
-			beacon := signature.NewThresholdProvider(encoding.RandomBeaconTag, privateDKGData.RandomBeaconPrivKey)
+			// initialize the threshold signature module for random beacon signatures, which
+			// uses the key share of the root epoch or the result of the dkg of later epochs
+			rootCounter, err := node.State.AtBlockID(node.RootBlock.ID()).Epochs().Current().Counter()
+			if err != nil {
+				return nil, fmt.Errorf("could not get root epoch counter: %w", err)
+			}
+			beacon := signature.NewThresholdVerifier(encoding.RandomBeaconTag)
+			beaconStore := signature.NewEpochAwareThresholdSignerStore(
+				encoding.RandomBeaconTag,
+				node.NodeID,
+				node.State,
+				dkgResults,
+				rootCounter,
+				privateDKGData.RandomBeaconPrivKey,
+			)
 
 			// initialize the simple merger to combine staking & beacon signatures
 			merger := signature.NewCombiner()
@@ -281,10 +303,14 @@ func main() {
 			if err != nil {
 				return nil, fmt.Errorf("could not create leader selection for main consensus: %w", err)
 			}
+			leaders, err := leader.NewReputationSelectionForConsensus(selection, node.BaseConfig.LeaderReputationWindow, node.RootBlock.Header, node.State, node.Storage.Headers)
+			if err != nil {
+				return nil, fmt.Errorf("could not create leader reputation for main consensus: %w", err)
+			}
 
 			// initialize Main consensus committee's state
 			var committee hotstuff.Committee
-			committee, err = committeeImpl.NewMainConsensusCommitteeState(node.State, node.Me.NodeID(), selection)
+			committee, err = committeeImpl.NewMainConsensusCommitteeState(node.State, node.Me.NodeID(), leaders)
 			if err != nil {
 				return nil, fmt.Errorf("could not create Committee state for main consensus: %w", err)
 			}
@@ -296,13 +322,15 @@ func main() {
 				committee,
 				staking,
 				beacon,
+				beaconStore,
 				merger,
 				node.NodeID,
 			)
 			signer = verification.NewMetricsWrapper(signer, mainMetrics) // wrapper for measuring time spent with crypto-related operations
 
 			// initialize a logging notifier for hotstuff
-			notifier := createNotifier(node.Logger, mainMetrics, node.Tracer, node.Storage.Index, node.RootChainID)
+			notifier := createNotifier(node.Logger, mainMetrics, node.Tracer, node.Storage.Index, node.RootChainID,
+				node.Storage.Headers, bstorage.NewSlashingEvidence(node.DB))
 			// initialize the persister
 			persist := persister.New(node.DB, node.RootChainID)
 
@@ -363,6 +391,22 @@ func main() {
 			// created with matching engine
 			return requesterEng, nil
 		}).
+		Component("dkg engine", func(node *cmd.FlowNodeBuilder) (module.ReadyDoneAware, error) {
+			dkg, err := dkgeng.New(
+				node.Logger,
+				node.Network,
+				node.Me,
+				node.State,
+				dkgResults,
+				dkgPhaseViews,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("could not initialize dkg engine: %w", err)
+			}
+			node.ProtocolEvents.AddConsumer(dkg)
+
+			return dkg, nil
+		}).
 		Run()
 }
 
@@ -14,13 +14,16 @@ import (
 )
 
 func createNotifier(log zerolog.Logger, metrics module.HotstuffMetrics, tracer module.Tracer, index storage.Index, chain flow.ChainID,
+	headers storage.Headers, evidence storage.SlashingEvidence,
 ) hotstuff.Consumer {
 	telemetryConsumer := notifications.NewTelemetryConsumer(log, chain)
 	tracingConsumer := notifications.NewConsensusTracingConsumer(log, tracer, index)
 	metricsConsumer := metricsconsumer.NewMetricsConsumer(metrics)
+	slashingConsumer := notifications.NewSlashingViolationsConsumer(log, headers, evidence)
 	dis := pubsub.NewDistributor()
 	dis.AddConsumer(telemetryConsumer)
 	dis.AddConsumer(tracingConsumer)
 	dis.AddConsumer(metricsConsumer)
+	dis.AddConsumer(slashingConsumer)
 	return dis
 }
@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"os"
@@ -17,6 +18,7 @@ import (
 	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/engine/common/provider"
 	"github.com/onflow/flow-go/engine/common/requester"
+	"github.com/onflow/flow-go/engine/execution/checkpoints"
 	"github.com/onflow/flow-go/engine/execution/computation"
 	"github.com/onflow/flow-go/engine/execution/ingestion"
 	exeprovider "github.com/onflow/flow-go/engine/execution/provider"
@@ -33,7 +35,10 @@ import (
 	chainsync "github.com/onflow/flow-go/module/synchronization"
 	storage "github.com/onflow/flow-go/storage/badger"
 	"github.com/onflow/flow-go/storage/ledger"
+	"github.com/onflow/flow-go/storage/ledger/mtrie/statesync"
+	mtriestorage "github.com/onflow/flow-go/storage/ledger/mtrie/storage"
 	"github.com/onflow/flow-go/storage/ledger/wal"
+	"github.com/onflow/flow-go/utils/logging"
 )
 
 func main() {
@@ -45,6 +50,7 @@ func main() {
 		results               *storage.ExecutionResults
 		receipts              *storage.ExecutionReceipts
 		providerEngine        *exeprovider.Engine
+		checkpointsEngine     *checkpoints.Engine
 		syncCore              *chainsync.Core
 		syncEngine            *synchronization.Engine
 		computationManager    *computation.Manager
@@ -56,10 +62,14 @@ func main() {
 		triedir               string
 		collector             module.ExecutionMetrics
 		mTrieCacheSize        uint32
+		mTrieNodeDir          string
+		mTrieNodeCacheSize    uint
 		checkpointDistance    uint
 		requestInterval       time.Duration
 		preferredExeNodeIDStr string
 		syncByBlocks          bool
+		parallelExecution     bool
+		syncSealedState       bool
 	)
 
 	cmd.FlowNode(flow.RoleExecution.String()).
@@ -70,10 +80,15 @@ func main() {
 			flags.StringVarP(&rpcConf.ListenAddr, "rpc-addr", "i", "localhost:9000", "the address the gRPC server listens on")
 			flags.StringVar(&triedir, "triedir", datadir, "directory to store the execution State")
 			flags.Uint32Var(&mTrieCacheSize, "mtrie-cache-size", 1000, "cache size for MTrie")
+			flags.StringVar(&mTrieNodeDir, "mtrie-node-dir", "", "directory to store the nodes of the execution state tries, which bounds their memory usage; the tries are held in memory entirely if empty")
+			flags.UintVar(&mTrieNodeCacheSize, "mtrie-node-cache-size", mtriestorage.DefaultCacheSize, "number of trie nodes held in memory if the nodes are stored in the mtrie-node-dir")
 			flags.UintVar(&checkpointDistance, "checkpoint-distance", 1, "number of WAL segments between checkpoints")
 			flags.DurationVar(&requestInterval, "request-interval", 60*time.Second, "the interval between requests for the requester engine")
 			flags.StringVar(&preferredExeNodeIDStr, "preferred-exe-node-id", "", "node ID for preferred execution node used for state sync")
 			flags.BoolVar(&syncByBlocks, "sync-by-blocks", true, "sync by blocks instead of execution state deltas")
+			flags.BoolVar(&parallelExecution, "parallel-execution", false, "execute the transactions of a collection optimistically in parallel")
+			flags.BoolVar(&syncSealedState, "sync-sealed-state", false, "synchronize the execution state of the latest sealed block from other execution nodes at startup, instead of executing all blocks since the last executed one")
+			flags.DurationVar(&rpcConf.ScriptTimeout, "script-timeout", rpc.DefaultScriptTimeout, "the time a script of a batch, or a simulated transaction, may run before it is aborted")
 		}).
 		Module("computation manager", func(node *cmd.FlowNodeBuilder) error {
 			rt := runtime.NewInterpreterRuntime()
@@ -93,6 +108,7 @@ func main() {
 				node.State,
 				vm,
 				vmCtx,
+				parallelExecution,
 			)
 			computationManager = manager
 
@@ -146,7 +162,11 @@ func main() {
 				}
 			}
 
-			ledgerStorage, err = ledger.NewMTrieStorage(triedir, int(mTrieCacheSize), collector, node.MetricsRegisterer)
+			var ledgerOpts []ledger.Option
+			if mTrieNodeDir != "" {
+				ledgerOpts = append(ledgerOpts, ledger.WithDiskNodeStorage(mTrieNodeDir, int(mTrieNodeCacheSize)))
+			}
+			ledgerStorage, err = ledger.NewMTrieStorage(triedir, int(mTrieCacheSize), collector, node.MetricsRegisterer, ledgerOpts...)
 			return ledgerStorage, err
 		}).
 		Component("execution state ledger WAL compactor", func(node *cmd.FlowNodeBuilder) (module.ReadyDoneAware, error) {
@@ -190,8 +210,34 @@ func main() {
 
 			return providerEngine, err
 		}).
+		Component("checkpoints engine", func(node *cmd.FlowNodeBuilder) (module.ReadyDoneAware, error) {
+			checkpointsEngine, err = checkpoints.New(
+				node.Logger,
+				node.Network,
+				node.Me,
+				node.State,
+				executionState,
+				ledgerStorage,
+				statesync.DefaultDepth,
+				checkpoints.DefaultRequestTimeout,
+			)
+			return checkpointsEngine, err
+		}).
 		Component("ingestion engine", func(node *cmd.FlowNodeBuilder) (module.ReadyDoneAware, error) {
 
+			// execution resumes from the highest executed block, which is the latest sealed block once its
+			// state was synchronized
+			if syncSealedState {
+				header, err := checkpointsEngine.SyncSealedState(context.Background())
+				if err != nil {
+					return nil, fmt.Errorf("could not synchronize sealed execution state: %w", err)
+				}
+				node.Logger.Info().
+					Hex("block_id", logging.Entity(header)).
+					Uint64("height", header.Height).
+					Msg("execution resumes from sealed block")
+			}
+
 			collectionRequester, err = requester.New(node.Logger, node.Metrics.Engine, node.Network, node.Me, node.State,
 				engine.RequestCollections,
 				filter.HasRole(flow.RoleCollection),
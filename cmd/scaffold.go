@@ -25,6 +25,9 @@ import (
 	"github.com/onflow/flow-go/module/local"
 	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/module/trace"
+	"github.com/onflow/flow-go/network"
+	cborcodec "github.com/onflow/flow-go/network/codec/cbor"
+	"github.com/onflow/flow-go/network/codec/compression"
 	jsoncodec "github.com/onflow/flow-go/network/codec/json"
 	"github.com/onflow/flow-go/network/gossip/libp2p"
 	"github.com/onflow/flow-go/network/gossip/libp2p/validators"
@@ -44,18 +47,25 @@ const notSet = "not set"
 
 // BaseConfig is the general config for the FlowNodeBuilder
 type BaseConfig struct {
-	nodeIDHex        string
-	bindAddr         string
-	nodeRole         string
-	timeout          time.Duration
-	datadir          string
-	level            string
-	metricsPort      uint
-	BootstrapDir     string
-	profilerEnabled  bool
-	profilerDir      string
-	profilerInterval time.Duration
-	profilerDuration time.Duration
+	nodeIDHex          string
+	bindAddr           string
+	nodeRole           string
+	timeout            time.Duration
+	datadir            string
+	level              string
+	metricsPort        uint
+	BootstrapDir       string
+	profilerEnabled    bool
+	profilerDir        string
+	profilerInterval   time.Duration
+	profilerDuration   time.Duration
+	networkCodec       string
+	networkCompression string
+	peerLimits         libp2p.PeerLimits
+
+	// LeaderReputationWindow is the number of views of the windows for the leader reputation of the
+	// main consensus, or 0 if unresponsive leaders are not deprioritized
+	LeaderReputationWindow uint64
 }
 
 type Metrics struct {
@@ -152,12 +162,55 @@ func (fnb *FlowNodeBuilder) baseFlags() {
 		"the interval between auto-profiler runs")
 	fnb.flags.DurationVar(&fnb.BaseConfig.profilerDuration, "profiler-duration", 10*time.Second,
 		"the duration to run the auto-profile for")
+	fnb.flags.StringVar(&fnb.BaseConfig.networkCodec, "network-codec", "json",
+		"codec of the network messages (json or cbor), all nodes of the network must use the same codec")
+	fnb.flags.StringVar(&fnb.BaseConfig.networkCompression, "network-compression", compression.None,
+		"compression of the network messages with the cbor codec (none, snappy or zstd)")
+	fnb.flags.Float64Var(&fnb.BaseConfig.peerLimits.PeerMessageRate, "peer-message-rate", libp2p.DefaultPeerMessageRate,
+		"number of messages per second accepted from a peer (0 disables the limit)")
+	fnb.flags.IntVar(&fnb.BaseConfig.peerLimits.PeerMessageBurst, "peer-message-burst", libp2p.DefaultPeerMessageBurst,
+		"number of messages accepted from a peer at once")
+	fnb.flags.Float64Var(&fnb.BaseConfig.peerLimits.ChannelMessageRate, "channel-message-rate", libp2p.DefaultChannelMessageRate,
+		"number of messages per second accepted from a peer on a single channel (0 disables the limit)")
+	fnb.flags.IntVar(&fnb.BaseConfig.peerLimits.ChannelMessageBurst, "channel-message-burst", libp2p.DefaultChannelMessageBurst,
+		"number of messages accepted from a peer on a single channel at once")
+	fnb.flags.Float64Var(&fnb.BaseConfig.peerLimits.MisbehaviorThreshold, "misbehavior-threshold", libp2p.DefaultMisbehaviorThreshold,
+		"misbehavior score at which a peer is disconnected and blocklisted (0 disables blocklisting)")
+	fnb.flags.Float64Var(&fnb.BaseConfig.peerLimits.MisbehaviorDecay, "misbehavior-decay", libp2p.DefaultMisbehaviorDecay,
+		"misbehavior score of a peer forgiven per second")
+	fnb.flags.DurationVar(&fnb.BaseConfig.peerLimits.BlocklistDuration, "blocklist-duration", libp2p.DefaultBlocklistDuration,
+		"duration for which a misbehaving peer is blocklisted")
+	fnb.flags.Uint64Var(&fnb.BaseConfig.LeaderReputationWindow, "leader-reputation-window", 0,
+		"number of views per window of the main consensus leader reputation, which deprioritizes unresponsive leaders (0 disables it), all nodes following the main consensus must use the same window")
+}
+
+// networkCodec creates the codec of the network messages from the flags.
+func (fnb *FlowNodeBuilder) networkCodec() (network.Codec, error) {
+	switch fnb.BaseConfig.networkCodec {
+	case "json":
+		if fnb.BaseConfig.networkCompression != compression.None {
+			return nil, fmt.Errorf("compression (%s) is not supported by the json codec", fnb.BaseConfig.networkCompression)
+		}
+		return jsoncodec.NewCodec(), nil
+	case "cbor":
+		// compressed messages may be transferred in chunks, up to the maximum size of chunked messages
+		compressor, err := compression.New(fnb.BaseConfig.networkCompression, libp2p.DefaultMaxChunkedMsgSize)
+		if err != nil {
+			return nil, err
+		}
+		return cborcodec.NewCodec(cborcodec.WithCompressor(compressor)), nil
+	default:
+		return nil, fmt.Errorf("invalid network codec (%s)", fnb.BaseConfig.networkCodec)
+	}
 }
 
 func (fnb *FlowNodeBuilder) enqueueNetworkInit() {
 	fnb.Component("network", func(builder *FlowNodeBuilder) (module.ReadyDoneAware, error) {
 
-		codec := jsoncodec.NewCodec()
+		codec, err := fnb.networkCodec()
+		if err != nil {
+			return nil, fmt.Errorf("could not initialize network codec: %w", err)
+		}
 
 		myAddr := fnb.Me.Address()
 		if fnb.BaseConfig.bindAddr != notSet {
@@ -166,7 +219,7 @@ func (fnb *FlowNodeBuilder) enqueueNetworkInit() {
 
 		mw, err := libp2p.NewMiddleware(fnb.Logger.Level(zerolog.ErrorLevel), codec, myAddr, fnb.Me.NodeID(),
 			fnb.networkKey, fnb.Metrics.Network, libp2p.DefaultMaxUnicastMsgSize, libp2p.DefaultMaxPubSubMsgSize,
-			fnb.RootBlock.ID().String(),
+			fnb.BaseConfig.peerLimits, fnb.RootBlock.ID().String(),
 			fnb.MsgValidators...)
 		if err != nil {
 			return nil, fmt.Errorf("could not initialize middleware: %w", err)
@@ -178,18 +231,27 @@ func (fnb *FlowNodeBuilder) enqueueNetworkInit() {
 			return nil, fmt.Errorf("could not get network identities: %w", err)
 		}
 
-		nodeID, err := fnb.State.Final().Identity(fnb.Me.NodeID())
+		topology, err := libp2p.NewEpochTopology(fnb.Logger, fnb.Me.NodeID(), fnb.State)
 		if err != nil {
-			return nil, fmt.Errorf("could not get node id: %w", err)
+			return nil, fmt.Errorf("could not initialize topology: %w", err)
 		}
-		nodeRole := nodeID.Role
-		topology := libp2p.NewRandPermTopology(nodeRole)
 
 		net, err := libp2p.NewNetwork(fnb.Logger, codec, participants, fnb.Me, fnb.Middleware, 10e6, topology, fnb.Metrics.Network)
 		if err != nil {
 			return nil, fmt.Errorf("could not initialize network: %w", err)
 		}
 
+		// on epoch transitions, update the identities of the network and the allowlist of the middleware,
+		// the topology is recomputed for the new epoch on the next request
+		topology.OnEpochTransition(func(ids flow.IdentityList) {
+			net.SetIDs(ids)
+			err := mw.UpdateAllowList()
+			if err != nil {
+				fnb.Logger.Error().Err(err).Msg("could not update allowlist on epoch transition")
+			}
+		})
+		fnb.ProtocolEvents.AddConsumer(topology)
+
 		fnb.Network = net
 		return net, err
 	})
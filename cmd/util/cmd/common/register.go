@@ -0,0 +1,21 @@
+package common
+
+import (
+	"encoding/hex"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// Register is the JSON representation of a register, with hex-encoded key and value
+type Register struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// NewRegister returns the JSON representation of the register
+func NewRegister(id flow.RegisterID, value flow.RegisterValue) *Register {
+	return &Register{
+		Key:   hex.EncodeToString(id),
+		Value: hex.EncodeToString(value),
+	}
+}
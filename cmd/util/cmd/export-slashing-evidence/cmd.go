@@ -0,0 +1,81 @@
+package slashing
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/cmd/util/cmd/common"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage/badger"
+)
+
+var (
+	flagDatadir    string
+	flagOffenderID string
+)
+
+var Cmd = &cobra.Command{
+	Use:   "export-slashing-evidence",
+	Short: "Exports the stored evidence of slashable offences, one JSON object per line",
+	Run:   run,
+}
+
+func init() {
+
+	Cmd.Flags().StringVar(&flagDatadir, "datadir", "",
+		"directory that stores the protocol state")
+	_ = Cmd.MarkFlagRequired("datadir")
+
+	Cmd.Flags().StringVar(&flagOffenderID, "offender-id", "",
+		"only export the evidence against the node with this ID (hex-encoded, 64 characters)")
+}
+
+// evidence is the JSON representation of a piece of slashing evidence
+type evidence struct {
+	ID       flow.Identifier        `json:"id"`
+	Offence  string                 `json:"offence"`
+	Evidence *flow.SlashingEvidence `json:"evidence"`
+}
+
+func run(*cobra.Command, []string) {
+
+	db := common.InitStorage(flagDatadir)
+	defer db.Close()
+
+	store := badger.NewSlashingEvidence(db)
+
+	var all []*flow.SlashingEvidence
+	var err error
+	if flagOffenderID != "" {
+		offenderID, err := flow.HexStringToIdentifier(flagOffenderID)
+		if err != nil {
+			log.Fatal().Err(err).Msg("malformed offender ID")
+		}
+		all, err = store.ByOffender(offenderID)
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not look up slashing evidence")
+		}
+	} else {
+		all, err = store.All()
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not look up slashing evidence")
+		}
+	}
+
+	for _, e := range all {
+		bytes, err := json.Marshal(evidence{
+			ID:       e.ID(),
+			Offence:  e.Offence.String(),
+			Evidence: e,
+		})
+		if err != nil {
+			log.Fatal().Err(err).Msg("error while marshalling slashing evidence")
+		}
+		fmt.Println(string(bytes))
+	}
+
+	log.Info().Int("evidence", len(all)).Msg("finished")
+}
@@ -6,7 +6,9 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 
+	diff_states "github.com/onflow/flow-go/cmd/util/cmd/read-execution-state/diff-states"
 	list_accounts "github.com/onflow/flow-go/cmd/util/cmd/read-execution-state/list-accounts"
+	list_registers "github.com/onflow/flow-go/cmd/util/cmd/read-execution-state/list-registers"
 	list_tries "github.com/onflow/flow-go/cmd/util/cmd/read-execution-state/list-tries"
 	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/storage/ledger"
@@ -36,21 +38,14 @@ func init() {
 func addSubcommands() {
 	Cmd.AddCommand(list_tries.Init(loadExecutionState))
 	Cmd.AddCommand(list_accounts.Init(loadExecutionState))
+	Cmd.AddCommand(list_registers.Init(loadLedger))
+	Cmd.AddCommand(diff_states.Init(loadLedger))
 }
 
 func loadExecutionState() *mtrie.MForest {
 
-	w, err := wal.NewWAL(
-		nil,
-		nil,
-		flagExecutionStateDir,
-		ledger.CacheSize,
-		ledger.RegisterKeySize,
-		wal.SegmentSize,
-	)
-	if err != nil {
-		log.Fatal().Err(err).Msg("error while creating WAL")
-	}
+	// the state dir may belong to a running node, so that it must not be modified
+	w := wal.NewReadOnlyWAL(flagExecutionStateDir, ledger.CacheSize, ledger.RegisterKeySize)
 
 	mForest, err := mtrie.NewMForest(ledger.RegisterKeySize, flagExecutionStateDir, ledger.CacheSize, metrics.NewNoopCollector(), nil)
 	if err != nil {
@@ -65,6 +60,15 @@ func loadExecutionState() *mtrie.MForest {
 	return mForest
 }
 
+// loadLedger loads the execution state as ledger storage, without modifying the state dir
+func loadLedger() *ledger.MTrieStorage {
+	storage, err := ledger.NewReadOnlyMTrieStorage(flagExecutionStateDir, ledger.CacheSize, metrics.NewNoopCollector())
+	if err != nil {
+		log.Fatal().Err(err).Msg("error while loading ledger storage")
+	}
+	return storage
+}
+
 func run(*cobra.Command, []string) {
 
 	log.Info().Msg("reading")
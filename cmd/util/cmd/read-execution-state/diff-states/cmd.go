@@ -0,0 +1,81 @@
+package diff_states
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/storage/ledger"
+)
+
+var cmd = &cobra.Command{
+	Use:   "diff-states",
+	Short: "Lists the registers which changed between two states, one JSON object per line",
+	Run:   run,
+}
+
+var ledgerLoader func() *ledger.MTrieStorage = nil
+var flagFrom string
+var flagTo string
+
+func Init(f func() *ledger.MTrieStorage) *cobra.Command {
+	ledgerLoader = f
+
+	cmd.Flags().StringVar(&flagFrom, "from", "",
+		"State commitment to compare from (64 chars, hex-encoded)")
+	_ = cmd.MarkFlagRequired("from")
+
+	cmd.Flags().StringVar(&flagTo, "to", "",
+		"State commitment to compare to (64 chars, hex-encoded)")
+	_ = cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+// change is the JSON representation of a changed register, where a missing value is not allocated
+type change struct {
+	Key string `json:"key"`
+	Old string `json:"old,omitempty"`
+	New string `json:"new,omitempty"`
+}
+
+func run(*cobra.Command, []string) {
+	startTime := time.Now()
+
+	from, err := hex.DecodeString(flagFrom)
+	if err != nil {
+		log.Fatal().Err(err).Msg("invalid flag, cannot decode")
+	}
+	to, err := hex.DecodeString(flagTo)
+	if err != nil {
+		log.Fatal().Err(err).Msg("invalid flag, cannot decode")
+	}
+
+	storage := ledgerLoader()
+	defer storage.CloseStorage()
+
+	changes, err := storage.Diff(from, to)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot compute changes")
+	}
+
+	for _, c := range changes {
+		b, err := json.Marshal(change{
+			Key: hex.EncodeToString(c.Key),
+			Old: hex.EncodeToString(c.Old),
+			New: hex.EncodeToString(c.New),
+		})
+		if err != nil {
+			log.Fatal().Err(err).Msg("error while marshalling change")
+		}
+		fmt.Println(string(b))
+	}
+
+	duration := time.Since(startTime)
+
+	log.Info().Int("changes", len(changes)).Float64("total_time_s", duration.Seconds()).Msg("finished")
+}
@@ -0,0 +1,84 @@
+package list_registers
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/cmd/util/cmd/common"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage/ledger"
+)
+
+var cmd = &cobra.Command{
+	Use:   "list-registers",
+	Short: "Lists the registers at a given state in the order of their keys, one JSON object per line",
+	Run:   run,
+}
+
+var ledgerLoader func() *ledger.MTrieStorage = nil
+var flagStateCommitment string
+var flagStartKey string
+var flagLimit int
+
+func Init(f func() *ledger.MTrieStorage) *cobra.Command {
+	ledgerLoader = f
+
+	cmd.Flags().StringVar(&flagStateCommitment, "state-commitment", "",
+		"State commitment (64 chars, hex-encoded)")
+	_ = cmd.MarkFlagRequired("state-commitment")
+
+	cmd.Flags().StringVar(&flagStartKey, "start-key", "",
+		"Register key to start listing at (hex-encoded), as printed at the end of a previous listing")
+
+	cmd.Flags().IntVar(&flagLimit, "limit", 1000,
+		"Maximum number of registers to list")
+
+	return cmd
+}
+
+func run(*cobra.Command, []string) {
+	startTime := time.Now()
+
+	stateCommitment, err := hex.DecodeString(flagStateCommitment)
+	if err != nil {
+		log.Fatal().Err(err).Msg("invalid flag, cannot decode")
+	}
+
+	var start flow.RegisterID
+	if flagStartKey != "" {
+		start, err = hex.DecodeString(flagStartKey)
+		if err != nil {
+			log.Fatal().Err(err).Msg("invalid start key, cannot decode")
+		}
+	}
+
+	storage := ledgerLoader()
+	defer storage.CloseStorage()
+
+	ids, values, next, err := storage.Registers(stateCommitment, start, flagLimit)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot list registers")
+	}
+
+	for i, id := range ids {
+		b, err := json.Marshal(common.NewRegister(id, values[i]))
+		if err != nil {
+			log.Fatal().Err(err).Msg("error while marshalling register")
+		}
+		fmt.Println(string(b))
+	}
+
+	duration := time.Since(startTime)
+
+	// the next key is logged to continue the listing with
+	log.Info().
+		Int("registers", len(ids)).
+		Str("next_key", hex.EncodeToString(next)).
+		Float64("total_time_s", duration.Seconds()).
+		Msg("finished")
+}
@@ -12,6 +12,7 @@ import (
 	checkpoint_list_tries "github.com/onflow/flow-go/cmd/util/cmd/checkpoint-list-tries"
 	export "github.com/onflow/flow-go/cmd/util/cmd/exec-data-json-export"
 	extract "github.com/onflow/flow-go/cmd/util/cmd/execution-state-extract"
+	slashing "github.com/onflow/flow-go/cmd/util/cmd/export-slashing-evidence"
 	"github.com/onflow/flow-go/cmd/util/cmd/find-block"
 	"github.com/onflow/flow-go/cmd/util/cmd/read-execution-state"
 )
@@ -52,6 +53,7 @@ func addCommands() {
 	rootCmd.AddCommand(find.Cmd)
 	rootCmd.AddCommand(read.Cmd)
 	rootCmd.AddCommand(checkpoint_list_tries.Cmd)
+	rootCmd.AddCommand(slashing.Cmd)
 }
 
 func initConfig() {
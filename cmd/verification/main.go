@@ -312,11 +312,15 @@ func main() {
 			if err != nil {
 				return nil, fmt.Errorf("could not create leader selection for main consensus: %w", err)
 			}
+			leaders, err := leader.NewReputationSelectionForConsensus(selection, node.BaseConfig.LeaderReputationWindow, node.RootBlock.Header, node.State, node.Storage.Headers)
+			if err != nil {
+				return nil, fmt.Errorf("could not create leader reputation for main consensus: %w", err)
+			}
 
 			// initialize consensus committee's membership state
 			// This committee state is for the HotStuff follower, which follows the MAIN CONSENSUS Committee
 			// Note: node.Me.NodeID() is not part of the consensus committee
-			mainConsensusCommittee, err := committee.NewMainConsensusCommitteeState(node.State, node.Me.NodeID(), selection)
+			mainConsensusCommittee, err := committee.NewMainConsensusCommitteeState(node.State, node.Me.NodeID(), leaders)
 			if err != nil {
 				return nil, fmt.Errorf("could not create Committee state for main consensus: %w", err)
 			}
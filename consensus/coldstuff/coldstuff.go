@@ -113,6 +113,11 @@ func (e *ColdStuff) SubmitVote(originID, blockID flow.Identifier, view uint64, s
 	}
 }
 
+func (e *ColdStuff) SubmitTimeout(originID flow.Identifier, view uint64, highestQC *flow.QuorumCertificate, sigData []byte) {
+	// ColdStuff has no notion of timeouts from other replicas, as each
+	// round is driven by the leader
+}
+
 func (e *ColdStuff) SubmitCommit(commit *model.Commit) {
 	e.commits <- commit
 }
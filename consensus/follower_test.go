@@ -77,8 +77,8 @@ func (s *HotStuffFollowerSuite) SetupTest() {
 	for _, identity := range identities {
 		s.committee.On("Identity", mock.Anything, identity.NodeID).Return(identity, nil)
 	}
-	s.committee.On("LeaderForView", mock.Anything).Return(
-		func(view uint64) flow.Identifier { return identities[int(view)%len(identities)].NodeID },
+	s.committee.On("LeaderForView", mock.Anything, mock.Anything).Return(
+		func(_ flow.Identifier, view uint64) flow.Identifier { return identities[int(view)%len(identities)].NodeID },
 		nil,
 	)
 
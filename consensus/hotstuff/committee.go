@@ -32,12 +32,15 @@ type Committee interface {
 	//    * ErrInvalidSigner if participantID does NOT correspond to a _staked_ HotStuff participant at the specified block.
 	Identity(blockID flow.Identifier, participantID flow.Identifier) (*flow.Identity, error)
 
-	// LeaderForView returns the identity of the leader for a given view.
-	// CAUTION: per liveness requirement of HotStuff, the leader must be fork-independent.
-	//          Therefore, a node retains its proposer view slots even if it is slashed.
+	// LeaderForView returns the identity of the leader for a given view, for a block extending the given parent.
+	// The leader may depend on the ancestry of the parent, e.g. to deprioritize leaders that failed to propose
+	// in the past. All replicas agree on the leader of a view for a given parent.
+	// CAUTION: per liveness requirement of HotStuff, the leader must not depend on the ancestry within the
+	//          recent views, which are not agreed on yet; in particular, it must not depend on the parent itself.
+	//          A node retains its proposer view slots even if it is slashed.
 	//          Its proposal is simply considered invalid, as it is not from a legitimate participant.
 	// Can error if view is in a future Epoch for which the HotStuff committee hasn't been determined yet.
-	LeaderForView(view uint64) (flow.Identifier, error)
+	LeaderForView(parentID flow.Identifier, view uint64) (flow.Identifier, error)
 
 	// Self returns our own node identifier.
 	// TODO: ultimately, the own identity of the node is necessary for signing.
@@ -40,8 +40,8 @@ type Committee struct {
 	//TODO: ultimately, the own identity of the node is necessary for signing.
 	//      Ideally, we would move the method for checking whether an Identifier refers to this node to the signer.
 	myID flow.Identifier // my own identifier
-	// pre-generated leader selection
-	leaderSelection *LeaderSelection
+	// leader selection, either pre-generated or deprioritizing unresponsive leaders
+	leaderSelection LeaderSelector
 }
 
 // Identities returns a IdentityList with legitimate HotStuff participants for the specified block.
@@ -89,16 +89,15 @@ func (c *Committee) Identity(blockID flow.Identifier, participantID flow.Identif
 	return identity, nil
 }
 
-// LeaderForView returns the identity of the leader for a given view.
-// CAUTION: per liveness requirement of HotStuff, the leader must be fork-independent.
-//          Therefore, a node retains its proposer view slots even if it is slashed.
+// LeaderForView returns the identity of the leader for a given view, for a block extending the given parent.
+// CAUTION: per liveness requirement of HotStuff, the leader must not depend on the recent ancestry of the parent.
+//          A node retains its proposer view slots even if it is slashed.
 //          Its proposal is simply considered invalid, as it is not from a legitimate participant.
 // Can error if view is in a future Epoch for which the HotStuff committee hasn't been determined yet.
-func (c *Committee) LeaderForView(view uint64) (flow.Identifier, error) {
-	// As long as there are no Epochs, this implementation will never return an error, as
-	// leaders can be pre-determined for every view. This will change, when Epochs are added.
-	// The API already contains the error return parameter, to be future-proof.
-	leaderIndex, err := c.leaderSelection.LeaderIndexForView(view)
+func (c *Committee) LeaderForView(parentID flow.Identifier, view uint64) (flow.Identifier, error) {
+	// As long as there are no Epochs, this implementation will only return an error if the ancestry
+	// of the parent is unknown to the leader selection. This will change, when Epochs are added.
+	leaderIndex, err := c.leaderSelection.LeaderIndexForBlock(parentID, view)
 	if err != nil {
 		return flow.ZeroID, fmt.Errorf("can not get leader index for view: %w", err)
 	}
@@ -128,6 +127,7 @@ func (c *Committee) DKG(blockID flow.Identifier) (hotstuff.DKG, error) {
 //      It must filter for: node role, non-zero stake, and potentially the collector cluster (if applicable)
 //    * epochParticipants: all nodes that were part of the initially released list of participants for this HotStuff instance.
 //	    All participants for the current Epoch retain their spot as primaries for the respective views (even if they are slashed!)
+//    * leaderSelection: determines the index of the primary within epochParticipants for each view
 //
 // While you can use this constructor to generate a committee state for the main consensus,
 // the function `NewMainConsensusCommitteeState` provides a more concise API.
@@ -137,7 +137,7 @@ func New(
 	myID flow.Identifier,
 	membersFilter flow.IdentityFilter,
 	epochParticipants flow.IdentifierList,
-	leaderSelection *LeaderSelection,
+	leaderSelection LeaderSelector,
 ) hotstuff.Committee {
 	return &Committee{
 		protocolState:     protocolState,
@@ -156,7 +156,7 @@ func New(
 //
 // For constructing committees for other HotStuff instances (such as collector HotStuff instances), please use the
 // generic `New` function.
-func NewMainConsensusCommitteeState(protocolState protocol.State, myID flow.Identifier, leaderSelection *LeaderSelection) (hotstuff.Committee, error) {
+func NewMainConsensusCommitteeState(protocolState protocol.State, myID flow.Identifier, leaderSelection LeaderSelector) (hotstuff.Committee, error) {
 
 	// finding all consensus members
 	epochConsensusMembers, err := protocolState.Final().Identities(filter.HasRole(flow.RoleConsensus))
@@ -8,6 +8,7 @@ import (
 	"github.com/onflow/flow-go/model/flow/filter"
 	"github.com/onflow/flow-go/model/indices"
 	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
 )
 
 const EstimatedSixMonthOfViews = 15000000 // 1 sec block time * 60 secs * 60 mins * 24 hours * 30 days * 6 months
@@ -31,3 +32,27 @@ func NewSelectionForConsensus(count int, rootHeader *flow.Header, rootQC *flow.Q
 
 	return selection, nil
 }
+
+// NewReputationSelectionForConsensus wraps the given leader selection for the main consensus, such
+// that consensus nodes which failed to propose are deprioritized, based on the ancestry of the blocks.
+// A window of 0 disables the reputation, and the given leader selection is returned as is.
+// All nodes following the main consensus have to use the same window, otherwise they disagree
+// on the leaders and reject each other's blocks.
+func NewReputationSelectionForConsensus(selection *committee.LeaderSelection, window uint64, rootHeader *flow.Header, st protocol.State, headers storage.Headers) (committee.LeaderSelector, error) {
+	if window == 0 {
+		return selection, nil
+	}
+
+	// find all consensus nodes identities, in the same order as for the wrapped selection
+	identities, err := st.AtBlockID(rootHeader.ID()).Identities(filter.HasRole(flow.RoleConsensus))
+	if err != nil {
+		return nil, fmt.Errorf("could not get consensus identities: %w", err)
+	}
+
+	reputation, err := committee.NewReputationLeaderSelection(selection, identities.NodeIDs(), headers, rootHeader.View, window)
+	if err != nil {
+		return nil, fmt.Errorf("could not create reputation leader selection: %w", err)
+	}
+
+	return reputation, nil
+}
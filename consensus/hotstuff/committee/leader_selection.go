@@ -33,6 +33,11 @@ func (l LeaderSelection) LeaderIndexForView(view uint64) (int, error) {
 	return l.leaderIndexesForView[index], nil
 }
 
+// LeaderIndexForBlock returns the leader index for given view, which does not depend on the parent.
+func (l LeaderSelection) LeaderIndexForBlock(_ flow.Identifier, view uint64) (int, error) {
+	return l.LeaderIndexForView(view)
+}
+
 // ComputeLeaderSelectionFromSeed pre-generates a certain number of leader selections, and returns a
 // leader selection instance for querying the leader indexes for certain views.
 // epochStartView - the start view of the epoch, the generated leader selections start from this view.
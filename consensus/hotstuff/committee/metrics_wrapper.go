@@ -40,9 +40,9 @@ func (w CommitteeMetricsWrapper) Identity(blockID flow.Identifier, participantID
 	return identity, err
 }
 
-func (w CommitteeMetricsWrapper) LeaderForView(view uint64) (flow.Identifier, error) {
+func (w CommitteeMetricsWrapper) LeaderForView(parentID flow.Identifier, view uint64) (flow.Identifier, error) {
 	processStart := time.Now()
-	id, err := w.committee.LeaderForView(view)
+	id, err := w.committee.LeaderForView(parentID, view)
 	w.metrics.CommitteeProcessingDuration(time.Since(processStart))
 	return id, err
 }
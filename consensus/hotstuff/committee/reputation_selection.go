@@ -0,0 +1,257 @@
+package committee
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// LeaderSelector determines the index of the leader for a given view, for a block extending
+// the given parent. The index refers to the list of HotStuff participants the leader selection
+// was generated for.
+type LeaderSelector interface {
+	LeaderIndexForBlock(parentID flow.Identifier, view uint64) (int, error)
+}
+
+// ViewLeaderSelector determines the index of the leader for a given view, independently of
+// the fork.
+type ViewLeaderSelector interface {
+	LeaderIndexForView(view uint64) (int, error)
+}
+
+// Headers provides the headers of the blocks of a HotStuff instance.
+type Headers interface {
+	ByBlockID(blockID flow.Identifier) (*flow.Header, error)
+}
+
+// ReputationLeaderSelection is a leader selection that deprioritizes unresponsive leaders.
+// Views are grouped into windows of fixed size, starting at the epoch start view. The
+// leaders for the views of a window are taken from the wrapped leader selection, except for
+// leaders that were unresponsive two windows earlier; their views are handed over to the
+// next leader in the wrapped selection that was responsive.
+//
+// A participant is considered unresponsive within a window if it did not propose any of the
+// window's blocks, and it either did not propose a block for one of its views, or it did not
+// sign any QC included in the window's blocks. Hence, a participant that did not get to propose
+// because it was deprioritized regains its views as soon as it votes.
+//
+// The reputation is derived from the ancestry of the block that a proposal extends, so that all
+// replicas agree on the leader of a proposal regardless of what they have finalized. As the
+// window the reputation is derived from lies at least a full window behind, replicas building
+// on different blocks only disagree on a leader if their forks diverged before that, which
+// HotStuff resolves long before.
+type ReputationLeaderSelection struct {
+	selection      ViewLeaderSelector
+	participants   flow.IdentifierList
+	headers        Headers
+	epochStartView uint64
+	window         uint64
+
+	lock sync.Mutex
+	// anchors caches the newest ancestor of a block which lies before the end of a window
+	anchors map[windowBlock]flow.Identifier
+	// unresponsive caches the indexes of the unresponsive participants by window and anchor
+	unresponsive map[windowBlock]map[int]struct{}
+}
+
+// windowBlock identifies a block in the context of a window
+type windowBlock struct {
+	window  uint64
+	blockID flow.Identifier
+}
+
+// NewReputationLeaderSelection creates a leader selection which deprioritizes unresponsive leaders.
+// selection - the leader selection providing the default leaders for each view
+// participants - the participants, in the same order as used for the wrapped selection
+// headers - the headers of the blocks, which are used to determine unresponsive participants
+// epochStartView - the start view of the epoch, the first window starts with this view.
+// window - the number of views of a window, for which the same participants are deprioritized
+func NewReputationLeaderSelection(selection ViewLeaderSelector, participants flow.IdentifierList, headers Headers, epochStartView uint64, window uint64) (*ReputationLeaderSelection, error) {
+	if len(participants) == 0 {
+		return nil, fmt.Errorf("participants is empty")
+	}
+	if window == 0 {
+		return nil, fmt.Errorf("window must be greater than 0")
+	}
+
+	r := &ReputationLeaderSelection{
+		selection:      selection,
+		participants:   participants,
+		headers:        headers,
+		epochStartView: epochStartView,
+		window:         window,
+		anchors:        make(map[windowBlock]flow.Identifier),
+		unresponsive:   make(map[windowBlock]map[int]struct{}),
+	}
+	return r, nil
+}
+
+// LeaderIndexForBlock returns the leader index for given view, for a block extending the given parent.
+// If the view is smaller than the epochStartView, an error will be returned.
+func (r *ReputationLeaderSelection) LeaderIndexForBlock(parentID flow.Identifier, view uint64) (int, error) {
+	if view < r.epochStartView {
+		return 0, fmt.Errorf("view (%v) is smaller than the epochStartView (%v)", view, r.epochStartView)
+	}
+
+	leader, err := r.selection.LeaderIndexForView(view)
+	if err != nil {
+		return 0, fmt.Errorf("could not get default leader: %w", err)
+	}
+
+	// the first two windows have no history to derive the reputation from
+	window := (view - r.epochStartView) / r.window
+	if window < 2 {
+		return leader, nil
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	unresponsive, err := r.unresponsiveForWindow(parentID, window-2)
+	if err != nil {
+		return 0, fmt.Errorf("could not get unresponsive participants for view %d: %w", view, err)
+	}
+	if _, ok := unresponsive[leader]; !ok {
+		return leader, nil
+	}
+
+	// hand the view over to the next responsive leader in the default selection; as the
+	// default selection is stake-weighted, so is the choice among responsive participants
+	for next := view + 1; next <= view+r.window; next++ {
+		substitute, err := r.selection.LeaderIndexForView(next)
+		if err != nil {
+			// the default selection is exhausted, we can't find a substitute
+			break
+		}
+		if _, ok := unresponsive[substitute]; !ok {
+			return substitute, nil
+		}
+	}
+
+	return leader, nil
+}
+
+// unresponsiveForWindow returns the indexes of the participants which were unresponsive in the
+// given window, according to the ancestry of the given block.
+func (r *ReputationLeaderSelection) unresponsiveForWindow(blockID flow.Identifier, window uint64) (map[int]struct{}, error) {
+	from := r.epochStartView + window*r.window
+	to := from + r.window
+
+	anchorID, err := r.anchor(blockID, window, to)
+	if err != nil {
+		return nil, fmt.Errorf("could not find ancestor before view %d: %w", to, err)
+	}
+	key := windowBlock{window: window, blockID: anchorID}
+	unresponsive, ok := r.unresponsive[key]
+	if ok {
+		return unresponsive, nil
+	}
+
+	// collect the ancestors within the window, which all descend from the anchor
+	var headers []*flow.Header
+	for ancestorID := anchorID; ; {
+		header, err := r.headers.ByBlockID(ancestorID)
+		if err != nil {
+			return nil, fmt.Errorf("could not get ancestor %x: %w", ancestorID, err)
+		}
+		if header.View < from {
+			break
+		}
+		headers = append(headers, header)
+		if header.View <= r.epochStartView {
+			break
+		}
+		ancestorID = header.ParentID
+	}
+
+	// collect who proposed and who voted for the blocks
+	views := make(map[uint64]struct{}, len(headers))
+	proposers := make(map[flow.Identifier]struct{})
+	voters := make(map[flow.Identifier]struct{})
+	for _, header := range headers {
+		views[header.View] = struct{}{}
+		proposers[header.ProposerID] = struct{}{}
+		for _, voterID := range header.ParentVoterIDs {
+			voters[voterID] = struct{}{}
+		}
+	}
+
+	// collect the leaders that failed to propose for one of their views
+	missed := make(map[int]struct{})
+	for view := from; view < to; view++ {
+		if _, ok := views[view]; ok {
+			continue
+		}
+		leader, err := r.selection.LeaderIndexForView(view)
+		if err != nil {
+			return nil, fmt.Errorf("could not get default leader for view %d: %w", view, err)
+		}
+		missed[leader] = struct{}{}
+	}
+
+	unresponsive = make(map[int]struct{})
+	for index, participantID := range r.participants {
+		if _, ok := proposers[participantID]; ok {
+			continue
+		}
+		_, hasMissed := missed[index]
+		_, hasVoted := voters[participantID]
+		if hasMissed || !hasVoted {
+			unresponsive[index] = struct{}{}
+		}
+	}
+
+	// if nobody is responsive, we don't deprioritize anybody
+	if len(unresponsive) == len(r.participants) {
+		unresponsive = make(map[int]struct{})
+	}
+
+	r.prune(window)
+	r.unresponsive[key] = unresponsive
+	return unresponsive, nil
+}
+
+// anchor returns the ID of the newest ancestor of the given block, including the block itself, with
+// a view before the given view, which is the end of the given window. The ancestors walked through are
+// cached, so that finding the anchor for a child of a known block doesn't require to walk any further.
+func (r *ReputationLeaderSelection) anchor(blockID flow.Identifier, window uint64, to uint64) (flow.Identifier, error) {
+	var walked []flow.Identifier
+	anchorID := blockID
+	for {
+		cachedID, ok := r.anchors[windowBlock{window: window, blockID: anchorID}]
+		if ok {
+			anchorID = cachedID
+			break
+		}
+		header, err := r.headers.ByBlockID(anchorID)
+		if err != nil {
+			return flow.ZeroID, fmt.Errorf("could not get ancestor %x: %w", anchorID, err)
+		}
+		if header.View < to {
+			break
+		}
+		walked = append(walked, anchorID)
+		anchorID = header.ParentID
+	}
+
+	for _, walkedID := range walked {
+		r.anchors[windowBlock{window: window, blockID: walkedID}] = anchorID
+	}
+	return anchorID, nil
+}
+
+// prune drops the cached data of the windows before the given window, which are only needed for
+// proposals that are long outdated
+func (r *ReputationLeaderSelection) prune(window uint64) {
+	for key := range r.anchors {
+		if key.window < window {
+			delete(r.anchors, key)
+		}
+	}
+	for key := range r.unresponsive {
+		if key.window < window {
+			delete(r.unresponsive, key)
+		}
+	}
+}
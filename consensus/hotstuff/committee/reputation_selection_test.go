@@ -0,0 +1,242 @@
+package committee
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// testHeaders is a block tree that is built in memory
+type testHeaders struct {
+	headers  map[flow.Identifier]*flow.Header
+	requests int
+}
+
+// newTestHeaders creates a block tree containing only a root block at view 0
+func newTestHeaders() (*testHeaders, *flow.Header) {
+	root := &flow.Header{View: 0}
+	h := &testHeaders{headers: map[flow.Identifier]*flow.Header{root.ID(): root}}
+	return h, root
+}
+
+func (h *testHeaders) ByBlockID(blockID flow.Identifier) (*flow.Header, error) {
+	h.requests++
+	header, ok := h.headers[blockID]
+	if !ok {
+		return nil, fmt.Errorf("unknown block %x", blockID)
+	}
+	return header, nil
+}
+
+// add adds a block for the given view on top of the given parent, with the given proposer and voters
+func (h *testHeaders) add(parent *flow.Header, view uint64, proposerID flow.Identifier, voters flow.IdentifierList) *flow.Header {
+	header := &flow.Header{
+		ParentID:       parent.ID(),
+		Height:         parent.Height + 1,
+		View:           view,
+		ProposerID:     proposerID,
+		ParentVoterIDs: voters,
+	}
+	h.headers[header.ID()] = header
+	return header
+}
+
+// extend adds a chain of blocks on top of the given parent, one for each of the given views,
+// proposed by the default leader for the view and signed by the given voters, and returns the
+// last block
+func (h *testHeaders) extend(parent *flow.Header, participants flow.IdentifierList, selection ViewLeaderSelector, voters flow.IdentifierList, views ...uint64) *flow.Header {
+	for _, view := range views {
+		leader, _ := selection.LeaderIndexForView(view)
+		parent = h.add(parent, view, participants[leader], voters)
+	}
+	return parent
+}
+
+func viewRange(from uint64, to uint64) []uint64 {
+	views := make([]uint64, 0, to-from)
+	for view := from; view < to; view++ {
+		views = append(views, view)
+	}
+	return views
+}
+
+// roundRobin returns a pre-generated leader selection where the participants take turns
+func roundRobin(participants int, count int) *LeaderSelection {
+	leaders := make([]int, 0, count)
+	for i := 0; i < count; i++ {
+		leaders = append(leaders, i%participants)
+	}
+	return &LeaderSelection{leaderIndexesForView: leaders}
+}
+
+// Test the default leaders are used for the first two windows, which have no history
+func TestReputationFirstWindows(t *testing.T) {
+	participants := unittest.IdentifierListFixture(4)
+	selection := roundRobin(4, 100)
+	headers, _ := newTestHeaders()
+
+	reputation, err := NewReputationLeaderSelection(selection, participants, headers, 0, 8)
+	require.NoError(t, err)
+
+	for view := uint64(0); view < 16; view++ {
+		expected, err := selection.LeaderIndexForView(view)
+		require.NoError(t, err)
+		actual, err := reputation.LeaderIndexForBlock(unittest.IdentifierFixture(), view)
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+	}
+	require.Zero(t, headers.requests)
+}
+
+// Test a participant that didn't propose or vote is skipped, and its views are handed
+// over to the next responsive leader
+func TestReputationSkipsUnresponsiveLeader(t *testing.T) {
+	participants := unittest.IdentifierListFixture(4)
+	selection := roundRobin(4, 100)
+	headers, root := newTestHeaders()
+
+	// participant 1 is crashed and misses all of its views
+	voters := flow.IdentifierList{participants[0], participants[2], participants[3]}
+	var views []uint64
+	for view := uint64(1); view < 24; view++ {
+		if view%4 != 1 {
+			views = append(views, view)
+		}
+	}
+	tip := headers.extend(root, participants, selection, voters, views...)
+
+	reputation, err := NewReputationLeaderSelection(selection, participants, headers, 0, 8)
+	require.NoError(t, err)
+
+	for view := uint64(24); view < 32; view++ {
+		leader, err := reputation.LeaderIndexForBlock(tip.ID(), view)
+		require.NoError(t, err)
+		if view%4 == 1 {
+			require.Equal(t, 2, leader, "view %d should be handed over to the next leader", view)
+			continue
+		}
+		require.Equal(t, int(view%4), leader)
+	}
+}
+
+// Test a participant that didn't get to propose, but voted, regains its views
+func TestReputationVoterRegainsViews(t *testing.T) {
+	participants := unittest.IdentifierListFixture(4)
+	selection := roundRobin(4, 100)
+	headers, root := newTestHeaders()
+
+	// participant 1 has no block in the ancestry, as its views were handed over
+	tip := root
+	for view := uint64(1); view < 24; view++ {
+		proposer := view % 4
+		if proposer == 1 {
+			proposer = 2
+		}
+		tip = headers.add(tip, view, participants[proposer], participants)
+	}
+
+	reputation, err := NewReputationLeaderSelection(selection, participants, headers, 0, 8)
+	require.NoError(t, err)
+
+	for view := uint64(24); view < 32; view++ {
+		leader, err := reputation.LeaderIndexForBlock(tip.ID(), view)
+		require.NoError(t, err)
+		require.Equal(t, int(view%4), leader)
+	}
+}
+
+// Test the leaders are derived from the ancestry of the parent, regardless of other forks
+func TestReputationDependsOnAncestry(t *testing.T) {
+	participants := unittest.IdentifierListFixture(4)
+	selection := roundRobin(4, 100)
+	headers, root := newTestHeaders()
+
+	// on the first fork, participant 1 is crashed and misses its views of the first window;
+	// on the second fork, all participants are responsive
+	voters := flow.IdentifierList{participants[0], participants[2], participants[3]}
+	crashed := headers.extend(root, participants, selection, voters, 2, 3, 4, 6, 7, 8)
+	responsive := headers.extend(root, participants, selection, participants, viewRange(1, 9)...)
+
+	reputation, err := NewReputationLeaderSelection(selection, participants, headers, 0, 8)
+	require.NoError(t, err)
+
+	leader, err := reputation.LeaderIndexForBlock(crashed.ID(), 17)
+	require.NoError(t, err)
+	require.Equal(t, 2, leader)
+
+	leader, err = reputation.LeaderIndexForBlock(responsive.ID(), 17)
+	require.NoError(t, err)
+	require.Equal(t, 1, leader)
+
+	// a proposal extending an unknown block can't be attributed to a leader
+	_, err = reputation.LeaderIndexForBlock(unittest.IdentifierFixture(), 17)
+	require.Error(t, err)
+}
+
+// Test nobody is deprioritized if all participants are unresponsive
+func TestReputationAllUnresponsive(t *testing.T) {
+	participants := unittest.IdentifierListFixture(4)
+	selection := roundRobin(4, 100)
+	headers, root := newTestHeaders()
+
+	reputation, err := NewReputationLeaderSelection(selection, participants, headers, 0, 8)
+	require.NoError(t, err)
+
+	for view := uint64(16); view < 24; view++ {
+		leader, err := reputation.LeaderIndexForBlock(root.ID(), view)
+		require.NoError(t, err)
+		require.Equal(t, int(view%4), leader)
+	}
+}
+
+// Test the reputation is only derived once for each window of a fork
+func TestReputationCachedByWindow(t *testing.T) {
+	participants := unittest.IdentifierListFixture(4)
+	selection := roundRobin(4, 100)
+	headers, root := newTestHeaders()
+	tip := headers.extend(root, participants, selection, participants, viewRange(1, 30)...)
+
+	reputation, err := NewReputationLeaderSelection(selection, participants, headers, 0, 8)
+	require.NoError(t, err)
+
+	for view := uint64(30); view < 32; view++ {
+		_, err := reputation.LeaderIndexForBlock(tip.ID(), view)
+		require.NoError(t, err)
+	}
+	requests := headers.requests
+	require.NotZero(t, requests)
+
+	// the same leaders don't require any further headers
+	for view := uint64(30); view < 32; view++ {
+		_, err := reputation.LeaderIndexForBlock(tip.ID(), view)
+		require.NoError(t, err)
+	}
+	require.Equal(t, requests, headers.requests)
+
+	// the leader for a child only requires the header of the child
+	child := headers.add(tip, 30, participants[2], participants)
+	_, err = reputation.LeaderIndexForBlock(child.ID(), 31)
+	require.NoError(t, err)
+	require.Equal(t, requests+1, headers.requests)
+}
+
+func TestReputationInvalidParameters(t *testing.T) {
+	participants := unittest.IdentifierListFixture(4)
+	selection := roundRobin(4, 100)
+	headers, root := newTestHeaders()
+
+	_, err := NewReputationLeaderSelection(selection, participants, headers, 0, 0)
+	require.Error(t, err)
+
+	_, err = NewReputationLeaderSelection(selection, nil, headers, 0, 8)
+	require.Error(t, err)
+
+	reputation, err := NewReputationLeaderSelection(selection, participants, headers, 10, 8)
+	require.NoError(t, err)
+	_, err = reputation.LeaderIndexForBlock(root.ID(), 9)
+	require.Error(t, err)
+}
@@ -41,7 +41,7 @@ func (s Static) Identity(_ flow.Identifier, participantID flow.Identifier) (*flo
 	return identity, nil
 }
 
-func (s Static) LeaderForView(_ uint64) (flow.Identifier, error) {
+func (s Static) LeaderForView(_ flow.Identifier, _ uint64) (flow.Identifier, error) {
 	return flow.ZeroID, fmt.Errorf("invalid for static committee")
 }
 
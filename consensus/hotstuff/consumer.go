@@ -96,6 +96,27 @@ type Consumer interface {
 	// and must handle repetition of the same events (with some processing overhead).
 	OnVoting(vote *model.Vote)
 
+	// OnReceiveTimeout notifications are produced by the EventHandler when it starts processing a timeout.
+	// Prerequisites:
+	// Implementation must be concurrency safe; Non-blocking;
+	// and must handle repetition of the same events (with some processing overhead).
+	OnReceiveTimeout(currentView uint64, timeout *model.TimeoutObject)
+
+	// OnSendingTimeout notifications are produced by the EventHandler when the replica times out
+	// in a view and broadcasts its timeout.
+	// Prerequisites:
+	// Implementation must be concurrency safe; Non-blocking;
+	// and must handle repetition of the same events (with some processing overhead).
+	OnSendingTimeout(timeout *model.TimeoutObject)
+
+	// OnTcTriggeredViewChange notifications are produced by PaceMaker when it moves to a new view
+	// based on processing a TC. The arguments specify the tc (first argument), which triggered
+	// the view change, and the newView to which the PaceMaker transitioned (second argument).
+	// Prerequisites:
+	// Implementation must be concurrency safe; Non-blocking;
+	// and must handle repetition of the same events (with some processing overhead).
+	OnTcTriggeredViewChange(tc *model.TimeoutCertificate, newView uint64)
+
 	// OnQcConstructedFromVotes notifications are produced by the VoteAggregator
 	// component, whenever it constructs a QC from votes.
 	// Prerequisites:
@@ -103,6 +124,13 @@ type Consumer interface {
 	// and must handle repetition of the same events (with some processing overhead).
 	OnQcConstructedFromVotes(*flow.QuorumCertificate)
 
+	// OnTcConstructedFromTimeouts notifications are produced by the TimeoutAggregator
+	// component, whenever it constructs a TC from timeouts.
+	// Prerequisites:
+	// Implementation must be concurrency safe; Non-blocking;
+	// and must handle repetition of the same events (with some processing overhead).
+	OnTcConstructedFromTimeouts(*model.TimeoutCertificate)
+
 	// OnStartingTimeout notifications are produced by PaceMaker. Such a notification indicates that the
 	// PaceMaker is now waiting for the system to (receive and) process blocks or votes.
 	// The specific timeout type is contained in the TimerInfo.
@@ -146,4 +174,11 @@ type Consumer interface {
 	// Implementation must be concurrency safe; Non-blocking;
 	// and must handle repetition of the same events (with some processing overhead).
 	OnInvalidVoteDetected(*model.Vote)
+
+	// OnInvalidTimeoutDetected notifications are produced by the Timeout Aggregation logic
+	// whenever an invalid timeout was detected.
+	// Prerequisites:
+	// Implementation must be concurrency safe; Non-blocking;
+	// and must handle repetition of the same events (with some processing overhead).
+	OnInvalidTimeoutDetected(*model.TimeoutObject)
 }
@@ -17,6 +17,10 @@ type EventHandler interface {
 	// consensus participant.
 	OnReceiveProposal(proposal *model.Proposal) error
 
+	// OnReceiveTimeout processes a timeout received from another HotStuff
+	// consensus participant.
+	OnReceiveTimeout(timeout *model.TimeoutObject) error
+
 	// OnLocalTimeout will check if there was a local timeout.
 	OnLocalTimeout() error
 
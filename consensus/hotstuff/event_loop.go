@@ -19,6 +19,7 @@ type EventLoop struct {
 	metrics      module.HotstuffMetrics
 	proposals    chan *model.Proposal
 	votes        chan *model.Vote
+	timeouts     chan *model.TimeoutObject
 
 	unit *engine.Unit // lock for preventing concurrent state transitions
 }
@@ -27,6 +28,7 @@ type EventLoop struct {
 func NewEventLoop(log zerolog.Logger, metrics module.HotstuffMetrics, eventHandler EventHandler) (*EventLoop, error) {
 	proposals := make(chan *model.Proposal)
 	votes := make(chan *model.Vote)
+	timeouts := make(chan *model.TimeoutObject)
 
 	el := &EventLoop{
 		log:          log,
@@ -34,6 +36,7 @@ func NewEventLoop(log zerolog.Logger, metrics module.HotstuffMetrics, eventHandl
 		metrics:      metrics,
 		proposals:    proposals,
 		votes:        votes,
+		timeouts:     timeouts,
 		unit:         engine.NewUnit(),
 	}
 
@@ -95,7 +98,7 @@ func (el *EventLoop) loop() {
 
 		idleStart := time.Now()
 
-		// select for block headers/votes here
+		// select for block headers/votes/timeouts here
 		select {
 
 		// same as before
@@ -152,6 +155,23 @@ func (el *EventLoop) loop() {
 			if err != nil {
 				el.log.Fatal().Err(err).Msg("could not process vote")
 			}
+
+		// if we have a new timeout, process it
+		case t := <-el.timeouts:
+			// measure how long the event loop was idle waiting for an
+			// incoming event
+			el.metrics.HotStuffIdleDuration(time.Since(idleStart))
+
+			processStart := time.Now()
+
+			err := el.eventHandler.OnReceiveTimeout(t)
+
+			// measure how long it takes for a timeout to be processed
+			el.metrics.HotStuffBusyDuration(time.Since(processStart), metrics.HotstuffEventTypeOnTimeout)
+
+			if err != nil {
+				el.log.Fatal().Err(err).Msg("could not process timeout from replica")
+			}
 		}
 	}
 }
@@ -190,6 +210,23 @@ func (el *EventLoop) SubmitVote(originID flow.Identifier, blockID flow.Identifie
 	el.metrics.HotStuffWaitDuration(time.Since(received), metrics.HotstuffEventTypeOnVote)
 }
 
+// SubmitTimeout pushes the received timeout to the timeouts channel
+func (el *EventLoop) SubmitTimeout(originID flow.Identifier, view uint64, highestQC *flow.QuorumCertificate, sigData []byte) {
+	received := time.Now()
+
+	timeout := model.TimeoutFromFlow(originID, view, highestQC, sigData)
+
+	select {
+	case el.timeouts <- timeout:
+	case <-el.unit.Quit():
+		return
+	}
+
+	// the wait duration is measured as how long it takes from a timeout being
+	// received to event handler commencing the processing of the timeout
+	el.metrics.HotStuffWaitDuration(time.Since(received), metrics.HotstuffEventTypeOnTimeout)
+}
+
 // Ready implements interface module.ReadyDoneAware
 // Method call will starts the EventLoop's internal processing loop.
 // Multiple calls are handled gracefully and the event loop will only start
@@ -13,22 +13,28 @@ import (
 	"github.com/onflow/flow-go/utils/logging"
 )
 
+// maxVoteViewsAhead is the number of views beyond the current view for which votes are processed. Votes for
+// views further ahead are dropped, so that byzantine replicas can't exhaust the memory or the disk with votes
+// for arbitrary views. A replica lagging further behind catches up with the QCs of proposals instead.
+const maxVoteViewsAhead = 1000
+
 // EventHandler is the main handler for individual events that trigger state transition.
 // It exposes API to handle one event at a time synchronously. The caller is
 // responsible for running the event loop to ensure that.
 type EventHandler struct {
-	log            zerolog.Logger
-	paceMaker      hotstuff.PaceMaker
-	blockProducer  hotstuff.BlockProducer
-	forks          hotstuff.Forks
-	persist        hotstuff.Persister
-	communicator   hotstuff.Communicator
-	committee      hotstuff.Committee
-	voteAggregator hotstuff.VoteAggregator
-	voter          hotstuff.Voter
-	validator      hotstuff.Validator
-	notifier       hotstuff.Consumer
-	ownProposal    flow.Identifier
+	log               zerolog.Logger
+	paceMaker         hotstuff.PaceMaker
+	blockProducer     hotstuff.BlockProducer
+	forks             hotstuff.Forks
+	persist           hotstuff.Persister
+	communicator      hotstuff.Communicator
+	committee         hotstuff.Committee
+	voteAggregator    hotstuff.VoteAggregator
+	timeoutAggregator hotstuff.TimeoutAggregator
+	voter             hotstuff.Voter
+	validator         hotstuff.Validator
+	notifier          hotstuff.Consumer
+	ownProposal       flow.Identifier
 }
 
 // New creates an EventHandler instance with initial components.
@@ -41,23 +47,25 @@ func New(
 	communicator hotstuff.Communicator,
 	committee hotstuff.Committee,
 	voteAggregator hotstuff.VoteAggregator,
+	timeoutAggregator hotstuff.TimeoutAggregator,
 	voter hotstuff.Voter,
 	validator hotstuff.Validator,
 	notifier hotstuff.Consumer,
 ) (*EventHandler, error) {
 	e := &EventHandler{
-		log:            log.With().Str("hotstuff", "participant").Logger(),
-		paceMaker:      paceMaker,
-		blockProducer:  blockProducer,
-		forks:          forks,
-		persist:        persist,
-		communicator:   communicator,
-		voteAggregator: voteAggregator,
-		voter:          voter,
-		validator:      validator,
-		committee:      committee,
-		notifier:       notifier,
-		ownProposal:    flow.ZeroID,
+		log:               log.With().Str("hotstuff", "participant").Logger(),
+		paceMaker:         paceMaker,
+		blockProducer:     blockProducer,
+		forks:             forks,
+		persist:           persist,
+		communicator:      communicator,
+		voteAggregator:    voteAggregator,
+		timeoutAggregator: timeoutAggregator,
+		voter:             voter,
+		validator:         validator,
+		committee:         committee,
+		notifier:          notifier,
+		ownProposal:       flow.ZeroID,
 	}
 	return e, nil
 }
@@ -83,6 +91,13 @@ func (e *EventHandler) OnReceiveVote(vote *model.Vote) error {
 		return nil
 	}
 
+	// votes too far ahead of the current view should be dropped, as they are
+	// neither pruned nor needed to catch up:
+	if vote.View > curView+maxVoteViewsAhead {
+		log.Debug().Msg("skipping vote view too far ahead of current view")
+		return nil
+	}
+
 	err := e.processVote(vote)
 	if err != nil {
 		return fmt.Errorf("failed processing vote: %w", err)
@@ -92,6 +107,49 @@ func (e *EventHandler) OnReceiveVote(vote *model.Vote) error {
 	return nil
 }
 
+// OnReceiveTimeout processes the timeout when a timeout is received.
+// Timeouts are aggregated into a TC, which allows replicas lagging behind to
+// catch up with the replicas that have already timed out.
+func (e *EventHandler) OnReceiveTimeout(timeout *model.TimeoutObject) error {
+	curView := e.paceMaker.CurView()
+	log := e.log.With().
+		Uint64("cur_view", curView).
+		Uint64("timeout_view", timeout.View).
+		Uint64("qc_view", timeout.HighestQC.View).
+		Hex("signer", timeout.SignerID[:]).
+		Logger()
+
+	e.notifier.OnReceiveTimeout(curView, timeout)
+	defer e.notifier.OnEventProcessed()
+	log.Debug().Msg("timeout forwarded from compliance engine")
+
+	// timeouts for finalized view or older should be dropped:
+	if timeout.View <= e.forks.FinalizedView() {
+		log.Debug().Msg("skipping timeout view equal or below finalized view")
+		return nil
+	}
+
+	tc, built, err := e.timeoutAggregator.StoreTimeoutAndBuildTC(timeout)
+	if err != nil {
+		return fmt.Errorf("building tc for view %d failed: %w", timeout.View, err)
+	}
+	// if we don't have enough timeouts to build TC for this view:
+	// nothing more to do for processing timeout
+	if !built {
+		log.Debug().Msg("insufficient timeouts for TC, waiting for more")
+		return nil
+	}
+	log.Debug().Msg("enough timeouts for TC collected")
+
+	err = e.processTC(tc)
+	if err != nil {
+		return fmt.Errorf("failed processing tc: %w", err)
+	}
+	log.Debug().Msg("timeout processed")
+
+	return nil
+}
+
 // OnReceiveProposal processes the block when a block proposal is received.
 // It is assumed that the block proposal is incorporated. (its parent can be found
 // in the forks)
@@ -184,6 +242,14 @@ func (e *EventHandler) TimeoutChannel() <-chan time.Time {
 func (e *EventHandler) OnLocalTimeout() error {
 
 	curView := e.paceMaker.CurView()
+
+	// let the other replicas know that we timed out, so they can build a TC for the view;
+	// failing to do so must not keep this replica from moving on to the next view
+	err := e.broadcastTimeout(curView)
+	if err != nil {
+		e.log.Error().Err(err).Uint64("cur_view", curView).Msg("could not broadcast timeout")
+	}
+
 	newView := e.paceMaker.OnTimeout()
 	defer e.notifier.OnEventProcessed()
 
@@ -199,7 +265,7 @@ func (e *EventHandler) OnLocalTimeout() error {
 	}
 
 	// current view has changed, go to new view
-	err := e.startNewView()
+	err = e.startNewView()
 	if err != nil {
 		return fmt.Errorf("could not start new view: %w", err)
 	}
@@ -209,6 +275,43 @@ func (e *EventHandler) OnLocalTimeout() error {
 	return nil
 }
 
+// broadcastTimeout produces the timeout for the given view, including the newest QC known to
+// this replica, and sends it to all other consensus participants.
+// The own timeout is also stored in the timeout aggregator, as it counts towards the TC.
+// However, a TC built from it is not processed, because the local timeout already
+// triggers the view change.
+func (e *EventHandler) broadcastTimeout(curView uint64) error {
+	highestQC, _, err := e.forks.MakeForkChoice(curView)
+	if err != nil {
+		return fmt.Errorf("can not make fork choice for view %v: %w", curView, err)
+	}
+
+	timeout, err := e.voter.ProduceTimeout(curView, highestQC)
+	if err != nil {
+		return fmt.Errorf("could not produce timeout: %w", err)
+	}
+	e.notifier.OnSendingTimeout(timeout)
+
+	log := e.log.With().
+		Uint64("timeout_view", timeout.View).
+		Uint64("qc_view", highestQC.View).
+		Hex("qc_block_id", highestQC.BlockID[:]).
+		Logger()
+	log.Debug().Msg("forwarding timeout to communicator for broadcasting")
+
+	err = e.communicator.BroadcastTimeout(timeout.View, timeout.HighestQC, timeout.SigData)
+	if err != nil {
+		log.Warn().Err(err).Msg("could not forward timeout")
+	}
+
+	_, _, err = e.timeoutAggregator.StoreTimeoutAndBuildTC(timeout)
+	if err != nil {
+		return fmt.Errorf("could not store own timeout: %w", err)
+	}
+
+	return nil
+}
+
 // Start will start the pacemaker's timer and start the new view
 func (e *EventHandler) Start() error {
 	e.paceMaker.Start()
@@ -225,7 +328,8 @@ func (e *EventHandler) startNewView() error {
 		return fmt.Errorf("could not persist current view: %w", err)
 	}
 
-	currentLeader, err := e.committee.LeaderForView(curView)
+	// the leader of the current view builds on the highest QC, which is our fork choice
+	currentLeader, err := e.committee.LeaderForView(e.forks.HighestQC().BlockID, curView)
 	if err != nil {
 		return fmt.Errorf("failed to determine primary for new view %d: %w", curView, err)
 	}
@@ -238,7 +342,17 @@ func (e *EventHandler) startNewView() error {
 		Msg("entering new view")
 	e.notifier.OnEnteringView(curView, currentLeader)
 
-	e.pruneSubcomponents()
+	err = e.pruneSubcomponents()
+	if err != nil {
+		return fmt.Errorf("could not prune sub-components: %w", err)
+	}
+
+	// the view change might have been caused by a new QC, which updates the
+	// QCs of the safety data
+	err = e.voter.UpdateSafetyData()
+	if err != nil {
+		return fmt.Errorf("could not update safety data: %w", err)
+	}
 
 	if e.committee.Self() == currentLeader {
 		log.Debug().Msg("generating block proposal as leader")
@@ -318,8 +432,17 @@ func (e *EventHandler) startNewView() error {
 // notifications and prune immediately. However, we have followed the design paradigm that all
 // events are only for HotStuff-External components. The interaction of the HotStuff-internal
 // components is directly handled by the EventHandler.
-func (e *EventHandler) pruneSubcomponents() {
-	e.voteAggregator.PruneByView(e.forks.FinalizedView())
+func (e *EventHandler) pruneSubcomponents() error {
+	finalizedView := e.forks.FinalizedView()
+	e.voteAggregator.PruneByView(finalizedView)
+	e.timeoutAggregator.PruneByView(finalizedView)
+
+	// votes for the finalized view or older are dropped, so we don't need to keep them
+	err := e.persist.PruneVotes(finalizedView + 1)
+	if err != nil {
+		return fmt.Errorf("could not prune persisted votes: %w", err)
+	}
+	return nil
 }
 
 // processBlockForCurrentView processes the block for the current view.
@@ -337,7 +460,7 @@ func (e *EventHandler) processBlockForCurrentView(block *model.Block) error {
 
 	// checking if I'm the next leader
 	nextView := curView + 1
-	nextLeader, err := e.committee.LeaderForView(nextView)
+	nextLeader, err := e.committee.LeaderForView(block.BlockID, nextView)
 	if err != nil {
 		return fmt.Errorf("failed to determine primary for next view %d: %w", nextView, err)
 	}
@@ -538,3 +661,43 @@ func (e *EventHandler) processQC(qc *flow.QuorumCertificate) error {
 	// current view has changed, go to new view
 	return e.startNewView()
 }
+
+// processTC processes the QC included in the TC and checks whether the TC will trigger view change.
+// If triggered, then go to the new view.
+func (e *EventHandler) processTC(tc *model.TimeoutCertificate) error {
+
+	log := e.log.With().
+		Uint64("tc_view", tc.View).
+		Uint64("qc_view", tc.HighestQC.View).
+		Hex("qc_block_id", tc.HighestQC.BlockID[:]).
+		Int("signers", len(tc.SignerIDs)).
+		Logger()
+
+	// the newest QC included in the TC might be unknown to this replica. We only use it
+	// if the referenced block is known, in which case the QC can be validated. Otherwise,
+	// the QC will be received with the next proposal.
+	block, found := e.forks.GetBlock(tc.HighestQC.BlockID)
+	if found {
+		err := e.validator.ValidateQC(tc.HighestQC, block)
+		if model.IsInvalidBlockError(err) {
+			log.Warn().Err(err).Msg("invalid qc included in tc")
+		} else if err != nil {
+			return fmt.Errorf("cannot validate qc included in tc (%x): %w", tc.HighestQC.BlockID, err)
+		} else {
+			err = e.forks.AddQC(tc.HighestQC)
+			if err != nil {
+				return fmt.Errorf("cannot add QC to forks: %w", err)
+			}
+		}
+	}
+
+	_, viewChanged := e.paceMaker.UpdateCurViewWithTC(tc)
+	if !viewChanged {
+		log.Debug().Msg("TC didn't trigger view change, nothing to do")
+		return nil
+	}
+	log.Debug().Msg("TC triggered view change, starting new view now")
+
+	// current view has changed, go to new view
+	return e.startNewView()
+}
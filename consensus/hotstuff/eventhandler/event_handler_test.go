@@ -1,6 +1,7 @@
 package eventhandler_test
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"testing"
@@ -57,6 +58,13 @@ func (p *TestPaceMaker) UpdateCurViewWithBlock(block *model.Block, isLeaderForNe
 	return newView, changed
 }
 
+func (p *TestPaceMaker) UpdateCurViewWithTC(tc *model.TimeoutCertificate) (*model.NewViewEvent, bool) {
+	oldView := p.CurView()
+	newView, changed := p.PaceMaker.UpdateCurViewWithTC(tc)
+	p.t.Logf("pacemaker.UpdateCurViewWithTC old view: %v, new view: %v\n", oldView, p.CurView())
+	return newView, changed
+}
+
 func (p *TestPaceMaker) OnTimeout() *model.NewViewEvent {
 	oldView := p.CurView()
 	newView := p.PaceMaker.OnTimeout()
@@ -80,6 +88,7 @@ func initPaceMaker(t *testing.T, view uint64) hotstuff.PaceMaker {
 	pm := NewTestPaceMaker(t, view, timeout.NewController(tc), notifier)
 	notifier.On("OnStartingTimeout", mock.Anything).Return()
 	notifier.On("OnQcTriggeredViewChange", mock.Anything, mock.Anything).Return()
+	notifier.On("OnTcTriggeredViewChange", mock.Anything, mock.Anything).Return()
 	notifier.On("OnReachedTimeout", mock.Anything).Return()
 	pm.Start()
 	return pm
@@ -125,6 +134,34 @@ func (v *VoteAggregator) PruneByView(view uint64) {
 	v.t.Logf("pruned at view:%v\n", view)
 }
 
+// TimeoutAggregator is a mock for testing eventhandler
+type TimeoutAggregator struct {
+	// if a view exists in tcs field, then a timeout can be made into a TC
+	tcs map[uint64]*model.TimeoutCertificate
+	// stored records all timeouts that have been stored
+	stored []*model.TimeoutObject
+	t      *testing.T
+}
+
+func NewTimeoutAggregator(t *testing.T) *TimeoutAggregator {
+	return &TimeoutAggregator{
+		tcs: make(map[uint64]*model.TimeoutCertificate),
+		t:   t,
+	}
+}
+
+func (a *TimeoutAggregator) StoreTimeoutAndBuildTC(timeout *model.TimeoutObject) (*model.TimeoutCertificate, bool, error) {
+	a.stored = append(a.stored, timeout)
+	tc, ok := a.tcs[timeout.View]
+	a.t.Logf("timeoutaggregator.StoreTimeoutAndBuildTC, tc built: %v, for view: %v\n", ok, timeout.View)
+
+	return tc, ok, nil
+}
+
+func (a *TimeoutAggregator) PruneByView(view uint64) {
+	a.t.Logf("pruned timeouts at view:%v\n", view)
+}
+
 type Committee struct {
 	mocks.Committee
 	// to mock I'm the leader of a certain view, add the view into the keys of leaders field
@@ -137,7 +174,7 @@ func NewCommittee() *Committee {
 	}
 }
 
-func (c *Committee) LeaderForView(view uint64) (flow.Identifier, error) {
+func (c *Committee) LeaderForView(_ flow.Identifier, view uint64) (flow.Identifier, error) {
 	_, isLeader := c.leaders[view]
 	if isLeader {
 		return flow.Identifier{0x01}, nil
@@ -153,7 +190,11 @@ func (c *Committee) Self() flow.Identifier {
 type Voter struct {
 	votable       map[flow.Identifier]struct{}
 	lastVotedView uint64
-	t             *testing.T
+	// timeoutErr is returned when producing a timeout, if set
+	timeoutErr error
+	// updates counts how often the safety data was updated
+	updates int
+	t       *testing.T
 }
 
 func NewVoter(t *testing.T, lastVotedView uint64) *Voter {
@@ -173,14 +214,30 @@ func (v *Voter) ProduceVoteIfVotable(block *model.Block, curView uint64) (*model
 	return createVote(block), nil
 }
 
+// voter will always produce a timeout, unless timeoutErr is set
+func (v *Voter) ProduceTimeout(curView uint64, highestQC *flow.QuorumCertificate) (*model.TimeoutObject, error) {
+	if v.timeoutErr != nil {
+		return nil, v.timeoutErr
+	}
+	return createTimeout(curView, highestQC), nil
+}
+
+func (v *Voter) UpdateSafetyData() error {
+	v.updates++
+	return nil
+}
+
 // Forks mock allows to customize the Add QC and AddBlock function by specifying the addQC and addBlock callbacks
 type Forks struct {
 	mocks.Forks
 	// blocks stores all the blocks that have been added to the forks
 	blocks    map[flow.Identifier]*model.Block
 	finalized uint64
-	t         *testing.T
-	qc        *flow.QuorumCertificate
+	// finalizedBlock is the latest finalized block, whose QC is used for the fork choice
+	// before any other QC has been added
+	finalizedBlock *model.Block
+	t              *testing.T
+	qc             *flow.QuorumCertificate
 	// addQC is to customize the logic to change finalized view
 	addQC func(qc *flow.QuorumCertificate) error
 	// addBlock is to customize the logic to change finalized view
@@ -189,10 +246,12 @@ type Forks struct {
 
 func NewForks(t *testing.T, finalized uint64) *Forks {
 	f := &Forks{
-		blocks:    make(map[flow.Identifier]*model.Block),
-		finalized: finalized,
-		t:         t,
+		blocks:         make(map[flow.Identifier]*model.Block),
+		finalized:      finalized,
+		finalizedBlock: createBlock(finalized),
+		t:              t,
 	}
+	f.qc = createQC(f.finalizedBlock)
 
 	f.addQC = func(qc *flow.QuorumCertificate) error {
 		if f.qc == nil || qc.View > f.qc.View {
@@ -250,6 +309,9 @@ func (f *Forks) MakeForkChoice(curView uint64) (*flow.QuorumCertificate, *model.
 	}
 
 	block, ok := f.blocks[f.qc.BlockID]
+	if !ok && f.qc.BlockID == f.finalizedBlock.BlockID {
+		block, ok = f.finalizedBlock, true
+	}
 	if !ok {
 		return nil, nil, fmt.Errorf("cannot block %V for fork choice qc", f.qc.BlockID)
 	}
@@ -257,6 +319,10 @@ func (f *Forks) MakeForkChoice(curView uint64) (*flow.QuorumCertificate, *model.
 	return f.qc, block, nil
 }
 
+func (f *Forks) HighestQC() *flow.QuorumCertificate {
+	return f.qc
+}
+
 // BlockProducer mock will always make a valid block
 type BlockProducer struct{}
 
@@ -312,16 +378,17 @@ type EventHandlerSuite struct {
 
 	eventhandler *eventhandler.EventHandler
 
-	paceMaker      hotstuff.PaceMaker
-	forks          *Forks
-	persist        *mocks.Persister
-	blockProducer  *BlockProducer
-	communicator   *mocks.Communicator
-	committee      *Committee
-	voteAggregator *VoteAggregator
-	voter          *Voter
-	validator      *BlacklistValidator
-	notifier       hotstuff.Consumer
+	paceMaker         hotstuff.PaceMaker
+	forks             *Forks
+	persist           *mocks.Persister
+	blockProducer     *BlockProducer
+	communicator      *mocks.Communicator
+	committee         *Committee
+	voteAggregator    *VoteAggregator
+	timeoutAggregator *TimeoutAggregator
+	voter             *Voter
+	validator         *BlacklistValidator
+	notifier          hotstuff.Consumer
 
 	initView    uint64
 	endView     uint64
@@ -338,12 +405,15 @@ func (es *EventHandlerSuite) SetupTest() {
 	es.forks = NewForks(es.T(), finalized)
 	es.persist = &mocks.Persister{}
 	es.persist.On("PutStarted", mock.Anything).Return(nil)
+	es.persist.On("PruneVotes", mock.Anything).Return(nil)
 	es.blockProducer = &BlockProducer{}
 	es.communicator = &mocks.Communicator{}
 	es.communicator.On("BroadcastProposalWithDelay", mock.Anything, mock.Anything).Return(nil)
 	es.communicator.On("SendVote", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	es.communicator.On("BroadcastTimeout", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	es.committee = NewCommittee()
 	es.voteAggregator = NewVoteAggregator(es.T())
+	es.timeoutAggregator = NewTimeoutAggregator(es.T())
 	es.voter = NewVoter(es.T(), finalized)
 	es.validator = NewBlacklistValidator(es.T())
 	es.notifier = &notifications.NoopConsumer{}
@@ -357,6 +427,7 @@ func (es *EventHandlerSuite) SetupTest() {
 		es.communicator,
 		es.committee,
 		es.voteAggregator,
+		es.timeoutAggregator,
 		es.voter,
 		es.validator,
 		es.notifier)
@@ -412,6 +483,17 @@ func (es *EventHandlerSuite) TestMissingVotingBlock() {
 	require.Equal(es.T(), es.endView, es.paceMaker.CurView(), "incorrect view change")
 }
 
+func (es *EventHandlerSuite) TestVoteTooFarAhead() {
+	// the voting block is known, so the vote would be processed otherwise
+	es.vote.View = es.paceMaker.CurView() + 1001
+	es.forks.blocks[es.votingBlock.BlockID] = es.votingBlock
+	es.voteAggregator.qcs[es.votingBlock.BlockID] = createQC(es.votingBlock)
+
+	err := es.eventhandler.OnReceiveVote(es.vote)
+	require.NoError(es.T(), err, "if a vote's view is too far ahead of the current view, it should be ignored")
+	require.Equal(es.T(), es.endView, es.paceMaker.CurView(), "incorrect view change")
+}
+
 func (es *EventHandlerSuite) TestNoQCBuilt() {
 	// voting block exists
 	es.forks.blocks[es.vote.BlockID] = es.votingBlock
@@ -463,6 +545,10 @@ func (es *EventHandlerSuite) TestQCBuiltViewChanged() {
 	require.NoError(es.T(), err, "if a vote can trigger a QC to be built,"+
 		"and the QC triggered a view change, then start new view")
 	require.Equal(es.T(), es.endView, es.paceMaker.CurView(), "incorrect view change")
+
+	// entering the new view updates the safety data and prunes the persisted votes
+	require.Equal(es.T(), 1, es.voter.updates)
+	es.persist.AssertCalled(es.T(), "PruneVotes", es.forks.finalized+1)
 }
 
 // in the newview, I'm not the leader, and I have the cur block,
@@ -733,6 +819,135 @@ func (es *EventHandlerSuite) TestOnTimeout() {
 	es.endView++
 	require.NoError(es.T(), err)
 	require.Equal(es.T(), es.endView, es.paceMaker.CurView(), "incorrect view change")
+
+	// the timeout for the view is broadcast and stored as the own timeout
+	es.communicator.AssertCalled(es.T(), "BroadcastTimeout", es.initView, es.forks.qc, mock.Anything)
+	require.Len(es.T(), es.timeoutAggregator.stored, 1)
+	require.Equal(es.T(), es.initView, es.timeoutAggregator.stored[0].View)
+}
+
+// a timeout that can't be produced does not keep the replica from changing the view
+func (es *EventHandlerSuite) TestOnTimeout_ProduceTimeoutFailed() {
+	es.voter.timeoutErr = errors.New("dummy error")
+
+	err := es.eventhandler.OnLocalTimeout()
+	es.endView++
+	require.NoError(es.T(), err)
+	require.Equal(es.T(), es.endView, es.paceMaker.CurView(), "incorrect view change")
+	es.communicator.AssertNotCalled(es.T(), "BroadcastTimeout", mock.Anything, mock.Anything, mock.Anything)
+	require.Empty(es.T(), es.timeoutAggregator.stored)
+}
+
+// a TC built from the own timeout does not trigger another view change
+func (es *EventHandlerSuite) TestOnTimeout_TCBuilt() {
+	es.timeoutAggregator.tcs[es.initView] = createTC(es.initView, es.forks.qc)
+
+	err := es.eventhandler.OnLocalTimeout()
+	es.endView++
+	require.NoError(es.T(), err)
+	require.Equal(es.T(), es.endView, es.paceMaker.CurView(), "incorrect view change")
+}
+
+func (es *EventHandlerSuite) TestTimeoutEqualFinalView() {
+	timeout := createTimeout(es.forks.finalized, createQC(createBlock(es.forks.finalized-1)))
+	es.timeoutAggregator.tcs[timeout.View] = createTC(timeout.View, timeout.HighestQC)
+
+	err := es.eventhandler.OnReceiveTimeout(timeout)
+	require.NoError(es.T(), err, "if a timeout's view is equal to the finalized view, "+
+		"it should be ignored")
+	require.Empty(es.T(), es.timeoutAggregator.stored)
+	require.Equal(es.T(), es.endView, es.paceMaker.CurView(), "incorrect view change")
+}
+
+func (es *EventHandlerSuite) TestTimeout_NoTCBuilt() {
+	timeout := createTimeout(es.initView, es.forks.qc)
+
+	err := es.eventhandler.OnReceiveTimeout(timeout)
+	require.NoError(es.T(), err)
+	require.Len(es.T(), es.timeoutAggregator.stored, 1)
+	require.Equal(es.T(), es.endView, es.paceMaker.CurView(), "incorrect view change")
+}
+
+func (es *EventHandlerSuite) TestTimeout_TCBuilt_ViewChanged() {
+	timeout := createTimeout(es.initView, es.forks.qc)
+	es.timeoutAggregator.tcs[timeout.View] = createTC(timeout.View, timeout.HighestQC)
+
+	err := es.eventhandler.OnReceiveTimeout(timeout)
+	// TC for the current view will trigger view change
+	es.endView++
+	require.NoError(es.T(), err)
+	require.Equal(es.T(), es.endView, es.paceMaker.CurView(), "incorrect view change")
+}
+
+func (es *EventHandlerSuite) TestTimeout_TCForNewerView_ViewChanged() {
+	timeout := createTimeout(es.initView+3, es.forks.qc)
+	es.timeoutAggregator.tcs[timeout.View] = createTC(timeout.View, timeout.HighestQC)
+
+	err := es.eventhandler.OnReceiveTimeout(timeout)
+	// TC for a newer view will skip to the view after the TC
+	es.endView += 4
+	require.NoError(es.T(), err)
+	require.Equal(es.T(), es.endView, es.paceMaker.CurView(), "incorrect view change")
+}
+
+func (es *EventHandlerSuite) TestTimeout_TCForOlderView_NoViewChange() {
+	timeout := createTimeout(es.initView-1, es.forks.qc)
+	es.timeoutAggregator.tcs[timeout.View] = createTC(timeout.View, timeout.HighestQC)
+
+	err := es.eventhandler.OnReceiveTimeout(timeout)
+	require.NoError(es.T(), err)
+	require.Equal(es.T(), es.endView, es.paceMaker.CurView(), "incorrect view change")
+}
+
+// the QC included in a TC is added to forks if the block is known and the QC is valid
+func (es *EventHandlerSuite) TestTimeout_TCWithKnownQC() {
+	block := createBlockWithQC(es.initView-1, es.initView-2)
+	es.forks.blocks[block.BlockID] = block
+	qc := createQC(block)
+	es.validator.On("ValidateQC", qc, block).Return(nil)
+	timeout := createTimeout(es.initView, qc)
+	es.timeoutAggregator.tcs[timeout.View] = createTC(timeout.View, timeout.HighestQC)
+
+	err := es.eventhandler.OnReceiveTimeout(timeout)
+	es.endView++
+	require.NoError(es.T(), err)
+	require.Equal(es.T(), es.endView, es.paceMaker.CurView(), "incorrect view change")
+	require.Equal(es.T(), qc, es.forks.qc)
+}
+
+// the QC included in a TC is ignored if it is invalid, but the TC still triggers the view change
+func (es *EventHandlerSuite) TestTimeout_TCWithInvalidQC() {
+	block := createBlockWithQC(es.initView-1, es.initView-2)
+	es.forks.blocks[block.BlockID] = block
+	es.validator.On("ValidateQC", mock.Anything, block).Return(model.InvalidBlockError{
+		BlockID: block.BlockID,
+		View:    block.View,
+		Err:     fmt.Errorf("some error"),
+	})
+	timeout := createTimeout(es.initView, createQC(block))
+	es.timeoutAggregator.tcs[timeout.View] = createTC(timeout.View, timeout.HighestQC)
+	qc := es.forks.qc
+
+	err := es.eventhandler.OnReceiveTimeout(timeout)
+	es.endView++
+	require.NoError(es.T(), err)
+	require.Equal(es.T(), es.endView, es.paceMaker.CurView(), "incorrect view change")
+	require.Equal(es.T(), qc, es.forks.qc)
+}
+
+// the QC included in a TC is ignored if the block is unknown
+func (es *EventHandlerSuite) TestTimeout_TCWithUnknownQC() {
+	block := createBlockWithQC(es.initView-1, es.initView-2)
+	timeout := createTimeout(es.initView, createQC(block))
+	es.timeoutAggregator.tcs[timeout.View] = createTC(timeout.View, timeout.HighestQC)
+	qc := es.forks.qc
+
+	err := es.eventhandler.OnReceiveTimeout(timeout)
+	es.endView++
+	require.NoError(es.T(), err)
+	require.Equal(es.T(), es.endView, es.paceMaker.CurView(), "incorrect view change")
+	require.Equal(es.T(), qc, es.forks.qc)
+	es.validator.AssertNotCalled(es.T(), "ValidateQC", mock.Anything, mock.Anything)
 }
 
 func (es *EventHandlerSuite) Test100Timeout() {
@@ -839,6 +1054,24 @@ func createVote(block *model.Block) *model.Vote {
 	}
 }
 
+func createTimeout(view uint64, highestQC *flow.QuorumCertificate) *model.TimeoutObject {
+	return &model.TimeoutObject{
+		View:      view,
+		HighestQC: highestQC,
+		SignerID:  flow.ZeroID,
+		SigData:   nil,
+	}
+}
+
+func createTC(view uint64, highestQC *flow.QuorumCertificate) *model.TimeoutCertificate {
+	return &model.TimeoutCertificate{
+		View:      view,
+		HighestQC: highestQC,
+		SignerIDs: nil,
+		SigData:   nil,
+	}
+}
+
 func createProposal(view uint64, qcview uint64) *model.Proposal {
 	block := createBlockWithQC(view, qcview)
 	return &model.Proposal{
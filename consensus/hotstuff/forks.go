@@ -51,6 +51,13 @@ type Forks interface {
 	// Note that tracking the view of the newest qc is for safety purposes
 	// and _independent_ of the fork-choice rule.
 	MakeForkChoice(curView uint64) (*flow.QuorumCertificate, *model.Block, error)
+
+	// LockedQC returns the QC pointing to the locked block, i.e. the newest block
+	// with a 2-chain on top of it. We must not vote for blocks conflicting with it.
+	LockedQC() *flow.QuorumCertificate
+
+	// HighestQC returns the QC with the highest view known to Forks.
+	HighestQC() *flow.QuorumCertificate
 }
 
 // ForksReader only reads the forks' state
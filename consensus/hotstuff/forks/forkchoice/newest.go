@@ -106,6 +106,12 @@ func (fc *NewestForkChoice) AddQC(qc *flow.QuorumCertificate) error {
 	return nil
 }
 
+// HighestQC returns the QC with the largest view number seen, which
+// NewestForkChoice uses as its fork choice.
+func (fc *NewestForkChoice) HighestQC() *flow.QuorumCertificate {
+	return fc.preferredParent.QC
+}
+
 func (fc *NewestForkChoice) ensureBlockStored(qc *flow.QuorumCertificate) (*model.Block, error) {
 	block, haveBlock := fc.finalizer.GetBlock(qc.BlockID)
 	if !haveBlock {
@@ -41,6 +41,16 @@ func (f *Forks) FinalizedView() uint64 {
 	return f.finalizer.FinalizedBlock().View
 }
 
+// LockedQC returns the QC pointing to the latest locked block
+func (f *Forks) LockedQC() *flow.QuorumCertificate {
+	return f.finalizer.LockedBlockQC()
+}
+
+// HighestQC returns the QC with the highest view known to Forks
+func (f *Forks) HighestQC() *flow.QuorumCertificate {
+	return f.forkchoice.HighestQC()
+}
+
 // IsSafeBlock returns whether a block is safe to vote for.
 func (f *Forks) IsSafeBlock(block *model.Block) bool {
 	if err := f.finalizer.VerifyBlock(block); err != nil {
@@ -0,0 +1,42 @@
+package helper
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func MakeTimeout(t *testing.T, options ...func(*model.TimeoutObject)) *model.TimeoutObject {
+	view := uint64(rand.Uint32()) + 1
+	timeout := model.TimeoutObject{
+		View:      view,
+		HighestQC: MakeQC(t, WithQCView(view-1)),
+		SignerID:  unittest.IdentifierFixture(),
+		SigData:   unittest.SignatureFixture(),
+	}
+	for _, option := range options {
+		option(&timeout)
+	}
+	return &timeout
+}
+
+func WithTimeoutView(view uint64) func(*model.TimeoutObject) {
+	return func(timeout *model.TimeoutObject) {
+		timeout.View = view
+	}
+}
+
+func WithTimeoutSigner(signerID flow.Identifier) func(*model.TimeoutObject) {
+	return func(timeout *model.TimeoutObject) {
+		timeout.SignerID = signerID
+	}
+}
+
+func WithTimeoutQC(qc *flow.QuorumCertificate) func(*model.TimeoutObject) {
+	return func(timeout *model.TimeoutObject) {
+		timeout.HighestQC = qc
+	}
+}
@@ -6,6 +6,10 @@ func RightAway(*Instance) bool {
 	return true
 }
 
+func Never(*Instance) bool {
+	return false
+}
+
 func ViewFinalized(view uint64) Condition {
 	return func(in *Instance) bool {
 		return in.forks.FinalizedView() >= view
@@ -25,7 +25,3 @@ func DefaultStart() uint64 {
 func DefaultPruned() uint64 {
 	return 0
 }
-
-func DefaultVoted() uint64 {
-	return 0
-}
@@ -50,3 +50,13 @@ func BlockProposalsBy(proposerID flow.Identifier) ProposalFilter {
 		return proposal.Block.ProposerID == proposerID
 	}
 }
+
+type TimeoutFilter func(*model.TimeoutObject) bool
+
+func BlockNoTimeouts(*model.TimeoutObject) bool {
+	return false
+}
+
+func BlockAllTimeouts(*model.TimeoutObject) bool {
+	return true
+}
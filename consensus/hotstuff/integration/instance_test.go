@@ -3,6 +3,7 @@ package integration
 import (
 	"fmt"
 	"os"
+	"sort"
 	"sync"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 
 	"github.com/onflow/flow-go/consensus/hotstuff"
 	"github.com/onflow/flow-go/consensus/hotstuff/blockproducer"
+	"github.com/onflow/flow-go/consensus/hotstuff/committee"
 	"github.com/onflow/flow-go/consensus/hotstuff/eventhandler"
 	"github.com/onflow/flow-go/consensus/hotstuff/forks"
 	"github.com/onflow/flow-go/consensus/hotstuff/forks/finalizer"
@@ -21,9 +23,11 @@ import (
 	"github.com/onflow/flow-go/consensus/hotstuff/notifications"
 	"github.com/onflow/flow-go/consensus/hotstuff/pacemaker"
 	"github.com/onflow/flow-go/consensus/hotstuff/pacemaker/timeout"
+	"github.com/onflow/flow-go/consensus/hotstuff/timeoutaggregator"
 	"github.com/onflow/flow-go/consensus/hotstuff/validator"
 	"github.com/onflow/flow-go/consensus/hotstuff/voteaggregator"
 	"github.com/onflow/flow-go/consensus/hotstuff/voter"
+	"github.com/onflow/flow-go/consensus/recovery"
 	"github.com/onflow/flow-go/model/flow"
 	module "github.com/onflow/flow-go/module/mock"
 	"github.com/onflow/flow-go/utils/unittest"
@@ -38,26 +42,30 @@ type Instance struct {
 	blockVoteOut VoteFilter
 	blockPropIn  ProposalFilter
 	blockPropOut ProposalFilter
+	timeoutIn    TimeoutFilter
+	timeoutOut   TimeoutFilter
 	stop         Condition
 
 	// instance data
 	queue   chan interface{}
 	headers sync.Map //	headers map[flow.Identifier]*flow.Header
+	leaders committee.LeaderSelector
 
 	// mocked dependencies
 	committee    *mocks.Committee
 	builder      *module.Builder
 	finalizer    *module.Finalizer
-	persist      *mocks.Persister
 	signer       *mocks.SignerVerifier
 	verifier     *mocks.Verifier
 	communicator *mocks.Communicator
 
 	// real dependencies
+	persist    *Persister
 	pacemaker  hotstuff.PaceMaker
 	producer   *blockproducer.BlockProducer
 	forks      *forks.Forks
 	aggregator *voteaggregator.VoteAggregator
+	timeouts   *timeoutaggregator.TimeoutAggregator
 	voter      *voter.Voter
 	validator  *validator.Validator
 
@@ -80,7 +88,10 @@ func NewInstance(t require.TestingT, options ...Option) *Instance {
 		OutgoingVotes:     BlockNoVotes,
 		IncomingProposals: BlockNoProposals,
 		OutgoingProposals: BlockNoProposals,
+		IncomingTimeouts:  BlockNoTimeouts,
+		OutgoingTimeouts:  BlockNoTimeouts,
 		StopCondition:     RightAway,
+		Persister:         NewPersister(),
 	}
 
 	// apply the custom options
@@ -110,23 +121,29 @@ func NewInstance(t require.TestingT, options ...Option) *Instance {
 		blockVoteOut: cfg.OutgoingVotes,
 		blockPropIn:  cfg.IncomingProposals,
 		blockPropOut: cfg.OutgoingProposals,
+		timeoutIn:    cfg.IncomingTimeouts,
+		timeoutOut:   cfg.OutgoingTimeouts,
 		stop:         cfg.StopCondition,
 
 		// instance data
-		queue: make(chan interface{}, 1024),
+		queue:   make(chan interface{}, 1024),
+		leaders: roundRobin(len(cfg.Participants)),
+		persist: cfg.Persister,
 
 		// instance mocks
 		committee:    &mocks.Committee{},
 		builder:      &module.Builder{},
-		persist:      &mocks.Persister{},
 		signer:       &mocks.SignerVerifier{},
 		verifier:     &mocks.Verifier{},
 		communicator: &mocks.Communicator{},
 		finalizer:    &module.Finalizer{},
 	}
 
-	// insert root block into headers register
+	// insert root block and the blocks to recover into headers register
 	in.headers.Store(cfg.Root.ID(), cfg.Root)
+	for _, header := range cfg.Pending {
+		in.headers.Store(header.ID(), header)
+	}
 
 	// program the hotstuff committee state
 	in.committee.On("Identities", mock.Anything, mock.Anything).Return(
@@ -139,10 +156,23 @@ func NewInstance(t require.TestingT, options ...Option) *Instance {
 		in.committee.On("Identity", mock.Anything, participant.NodeID).Return(participant, nil)
 	}
 	in.committee.On("Self").Return(in.localID)
-	in.committee.On("LeaderForView", mock.Anything).Return(
-		func(view uint64) flow.Identifier {
-			return in.participants[int(view)%len(in.participants)].NodeID
-		}, nil,
+	if cfg.ReputationWindow > 0 {
+		reputation, err := committee.NewReputationLeaderSelection(roundRobin(len(cfg.Participants)), in.participants.NodeIDs(), &in, cfg.Root.View, cfg.ReputationWindow)
+		require.NoError(t, err)
+		in.leaders = reputation
+	}
+	in.committee.On("LeaderForView", mock.Anything, mock.Anything).Return(
+		func(parentID flow.Identifier, view uint64) flow.Identifier {
+			index, err := in.leaders.LeaderIndexForBlock(parentID, view)
+			if err != nil {
+				return flow.ZeroID
+			}
+			return in.participants[index].NodeID
+		},
+		func(parentID flow.Identifier, view uint64) error {
+			_, err := in.leaders.LeaderIndexForBlock(parentID, view)
+			return err
+		},
 	)
 
 	// program the builder module behaviour
@@ -172,10 +202,6 @@ func NewInstance(t require.TestingT, options ...Option) *Instance {
 		},
 	)
 
-	// check on stop condition, stop the tests as soon as entering a certain view
-	in.persist.On("PutStarted", mock.Anything).Return(nil)
-	in.persist.On("PutVoted", mock.Anything).Return(nil)
-
 	// program the hotstuff signer behaviour
 	in.signer.On("CreateProposal", mock.Anything).Return(
 		func(block *model.Block) *model.Proposal {
@@ -216,9 +242,43 @@ func NewInstance(t require.TestingT, options ...Option) *Instance {
 		nil,
 	)
 
+	in.signer.On("CreateTimeout", mock.Anything, mock.Anything).Return(
+		func(view uint64, highestQC *flow.QuorumCertificate) *model.TimeoutObject {
+			timeout := &model.TimeoutObject{
+				View:      view,
+				HighestQC: highestQC,
+				SignerID:  in.localID,
+				SigData:   nil,
+			}
+			return timeout
+		},
+		nil,
+	)
+	in.signer.On("CreateTC", mock.Anything).Return(
+		func(timeouts []*model.TimeoutObject) *model.TimeoutCertificate {
+			signerIDs := make([]flow.Identifier, 0, len(timeouts))
+			highestQC := timeouts[0].HighestQC
+			for _, timeout := range timeouts {
+				signerIDs = append(signerIDs, timeout.SignerID)
+				if timeout.HighestQC.View > highestQC.View {
+					highestQC = timeout.HighestQC
+				}
+			}
+			tc := &model.TimeoutCertificate{
+				View:      timeouts[0].View,
+				HighestQC: highestQC,
+				SignerIDs: signerIDs,
+				SigData:   nil,
+			}
+			return tc
+		},
+		nil,
+	)
+
 	// program the hotstuff verifier behaviour
 	in.verifier.On("VerifyVote", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
 	in.verifier.On("VerifyQC", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	in.verifier.On("VerifyTimeout", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
 
 	// program the hotstuff communicator behaviour
 	in.communicator.On("BroadcastProposalWithDelay", mock.Anything, mock.Anything).Return(
@@ -246,6 +306,7 @@ func NewInstance(t require.TestingT, options ...Option) *Instance {
 		},
 	)
 	in.communicator.On("SendVote", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	in.communicator.On("BroadcastTimeout", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	// program the finalizer module behaviour
 	in.finalizer.On("MakeFinal", mock.Anything).Return(
@@ -285,11 +346,6 @@ func NewInstance(t require.TestingT, options ...Option) *Instance {
 	log := zerolog.New(os.Stderr).Level(zerolog.DebugLevel).With().Timestamp().Uint("index", index).Hex("local_id", in.localID[:]).Logger()
 	notifier := notifications.NewLogConsumer(log)
 
-	// initialize the pacemaker
-	controller := timeout.NewController(cfg.Timeouts)
-	in.pacemaker, err = pacemaker.New(DefaultStart(), controller, notifier)
-	require.NoError(t, err)
-
 	// initialize the block producer
 	in.producer, err = blockproducer.New(in.signer, in.committee, in.builder)
 	require.NoError(t, err)
@@ -316,13 +372,38 @@ func NewInstance(t require.TestingT, options ...Option) *Instance {
 	in.validator = validator.New(in.committee, in.forks, in.verifier)
 
 	// initialize the vote aggregator
-	in.aggregator = voteaggregator.New(notifier, DefaultPruned(), in.committee, in.validator, in.signer)
+	in.aggregator = voteaggregator.New(notifier, DefaultPruned(), in.committee, in.validator, in.signer, in.persist)
+
+	// recover the pending blocks and the collected votes from before a restart
+	started, err := in.persist.GetStarted()
+	require.NoError(t, err)
+	safetyData, err := in.persist.GetSafetyData()
+	require.NoError(t, err)
+	votes, err := in.persist.GetVotes(cfg.Root.View + 1)
+	require.NoError(t, err)
+	err = recovery.Participant(log, in.forks, in.aggregator, in.validator, cfg.Root, cfg.Pending, safetyData, votes)
+	require.NoError(t, err)
+
+	// initialize the pacemaker after the view we were in before a restart
+	startView := DefaultStart()
+	if started >= startView {
+		startView = started + 1
+	}
+	if in.forks.HighestQC().View >= startView {
+		startView = in.forks.HighestQC().View + 1
+	}
+	controller := timeout.NewController(cfg.Timeouts)
+	in.pacemaker, err = pacemaker.New(startView, controller, notifier)
+	require.NoError(t, err)
+
+	// initialize the timeout aggregator
+	in.timeouts = timeoutaggregator.New(notifier, DefaultPruned(), in.committee, in.forks, in.pacemaker, in.validator, in.signer)
 
 	// initialize the voter
-	in.voter = voter.New(in.signer, in.forks, in.persist, DefaultVoted())
+	in.voter = voter.New(in.signer, in.forks, in.persist, safetyData)
 
 	// initialize the event handler
-	in.handler, err = eventhandler.New(log, in.pacemaker, in.producer, in.forks, in.persist, in.communicator, in.committee, in.aggregator, in.voter, in.validator, notifier)
+	in.handler, err = eventhandler.New(log, in.pacemaker, in.producer, in.forks, in.persist, in.communicator, in.committee, in.aggregator, in.timeouts, in.voter, in.validator, notifier)
 	require.NoError(t, err)
 
 	return &in
@@ -378,8 +459,60 @@ func (in *Instance) Run() error {
 				if err != nil {
 					return fmt.Errorf("could not process vote: %w", err)
 				}
+			case *model.TimeoutObject:
+				err := in.handler.OnReceiveTimeout(m)
+				if err != nil {
+					return fmt.Errorf("could not process timeout from replica: %w", err)
+				}
 			}
 		}
 
 	}
 }
+
+// ByBlockID returns the header of a block the instance knows, so that the instance can
+// serve as headers for the leader selection.
+func (in *Instance) ByBlockID(blockID flow.Identifier) (*flow.Header, error) {
+	header, ok := in.headers.Load(blockID)
+	if !ok {
+		return nil, fmt.Errorf("header not found (block: %x)", blockID)
+	}
+	return header.(*flow.Header), nil
+}
+
+// Pending returns the headers the instance received on top of the root block, ordered
+// such that each parent comes before its children, as needed to restart the instance.
+func (in *Instance) Pending() []*flow.Header {
+	var headers []*flow.Header
+	in.headers.Range(func(key interface{}, value interface{}) bool {
+		headers = append(headers, value.(*flow.Header))
+		return true
+	})
+	sort.Slice(headers, func(i int, j int) bool {
+		return headers[i].Height < headers[j].Height
+	})
+
+	// the root block is the only block at the lowest height
+	root := headers[0]
+	connected := map[flow.Identifier]struct{}{root.ID(): {}}
+	pending := make([]*flow.Header, 0, len(headers)-1)
+	for _, header := range headers[1:] {
+		if _, ok := connected[header.ParentID]; !ok {
+			continue
+		}
+		connected[header.ID()] = struct{}{}
+		pending = append(pending, header)
+	}
+	return pending
+}
+
+// roundRobin is the default leader selection, where the participants take turns
+type roundRobin int
+
+func (r roundRobin) LeaderIndexForView(view uint64) (int, error) {
+	return int(view % uint64(r)), nil
+}
+
+func (r roundRobin) LeaderIndexForBlock(_ flow.Identifier, view uint64) (int, error) {
+	return r.LeaderIndexForView(view)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go/consensus/hotstuff/pacemaker/timeout"
+	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -166,3 +167,177 @@ func TestSevenInstances(t *testing.T) {
 		assert.Equal(t, finalizedViews, FinalizedViews(instances[i]), "instance %d should have same finalized view as first instance")
 	}
 }
+
+// TestTimeoutCertificates checks that a replica lagging behind catches up through the
+// timeout certificate built from the timeouts of the other replicas, instead of waiting
+// for its own timeout. The proposals of one leader are never delivered, so the other
+// replicas time out in its views. The lagging replica has a very long timeout, and its
+// votes are required to build any QC; hence, without TCs, consensus would stall until
+// the lagging replica times out.
+func TestTimeoutCertificates(t *testing.T) {
+
+	// test parameters
+	// 7 participants have a threshold of 5 for QCs and TCs:
+	// * the leader whose proposals are blocked advances with its own proposal
+	//   and does not time out, leaving exactly 5 replicas to build the TC
+	// * two replicas don't vote, so the lagging replica's vote is required for every QC
+	num := 7
+	finalView := uint64(20)
+	slowTimeout := 10 * time.Minute
+
+	// generate the seven hotstuff participants
+	participants := unittest.IdentityListFixture(num)
+	silent := participants[0]
+	slow := participants[num-1]
+	root := DefaultRoot()
+	timeouts, err := timeout.NewConfig(safeTimeout, safeTimeout, 0.5, 1.5, safeDecreaseFactor, 0)
+	require.NoError(t, err)
+	slowTimeouts, err := timeout.NewConfig(slowTimeout, slowTimeout, 0.5, 1.5, safeDecreaseFactor, 0)
+	require.NoError(t, err)
+
+	instances := make([]*Instance, 0, num)
+	for n := 0; n < num; n++ {
+		options := []Option{
+			WithRoot(root),
+			WithParticipants(participants),
+			WithLocalID(participants[n].NodeID),
+			WithTimeouts(timeouts),
+			WithStopCondition(ViewFinalized(finalView)),
+		}
+		switch {
+		case participants[n].NodeID == silent.NodeID:
+			// the proposals of the silent leader never reach the other replicas
+			options = append(options, WithOutgoingProposals(BlockAllProposals))
+		case participants[n].NodeID == slow.NodeID:
+			options = append(options, WithTimeouts(slowTimeouts))
+		case n >= num-3:
+			options = append(options, WithOutgoingVotes(BlockAllVotes))
+		}
+		in := NewInstance(t, options...)
+		instances = append(instances, in)
+	}
+
+	// connect the communicators of the instances together
+	Connect(instances)
+
+	// start all seven instances and wait for them to wrap up
+	start := time.Now()
+	var wg sync.WaitGroup
+	for _, in := range instances {
+		wg.Add(1)
+		go func(in *Instance) {
+			err := in.Run()
+			require.True(t, errors.Is(err, errStopCondition), "should run until stop condition")
+			wg.Done()
+		}(in)
+	}
+	wg.Wait()
+
+	// the lagging replica should never have waited for its own timeout
+	assert.True(t, time.Since(start) < slowTimeout, "lagging replica should catch up through timeout certificates")
+
+	// check that all instances have the same finalized block
+	ref := instances[0]
+	assert.GreaterOrEqual(t, ref.forks.FinalizedBlock().View, finalView, "expect instance 0 should made enough progress, but didn't")
+	finalizedViews := FinalizedViews(ref)
+	for i := 1; i < num; i++ {
+		assert.Equal(t, ref.forks.FinalizedBlock(), instances[i].forks.FinalizedBlock(), "instance %d should have same finalized block as first instance", i)
+		assert.Equal(t, finalizedViews, FinalizedViews(instances[i]), "instance %d should have same finalized view as first instance", i)
+	}
+}
+
+// TestCrashedLeadersSkipped checks that crashed replicas are deprioritized as leaders once
+// the chain shows that they failed to propose. Two of the seven participants are crashed,
+// i.e. they never receive or send any messages. With the default leader selection, each of
+// their views would cost a timeout. With the leader reputation, they only cost a timeout
+// until their failure is part of the ancestry, after which their views are handed over.
+func TestCrashedLeadersSkipped(t *testing.T) {
+
+	// test parameters
+	// 7 participants have a threshold of 5 for QCs and TCs, so the 5 remaining replicas can
+	// make progress; each window contains two views of every participant
+	num := 7
+	window := uint64(14)
+	finalView := 5 * window
+
+	// generate the seven hotstuff participants, out of which two are crashed; the crashed
+	// replicas lead consecutive views in the default round-robin selection, so that the
+	// remaining replicas lead enough consecutive views to finalize blocks
+	participants := unittest.IdentityListFixture(num)
+	crashed := map[flow.Identifier]struct{}{
+		participants[2].NodeID: {},
+		participants[3].NodeID: {},
+	}
+	root := DefaultRoot()
+	timeouts, err := timeout.NewConfig(safeTimeout, safeTimeout, 0.5, 1.5, safeDecreaseFactor, 0)
+	require.NoError(t, err)
+
+	// set up the instances for the replicas that didn't crash
+	instances := make([]*Instance, 0, num)
+	for n := 0; n < num; n++ {
+		if _, ok := crashed[participants[n].NodeID]; ok {
+			continue
+		}
+		in := NewInstance(t,
+			WithRoot(root),
+			WithParticipants(participants),
+			WithLocalID(participants[n].NodeID),
+			WithTimeouts(timeouts),
+			WithStopCondition(ViewFinalized(finalView)),
+			WithLeaderReputation(window),
+		)
+		instances = append(instances, in)
+	}
+
+	// connect the communicators of the instances together
+	Connect(instances)
+
+	// start all five instances and wait for them to wrap up
+	var wg sync.WaitGroup
+	for _, in := range instances {
+		wg.Add(1)
+		go func(in *Instance) {
+			err := in.Run()
+			require.True(t, errors.Is(err, errStopCondition), "should run until stop condition")
+			wg.Done()
+		}(in)
+	}
+	wg.Wait()
+
+	// check that all instances have the same finalized block
+	ref := instances[0]
+	assert.GreaterOrEqual(t, ref.forks.FinalizedBlock().View, finalView, "expect instance 0 should made enough progress, but didn't")
+	finalizedViews := FinalizedViews(ref)
+	for i := 1; i < len(instances); i++ {
+		assert.Equal(t, ref.forks.FinalizedBlock(), instances[i].forks.FinalizedBlock(), "instance %d should have same finalized block as first instance", i)
+		assert.Equal(t, finalizedViews, FinalizedViews(instances[i]), "instance %d should have same finalized view as first instance", i)
+	}
+
+	// the crashed replicas lead four views of each of the first two windows, which therefore
+	// contain skipped views; afterwards, none of the crashed replicas is leader anymore
+	finalized := make(map[uint64]struct{})
+	for _, view := range finalizedViews {
+		finalized[view] = struct{}{}
+	}
+	var skipped []uint64
+	for view := uint64(1); view < 2*window; view++ {
+		if _, ok := finalized[view]; !ok {
+			skipped = append(skipped, view)
+		}
+	}
+	assert.NotEmpty(t, skipped, "crashed replicas should cause skipped views before their failure is finalized")
+	for view := 2 * window; view <= finalView; view++ {
+		_, ok := finalized[view]
+		assert.True(t, ok, "view %d should not be skipped", view)
+	}
+	for _, header := range FinalizedBlocks(ref) {
+		if header.View < 2*window {
+			continue
+		}
+		leader, err := ref.committee.LeaderForView(header.ParentID, header.View)
+		require.NoError(t, err)
+		assert.Equal(t, header.ProposerID, leader, "proposer of view %d should be the leader", header.View)
+		_, ok := crashed[leader]
+		assert.False(t, ok, "crashed replica should not lead view %d", header.View)
+	}
+}
@@ -20,7 +20,12 @@ type Config struct {
 	OutgoingVotes     VoteFilter
 	IncomingProposals ProposalFilter
 	OutgoingProposals ProposalFilter
+	IncomingTimeouts  TimeoutFilter
+	OutgoingTimeouts  TimeoutFilter
 	StopCondition     Condition
+	ReputationWindow  uint64
+	Persister         *Persister
+	Pending           []*flow.Header
 }
 
 func WithRoot(root *flow.Header) Option {
@@ -71,8 +76,35 @@ func WithOutgoingProposals(Filter ProposalFilter) Option {
 	}
 }
 
+func WithIncomingTimeouts(Filter TimeoutFilter) Option {
+	return func(cfg *Config) {
+		cfg.IncomingTimeouts = Filter
+	}
+}
+
+func WithOutgoingTimeouts(Filter TimeoutFilter) Option {
+	return func(cfg *Config) {
+		cfg.OutgoingTimeouts = Filter
+	}
+}
+
 func WithStopCondition(stop Condition) Option {
 	return func(cfg *Config) {
 		cfg.StopCondition = stop
 	}
 }
+
+func WithLeaderReputation(window uint64) Option {
+	return func(cfg *Config) {
+		cfg.ReputationWindow = window
+	}
+}
+
+// WithRecovery restarts the instance from the given persisted state and the
+// blocks it received before, which are not finalized yet.
+func WithRecovery(persist *Persister, pending []*flow.Header) Option {
+	return func(cfg *Config) {
+		cfg.Persister = persist
+		cfg.Pending = pending
+	}
+}
@@ -0,0 +1,78 @@
+package integration
+
+import (
+	"sort"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// Persister keeps the hotstuff state in memory, so that it survives the restart
+// of an instance within a test.
+type Persister struct {
+	started    uint64
+	safetyData *model.SafetyData
+	votes      map[uint64]map[flow.Identifier]*model.Vote
+}
+
+func NewPersister() *Persister {
+	return &Persister{
+		safetyData: &model.SafetyData{},
+		votes:      make(map[uint64]map[flow.Identifier]*model.Vote),
+	}
+}
+
+func (p *Persister) GetStarted() (uint64, error) {
+	return p.started, nil
+}
+
+func (p *Persister) GetSafetyData() (*model.SafetyData, error) {
+	return p.safetyData, nil
+}
+
+func (p *Persister) GetVotes(view uint64) ([]*model.Vote, error) {
+	var votes []*model.Vote
+	for voteView, byVoter := range p.votes {
+		if voteView < view {
+			continue
+		}
+		for _, vote := range byVoter {
+			votes = append(votes, vote)
+		}
+	}
+	sort.Slice(votes, func(i int, j int) bool {
+		return votes[i].View < votes[j].View
+	})
+	return votes, nil
+}
+
+func (p *Persister) PutStarted(view uint64) error {
+	p.started = view
+	return nil
+}
+
+func (p *Persister) PutSafetyData(safetyData *model.SafetyData) error {
+	p.safetyData = safetyData
+	return nil
+}
+
+func (p *Persister) PutVote(vote *model.Vote) error {
+	byVoter, ok := p.votes[vote.View]
+	if !ok {
+		byVoter = make(map[flow.Identifier]*model.Vote)
+		p.votes[vote.View] = byVoter
+	}
+	if _, ok := byVoter[vote.SignerID]; !ok {
+		byVoter[vote.SignerID] = vote
+	}
+	return nil
+}
+
+func (p *Persister) PruneVotes(view uint64) error {
+	for voteView := range p.votes {
+		if voteView < view {
+			delete(p.votes, voteView)
+		}
+	}
+	return nil
+}
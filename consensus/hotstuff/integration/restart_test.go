@@ -0,0 +1,179 @@
+package integration
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// restartFixture drives a single instance of seven participants through the first
+// views by feeding it proposals and votes, as if the other participants were online.
+// The instance is the leader of view 4, so it collects the votes for the block of view 3.
+type restartFixture struct {
+	t            *testing.T
+	participants flow.IdentityList
+	root         *flow.Header
+	headers      []*flow.Header
+}
+
+func newRestartFixture(t *testing.T) *restartFixture {
+	return &restartFixture{
+		t:            t,
+		participants: unittest.IdentityListFixture(7),
+		root:         DefaultRoot(),
+	}
+}
+
+func (f *restartFixture) local() flow.Identifier {
+	return f.participants[4].NodeID
+}
+
+func (f *restartFixture) instance(options ...Option) *Instance {
+	options = append([]Option{
+		WithRoot(f.root),
+		WithParticipants(f.participants),
+		WithLocalID(f.local()),
+		WithStopCondition(Never),
+	}, options...)
+	return NewInstance(f.t, options...)
+}
+
+// propose feeds the instance with a proposal for each of the given views, each built on
+// the previous one and proposed by the round-robin leader of the view
+func (f *restartFixture) propose(in *Instance, views ...uint64) {
+	for _, view := range views {
+		parent := f.root
+		if len(f.headers) > 0 {
+			parent = f.headers[len(f.headers)-1]
+		}
+		header := &flow.Header{
+			ChainID:        parent.ChainID,
+			ParentID:       parent.ID(),
+			Height:         parent.Height + 1,
+			View:           view,
+			ProposerID:     f.participants[view%uint64(len(f.participants))].NodeID,
+			ParentVoterIDs: f.participants.NodeIDs(),
+			PayloadHash:    unittest.IdentifierFixture(),
+			Timestamp:      time.Now().UTC(),
+		}
+		f.headers = append(f.headers, header)
+		in.headers.Store(header.ID(), header)
+		err := in.handler.OnReceiveProposal(model.ProposalFromFlow(header, parent.View))
+		require.NoError(f.t, err)
+	}
+}
+
+// vote feeds the instance with the votes of the given participants for the latest block
+func (f *restartFixture) vote(in *Instance, indexes ...int) {
+	header := f.headers[len(f.headers)-1]
+	for _, index := range indexes {
+		vote := &model.Vote{
+			View:     header.View,
+			BlockID:  header.ID(),
+			SignerID: f.participants[index].NodeID,
+		}
+		err := in.handler.OnReceiveVote(vote)
+		require.NoError(f.t, err)
+	}
+}
+
+// Test a restarted instance doesn't vote again for the view it voted for before the crash,
+// and builds the QC from the votes it collected before the crash.
+func TestRestartWithCollectedVotes(t *testing.T) {
+	f := newRestartFixture(t)
+
+	// the instance votes for the blocks of views 1 to 3, and collects two votes for
+	// the block of view 3, which is not enough for a QC with its own and the proposer's vote
+	in := f.instance()
+	require.NoError(t, in.handler.Start())
+	f.propose(in, 1, 2, 3)
+	f.vote(in, 5, 6)
+	require.Equal(t, uint64(3), in.pacemaker.CurView())
+	require.Equal(t, uint64(2), in.forks.HighestQC().View)
+
+	// after the restart, the instance continues with the next view
+	restarted := f.instance(WithRecovery(in.persist, in.Pending()))
+	assert.Equal(t, uint64(4), restarted.pacemaker.CurView())
+	assert.Equal(t, uint64(2), restarted.forks.HighestQC().View)
+
+	// the instance doesn't vote for the block of view 3 again
+	block, found := restarted.forks.GetBlock(f.headers[2].ID())
+	require.True(t, found)
+	_, err := restarted.voter.ProduceVoteIfVotable(block, block.View)
+	assert.True(t, model.IsNoVoteError(err), "should not vote twice for the same view")
+	restarted.signer.AssertNotCalled(t, "CreateVote", mock.Anything)
+
+	// a single vote completes the QC with the votes collected before the crash
+	f.vote(restarted, 0)
+	assert.Equal(t, uint64(3), restarted.forks.HighestQC().View)
+	assert.Equal(t, f.headers[2].ID(), restarted.forks.HighestQC().BlockID)
+}
+
+// Test a restarted instance recovers the QC it built from votes before the crash, even
+// though it didn't get to propose a block including the QC.
+func TestRestartWithHighestQC(t *testing.T) {
+	f := newRestartFixture(t)
+
+	// the instance builds the QC for the block of view 3, and enters view 4 as the leader
+	in := f.instance()
+	require.NoError(t, in.handler.Start())
+	f.propose(in, 1, 2, 3)
+	f.vote(in, 5, 6, 0)
+	require.Equal(t, uint64(4), in.pacemaker.CurView())
+	require.Equal(t, uint64(3), in.forks.HighestQC().View)
+
+	// the instance crashes before its proposal with the QC is stored
+	restarted := f.instance(WithRecovery(in.persist, f.headers))
+	assert.Equal(t, uint64(5), restarted.pacemaker.CurView())
+	assert.Equal(t, uint64(3), restarted.forks.HighestQC().View)
+	assert.Equal(t, f.headers[2].ID(), restarted.forks.HighestQC().BlockID)
+	assert.Equal(t, uint64(1), restarted.forks.LockedQC().View)
+}
+
+// Test a single instance that is restarted keeps finalizing blocks without voting again
+// for the views it voted for before the restart.
+func TestRestartSingleInstance(t *testing.T) {
+
+	// NOTE: see TestSingleInstance on why we keep the number of views low
+	identity := unittest.IdentityFixture()
+	root := DefaultRoot()
+	in := NewInstance(t,
+		WithRoot(root),
+		WithParticipants(flow.IdentityList{identity}),
+		WithLocalID(identity.NodeID),
+		WithStopCondition(ViewFinalized(5)),
+	)
+	err := in.Run()
+	require.True(t, errors.Is(err, errStopCondition), "should run until stop condition")
+	safetyData, err := in.persist.GetSafetyData()
+	require.NoError(t, err)
+	lastVotedView := safetyData.LastVotedView()
+	require.Greater(t, lastVotedView, uint64(5))
+
+	restarted := NewInstance(t,
+		WithRoot(root),
+		WithParticipants(flow.IdentityList{identity}),
+		WithLocalID(identity.NodeID),
+		WithRecovery(in.persist, in.Pending()),
+		WithStopCondition(ViewFinalized(10)),
+	)
+	err = restarted.Run()
+	require.True(t, errors.Is(err, errStopCondition), "should run until stop condition")
+	assert.Equal(t, uint64(10), restarted.forks.FinalizedView())
+
+	for _, call := range restarted.signer.Calls {
+		if call.Method != "CreateVote" {
+			continue
+		}
+		block := call.Arguments.Get(0).(*model.Block)
+		assert.Greater(t, block.View, lastVotedView, "should not vote again after the restart")
+	}
+}
@@ -83,13 +83,13 @@ func (_m *Committee) Identity(blockID flow.Identifier, participantID flow.Identi
 	return r0, r1
 }
 
-// LeaderForView provides a mock function with given fields: view
-func (_m *Committee) LeaderForView(view uint64) (flow.Identifier, error) {
-	ret := _m.Called(view)
+// LeaderForView provides a mock function with given fields: parentID, view
+func (_m *Committee) LeaderForView(parentID flow.Identifier, view uint64) (flow.Identifier, error) {
+	ret := _m.Called(parentID, view)
 
 	var r0 flow.Identifier
-	if rf, ok := ret.Get(0).(func(uint64) flow.Identifier); ok {
-		r0 = rf(view)
+	if rf, ok := ret.Get(0).(func(flow.Identifier, uint64) flow.Identifier); ok {
+		r0 = rf(parentID, view)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(flow.Identifier)
@@ -97,8 +97,8 @@ func (_m *Committee) LeaderForView(view uint64) (flow.Identifier, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint64) error); ok {
-		r1 = rf(view)
+	if rf, ok := ret.Get(1).(func(flow.Identifier, uint64) error); ok {
+		r1 = rf(parentID, view)
 	} else {
 		r1 = ret.Error(1)
 	}
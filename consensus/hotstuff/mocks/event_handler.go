@@ -42,6 +42,20 @@ func (_m *EventHandler) OnReceiveProposal(proposal *model.Proposal) error {
 	return r0
 }
 
+// OnReceiveTimeout provides a mock function with given fields: timeout
+func (_m *EventHandler) OnReceiveTimeout(timeout *model.TimeoutObject) error {
+	ret := _m.Called(timeout)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.TimeoutObject) error); ok {
+		r0 = rf(timeout)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // OnReceiveVote provides a mock function with given fields: vote
 func (_m *EventHandler) OnReceiveVote(vote *model.Vote) error {
 	ret := _m.Called(vote)
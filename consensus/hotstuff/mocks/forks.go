@@ -112,6 +112,22 @@ func (_m *Forks) GetBlocksForView(view uint64) []*model.Block {
 	return r0
 }
 
+// HighestQC provides a mock function with given fields:
+func (_m *Forks) HighestQC() *flow.QuorumCertificate {
+	ret := _m.Called()
+
+	var r0 *flow.QuorumCertificate
+	if rf, ok := ret.Get(0).(func() *flow.QuorumCertificate); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.QuorumCertificate)
+		}
+	}
+
+	return r0
+}
+
 // IsSafeBlock provides a mock function with given fields: block
 func (_m *Forks) IsSafeBlock(block *model.Block) bool {
 	ret := _m.Called(block)
@@ -126,6 +142,22 @@ func (_m *Forks) IsSafeBlock(block *model.Block) bool {
 	return r0
 }
 
+// LockedQC provides a mock function with given fields:
+func (_m *Forks) LockedQC() *flow.QuorumCertificate {
+	ret := _m.Called()
+
+	var r0 *flow.QuorumCertificate
+	if rf, ok := ret.Get(0).(func() *flow.QuorumCertificate); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.QuorumCertificate)
+		}
+	}
+
+	return r0
+}
+
 // MakeForkChoice provides a mock function with given fields: curView
 func (_m *Forks) MakeForkChoice(curView uint64) (*flow.QuorumCertificate, *model.Block, error) {
 	ret := _m.Called(curView)
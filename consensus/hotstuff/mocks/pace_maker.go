@@ -127,3 +127,26 @@ func (_m *PaceMaker) UpdateCurViewWithQC(qc *flow.QuorumCertificate) (*model.New
 
 	return r0, r1
 }
+
+// UpdateCurViewWithTC provides a mock function with given fields: tc
+func (_m *PaceMaker) UpdateCurViewWithTC(tc *model.TimeoutCertificate) (*model.NewViewEvent, bool) {
+	ret := _m.Called(tc)
+
+	var r0 *model.NewViewEvent
+	if rf, ok := ret.Get(0).(func(*model.TimeoutCertificate) *model.NewViewEvent); ok {
+		r0 = rf(tc)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.NewViewEvent)
+		}
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(*model.TimeoutCertificate) bool); ok {
+		r1 = rf(tc)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
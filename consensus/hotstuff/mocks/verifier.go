@@ -36,6 +36,27 @@ func (_m *Verifier) VerifyQC(voterIDs []flow.Identifier, sigData []byte, block *
 	return r0, r1
 }
 
+// VerifyTimeout provides a mock function with given fields: signerID, sigData, view, highestQC, block
+func (_m *Verifier) VerifyTimeout(signerID flow.Identifier, sigData []byte, view uint64, highestQC *flow.QuorumCertificate, block *model.Block) (bool, error) {
+	ret := _m.Called(signerID, sigData, view, highestQC, block)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(flow.Identifier, []byte, uint64, *flow.QuorumCertificate, *model.Block) bool); ok {
+		r0 = rf(signerID, sigData, view, highestQC, block)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(flow.Identifier, []byte, uint64, *flow.QuorumCertificate, *model.Block) error); ok {
+		r1 = rf(signerID, sigData, view, highestQC, block)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // VerifyVote provides a mock function with given fields: voterID, sigData, block
 func (_m *Verifier) VerifyVote(voterID flow.Identifier, sigData []byte, block *model.Block) (bool, error) {
 	ret := _m.Called(voterID, sigData, block)
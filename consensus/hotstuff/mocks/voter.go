@@ -3,8 +3,11 @@
 package mocks
 
 import (
-	model "github.com/onflow/flow-go/consensus/hotstuff/model"
+	flow "github.com/onflow/flow-go/model/flow"
+
 	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/onflow/flow-go/consensus/hotstuff/model"
 )
 
 // Voter is an autogenerated mock type for the Voter type
@@ -12,6 +15,29 @@ type Voter struct {
 	mock.Mock
 }
 
+// ProduceTimeout provides a mock function with given fields: curView, highestQC
+func (_m *Voter) ProduceTimeout(curView uint64, highestQC *flow.QuorumCertificate) (*model.TimeoutObject, error) {
+	ret := _m.Called(curView, highestQC)
+
+	var r0 *model.TimeoutObject
+	if rf, ok := ret.Get(0).(func(uint64, *flow.QuorumCertificate) *model.TimeoutObject); ok {
+		r0 = rf(curView, highestQC)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TimeoutObject)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint64, *flow.QuorumCertificate) error); ok {
+		r1 = rf(curView, highestQC)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ProduceVoteIfVotable provides a mock function with given fields: block, curView
 func (_m *Voter) ProduceVoteIfVotable(block *model.Block, curView uint64) (*model.Vote, error) {
 	ret := _m.Called(block, curView)
@@ -34,3 +60,17 @@ func (_m *Voter) ProduceVoteIfVotable(block *model.Block, curView uint64) (*mode
 
 	return r0, r1
 }
+
+// UpdateSafetyData provides a mock function with given fields:
+func (_m *Voter) UpdateSafetyData() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
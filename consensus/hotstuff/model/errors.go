@@ -85,6 +85,26 @@ func (e InvalidVoteError) Unwrap() error {
 	return e.Err
 }
 
+type InvalidTimeoutError struct {
+	TimeoutID flow.Identifier
+	View      uint64
+	Err       error
+}
+
+func (e InvalidTimeoutError) Error() string {
+	return fmt.Sprintf("invalid timeout %x for view %d: %s", e.TimeoutID, e.View, e.Err.Error())
+}
+
+// IsInvalidTimeoutError returns whether an error is InvalidTimeoutError
+func IsInvalidTimeoutError(err error) bool {
+	var e InvalidTimeoutError
+	return errors.As(err, &e)
+}
+
+func (e InvalidTimeoutError) Unwrap() error {
+	return e.Err
+}
+
 // ByzantineThresholdExceededError is raised if HotStuff detects malicious conditions which
 // prove a Byzantine threshold of consensus replicas has been exceeded.
 // Per definition, the byzantine threshold is exceeded is there are byzantine consensus
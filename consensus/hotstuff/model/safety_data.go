@@ -0,0 +1,27 @@
+package model
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// SafetyData is the safety-critical state of a replica. It is persisted in a single
+// write, such that a replica restarting after a crash never votes twice in the same
+// view and doesn't lose the newest QCs it has seen.
+type SafetyData struct {
+	LockedQC  *flow.QuorumCertificate // QC for the block the replica is locked on
+	HighestQC *flow.QuorumCertificate // newest QC known to the replica
+	LastVote  *Vote                   // last vote signed by the replica, nil if it has not voted yet
+
+	// LegacyVotedView is the view of the last vote of a replica that persisted only the voted view
+	// before it was upgraded, in which case the vote itself is unknown. It is superseded by the
+	// next vote of the replica.
+	LegacyVotedView uint64
+}
+
+// LastVotedView returns the view of the last vote, or zero if the replica has not voted yet.
+func (s *SafetyData) LastVotedView() uint64 {
+	if s.LastVote == nil {
+		return s.LegacyVotedView
+	}
+	return s.LastVote.View
+}
@@ -0,0 +1,15 @@
+package model
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// TimeoutCertificate proves that replicas with a supermajority of stake timed out
+// in a view. It contains the aggregated signature of the timeouts over the view,
+// as well as the newest QC included in any of the aggregated timeouts.
+type TimeoutCertificate struct {
+	View      uint64
+	HighestQC *flow.QuorumCertificate
+	SignerIDs []flow.Identifier
+	SigData   []byte
+}
@@ -0,0 +1,33 @@
+package model
+
+import (
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// TimeoutObject is the HotStuff algorithm's concept of a timeout for a view. A replica
+// signs and broadcasts a timeout object when its local timer for the view expires.
+// It includes the newest QC known to the replica, so that replicas which missed
+// the QC can catch up on it.
+type TimeoutObject struct {
+	View      uint64
+	HighestQC *flow.QuorumCertificate
+	SignerID  flow.Identifier
+	SigData   []byte
+}
+
+// ID returns the identifier for the timeout object.
+func (t *TimeoutObject) ID() flow.Identifier {
+	return flow.MakeID(t)
+}
+
+// TimeoutFromFlow turns the timeout parameters into a timeout object.
+func TimeoutFromFlow(signerID flow.Identifier, view uint64, highestQC *flow.QuorumCertificate, sig crypto.Signature) *TimeoutObject {
+	timeout := TimeoutObject{
+		View:      view,
+		HighestQC: highestQC,
+		SignerID:  signerID,
+		SigData:   sig,
+	}
+	return &timeout
+}
@@ -26,16 +26,24 @@ func (c *NoopConsumer) OnReceiveVote(uint64, *model.Vote) {}
 
 func (c *NoopConsumer) OnReceiveProposal(uint64, *model.Proposal) {}
 
+func (c *NoopConsumer) OnReceiveTimeout(uint64, *model.TimeoutObject) {}
+
 func (*NoopConsumer) OnEnteringView(uint64, flow.Identifier) {}
 
 func (c *NoopConsumer) OnQcTriggeredViewChange(*flow.QuorumCertificate, uint64) {}
 
+func (c *NoopConsumer) OnTcTriggeredViewChange(*model.TimeoutCertificate, uint64) {}
+
 func (c *NoopConsumer) OnProposingBlock(*model.Proposal) {}
 
 func (c *NoopConsumer) OnVoting(*model.Vote) {}
 
+func (c *NoopConsumer) OnSendingTimeout(*model.TimeoutObject) {}
+
 func (c *NoopConsumer) OnQcConstructedFromVotes(*flow.QuorumCertificate) {}
 
+func (c *NoopConsumer) OnTcConstructedFromTimeouts(*model.TimeoutCertificate) {}
+
 func (*NoopConsumer) OnStartingTimeout(*model.TimerInfo) {}
 
 func (*NoopConsumer) OnReachedTimeout(*model.TimerInfo) {}
@@ -47,3 +55,5 @@ func (*NoopConsumer) OnForkChoiceGenerated(uint64, *flow.QuorumCertificate) {}
 func (*NoopConsumer) OnDoubleVotingDetected(*model.Vote, *model.Vote) {}
 
 func (*NoopConsumer) OnInvalidVoteDetected(*model.Vote) {}
+
+func (*NoopConsumer) OnInvalidTimeoutDetected(*model.TimeoutObject) {}
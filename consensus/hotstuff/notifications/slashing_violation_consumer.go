@@ -1,21 +1,30 @@
 package notifications
 
 import (
+	"errors"
+
 	"github.com/rs/zerolog"
 
 	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
 )
 
 // SlashingViolationsConsumer is an implementation of the notifications consumer that logs a
-// message for any slashable offences.
+// message for any slashable offences, and persists evidence of them. The evidence of a double
+// proposal contains the full headers of the proposed blocks, which are looked up in storage.
 type SlashingViolationsConsumer struct {
 	NoopConsumer
-	log zerolog.Logger
+	log      zerolog.Logger
+	headers  storage.Headers
+	evidence storage.SlashingEvidence
 }
 
-func NewSlashingViolationsConsumer(log zerolog.Logger) *SlashingViolationsConsumer {
+func NewSlashingViolationsConsumer(log zerolog.Logger, headers storage.Headers, evidence storage.SlashingEvidence) *SlashingViolationsConsumer {
 	return &SlashingViolationsConsumer{
-		log: log,
+		log:      log,
+		headers:  headers,
+		evidence: evidence,
 	}
 }
 
@@ -26,8 +35,18 @@ func (c *SlashingViolationsConsumer) OnDoubleVotingDetected(vote1 *model.Vote, v
 		Hex("voted_block_id1", vote1.BlockID[:]).
 		Hex("voted_block_id2", vote2.BlockID[:]).
 		Msg("OnDoubleVotingDetected")
+
+	c.store(&flow.SlashingEvidence{
+		Offence:    flow.OffenceDoubleVote,
+		OffenderID: vote1.SignerID,
+		View:       vote1.View,
+		Votes:      []*flow.SignedVote{signedVote(vote1), signedVote(vote2)},
+	})
 }
 
+// OnInvalidVoteDetected only logs the invalid vote. A vote is mostly invalid because its signature
+// does not verify, so anyone can forge it in the name of any voter and it is no evidence against
+// the voter. Storing it would even block the evidence of a real offence at the same view.
 func (c *SlashingViolationsConsumer) OnInvalidVoteDetected(vote *model.Vote) {
 	c.log.Warn().
 		Uint64("vote_view", vote.View).
@@ -36,6 +55,15 @@ func (c *SlashingViolationsConsumer) OnInvalidVoteDetected(vote *model.Vote) {
 		Msg("OnInvalidVoteDetected")
 }
 
+// OnInvalidTimeoutDetected only logs the invalid timeout, as timeouts are not covered
+// by the slashing evidence.
+func (c *SlashingViolationsConsumer) OnInvalidTimeoutDetected(timeout *model.TimeoutObject) {
+	c.log.Warn().
+		Uint64("timeout_view", timeout.View).
+		Hex("signer_id", timeout.SignerID[:]).
+		Msg("OnInvalidTimeoutDetected")
+}
+
 func (c *SlashingViolationsConsumer) OnDoubleProposeDetected(block1 *model.Block, block2 *model.Block) {
 	c.log.Warn().
 		Hex("proposer_id", block1.ProposerID[:]).
@@ -43,4 +71,56 @@ func (c *SlashingViolationsConsumer) OnDoubleProposeDetected(block1 *model.Block
 		Hex("block_id1", block1.BlockID[:]).
 		Hex("block_id2", block2.BlockID[:]).
 		Msg("OnDoubleProposeDetected")
+
+	proposals := make([]*flow.Header, 0, 2)
+	for _, block := range []*model.Block{block1, block2} {
+		header, err := c.headers.ByBlockID(block.BlockID)
+		if err != nil {
+			c.log.Error().Err(err).
+				Hex("block_id", block.BlockID[:]).
+				Msg("could not retrieve proposal for slashing evidence")
+			return
+		}
+		proposals = append(proposals, header)
+	}
+
+	c.store(&flow.SlashingEvidence{
+		Offence:    flow.OffenceDoubleProposal,
+		OffenderID: block1.ProposerID,
+		View:       block1.View,
+		Proposals:  proposals,
+	})
+}
+
+// store persists the evidence, where evidence for an offence that was recorded before is dropped
+func (c *SlashingViolationsConsumer) store(evidence *flow.SlashingEvidence) {
+	err := c.evidence.Store(evidence)
+	if errors.Is(err, storage.ErrAlreadyExists) {
+		return
+	}
+	if err != nil {
+		c.log.Error().Err(err).
+			Hex("offender_id", evidence.OffenderID[:]).
+			Uint64("view", evidence.View).
+			Str("offence", evidence.Offence.String()).
+			Msg("could not store slashing evidence")
+		return
+	}
+	evidenceID := evidence.ID()
+	c.log.Info().
+		Hex("offender_id", evidence.OffenderID[:]).
+		Uint64("view", evidence.View).
+		Str("offence", evidence.Offence.String()).
+		Hex("evidence_id", evidenceID[:]).
+		Msg("slashing evidence stored")
+}
+
+// signedVote converts a HotStuff vote into a vote for slashing evidence
+func signedVote(vote *model.Vote) *flow.SignedVote {
+	return &flow.SignedVote{
+		View:     vote.View,
+		BlockID:  vote.BlockID,
+		SignerID: vote.SignerID,
+		SigData:  vote.SigData,
+	}
 }
@@ -18,6 +18,7 @@ import (
 //   * A path through the state machine begins when:
 //      - a vote is received
 //      - a block is received
+//      - a timeout from another replica is received
 //      - a new view is started
 //      - a timeout is processed
 //   * Each path through the state machine is identified by a unique id.
@@ -62,6 +63,16 @@ func (t *TelemetryConsumer) OnReceiveProposal(currentView uint64, proposal *mode
 	step.Msg("OnReceiveProposal")
 }
 
+func (t *TelemetryConsumer) OnReceiveTimeout(currentView uint64, timeout *model.TimeoutObject) {
+	t.pathHandler.StartNextPath(currentView)
+	t.pathHandler.NextStep().
+		Uint64("timeout_view", timeout.View).
+		Uint64("qc_block_view", timeout.HighestQC.View).
+		Hex("qc_block_id", timeout.HighestQC.BlockID[:]).
+		Hex("signer_id", timeout.SignerID[:]).
+		Msg("OnReceiveTimeout")
+}
+
 func (t *TelemetryConsumer) OnEventProcessed() {
 	if t.pathHandler.IsCurrentPathClosed() {
 		return
@@ -119,6 +130,15 @@ func (t *TelemetryConsumer) OnQcTriggeredViewChange(qc *flow.QuorumCertificate,
 		Msg("OnQcTriggeredViewChange")
 }
 
+func (t *TelemetryConsumer) OnTcTriggeredViewChange(tc *model.TimeoutCertificate, newView uint64) {
+	t.pathHandler.NextStep().
+		Uint64("tc_view", tc.View).
+		Uint64("next_view", newView).
+		Uint64("qc_block_view", tc.HighestQC.View).
+		Hex("qc_block_id", tc.HighestQC.BlockID[:]).
+		Msg("OnTcTriggeredViewChange")
+}
+
 func (t *TelemetryConsumer) OnProposingBlock(proposal *model.Proposal) {
 	block := proposal.Block
 	step := t.pathHandler.NextStep()
@@ -143,6 +163,14 @@ func (t *TelemetryConsumer) OnVoting(vote *model.Vote) {
 		Msg("OnVoting")
 }
 
+func (t *TelemetryConsumer) OnSendingTimeout(timeout *model.TimeoutObject) {
+	t.pathHandler.NextStep().
+		Uint64("timeout_view", timeout.View).
+		Uint64("qc_block_view", timeout.HighestQC.View).
+		Hex("qc_block_id", timeout.HighestQC.BlockID[:]).
+		Msg("OnSendingTimeout")
+}
+
 func (t *TelemetryConsumer) OnForkChoiceGenerated(current_view uint64, qc *flow.QuorumCertificate) {
 	t.pathHandler.NextStep().
 		Uint64("block_view", current_view).
@@ -158,6 +186,14 @@ func (t *TelemetryConsumer) OnQcConstructedFromVotes(qc *flow.QuorumCertificate)
 		Msg("OnQcIncorporated")
 }
 
+func (t *TelemetryConsumer) OnTcConstructedFromTimeouts(tc *model.TimeoutCertificate) {
+	t.pathHandler.NextStep().
+		Uint64("tc_view", tc.View).
+		Uint64("qc_block_view", tc.HighestQC.View).
+		Hex("qc_block_id", tc.HighestQC.BlockID[:]).
+		Msg("OnTcConstructedFromTimeouts")
+}
+
 func (t *TelemetryConsumer) OnQcIncorporated(qc *flow.QuorumCertificate) {
 	t.pathHandler.NextStep().
 		Uint64("qc_block_view", qc.View).
@@ -53,6 +53,10 @@ type PaceMaker interface {
 	// True corresponds to this replica being the next primary.
 	UpdateCurViewWithBlock(block *model.Block, isLeaderForNextView bool) (*model.NewViewEvent, bool)
 
+	// UpdateCurViewWithTC will check if the given TC will allow PaceMaker to fast
+	// forward to TC.view+1. If PaceMaker incremented the current View, a NewViewEvent will be returned.
+	UpdateCurViewWithTC(tc *model.TimeoutCertificate) (*model.NewViewEvent, bool)
+
 	// TimeoutChannel returns the timeout channel for the CURRENTLY ACTIVE timeout.
 	// Each time the pace maker starts a new timeout, this channel is replaced.
 	TimeoutChannel() <-chan time.Time
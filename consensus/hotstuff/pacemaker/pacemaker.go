@@ -130,6 +130,24 @@ func (p *NitroPaceMaker) actOnBlockForCurView(block *model.Block, isLeaderForNex
 	return p.gotoView(p.currentView + 1), true
 }
 
+// UpdateCurViewWithTC notifies the pacemaker with a new TC, which might allow pacemaker to
+// fast forward its view.
+func (p *NitroPaceMaker) UpdateCurViewWithTC(tc *model.TimeoutCertificate) (*model.NewViewEvent, bool) {
+	if tc.View < p.currentView {
+		return nil, false
+	}
+	// tc.view = p.currentView + k for k ≥ 0
+	// 2/3 of replicas have already timed out in view p.currentView + k, hence proceeded past it
+	// => replica can skip ahead to view tc.view + 1
+	// As the committee failed to make progress in tc.view, we increase the timeout in the same
+	// way as the replicas which reached their local timeout, so that the timeouts stay in sync.
+	p.timeoutControl.OnTimeout()
+
+	newView := tc.View + 1
+	p.notifier.OnTcTriggeredViewChange(tc, newView)
+	return p.gotoView(newView), true
+}
+
 // OnTimeout notifies the pacemaker that the timeout event has looped through the event loop.
 // It always trigger a view change, and the new view will be returned as NewViewEvent
 func (p *NitroPaceMaker) OnTimeout() *model.NewViewEvent {
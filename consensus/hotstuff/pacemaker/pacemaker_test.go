@@ -64,6 +64,10 @@ func QC(view uint64) *flow.QuorumCertificate {
 	return &flow.QuorumCertificate{View: view}
 }
 
+func TC(view uint64, qcView uint64) *model.TimeoutCertificate {
+	return &model.TimeoutCertificate{View: view, HighestQC: QC(qcView)}
+}
+
 func makeBlock(qcView, blockView uint64) *model.Block {
 	return &model.Block{View: blockView, QC: QC(qcView)}
 }
@@ -100,6 +104,63 @@ func Test_IgnoreOldQC(t *testing.T) {
 	assert.Equal(t, uint64(3), pm.CurView())
 }
 
+// Test_SkipIncreaseViewThroughTC tests that PaceMaker increases View when receiving TC,
+// if applicable, by skipping views
+func Test_SkipIncreaseViewThroughTC(t *testing.T) {
+	pm, notifier := initPaceMaker(t, 3)
+
+	tc := TC(3, 1)
+	notifier.On("OnStartingTimeout", expectedTimerInfo(4, model.ReplicaTimeout)).Return().Once()
+	notifier.On("OnTcTriggeredViewChange", tc, uint64(4)).Return().Once()
+	nve, nveOccurred := pm.UpdateCurViewWithTC(tc)
+	notifier.AssertExpectations(t)
+	assert.Equal(t, uint64(4), pm.CurView())
+	assert.True(t, nveOccurred && nve.View == 4)
+
+	tc = TC(12, 2)
+	notifier.On("OnStartingTimeout", expectedTimerInfo(13, model.ReplicaTimeout)).Return().Once()
+	notifier.On("OnTcTriggeredViewChange", tc, uint64(13)).Return().Once()
+	nve, nveOccurred = pm.UpdateCurViewWithTC(tc)
+	assert.True(t, nveOccurred && nve.View == 13)
+
+	notifier.AssertExpectations(t)
+	assert.Equal(t, uint64(13), pm.CurView())
+}
+
+// Test_IgnoreOldTC tests that PaceMaker ignores old TCs
+func Test_IgnoreOldTC(t *testing.T) {
+	pm, notifier := initPaceMaker(t, 3)
+	nve, nveOccurred := pm.UpdateCurViewWithTC(TC(2, 1))
+	assert.True(t, !nveOccurred && nve == nil)
+	notifier.AssertExpectations(t)
+	assert.Equal(t, uint64(3), pm.CurView())
+}
+
+// Test_ViewChangeThroughTC tests that the PaceMaker increases its timeout when moving to a new view
+// through a TC, as the committee failed to make progress in the TC's view
+func Test_ViewChangeThroughTC(t *testing.T) {
+	pm, notifier := initPaceMaker(t, 5) // initPaceMaker also calls Start() on PaceMaker
+
+	notifier.On("OnTcTriggeredViewChange", mock.Anything, uint64(6)).Return().Once()
+	notifier.On("OnStartingTimeout", expectedTimerInfo(6, model.ReplicaTimeout)).Return().Once()
+	start := time.Now()
+	nve, nveOccurred := pm.UpdateCurViewWithTC(TC(5, 4))
+	assert.True(t, nveOccurred && nve.View == 6)
+	notifier.AssertExpectations(t)
+
+	select {
+	case <-pm.TimeoutChannel():
+		break // testing path: corresponds to EventLoop picking up timeout from channel
+	case <-time.After(time.Duration(2) * time.Duration(startRepTimeout*multiplicativeIncrease) * time.Millisecond):
+		t.Fail() // to prevent test from hanging
+	}
+
+	actualTimeout := float64(time.Since(start).Milliseconds()) // in millisecond
+	expectedTimeout := startRepTimeout * multiplicativeIncrease
+	assert.True(t, math.Abs(actualTimeout-expectedTimeout) < 0.1*expectedTimeout)
+	assert.Equal(t, uint64(6), pm.CurView())
+}
+
 // Test_SkipViewThroughBlock tests that PaceMaker skips View when receiving Block containing QC with larger View Number
 func Test_SkipViewThroughBlock(t *testing.T) {
 	pm, notifier := initPaceMaker(t, 3)
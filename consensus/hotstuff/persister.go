@@ -1,5 +1,9 @@
 package hotstuff
 
+import (
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+)
+
 // Persister is responsible for persisting state we need to bootstrap after a
 // restart or crash.
 type Persister interface {
@@ -7,12 +11,21 @@ type Persister interface {
 	// GetStarted will retrieve the last started view.
 	GetStarted() (uint64, error)
 
-	// GetVoted will retrieve the last voted view.
-	GetVoted() (uint64, error)
+	// GetSafetyData will retrieve the last persisted safety data.
+	GetSafetyData() (*model.SafetyData, error)
+
+	// GetVotes will retrieve the persisted votes for the given view and above.
+	GetVotes(view uint64) ([]*model.Vote, error)
 
 	// PutStarted persists the last started view.
 	PutStarted(view uint64) error
 
-	// PutVoted persists the last voted view.
-	PutVoted(view uint64) error
+	// PutSafetyData persists the safety data in a single write.
+	PutSafetyData(safetyData *model.SafetyData) error
+
+	// PutVote persists a validated vote, so that collected votes survive a restart.
+	PutVote(vote *model.Vote) error
+
+	// PruneVotes removes the persisted votes below the given view.
+	PruneVotes(view uint64) error
 }
@@ -1,9 +1,14 @@
 package persister
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/dgraph-io/badger/v2"
 
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
 	"github.com/onflow/flow-go/storage/badger/operation"
 )
 
@@ -30,11 +35,33 @@ func (p *Persister) GetStarted() (uint64, error) {
 	return view, err
 }
 
-// GetVoted returns the last persisted started view.
-func (p *Persister) GetVoted() (uint64, error) {
-	var view uint64
-	err := p.db.View(operation.RetrieveVotedView(p.chainID, &view))
-	return view, err
+// GetSafetyData returns the last persisted safety data. If no safety data was
+// persisted yet, i.e. the node never voted since bootstrapping or since it was
+// upgraded from persisting only the voted view, it returns safety data with
+// just the legacy voted view, if there is one.
+func (p *Persister) GetSafetyData() (*model.SafetyData, error) {
+	var safetyData model.SafetyData
+	err := p.db.View(operation.RetrieveSafetyData(p.chainID, &safetyData))
+	if !errors.Is(err, storage.ErrNotFound) {
+		return &safetyData, err
+	}
+
+	var votedView uint64
+	err = p.db.View(operation.RetrieveLegacyVotedView(p.chainID, &votedView))
+	if errors.Is(err, storage.ErrNotFound) {
+		return &model.SafetyData{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve legacy voted view: %w", err)
+	}
+	return &model.SafetyData{LegacyVotedView: votedView}, nil
+}
+
+// GetVotes returns the persisted votes for the given view and above.
+func (p *Persister) GetVotes(view uint64) ([]*model.Vote, error) {
+	var votes []*model.Vote
+	err := p.db.View(operation.LookupCollectedVotes(p.chainID, view, &votes))
+	return votes, err
 }
 
 // PutStarted persists the view when we start it in hotstuff.
@@ -42,7 +69,38 @@ func (p *Persister) PutStarted(view uint64) error {
 	return operation.RetryOnConflict(p.db.Update, operation.UpdateStartedView(p.chainID, view))
 }
 
-// PutVoted persist the view when we voted in hotstuff.
-func (p *Persister) PutVoted(view uint64) error {
-	return operation.RetryOnConflict(p.db.Update, operation.UpdateVotedView(p.chainID, view))
+// PutSafetyData persists the safety data in a single transaction, so that the
+// vote and the QCs are always consistent. The first safety data supersedes the
+// legacy voted view, which is removed in the same transaction.
+func (p *Persister) PutSafetyData(safetyData *model.SafetyData) error {
+	return operation.RetryOnConflict(p.db.Update, func(tx *badger.Txn) error {
+		err := operation.UpdateSafetyData(p.chainID, safetyData)(tx)
+		if !errors.Is(err, storage.ErrNotFound) {
+			return err
+		}
+		err = operation.InsertSafetyData(p.chainID, safetyData)(tx)
+		if err != nil {
+			return err
+		}
+		err = operation.RemoveLegacyVotedView(p.chainID)(tx)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return fmt.Errorf("could not remove legacy voted view: %w", err)
+		}
+		return nil
+	})
+}
+
+// PutVote persists a vote received in hotstuff. Only the first vote of a
+// replica for a view is kept.
+func (p *Persister) PutVote(vote *model.Vote) error {
+	err := operation.RetryOnConflict(p.db.Update, operation.InsertCollectedVote(p.chainID, vote))
+	if errors.Is(err, storage.ErrAlreadyExists) {
+		return nil
+	}
+	return err
+}
+
+// PruneVotes removes the persisted votes below the given view.
+func (p *Persister) PruneVotes(view uint64) error {
+	return operation.RetryOnConflict(p.db.Update, operation.PruneCollectedVotes(p.chainID, view))
 }
@@ -0,0 +1,145 @@
+package persister
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// Test the safety data is empty until it is persisted for the first time, and then
+// overwritten by every later write.
+func TestPersisterSafetyData(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		persist := New(db, "chain")
+
+		safetyData, err := persist.GetSafetyData()
+		require.NoError(t, err)
+		assert.Equal(t, &model.SafetyData{}, safetyData)
+		assert.Zero(t, safetyData.LastVotedView())
+
+		first := &model.SafetyData{
+			LockedQC:  unittest.QuorumCertificateFixture(),
+			HighestQC: unittest.QuorumCertificateFixture(),
+			LastVote:  unittest.VoteFixture(),
+		}
+		err = persist.PutSafetyData(first)
+		require.NoError(t, err)
+
+		second := &model.SafetyData{
+			LockedQC:  first.HighestQC,
+			HighestQC: unittest.QuorumCertificateFixture(),
+			LastVote:  unittest.VoteFixture(),
+		}
+		err = persist.PutSafetyData(second)
+		require.NoError(t, err)
+
+		safetyData, err = persist.GetSafetyData()
+		require.NoError(t, err)
+		assert.Equal(t, second, safetyData)
+	})
+}
+
+// Test the collected votes are kept until they are pruned, and a vote that is
+// received twice is only kept once.
+func TestPersisterVotes(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		persist := New(db, "chain")
+
+		vote1 := unittest.VoteFixture()
+		vote1.View = 10
+		vote2 := unittest.VoteFixture()
+		vote2.View = 11
+
+		for _, vote := range []*model.Vote{vote1, vote2, vote1} {
+			err := persist.PutVote(vote)
+			require.NoError(t, err)
+		}
+
+		votes, err := persist.GetVotes(10)
+		require.NoError(t, err)
+		assert.Equal(t, []*model.Vote{vote1, vote2}, votes)
+
+		err = persist.PruneVotes(11)
+		require.NoError(t, err)
+
+		votes, err = persist.GetVotes(0)
+		require.NoError(t, err)
+		assert.Equal(t, []*model.Vote{vote2}, votes)
+	})
+}
+
+// Test the persisted state is recovered by a new persister on the same database,
+// as it happens after a restart.
+func TestPersisterRestart(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		// the started view is bootstrapped with the protocol state
+		require.NoError(t, db.Update(operation.InsertStartedView("chain", 0)))
+		persist := New(db, "chain")
+
+		safetyData := &model.SafetyData{
+			LockedQC:  unittest.QuorumCertificateFixture(),
+			HighestQC: unittest.QuorumCertificateFixture(),
+			LastVote:  unittest.VoteFixture(),
+		}
+		vote := unittest.VoteFixture()
+		require.NoError(t, persist.PutStarted(safetyData.LastVote.View))
+		require.NoError(t, persist.PutSafetyData(safetyData))
+		require.NoError(t, persist.PutVote(vote))
+
+		restarted := New(db, "chain")
+
+		started, err := restarted.GetStarted()
+		require.NoError(t, err)
+		assert.Equal(t, safetyData.LastVote.View, started)
+
+		recovered, err := restarted.GetSafetyData()
+		require.NoError(t, err)
+		assert.Equal(t, safetyData, recovered)
+
+		votes, err := restarted.GetVotes(vote.View)
+		require.NoError(t, err)
+		assert.Equal(t, []*model.Vote{vote}, votes)
+
+		// a persister for another chain doesn't see the state
+		other, err := New(db, "other").GetSafetyData()
+		require.NoError(t, err)
+		assert.Equal(t, &model.SafetyData{}, other)
+	})
+}
+
+// Test the voted view persisted by a node before the upgrade seeds the safety data,
+// and is removed once the first safety data is persisted.
+func TestPersisterLegacyVotedView(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		require.NoError(t, db.Update(operation.InsertLegacyVotedView("chain", 10)))
+		persist := New(db, "chain")
+
+		seeded, err := persist.GetSafetyData()
+		require.NoError(t, err)
+		assert.Equal(t, &model.SafetyData{LegacyVotedView: 10}, seeded)
+		assert.Equal(t, uint64(10), seeded.LastVotedView())
+
+		safetyData := &model.SafetyData{
+			LockedQC:  unittest.QuorumCertificateFixture(),
+			HighestQC: unittest.QuorumCertificateFixture(),
+			LastVote:  unittest.VoteFixture(),
+		}
+		require.NoError(t, persist.PutSafetyData(safetyData))
+
+		recovered, err := New(db, "chain").GetSafetyData()
+		require.NoError(t, err)
+		assert.Equal(t, safetyData, recovered)
+
+		var votedView uint64
+		err = db.View(operation.RetrieveLegacyVotedView("chain", &votedView))
+		assert.True(t, errors.Is(err, storage.ErrNotFound))
+	})
+}
@@ -0,0 +1,17 @@
+package hotstuff
+
+import (
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+)
+
+// TimeoutAggregator aggregates timeouts and produces timeout certificates.
+type TimeoutAggregator interface {
+
+	// StoreTimeoutAndBuildTC will store a timeout and build the TC for the
+	// timeout's view if enough timeouts can be accumulated.
+	StoreTimeoutAndBuildTC(timeout *model.TimeoutObject) (*model.TimeoutCertificate, bool, error)
+
+	// PruneByView will remove any data held for views up to and including
+	// the provided view.
+	PruneByView(view uint64)
+}
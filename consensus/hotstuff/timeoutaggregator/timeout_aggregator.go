@@ -0,0 +1,154 @@
+package timeoutaggregator
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/consensus/hotstuff"
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/model/flow/filter"
+)
+
+// maxViewsAhead is the number of views beyond the current view for which timeouts are stored. Timeouts for
+// views further ahead are dropped, so that byzantine replicas can't exhaust the memory with timeouts for
+// arbitrary views. A replica lagging further behind catches up with the QCs of proposals instead.
+const maxViewsAhead = 1000
+
+// TimeoutAggregator stores the timeouts and aggregates them into a TC when enough timeouts have been collected
+type TimeoutAggregator struct {
+	notifier            hotstuff.Consumer
+	committee           hotstuff.Committee
+	forks               hotstuff.ForksReader
+	paceMaker           hotstuff.PaceMaker
+	timeoutValidator    hotstuff.Validator
+	signer              hotstuff.SignerVerifier
+	highestPrunedView   uint64
+	viewToTimeoutStatus map[uint64]*TimeoutStatus            // keeps track of accumulated timeouts and stakes for views
+	createdTC           map[uint64]*model.TimeoutCertificate // keeps track of TCs that have been made for views
+}
+
+// New creates an instance of timeout aggregator
+func New(notifier hotstuff.Consumer, highestPrunedView uint64, committee hotstuff.Committee, forks hotstuff.ForksReader, paceMaker hotstuff.PaceMaker, timeoutValidator hotstuff.Validator, signer hotstuff.SignerVerifier) *TimeoutAggregator {
+	return &TimeoutAggregator{
+		notifier:            notifier,
+		highestPrunedView:   highestPrunedView,
+		committee:           committee,
+		forks:               forks,
+		paceMaker:           paceMaker,
+		timeoutValidator:    timeoutValidator,
+		signer:              signer,
+		viewToTimeoutStatus: make(map[uint64]*TimeoutStatus),
+		createdTC:           make(map[uint64]*model.TimeoutCertificate),
+	}
+}
+
+// StoreTimeoutAndBuildTC validates and stores the timeout, and returns a TC if there are timeouts
+// with enough stakes for the timeout's view.
+// It's idempotent. Meaning, calling it again with the same timeout returns the same result.
+// The TimeoutAggregator builds a TC as soon as the number of timeouts allow this.
+// While subsequent timeouts (past the required threshold) are not included in the TC anymore,
+// TimeoutAggregator ALWAYS returns the same TC as the one returned before.
+// It returns (tc, true, nil) if a TC is built
+// It returns (nil, false, nil) if not enough timeouts to build a TC, or the timeout is stale, too far ahead of the
+// current view, invalid or includes a QC for an unknown block
+// It returns (nil, false, err) if there is an unknown error
+func (ta *TimeoutAggregator) StoreTimeoutAndBuildTC(timeout *model.TimeoutObject) (*model.TimeoutCertificate, bool, error) {
+
+	// if the TC for the view has been created before, return the TC
+	oldTC, built := ta.createdTC[timeout.View]
+	if built {
+		return oldTC, true, nil
+	}
+
+	// ignore stale timeouts
+	if timeout.View <= ta.highestPrunedView {
+		return nil, false, nil
+	}
+
+	// ignore timeouts too far ahead of the current view
+	if timeout.View > ta.paceMaker.CurView()+maxViewsAhead {
+		return nil, false, nil
+	}
+
+	// validate the timeout and add it to the accumulated timeout status
+	valid, err := ta.validateAndStoreTimeout(timeout)
+	if err != nil {
+		return nil, false, fmt.Errorf("could not store timeout: %w", err)
+	}
+
+	// cannot build tc if timeout is invalid
+	if !valid {
+		return nil, false, nil
+	}
+
+	// try to build the TC with existing timeouts
+	tc, built, err := ta.viewToTimeoutStatus[timeout.View].TryBuildTC()
+	if err != nil {
+		return nil, false, fmt.Errorf("could not build TC: %w", err)
+	}
+	if !built {
+		return nil, false, nil
+	}
+
+	ta.createdTC[timeout.View] = tc
+	ta.notifier.OnTcConstructedFromTimeouts(tc)
+	return tc, true, nil
+}
+
+// PruneByView will delete all timeouts equal or below to the given view, as well as the TCs built from them.
+func (ta *TimeoutAggregator) PruneByView(view uint64) {
+	if view <= ta.highestPrunedView {
+		return
+	}
+	for v := range ta.viewToTimeoutStatus {
+		if v <= view {
+			delete(ta.viewToTimeoutStatus, v)
+		}
+	}
+	for v := range ta.createdTC {
+		if v <= view {
+			delete(ta.createdTC, v)
+		}
+	}
+	ta.highestPrunedView = view
+}
+
+// validateAndStoreTimeout validates the timeout and accumulates its stake for the timeout's view.
+// It drops invalid timeouts, timeouts including a QC for an unknown block, and repeated timeouts
+// by the same replica. Hence, a TC is only built from timeouts with validated QCs.
+func (ta *TimeoutAggregator) validateAndStoreTimeout(timeout *model.TimeoutObject) (bool, error) {
+	// validate the timeout
+	signer, err := ta.timeoutValidator.ValidateTimeout(timeout)
+
+	if model.IsInvalidTimeoutError(err) {
+		// does not report invalid timeout as an error, notify consumers instead
+		ta.notifier.OnInvalidTimeoutDetected(timeout)
+		return false, nil
+	}
+
+	if model.IsMissingBlockError(err) {
+		// the included QC can't be validated until we know its block, which this replica
+		// will receive with the proposals it is missing
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("could not validate timeout: %w", err)
+	}
+
+	// update existing timeout status or create a new one
+	timeoutStatus, exists := ta.viewToTimeoutStatus[timeout.View]
+	if !exists {
+		// get all identities, which are determined at the latest finalized block like for the validation
+		identities, err := ta.committee.Identities(ta.forks.FinalizedBlock().BlockID, filter.Any)
+		if err != nil {
+			return false, fmt.Errorf("error retrieving consensus participants: %w", err)
+		}
+
+		// create TimeoutStatus for view
+		stakeThreshold := hotstuff.ComputeStakeThresholdForBuildingQC(identities.TotalStake()) // stake threshold for building valid tc
+		timeoutStatus = NewTimeoutStatus(timeout.View, stakeThreshold, ta.signer)
+		ta.viewToTimeoutStatus[timeout.View] = timeoutStatus
+	}
+	timeoutStatus.AddTimeout(timeout, signer)
+	return true, nil
+}
@@ -0,0 +1,222 @@
+package timeoutaggregator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/helper"
+	"github.com/onflow/flow-go/consensus/hotstuff/mocks"
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestTimeoutAggregator(t *testing.T) {
+	suite.Run(t, new(AggregatorSuite))
+}
+
+type AggregatorSuite struct {
+	suite.Suite
+	participants flow.IdentityList
+	finalized    *model.Block
+	view         uint64
+	committee    *mocks.Committee
+	forks        *mocks.Forks
+	paceMaker    *mocks.PaceMaker
+	validator    *mocks.Validator
+	signer       *mocks.SignerVerifier
+	notifier     *mocks.Consumer
+
+	aggregator *TimeoutAggregator
+}
+
+func (as *AggregatorSuite) SetupTest() {
+
+	// generate the committee with a qualified majority threshold of 5
+	as.participants = unittest.IdentityListFixture(7, unittest.WithRole(flow.RoleConsensus))
+	as.finalized = helper.MakeBlock(as.T(), helper.WithBlockView(10))
+	as.view = as.finalized.View + 5
+
+	as.committee = &mocks.Committee{}
+	as.committee.On("Identities", as.finalized.BlockID, mock.Anything).Return(
+		func(blockID flow.Identifier, selector flow.IdentityFilter) flow.IdentityList {
+			return as.participants.Filter(selector)
+		},
+		nil,
+	)
+
+	as.forks = &mocks.Forks{}
+	as.forks.On("FinalizedBlock").Return(as.finalized)
+
+	as.paceMaker = &mocks.PaceMaker{}
+	as.paceMaker.On("CurView").Return(as.view)
+
+	// all timeouts are valid by default
+	as.validator = &mocks.Validator{}
+	as.validator.On("ValidateTimeout", mock.Anything).Return(
+		func(timeout *model.TimeoutObject) *flow.Identity {
+			signer, _ := as.participants.ByNodeID(timeout.SignerID)
+			return signer
+		},
+		nil,
+	)
+
+	as.signer = &mocks.SignerVerifier{}
+	as.signer.On("CreateTC", mock.Anything).Return(
+		func(timeouts []*model.TimeoutObject) *model.TimeoutCertificate {
+			signerIDs := make([]flow.Identifier, 0, len(timeouts))
+			for _, timeout := range timeouts {
+				signerIDs = append(signerIDs, timeout.SignerID)
+			}
+			return &model.TimeoutCertificate{
+				View:      timeouts[0].View,
+				HighestQC: timeouts[0].HighestQC,
+				SignerIDs: signerIDs,
+			}
+		},
+		nil,
+	)
+
+	as.notifier = &mocks.Consumer{}
+
+	as.aggregator = New(as.notifier, as.finalized.View, as.committee, as.forks, as.paceMaker, as.validator, as.signer)
+}
+
+// HAPPY PATH (TC is built as soon as the timeouts reach the stake threshold)
+func (as *AggregatorSuite) TestBuildTCWithSupermajority() {
+	for _, participant := range as.participants[:4] {
+		tc, built, err := as.aggregator.StoreTimeoutAndBuildTC(as.timeout(participant))
+		require.NoError(as.T(), err)
+		require.False(as.T(), built)
+		require.Nil(as.T(), tc)
+	}
+
+	as.notifier.On("OnTcConstructedFromTimeouts", mock.Anything).Return().Once()
+	tc, built, err := as.aggregator.StoreTimeoutAndBuildTC(as.timeout(as.participants[4]))
+	require.NoError(as.T(), err)
+	require.True(as.T(), built)
+	require.Equal(as.T(), as.view, tc.View)
+	require.ElementsMatch(as.T(), as.participants[:5].NodeIDs(), tc.SignerIDs)
+	as.notifier.AssertExpectations(as.T())
+
+	// subsequent timeouts return the same TC without building it again
+	again, built, err := as.aggregator.StoreTimeoutAndBuildTC(as.timeout(as.participants[5]))
+	require.NoError(as.T(), err)
+	require.True(as.T(), built)
+	require.Equal(as.T(), tc, again)
+	as.signer.AssertNumberOfCalls(as.T(), "CreateTC", 1)
+}
+
+// UNHAPPY PATH (repeated timeouts by the same signer are only counted once)
+func (as *AggregatorSuite) TestRepeatedTimeouts() {
+	for _, participant := range as.participants[:4] {
+		_, _, err := as.aggregator.StoreTimeoutAndBuildTC(as.timeout(participant))
+		require.NoError(as.T(), err)
+	}
+
+	// a repeated timeout with a different QC does not add to the stake
+	repeated := as.timeout(as.participants[0])
+	repeated.HighestQC = helper.MakeQC(as.T(), helper.WithQCView(as.view-1))
+	tc, built, err := as.aggregator.StoreTimeoutAndBuildTC(repeated)
+	require.NoError(as.T(), err)
+	require.False(as.T(), built)
+	require.Nil(as.T(), tc)
+	as.signer.AssertNotCalled(as.T(), "CreateTC", mock.Anything)
+}
+
+// UNHAPPY PATH (invalid timeouts are reported, but not counted)
+func (as *AggregatorSuite) TestInvalidTimeout() {
+	invalid := as.timeout(as.participants[0])
+	*as.validator = mocks.Validator{}
+	as.validator.On("ValidateTimeout", invalid).Return(nil, model.InvalidTimeoutError{View: invalid.View, Err: model.ErrInvalidSignature})
+	as.notifier.On("OnInvalidTimeoutDetected", invalid).Return().Once()
+
+	tc, built, err := as.aggregator.StoreTimeoutAndBuildTC(invalid)
+	require.NoError(as.T(), err)
+	require.False(as.T(), built)
+	require.Nil(as.T(), tc)
+	require.Empty(as.T(), as.aggregator.viewToTimeoutStatus)
+	as.notifier.AssertExpectations(as.T())
+}
+
+// UNHAPPY PATH (timeouts with a QC for an unknown block are dropped, without being reported as invalid)
+func (as *AggregatorSuite) TestTimeoutQCUnknownBlock() {
+	timeout := as.timeout(as.participants[0])
+	*as.validator = mocks.Validator{}
+	as.validator.On("ValidateTimeout", timeout).Return(nil, model.MissingBlockError{View: timeout.HighestQC.View, BlockID: timeout.HighestQC.BlockID})
+
+	tc, built, err := as.aggregator.StoreTimeoutAndBuildTC(timeout)
+	require.NoError(as.T(), err)
+	require.False(as.T(), built)
+	require.Nil(as.T(), tc)
+	require.Empty(as.T(), as.aggregator.viewToTimeoutStatus)
+	as.notifier.AssertNotCalled(as.T(), "OnInvalidTimeoutDetected", mock.Anything)
+}
+
+// UNHAPPY PATH (unexpected validation errors are returned)
+func (as *AggregatorSuite) TestValidationError() {
+	timeout := as.timeout(as.participants[0])
+	*as.validator = mocks.Validator{}
+	as.validator.On("ValidateTimeout", timeout).Return(nil, errors.New("dummy error"))
+
+	_, _, err := as.aggregator.StoreTimeoutAndBuildTC(timeout)
+	require.Error(as.T(), err)
+}
+
+// PRUNING (timeouts at or below the pruned view are dropped)
+func (as *AggregatorSuite) TestPruneByView() {
+	for _, participant := range as.participants[:5] {
+		as.notifier.On("OnTcConstructedFromTimeouts", mock.Anything).Return().Once()
+		_, _, err := as.aggregator.StoreTimeoutAndBuildTC(as.timeout(participant))
+		require.NoError(as.T(), err)
+	}
+	require.Len(as.T(), as.aggregator.createdTC, 1)
+
+	as.aggregator.PruneByView(as.view)
+	require.Empty(as.T(), as.aggregator.viewToTimeoutStatus)
+	require.Empty(as.T(), as.aggregator.createdTC)
+
+	// stale timeouts are ignored without validation
+	*as.validator = mocks.Validator{}
+	tc, built, err := as.aggregator.StoreTimeoutAndBuildTC(as.timeout(as.participants[0]))
+	require.NoError(as.T(), err)
+	require.False(as.T(), built)
+	require.Nil(as.T(), tc)
+	as.validator.AssertNotCalled(as.T(), "ValidateTimeout", mock.Anything)
+}
+
+// UNHAPPY PATH (timeouts too far ahead of the current view are dropped)
+func (as *AggregatorSuite) TestTimeoutTooFarAhead() {
+	*as.validator = mocks.Validator{}
+
+	// the timeout for the furthest view ahead is stored
+	timeout := as.timeout(as.participants[0])
+	timeout.View = as.view + maxViewsAhead
+	as.validator.On("ValidateTimeout", timeout).Return(as.participants[0], nil).Once()
+	_, built, err := as.aggregator.StoreTimeoutAndBuildTC(timeout)
+	require.NoError(as.T(), err)
+	require.False(as.T(), built)
+	require.Len(as.T(), as.aggregator.viewToTimeoutStatus, 1)
+
+	// timeouts beyond are ignored without validation
+	timeout = as.timeout(as.participants[0])
+	timeout.View = as.view + maxViewsAhead + 1
+	tc, built, err := as.aggregator.StoreTimeoutAndBuildTC(timeout)
+	require.NoError(as.T(), err)
+	require.False(as.T(), built)
+	require.Nil(as.T(), tc)
+	require.Len(as.T(), as.aggregator.viewToTimeoutStatus, 1)
+	as.validator.AssertExpectations(as.T())
+}
+
+func (as *AggregatorSuite) timeout(signer *flow.Identity) *model.TimeoutObject {
+	return helper.MakeTimeout(as.T(),
+		helper.WithTimeoutView(as.view),
+		helper.WithTimeoutSigner(signer.NodeID),
+		helper.WithTimeoutQC(helper.MakeQC(as.T(), helper.WithQCBlock(as.finalized))),
+	)
+}
@@ -0,0 +1,63 @@
+package timeoutaggregator
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/consensus/hotstuff"
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// TimeoutStatus keeps track of the timeouts for the same view
+type TimeoutStatus struct {
+	signer           hotstuff.SignerVerifier
+	view             uint64
+	stakeThreshold   uint64
+	accumulatedStake uint64
+	// assume timeouts are all valid to build TC
+	timeouts map[flow.Identifier]*model.TimeoutObject
+}
+
+// NewTimeoutStatus creates a new Timeout Status instance
+func NewTimeoutStatus(view uint64, stakeThreshold uint64, signer hotstuff.SignerVerifier) *TimeoutStatus {
+	return &TimeoutStatus{
+		signer:           signer,
+		view:             view,
+		stakeThreshold:   stakeThreshold,
+		accumulatedStake: 0,
+		timeouts:         make(map[flow.Identifier]*model.TimeoutObject),
+	}
+}
+
+// AddTimeout adds the timeout to the list, and accumulates the stake.
+// Assumes the timeout and its QC are valid. Only the first timeout by each signer is
+// accumulated, as a signer might time out with different QCs in the same view.
+func (ts *TimeoutStatus) AddTimeout(timeout *model.TimeoutObject, signer *flow.Identity) {
+	_, exists := ts.timeouts[timeout.SignerID]
+	if exists {
+		return
+	}
+	ts.timeouts[timeout.SignerID] = timeout
+	ts.accumulatedStake += signer.Stake
+}
+
+// TryBuildTC returns a TC if the existing timeouts are enough to build a TC.
+func (ts *TimeoutStatus) TryBuildTC() (*model.TimeoutCertificate, bool, error) {
+
+	// check if there are enough timeouts to build TC
+	if ts.accumulatedStake < ts.stakeThreshold {
+		return nil, false, nil
+	}
+
+	// build the aggregated signature
+	timeouts := make([]*model.TimeoutObject, 0, len(ts.timeouts))
+	for _, timeout := range ts.timeouts {
+		timeouts = append(timeouts, timeout)
+	}
+	tc, err := ts.signer.CreateTC(timeouts)
+	if err != nil {
+		return nil, false, fmt.Errorf("could not create TC from timeouts: %w", err)
+	}
+
+	return tc, true, nil
+}
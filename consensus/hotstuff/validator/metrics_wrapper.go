@@ -46,3 +46,10 @@ func (w ValidatorMetricsWrapper) ValidateVote(vote *model.Vote, block *model.Blo
 	w.metrics.ValidatorProcessingDuration(time.Since(processStart))
 	return identity, err
 }
+
+func (w ValidatorMetricsWrapper) ValidateTimeout(timeout *model.TimeoutObject) (*flow.Identity, error) {
+	processStart := time.Now()
+	identity, err := w.validator.ValidateTimeout(timeout)
+	w.metrics.ValidatorProcessingDuration(time.Since(processStart))
+	return identity, err
+}
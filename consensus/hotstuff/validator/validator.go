@@ -11,7 +11,7 @@ import (
 	"github.com/onflow/flow-go/model/flow/filter"
 )
 
-// Validator is responsible for validating QC, Block and Vote
+// Validator is responsible for validating QC, Block, Vote and Timeout
 type Validator struct {
 	committee hotstuff.Committee
 	forks     hotstuff.ForksReader
@@ -88,7 +88,7 @@ func (v *Validator) ValidateProposal(proposal *model.Proposal) error {
 	}
 
 	// check the proposer is the leader for the proposed block's view
-	leader, err := v.committee.LeaderForView(block.View)
+	leader, err := v.committee.LeaderForView(qc.BlockID, block.View)
 	if err != nil {
 		return fmt.Errorf("error determining leader for block %x: %w", block.BlockID, err)
 	}
@@ -159,6 +159,62 @@ func (v *Validator) ValidateVote(vote *model.Vote, block *model.Block) (*flow.Id
 	return voter, nil
 }
 
+// ValidateTimeout validates the timeout and returns the identity of the replica who signed
+// timeout - the timeout to be validated
+// Timeouts are not tied to a block, so the consensus committee is determined at the latest
+// finalized block. The QC included in the timeout is validated against the block it references;
+// if the block is unknown, a MissingBlockError is returned, as the QC can't be validated.
+func (v *Validator) ValidateTimeout(timeout *model.TimeoutObject) (*flow.Identity, error) {
+	// a timeout must include the newest QC known to the replica, which is always below the view
+	if timeout.HighestQC == nil {
+		return nil, newInvalidTimeoutError(timeout, fmt.Errorf("timeout is missing qc"))
+	}
+	if timeout.HighestQC.View >= timeout.View {
+		return nil, newInvalidTimeoutError(timeout, fmt.Errorf("timeout's qc view %d is not below timeout view %d", timeout.HighestQC.View, timeout.View))
+	}
+
+	finalized := v.forks.FinalizedBlock()
+	signer, err := v.committee.Identity(finalized.BlockID, timeout.SignerID)
+	if errors.Is(err, model.ErrInvalidSigner) {
+		return nil, newInvalidTimeoutError(timeout, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving signer Identity %x: %w", finalized.BlockID, err)
+	}
+
+	// check whether the signature data is valid for the timeout in the hotstuff context
+	valid, err := v.verifier.VerifyTimeout(timeout.SignerID, timeout.SigData, timeout.View, timeout.HighestQC, finalized)
+	if err != nil {
+		switch {
+		case errors.Is(err, verification.ErrInvalidFormat):
+			return nil, newInvalidTimeoutError(timeout, err)
+		case errors.Is(err, model.ErrInvalidSigner):
+			return nil, newInvalidTimeoutError(timeout, err)
+		default:
+			return nil, fmt.Errorf("cannot verify signature for timeout (%x): %w", timeout.ID(), err)
+		}
+	}
+	if !valid {
+		return nil, newInvalidTimeoutError(timeout, model.ErrInvalidSignature)
+	}
+
+	// validate the included QC - keep the most expensive the last to check
+	qc := timeout.HighestQC
+	block, found := v.forks.GetBlock(qc.BlockID)
+	if !found {
+		return nil, model.MissingBlockError{View: qc.View, BlockID: qc.BlockID}
+	}
+	err = v.ValidateQC(qc, block)
+	if model.IsInvalidBlockError(err) {
+		return nil, newInvalidTimeoutError(timeout, fmt.Errorf("invalid qc included in timeout: %w", err))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot validate qc included in timeout (%x): %w", timeout.ID(), err)
+	}
+
+	return signer, nil
+}
+
 func newInvalidBlockError(block *model.Block, err error) error {
 	return model.InvalidBlockError{
 		BlockID: block.BlockID,
@@ -174,3 +230,11 @@ func newInvalidVoteError(vote *model.Vote, err error) error {
 		Err:    err,
 	}
 }
+
+func newInvalidTimeoutError(timeout *model.TimeoutObject, err error) error {
+	return model.InvalidTimeoutError{
+		TimeoutID: timeout.ID(),
+		View:      timeout.View,
+		Err:       err,
+	}
+}
@@ -61,7 +61,7 @@ func (ps *ProposalSuite) SetupTest() {
 
 	// set up the mocked hotstuff Committee state
 	ps.committee = &mocks.Committee{}
-	ps.committee.On("LeaderForView", ps.block.View).Return(ps.leader.NodeID, nil)
+	ps.committee.On("LeaderForView", ps.block.QC.BlockID, ps.block.View).Return(ps.leader.NodeID, nil)
 	ps.committee.On("Identities", mock.Anything, mock.Anything).Return(
 		func(blockID flow.Identifier, selector flow.IdentityFilter) flow.IdentityList {
 			return ps.participants.Filter(selector)
@@ -142,7 +142,7 @@ func (ps *ProposalSuite) TestProposalWrongLeader() {
 
 	// change the hotstuff.Committee to return a different leader
 	*ps.committee = mocks.Committee{}
-	ps.committee.On("LeaderForView", ps.block.View).Return(ps.participants[1].NodeID, nil)
+	ps.committee.On("LeaderForView", ps.block.QC.BlockID, ps.block.View).Return(ps.participants[1].NodeID, nil)
 	for _, participant := range ps.participants {
 		ps.committee.On("Identity", mock.Anything, participant.NodeID).Return(participant, nil)
 	}
@@ -444,3 +444,159 @@ func (qs *QCSuite) TestQCSignatureInvalidFormat() {
 	err := qs.validator.ValidateQC(qs.qc, qs.block)
 	assert.True(qs.T(), model.IsInvalidBlockError(err), "if the signature has an invalid format, an ErrorInvalidBlock error should be raised")
 }
+
+func TestValidateTimeout(t *testing.T) {
+	suite.Run(t, new(TimeoutSuite))
+}
+
+type TimeoutSuite struct {
+	suite.Suite
+	signer       *flow.Identity
+	participants flow.IdentityList
+	finalized    *model.Block
+	timeout      *model.TimeoutObject
+	forks        *mocks.Forks
+	verifier     *mocks.Verifier
+	committee    *mocks.Committee
+	validator    *Validator
+}
+
+func (ts *TimeoutSuite) SetupTest() {
+
+	// create a list of 10 nodes with one stake each, the first of which signs the timeout
+	ts.participants = unittest.IdentityListFixture(10,
+		unittest.WithRole(flow.RoleConsensus),
+		unittest.WithStake(1),
+	)
+	ts.signer = ts.participants[0]
+
+	// create a timeout for the view after the finalized block, including the QC for the finalized block
+	ts.finalized = helper.MakeBlock(ts.T())
+	ts.timeout = helper.MakeTimeout(ts.T(),
+		helper.WithTimeoutView(ts.finalized.View+2),
+		helper.WithTimeoutQC(helper.MakeQC(ts.T(), helper.WithQCBlock(ts.finalized), helper.WithQCSigners(ts.participants[:7].NodeIDs()))),
+		helper.WithTimeoutSigner(ts.signer.NodeID),
+	)
+
+	// set up the mocked forks
+	ts.forks = &mocks.Forks{}
+	ts.forks.On("FinalizedBlock").Return(ts.finalized)
+	ts.forks.On("GetBlock", ts.finalized.BlockID).Return(ts.finalized, true)
+
+	// set up the mocked verifier
+	ts.verifier = &mocks.Verifier{}
+	ts.verifier.On("VerifyTimeout", ts.timeout.SignerID, ts.timeout.SigData, ts.timeout.View, ts.timeout.HighestQC, ts.finalized).Return(true, nil)
+	ts.verifier.On("VerifyQC", ts.timeout.HighestQC.SignerIDs, ts.timeout.HighestQC.SigData, ts.finalized).Return(true, nil)
+
+	// the signer is a valid consensus participant at the finalized block
+	ts.committee = &mocks.Committee{}
+	ts.committee.On("Identity", ts.finalized.BlockID, ts.signer.NodeID).Return(ts.signer, nil)
+	ts.committee.On("Identities", ts.finalized.BlockID, mock.Anything).Return(
+		func(blockID flow.Identifier, selector flow.IdentityFilter) flow.IdentityList {
+			return ts.participants.Filter(selector)
+		},
+		nil,
+	)
+
+	// set up the validator with the mocked dependencies
+	ts.validator = New(ts.committee, ts.forks, ts.verifier)
+}
+
+func (ts *TimeoutSuite) TestTimeoutOK() {
+
+	// check the happy case, which is the default for the suite
+	signer, err := ts.validator.ValidateTimeout(ts.timeout)
+	assert.NoError(ts.T(), err, "a valid timeout should be accepted")
+	assert.Equal(ts.T(), ts.signer, signer)
+}
+
+func (ts *TimeoutSuite) TestTimeoutMissingQC() {
+
+	// remove the QC from the timeout
+	ts.timeout.HighestQC = nil
+
+	// check that the timeout is no longer validated
+	_, err := ts.validator.ValidateTimeout(ts.timeout)
+	assert.True(ts.T(), model.IsInvalidTimeoutError(err), "a timeout without qc should create an invalid timeout error")
+}
+
+func (ts *TimeoutSuite) TestTimeoutQCNotBelowView() {
+
+	// make the QC view equal to the timeout view
+	ts.timeout.HighestQC.View = ts.timeout.View
+
+	// check that the timeout is no longer validated
+	_, err := ts.validator.ValidateTimeout(ts.timeout)
+	assert.True(ts.T(), model.IsInvalidTimeoutError(err), "a timeout with a qc for its own view should create an invalid timeout error")
+}
+
+func (ts *TimeoutSuite) TestTimeoutInvalidSigner() {
+
+	// make the signer an invalid consensus participant
+	*ts.committee = mocks.Committee{}
+	ts.committee.On("Identity", ts.finalized.BlockID, ts.signer.NodeID).Return(nil, model.ErrInvalidSigner)
+
+	// check that the timeout is no longer validated
+	_, err := ts.validator.ValidateTimeout(ts.timeout)
+	assert.True(ts.T(), model.IsInvalidTimeoutError(err), "a timeout by an invalid signer should create an invalid timeout error")
+}
+
+func (ts *TimeoutSuite) TestTimeoutSignatureError() {
+
+	// make the verification fail on signature
+	*ts.verifier = mocks.Verifier{}
+	ts.verifier.On("VerifyTimeout", ts.timeout.SignerID, ts.timeout.SigData, ts.timeout.View, ts.timeout.HighestQC, ts.finalized).Return(true, errors.New("dummy error"))
+
+	// check that the timeout is no longer validated, but the error is not considered a validation failure
+	_, err := ts.validator.ValidateTimeout(ts.timeout)
+	assert.Error(ts.T(), err, "a timeout with error on signature validation should be rejected")
+	assert.False(ts.T(), model.IsInvalidTimeoutError(err), "an unexpected verification error should not create an invalid timeout error")
+}
+
+func (ts *TimeoutSuite) TestTimeoutSignatureInvalidFormat() {
+
+	// make the signature fail with an invalid format
+	*ts.verifier = mocks.Verifier{}
+	ts.verifier.On("VerifyTimeout", ts.timeout.SignerID, ts.timeout.SigData, ts.timeout.View, ts.timeout.HighestQC, ts.finalized).Return(false, fmt.Errorf("nested: %w", verification.ErrInvalidFormat))
+
+	// check that the timeout is no longer validated
+	_, err := ts.validator.ValidateTimeout(ts.timeout)
+	assert.True(ts.T(), model.IsInvalidTimeoutError(err), "a timeout with an invalid signature format should create an invalid timeout error")
+}
+
+func (ts *TimeoutSuite) TestTimeoutSignatureInvalid() {
+
+	// make sure the signature is treated as invalid
+	*ts.verifier = mocks.Verifier{}
+	ts.verifier.On("VerifyTimeout", ts.timeout.SignerID, ts.timeout.SigData, ts.timeout.View, ts.timeout.HighestQC, ts.finalized).Return(false, nil)
+
+	// check that the timeout is no longer validated
+	_, err := ts.validator.ValidateTimeout(ts.timeout)
+	assert.True(ts.T(), model.IsInvalidTimeoutError(err), "a timeout with an invalid signature should create an invalid timeout error")
+}
+
+func (ts *TimeoutSuite) TestTimeoutQCUnknownBlock() {
+
+	// make the QC reference a block that is unknown to forks
+	unknown := helper.MakeBlock(ts.T(), helper.WithBlockView(ts.finalized.View+1))
+	ts.timeout.HighestQC = helper.MakeQC(ts.T(), helper.WithQCBlock(unknown))
+	ts.verifier.On("VerifyTimeout", ts.timeout.SignerID, ts.timeout.SigData, ts.timeout.View, ts.timeout.HighestQC, ts.finalized).Return(true, nil)
+	ts.forks.On("GetBlock", unknown.BlockID).Return(nil, false)
+
+	// check that the QC can't be validated, without treating the timeout as invalid
+	_, err := ts.validator.ValidateTimeout(ts.timeout)
+	assert.True(ts.T(), model.IsMissingBlockError(err), "a timeout with a qc for an unknown block should create a missing block error")
+	assert.False(ts.T(), model.IsInvalidTimeoutError(err))
+}
+
+func (ts *TimeoutSuite) TestTimeoutQCInvalid() {
+
+	// make the signature of the included QC invalid
+	*ts.verifier = mocks.Verifier{}
+	ts.verifier.On("VerifyTimeout", ts.timeout.SignerID, ts.timeout.SigData, ts.timeout.View, ts.timeout.HighestQC, ts.finalized).Return(true, nil)
+	ts.verifier.On("VerifyQC", ts.timeout.HighestQC.SignerIDs, ts.timeout.HighestQC.SigData, ts.finalized).Return(false, nil)
+
+	// check that the timeout is no longer validated
+	_, err := ts.validator.ValidateTimeout(ts.timeout)
+	assert.True(ts.T(), model.IsInvalidTimeoutError(err), "a timeout with an invalid qc should create an invalid timeout error")
+}
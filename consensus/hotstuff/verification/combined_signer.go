@@ -16,26 +16,28 @@ import (
 // data structures. The first type is an aggregating signer, which creates single
 // signatures that can be aggregated into a single aggregated signature. The second
 // type is a threshold signer, which creates threshold signature shares, which can
-// be used to reconstruct a threshold signature.
+// be used to reconstruct a threshold signature. The threshold signer is the one of
+// the epoch of the signed view, as the random beacon key shares change with epochs.
 type CombinedSigner struct {
 	*CombinedVerifier
-	staking  module.AggregatingSigner
-	beacon   module.ThresholdSigner
-	merger   module.Merger
-	signerID flow.Identifier
+	staking     module.AggregatingSigner
+	beaconStore module.ThresholdSignerStore
+	merger      module.Merger
+	signerID    flow.Identifier
 }
 
 // NewCombinedSigner creates a new combined signer with the given dependencies:
 // - the hotstuff committee's state is used to retrieve public keys for signers;
 // - the signer ID is used as the identity when creating signatures;
 // - the staking signer is used to create aggregatable signatures for the first signature part;
-// - the threshold signer is used to create threshold signture shres for the second signature part;
+// - the threshold verifier is used to verify threshold signature shares for the second signature part;
+// - the threshold signer store provides the threshold signer of a view's epoch to create the second signature part;
 // - the merger is used to join and split the two signature parts on our models;
-func NewCombinedSigner(committee hotstuff.Committee, staking module.AggregatingSigner, beacon module.ThresholdSigner, merger module.Merger, signerID flow.Identifier) *CombinedSigner {
+func NewCombinedSigner(committee hotstuff.Committee, staking module.AggregatingSigner, beacon module.ThresholdVerifier, beaconStore module.ThresholdSignerStore, merger module.Merger, signerID flow.Identifier) *CombinedSigner {
 	sc := &CombinedSigner{
 		CombinedVerifier: NewCombinedVerifier(committee, staking, beacon, merger),
 		staking:          staking,
-		beacon:           beacon,
+		beaconStore:      beaconStore,
 		merger:           merger,
 		signerID:         signerID,
 	}
@@ -149,7 +151,11 @@ func (c *CombinedSigner) CreateQC(votes []*model.Vote) (*flow.QuorumCertificate,
 	}
 
 	// construct the threshold signature from the shares
-	beaconThresSig, err := c.beacon.Combine(dkg.Size(), beaconShares, dkgIndices)
+	beacon, err := c.beaconStore.GetThresholdSigner(votes[0].View)
+	if err != nil {
+		return nil, fmt.Errorf("could not get threshold signer: %w", err)
+	}
+	beaconThresSig, err := beacon.Combine(dkg.Size(), beaconShares, dkgIndices)
 	if err != nil {
 		return nil, fmt.Errorf("could not aggregate second signatures: %w", err)
 	}
@@ -175,6 +181,69 @@ func (c *CombinedSigner) CreateQC(votes []*model.Vote) (*flow.QuorumCertificate,
 	return qc, nil
 }
 
+// CreateTimeout will create a timeout for the given view. Timeouts are only signed
+// with the staking key, as there is no random beacon signature share required for
+// timeout certificates.
+func (c *CombinedSigner) CreateTimeout(view uint64, highestQC *flow.QuorumCertificate) (*model.TimeoutObject, error) {
+
+	// the signature covers the included QC, so we can't time out without one
+	if highestQC == nil {
+		return nil, fmt.Errorf("missing qc for timeout at view %d", view)
+	}
+
+	// create the message to be signed and generate signature
+	msg := makeTimeoutMessage(view, highestQC)
+	stakingSig, err := c.staking.Sign(msg)
+	if err != nil {
+		return nil, fmt.Errorf("could not generate staking signature: %w", err)
+	}
+
+	// create the timeout
+	timeout := &model.TimeoutObject{
+		View:      view,
+		HighestQC: highestQC,
+		SignerID:  c.signerID,
+		SigData:   stakingSig,
+	}
+
+	return timeout, nil
+}
+
+// CreateTC will create a timeout certificate with an aggregated staking signature
+// for the given timeouts.
+func (c *CombinedSigner) CreateTC(timeouts []*model.TimeoutObject) (*model.TimeoutCertificate, error) {
+
+	// check the consistency of the timeouts
+	err := checkTimeoutsValidity(timeouts)
+	if err != nil {
+		return nil, fmt.Errorf("timeouts are not valid: %w", err)
+	}
+
+	// collect signers and staking signatures
+	signerIDs := make([]flow.Identifier, 0, len(timeouts))
+	stakingSigs := make([]crypto.Signature, 0, len(timeouts))
+	for _, timeout := range timeouts {
+		signerIDs = append(signerIDs, timeout.SignerID)
+		stakingSigs = append(stakingSigs, timeout.SigData)
+	}
+
+	// aggregate all staking signatures into one aggregated signature
+	stakingAggSig, err := c.staking.Aggregate(stakingSigs)
+	if err != nil {
+		return nil, fmt.Errorf("could not aggregate staking signatures: %w", err)
+	}
+
+	// create the TC
+	tc := &model.TimeoutCertificate{
+		View:      timeouts[0].View,
+		HighestQC: highestQC(timeouts),
+		SignerIDs: signerIDs,
+		SigData:   stakingAggSig,
+	}
+
+	return tc, nil
+}
+
 // genSigData generates the signature data for our local node for the given block.
 func (c *CombinedSigner) genSigData(block *model.Block) ([]byte, error) {
 
@@ -184,7 +253,11 @@ func (c *CombinedSigner) genSigData(block *model.Block) ([]byte, error) {
 	if err != nil {
 		return nil, fmt.Errorf("could not generate first signature: %w", err)
 	}
-	beaconShare, err := c.beacon.Sign(msg)
+	beacon, err := c.beaconStore.GetThresholdSigner(block.View)
+	if err != nil {
+		return nil, fmt.Errorf("could not get threshold signer: %w", err)
+	}
+	beaconShare, err := beacon.Sign(msg)
 	if err != nil {
 		return nil, fmt.Errorf("could not generate second signature: %w", err)
 	}
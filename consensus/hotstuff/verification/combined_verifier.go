@@ -143,3 +143,29 @@ func (c *CombinedVerifier) VerifyQC(voterIDs []flow.Identifier, sigData []byte,
 
 	return stakingValid && beaconValid, nil
 }
+
+// VerifyTimeout verifies the validity of the staking signature on a timeout, which covers the
+// view and the newest QC included in the timeout.
+func (c *CombinedVerifier) VerifyTimeout(signerID flow.Identifier, sigData []byte, view uint64, highestQC *flow.QuorumCertificate, block *model.Block) (bool, error) {
+
+	// get the set of signing participants
+	participants, err := c.committee.Identities(block.BlockID, filter.Any)
+	if err != nil {
+		return false, fmt.Errorf("could not get participants: %w", err)
+	}
+
+	// get the specific identity
+	signer, ok := participants.ByNodeID(signerID)
+	if !ok {
+		return false, fmt.Errorf("signer %x is not a valid consensus participant at block %x: %w", signerID, block.BlockID, model.ErrInvalidSigner)
+	}
+
+	// verify the staking signature against the timeout message
+	msg := makeTimeoutMessage(view, highestQC)
+	valid, err := c.staking.Verify(msg, sigData, signer.StakingPubKey)
+	if err != nil {
+		return false, fmt.Errorf("could not verify staking signature: %w", err)
+	}
+
+	return valid, nil
+}
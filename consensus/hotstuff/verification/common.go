@@ -23,6 +23,26 @@ func makeVoteMessage(view uint64, blockID flow.Identifier) []byte {
 	return msg[:]
 }
 
+// makeTimeoutMessage generates the message we have to sign in order to time out
+// in a view. It covers the view and block ID of the newest QC included in the
+// timeout, so that the QC can't be replaced without invalidating the signature.
+// The timeout flag separates the message from the vote messages, so that a
+// timeout signature can never be used as a vote signature.
+func makeTimeoutMessage(view uint64, highestQC *flow.QuorumCertificate) []byte {
+	msg := flow.MakeID(struct {
+		View      uint64
+		QCView    uint64
+		QCBlockID flow.Identifier
+		Timeout   bool
+	}{
+		View:      view,
+		QCView:    highestQC.View,
+		QCBlockID: highestQC.BlockID,
+		Timeout:   true,
+	})
+	return msg[:]
+}
+
 // checkVotesValidity checks the validity of each vote by checking that they are
 // all for the same view number, the same block ID and that each vote is from a
 // different signer.
@@ -65,3 +85,56 @@ func checkVotesValidity(votes []*model.Vote) error {
 
 	return nil
 }
+
+// checkTimeoutsValidity checks the validity of each timeout by checking that they
+// are all for the same view number, that they all include a QC and that each
+// timeout is from a different signer.
+func checkTimeoutsValidity(timeouts []*model.TimeoutObject) error {
+
+	// first, we should be sure to have timeouts at all
+	if len(timeouts) == 0 {
+		return fmt.Errorf("need at least one timeout")
+	}
+
+	// we use this map to check each timeout has a different signer
+	signerIDs := make(map[flow.Identifier]struct{}, len(timeouts))
+
+	// we use the view from the first timeout to check that all timeouts have the same view
+	view := timeouts[0].View
+
+	// go through all timeouts to check their validity
+	for _, timeout := range timeouts {
+
+		// if we have a view mismatch, bail
+		if timeout.View != view {
+			return fmt.Errorf("view mismatch between timeouts (%d != %d)", timeout.View, view)
+		}
+
+		// if we have no QC, bail
+		if timeout.HighestQC == nil {
+			return fmt.Errorf("missing qc in timeout (signer: %x)", timeout.SignerID)
+		}
+
+		// register the signer in our map
+		signerIDs[timeout.SignerID] = struct{}{}
+	}
+
+	// check that we have as many signers as timeouts
+	if len(signerIDs) != len(timeouts) {
+		return fmt.Errorf("less signers than timeouts (signers: %d, timeouts: %d)", len(signerIDs), len(timeouts))
+	}
+
+	return nil
+}
+
+// highestQC returns the QC with the highest view included in the given timeouts.
+// The QCs have to be validated beforehand, as the TC vouches for the returned QC.
+func highestQC(timeouts []*model.TimeoutObject) *flow.QuorumCertificate {
+	highest := timeouts[0].HighestQC
+	for _, timeout := range timeouts[1:] {
+		if timeout.HighestQC.View > highest.View {
+			highest = timeout.HighestQC
+		}
+	}
+	return highest
+}
@@ -40,6 +40,13 @@ func (w SignerMetricsWrapper) VerifyQC(voterIDs []flow.Identifier, sigData []byt
 	return valid, err
 }
 
+func (w SignerMetricsWrapper) VerifyTimeout(signerID flow.Identifier, sigData []byte, view uint64, highestQC *flow.QuorumCertificate, block *model.Block) (bool, error) {
+	processStart := time.Now()
+	valid, err := w.signer.VerifyTimeout(signerID, sigData, view, highestQC, block)
+	w.metrics.SignerProcessingDuration(time.Since(processStart))
+	return valid, err
+}
+
 func (w SignerMetricsWrapper) CreateProposal(block *model.Block) (*model.Proposal, error) {
 	processStart := time.Now()
 	proposal, err := w.signer.CreateProposal(block)
@@ -60,3 +67,17 @@ func (w SignerMetricsWrapper) CreateQC(votes []*model.Vote) (*flow.QuorumCertifi
 	w.metrics.SignerProcessingDuration(time.Since(processStart))
 	return qc, err
 }
+
+func (w SignerMetricsWrapper) CreateTimeout(view uint64, highestQC *flow.QuorumCertificate) (*model.TimeoutObject, error) {
+	processStart := time.Now()
+	timeout, err := w.signer.CreateTimeout(view, highestQC)
+	w.metrics.SignerProcessingDuration(time.Since(processStart))
+	return timeout, err
+}
+
+func (w SignerMetricsWrapper) CreateTC(timeouts []*model.TimeoutObject) (*model.TimeoutCertificate, error) {
+	processStart := time.Now()
+	tc, err := w.signer.CreateTC(timeouts)
+	w.metrics.SignerProcessingDuration(time.Since(processStart))
+	return tc, err
+}
@@ -121,3 +121,64 @@ func (s *SingleSigner) CreateQC(votes []*model.Vote) (*flow.QuorumCertificate, e
 
 	return qc, nil
 }
+
+// CreateTimeout creates a timeout with a single signature for the given view.
+func (s *SingleSigner) CreateTimeout(view uint64, highestQC *flow.QuorumCertificate) (*model.TimeoutObject, error) {
+
+	// the signature covers the included QC, so we can't time out without one
+	if highestQC == nil {
+		return nil, fmt.Errorf("missing qc for timeout at view %d", view)
+	}
+
+	// create the message to be signed and generate signature
+	msg := makeTimeoutMessage(view, highestQC)
+	sig, err := s.signer.Sign(msg)
+	if err != nil {
+		return nil, fmt.Errorf("could not generate staking signature: %w", err)
+	}
+
+	// create the timeout
+	timeout := &model.TimeoutObject{
+		View:      view,
+		HighestQC: highestQC,
+		SignerID:  s.signerID,
+		SigData:   sig,
+	}
+
+	return timeout, nil
+}
+
+// CreateTC generates a timeout certificate with a single aggregated signature for the
+// given timeouts.
+func (s *SingleSigner) CreateTC(timeouts []*model.TimeoutObject) (*model.TimeoutCertificate, error) {
+
+	// check the consistency of the timeouts
+	err := checkTimeoutsValidity(timeouts)
+	if err != nil {
+		return nil, fmt.Errorf("timeouts are not valid: %w", err)
+	}
+
+	// collect all the timeout signatures
+	signerIDs := make([]flow.Identifier, 0, len(timeouts))
+	sigs := make([]crypto.Signature, 0, len(timeouts))
+	for _, timeout := range timeouts {
+		signerIDs = append(signerIDs, timeout.SignerID)
+		sigs = append(sigs, timeout.SigData)
+	}
+
+	// aggregate the signatures
+	aggSig, err := s.signer.Aggregate(sigs)
+	if err != nil {
+		return nil, fmt.Errorf("could not aggregate signatures: %w", err)
+	}
+
+	// create the TC
+	tc := &model.TimeoutCertificate{
+		View:      timeouts[0].View,
+		HighestQC: highestQC(timeouts),
+		SignerIDs: signerIDs,
+		SigData:   aggSig,
+	}
+
+	return tc, nil
+}
@@ -76,3 +76,28 @@ func (s *SingleVerifier) VerifyQC(voterIDs []flow.Identifier, sigData []byte, bl
 
 	return valid, nil
 }
+
+// VerifyTimeout verifies a timeout with a single signature as signature data.
+func (s *SingleVerifier) VerifyTimeout(signerID flow.Identifier, sigData []byte, view uint64, highestQC *flow.QuorumCertificate, block *model.Block) (bool, error) {
+
+	// get the participants from the selector set
+	participants, err := s.committee.Identities(block.BlockID, filter.Any)
+	if err != nil {
+		return false, fmt.Errorf("error retrieving consensus participants for block %x: %w", block.BlockID, err)
+	}
+
+	// get the identity of the signer
+	signer, ok := participants.ByNodeID(signerID)
+	if !ok {
+		return false, fmt.Errorf("signer %x is not a valid consensus participant at block %x: %w", signerID, block.BlockID, model.ErrInvalidSigner)
+	}
+
+	// create the message we verify against and check signature
+	msg := makeTimeoutMessage(view, highestQC)
+	valid, err := s.verifier.Verify(msg, sigData, signer.StakingPubKey)
+	if err != nil {
+		return false, fmt.Errorf("could not verify signature: %w", err)
+	}
+
+	return valid, nil
+}
@@ -6,7 +6,8 @@ import (
 )
 
 // Verifier is the component responsible for validating votes, proposals and
-// QC's against the block they are based on.
+// QC's against the block they are based on, as well as timeouts against the
+// view they are for.
 type Verifier interface {
 
 	// VerifyVote checks the validity of a vote for the given block.
@@ -14,4 +15,9 @@ type Verifier interface {
 
 	// VerifyQC checks the validity of a QC for the given block.
 	VerifyQC(voterIDs []flow.Identifier, sigData []byte, block *model.Block) (bool, error)
+
+	// VerifyTimeout checks the validity of a timeout for the given view and
+	// the newest QC included in it. The consensus committee is determined at
+	// the given reference block.
+	VerifyTimeout(signerID flow.Identifier, sigData []byte, view uint64, highestQC *flow.QuorumCertificate, block *model.Block) (bool, error)
 }
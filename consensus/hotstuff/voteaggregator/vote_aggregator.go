@@ -15,6 +15,7 @@ type VoteAggregator struct {
 	committee             hotstuff.Committee
 	voteValidator         hotstuff.Validator
 	signer                hotstuff.SignerVerifier
+	persist               hotstuff.Persister
 	highestPrunedView     uint64
 	pendingVotes          *PendingVotes                               // keeps track of votes whose blocks can not be found
 	viewToBlockIDSet      map[uint64]map[flow.Identifier]struct{}     // for pruning
@@ -25,13 +26,14 @@ type VoteAggregator struct {
 }
 
 // New creates an instance of vote aggregator
-func New(notifier hotstuff.Consumer, highestPrunedView uint64, committee hotstuff.Committee, voteValidator hotstuff.Validator, signer hotstuff.SignerVerifier) *VoteAggregator {
+func New(notifier hotstuff.Consumer, highestPrunedView uint64, committee hotstuff.Committee, voteValidator hotstuff.Validator, signer hotstuff.SignerVerifier, persist hotstuff.Persister) *VoteAggregator {
 	return &VoteAggregator{
 		notifier:              notifier,
 		highestPrunedView:     highestPrunedView,
 		committee:             committee,
 		voteValidator:         voteValidator,
 		signer:                signer,
+		persist:               persist,
 		pendingVotes:          NewPendingVotes(),
 		viewToBlockIDSet:      make(map[uint64]map[flow.Identifier]struct{}),
 		viewToVoteID:          make(map[uint64]map[flow.Identifier]*model.Vote),
@@ -258,6 +260,7 @@ func (va *VoteAggregator) convertPendingVotes(pendingVotes []*model.Vote, block
 
 // storeIncorporatedVote stores incorporated votes and accumulate stakes
 // it drops invalid votes and duplicate votes
+// valid votes are persisted, so that the collected votes survive a restart of the replica
 func (va *VoteAggregator) validateAndStoreIncorporatedVote(vote *model.Vote, block *model.Block) (bool, error) {
 	// validate the vote
 	voter, err := va.voteValidator.ValidateVote(vote, block)
@@ -279,6 +282,11 @@ func (va *VoteAggregator) validateAndStoreIncorporatedVote(vote *model.Vote, blo
 		return false, nil
 	}
 
+	err = va.persist.PutVote(vote)
+	if err != nil {
+		return false, fmt.Errorf("could not persist vote: %w", err)
+	}
+
 	// update existing voting status or create a new one
 	votingStatus, exists := va.blockIDToVotingStatus[vote.BlockID]
 	if !exists {
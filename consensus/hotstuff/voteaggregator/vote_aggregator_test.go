@@ -3,6 +3,7 @@
 package voteaggregator
 
 import (
+	"errors"
 	"fmt"
 	"math/rand"
 	"testing"
@@ -40,6 +41,7 @@ type AggregatorSuite struct {
 	committee    hotstuff.Committee
 	validator    hotstuff.Validator
 	notifier     *mocks.Consumer
+	persist      *mocks.Persister
 
 	aggregator *VoteAggregator
 }
@@ -126,8 +128,10 @@ func (as *AggregatorSuite) SetupTest() {
 
 	as.validator = validator.New(as.committee, as.forks, as.signer) // create a real validator
 	as.notifier = &mocks.Consumer{}                                 // create a mock notification Consumer
+	as.persist = &mocks.Persister{}                                 // create a mock persister
+	as.persist.On("PutVote", mock.Anything).Return(nil)
 	// create the aggregator
-	as.aggregator = New(as.notifier, 0, as.committee, as.validator, as.signer)
+	as.aggregator = New(as.notifier, 0, as.committee, as.validator, as.signer, as.persist)
 }
 
 func (as *AggregatorSuite) MockProtocolByBlockID(id flow.Identifier) {
@@ -456,6 +460,52 @@ func (as *AggregatorSuite) TestDoubleVote() {
 	require.NoError(as.T(), err)
 }
 
+// PERSISTENCE
+// pending votes are only persisted once they are validated with the block, and invalid votes are never persisted
+func (as *AggregatorSuite) TestPersistValidVotesOnly() {
+	testView := uint64(5)
+	bp := newMockBlock(as, testView, as.participants[len(as.participants)-1].NodeID)
+
+	// a valid and an invalid pending vote
+	valid := as.newMockVote(testView, bp.Block.BlockID, as.participants[0].NodeID)
+	invalid := as.newMockVote(testView-1, bp.Block.BlockID, as.participants[1].NodeID)
+	as.notifier.On("OnInvalidVoteDetected", invalid)
+	for _, vote := range []*model.Vote{valid, invalid} {
+		_, err := as.aggregator.StorePendingVote(vote)
+		require.NoError(as.T(), err)
+	}
+	as.persist.AssertNotCalled(as.T(), "PutVote", mock.Anything)
+
+	// the votes are validated once the block is received
+	as.aggregator.StoreProposerVote(bp.ProposerVote())
+	_, _, err := as.aggregator.BuildQCOnReceivedBlock(bp.Block)
+	require.NoError(as.T(), err)
+	as.persist.AssertCalled(as.T(), "PutVote", valid)
+	as.persist.AssertNotCalled(as.T(), "PutVote", invalid)
+
+	// votes for a received block are persisted right away
+	vote := as.newMockVote(testView, bp.Block.BlockID, as.participants[2].NodeID)
+	_, _, err = as.aggregator.StoreVoteAndBuildQC(vote, bp.Block)
+	require.NoError(as.T(), err)
+	as.persist.AssertCalled(as.T(), "PutVote", vote)
+}
+
+// PERSISTENCE
+// a vote that can't be persisted is not counted
+func (as *AggregatorSuite) TestVoteNotPersisted() {
+	testView := uint64(5)
+	bp := newMockBlock(as, testView, as.participants[len(as.participants)-1].NodeID)
+	as.aggregator.StoreProposerVote(bp.ProposerVote())
+	_, _, err := as.aggregator.BuildQCOnReceivedBlock(bp.Block)
+	require.NoError(as.T(), err)
+
+	vote := as.newMockVote(testView, bp.Block.BlockID, as.participants[0].NodeID)
+	*as.persist = mocks.Persister{}
+	as.persist.On("PutVote", vote).Return(errors.New("dummy error"))
+	_, _, err = as.aggregator.StoreVoteAndBuildQC(vote, bp.Block)
+	require.Error(as.T(), err)
+}
+
 // INVALID VOTES
 // receive 4 invalid votes, and then the block, no QC should be built
 // should trigger the notifier when converting pending votes
@@ -2,12 +2,21 @@ package hotstuff
 
 import (
 	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/model/flow"
 )
 
-// Voter produces votes for the given block
+// Voter produces votes for the given block and timeouts for the given view
 type Voter interface {
 
 	// ProduceVoteIfVotable will produce a vote for the given block if voting on
 	// the given block is a valid action.
 	ProduceVoteIfVotable(block *model.Block, curView uint64) (*model.Vote, error)
+
+	// ProduceTimeout will produce a timeout for the given view, which includes
+	// the newest QC known to the replica.
+	ProduceTimeout(curView uint64, highestQC *flow.QuorumCertificate) (*model.TimeoutObject, error)
+
+	// UpdateSafetyData persists the locked QC and the highest QC, if they changed
+	// since the safety data was last persisted.
+	UpdateSafetyData() error
 }
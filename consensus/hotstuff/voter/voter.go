@@ -5,23 +5,24 @@ import (
 
 	"github.com/onflow/flow-go/consensus/hotstuff"
 	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/model/flow"
 )
 
-// Voter produces votes for the given block
+// Voter produces votes for the given block and timeouts for the given view
 type Voter struct {
-	signer        hotstuff.SignerVerifier
-	forks         hotstuff.ForksReader
-	persist       hotstuff.Persister
-	lastVotedView uint64 // need to keep track of the last view we voted for so we don't double vote accidentally
+	signer     hotstuff.SignerVerifier
+	forks      hotstuff.Forks
+	persist    hotstuff.Persister
+	safetyData *model.SafetyData // need to keep track of the last vote so we don't double vote accidentally
 }
 
-// New creates a new Voter instance
-func New(signer hotstuff.SignerVerifier, forks hotstuff.ForksReader, persist hotstuff.Persister, lastVotedView uint64) *Voter {
+// New creates a new Voter instance, which resumes from the given persisted safety data.
+func New(signer hotstuff.SignerVerifier, forks hotstuff.Forks, persist hotstuff.Persister, safetyData *model.SafetyData) *Voter {
 	return &Voter{
-		signer:        signer,
-		forks:         forks,
-		persist:       persist,
-		lastVotedView: lastVotedView,
+		signer:     signer,
+		forks:      forks,
+		persist:    persist,
+		safetyData: safetyData,
 	}
 }
 
@@ -41,7 +42,7 @@ func (v *Voter) ProduceVoteIfVotable(block *model.Block, curView uint64) (*model
 		return nil, model.NoVoteError{Msg: "not for current view"}
 	}
 
-	if curView <= v.lastVotedView {
+	if curView <= v.safetyData.LastVotedView() {
 		return nil, model.NoVoteError{Msg: "not above the last voted view"}
 	}
 
@@ -50,13 +51,67 @@ func (v *Voter) ProduceVoteIfVotable(block *model.Block, curView uint64) (*model
 		return nil, fmt.Errorf("could not vote for block: %w", err)
 	}
 
-	// vote for the current view has been produced, update lastVotedView
-	// to prevent from voting for the same view again
-	v.lastVotedView = curView
-	err = v.persist.PutVoted(curView)
+	// vote for the current view has been produced, persist it together with the
+	// QCs before releasing it, to prevent from voting for the same view again
+	safetyData := &model.SafetyData{
+		LockedQC:  v.forks.LockedQC(),
+		HighestQC: v.forks.HighestQC(),
+		LastVote:  vote,
+	}
+	err = v.persist.PutSafetyData(safetyData)
 	if err != nil {
-		return nil, fmt.Errorf("could not persist last voted: %w", err)
+		return nil, fmt.Errorf("could not persist safety data: %w", err)
 	}
+	v.safetyData = safetyData
 
 	return vote, nil
 }
+
+// UpdateSafetyData persists the locked and the highest QC known to Forks, if they changed
+// since the safety data was last persisted.
+func (v *Voter) UpdateSafetyData() error {
+	lockedQC := v.forks.LockedQC()
+	highestQC := v.forks.HighestQC()
+	if sameQC(lockedQC, v.safetyData.LockedQC) && sameQC(highestQC, v.safetyData.HighestQC) {
+		return nil
+	}
+
+	safetyData := &model.SafetyData{
+		LockedQC:        lockedQC,
+		HighestQC:       highestQC,
+		LastVote:        v.safetyData.LastVote,
+		LegacyVotedView: v.safetyData.LegacyVotedView,
+	}
+	err := v.persist.PutSafetyData(safetyData)
+	if err != nil {
+		return fmt.Errorf("could not persist safety data: %w", err)
+	}
+	v.safetyData = safetyData
+
+	return nil
+}
+
+// ProduceTimeout will produce a timeout for the current view, which includes the newest QC known
+// to the replica. As the replica only times out in views it has entered, the QC must be for a
+// lower view than the current view.
+// Timing out does not affect voting: the replica leaves the view upon its timeout, so it will
+// not vote for a block of the view anymore.
+func (v *Voter) ProduceTimeout(curView uint64, highestQC *flow.QuorumCertificate) (*model.TimeoutObject, error) {
+	if highestQC.View >= curView {
+		return nil, fmt.Errorf("qc view %d is not below the current view %d", highestQC.View, curView)
+	}
+
+	timeout, err := v.signer.CreateTimeout(curView, highestQC)
+	if err != nil {
+		return nil, fmt.Errorf("could not create timeout: %w", err)
+	}
+
+	return timeout, nil
+}
+
+func sameQC(qc1 *flow.QuorumCertificate, qc2 *flow.QuorumCertificate) bool {
+	if qc1 == nil || qc2 == nil {
+		return qc1 == qc2
+	}
+	return qc1.View == qc2.View && qc1.BlockID == qc2.BlockID
+}
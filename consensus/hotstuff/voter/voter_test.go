@@ -1,6 +1,7 @@
 package voter
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/mock"
@@ -18,23 +19,38 @@ func TestProduceVote(t *testing.T) {
 	t.Run("should not vote for block with its view above the current view", testAboveVote)
 	t.Run("should not vote for block with the same view as the last voted view", testEqualLastVotedView)
 	t.Run("should not vote for block with its view below the last voted view", testBelowLastVotedView)
+	t.Run("should not vote for block at or below the legacy voted view", testLegacyVotedView)
 	t.Run("should not vote for the same view again", testVotingAgain)
+	t.Run("should persist the vote with the qcs", testVotePersisted)
+	t.Run("should not vote if the vote can't be persisted", testVoteNotPersisted)
+}
+
+func TestUpdateSafetyData(t *testing.T) {
+	t.Run("should persist changed qcs with the last vote", testUpdateSafetyDataChanged)
+	t.Run("should not persist unchanged qcs", testUpdateSafetyDataUnchanged)
+}
+
+func TestProduceTimeout(t *testing.T) {
+	t.Run("should time out with newest qc", testTimeoutOK)
+	t.Run("should not time out with qc not below the current view", testTimeoutQCNotBelow)
 }
 
 func createVoter(t *testing.T, blockView uint64, lastVotedView uint64, isBlockSafe bool) (*model.Block, *model.Vote, *Voter) {
 	block := helper.MakeBlock(t, helper.WithBlockView(blockView))
 	expectVote := makeVote(block)
 
-	forks := &mocks.ForksReader{}
+	forks := &mocks.Forks{}
 	forks.On("IsSafeBlock", block).Return(isBlockSafe)
+	forks.On("LockedQC").Return(helper.MakeQC(t))
+	forks.On("HighestQC").Return(block.QC)
 
 	persist := &mocks.Persister{}
-	persist.On("PutVoted", mock.Anything).Return(nil)
+	persist.On("PutSafetyData", mock.Anything).Return(nil)
 
 	signer := &mocks.SignerVerifier{}
 	signer.On("CreateVote", mock.Anything).Return(expectVote, nil)
 
-	voter := New(signer, forks, persist, lastVotedView)
+	voter := New(signer, forks, persist, votedAt(lastVotedView))
 	return block, expectVote, voter
 }
 
@@ -111,6 +127,27 @@ func testBelowLastVotedView(t *testing.T) {
 	require.Contains(t, err.Error(), "not above the last voted view")
 }
 
+func testLegacyVotedView(t *testing.T) {
+	// the replica was upgraded from persisting only the voted view, so the vote itself is unknown
+	blockView, curView, lastVotedView, isBlockSafe := uint64(3), uint64(3), uint64(3), true
+
+	block, _, voter := createVoter(t, blockView, lastVotedView, isBlockSafe)
+	voter.safetyData = &model.SafetyData{LegacyVotedView: lastVotedView}
+
+	_, err := voter.ProduceVoteIfVotable(block, curView)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not above the last voted view")
+
+	// the next vote supersedes the legacy voted view
+	block, expectVote, voter := createVoter(t, blockView+1, lastVotedView, isBlockSafe)
+	voter.safetyData = &model.SafetyData{LegacyVotedView: lastVotedView}
+
+	vote, err := voter.ProduceVoteIfVotable(block, curView+1)
+	require.NoError(t, err)
+	require.Equal(t, expectVote, vote)
+	require.Equal(t, blockView+1, voter.safetyData.LastVotedView())
+}
+
 func testVotingAgain(t *testing.T) {
 	blockView, curView, lastVotedView, isBlockSafe := uint64(3), uint64(3), uint64(2), true
 
@@ -128,6 +165,124 @@ func testVotingAgain(t *testing.T) {
 	require.Contains(t, err.Error(), "not above the last voted view")
 }
 
+func testVotePersisted(t *testing.T) {
+	block := helper.MakeBlock(t, helper.WithBlockView(3))
+	expectVote := makeVote(block)
+	lockedQC := helper.MakeQC(t, helper.WithQCView(1))
+
+	forks := &mocks.Forks{}
+	forks.On("IsSafeBlock", block).Return(true)
+	forks.On("LockedQC").Return(lockedQC)
+	forks.On("HighestQC").Return(block.QC)
+
+	signer := &mocks.SignerVerifier{}
+	signer.On("CreateVote", block).Return(expectVote, nil)
+
+	persist := &mocks.Persister{}
+	persist.On("PutSafetyData", &model.SafetyData{
+		LockedQC:  lockedQC,
+		HighestQC: block.QC,
+		LastVote:  expectVote,
+	}).Return(nil).Once()
+
+	voter := New(signer, forks, persist, votedAt(2))
+	vote, err := voter.ProduceVoteIfVotable(block, 3)
+	require.NoError(t, err)
+	require.Equal(t, expectVote, vote)
+	persist.AssertExpectations(t)
+}
+
+func testVoteNotPersisted(t *testing.T) {
+	block := helper.MakeBlock(t, helper.WithBlockView(3))
+
+	forks := &mocks.Forks{}
+	forks.On("IsSafeBlock", block).Return(true)
+	forks.On("LockedQC").Return(helper.MakeQC(t))
+	forks.On("HighestQC").Return(block.QC)
+
+	signer := &mocks.SignerVerifier{}
+	signer.On("CreateVote", block).Return(makeVote(block), nil)
+
+	persist := &mocks.Persister{}
+	persist.On("PutSafetyData", mock.Anything).Return(errors.New("dummy error"))
+
+	voter := New(signer, forks, persist, votedAt(2))
+	_, err := voter.ProduceVoteIfVotable(block, 3)
+	require.Error(t, err)
+	require.False(t, model.IsNoVoteError(err))
+}
+
+func testUpdateSafetyDataChanged(t *testing.T) {
+	lastVote := &model.Vote{View: 4}
+	oldQC := helper.MakeQC(t, helper.WithQCView(3))
+	newQC := helper.MakeQC(t, helper.WithQCView(5))
+
+	forks := &mocks.Forks{}
+	forks.On("LockedQC").Return(oldQC)
+	forks.On("HighestQC").Return(newQC)
+
+	persist := &mocks.Persister{}
+	persist.On("PutSafetyData", &model.SafetyData{
+		LockedQC:  oldQC,
+		HighestQC: newQC,
+		LastVote:  lastVote,
+	}).Return(nil).Once()
+
+	voter := New(&mocks.SignerVerifier{}, forks, persist, &model.SafetyData{LockedQC: oldQC, HighestQC: oldQC, LastVote: lastVote})
+	err := voter.UpdateSafetyData()
+	require.NoError(t, err)
+
+	// the persisted data is now up to date, so we don't persist it again
+	err = voter.UpdateSafetyData()
+	require.NoError(t, err)
+	persist.AssertExpectations(t)
+}
+
+func testUpdateSafetyDataUnchanged(t *testing.T) {
+	qc := helper.MakeQC(t, helper.WithQCView(3))
+
+	forks := &mocks.Forks{}
+	forks.On("LockedQC").Return(qc)
+	forks.On("HighestQC").Return(qc)
+
+	persist := &mocks.Persister{}
+
+	voter := New(&mocks.SignerVerifier{}, forks, persist, &model.SafetyData{LockedQC: qc, HighestQC: qc})
+	err := voter.UpdateSafetyData()
+	require.NoError(t, err)
+	persist.AssertNotCalled(t, "PutSafetyData", mock.Anything)
+}
+
+func testTimeoutOK(t *testing.T) {
+	curView := uint64(5)
+	qc := helper.MakeQC(t, helper.WithQCView(curView-2))
+	expectTimeout := helper.MakeTimeout(t, helper.WithTimeoutView(curView), helper.WithTimeoutQC(qc))
+
+	signer := &mocks.SignerVerifier{}
+	signer.On("CreateTimeout", curView, qc).Return(expectTimeout, nil)
+	voter := New(signer, &mocks.Forks{}, &mocks.Persister{}, votedAt(curView-1))
+
+	timeout, err := voter.ProduceTimeout(curView, qc)
+	require.NoError(t, err)
+	require.Equal(t, expectTimeout, timeout)
+}
+
+func testTimeoutQCNotBelow(t *testing.T) {
+	curView := uint64(5)
+	qc := helper.MakeQC(t, helper.WithQCView(curView))
+
+	signer := &mocks.SignerVerifier{}
+	voter := New(signer, &mocks.Forks{}, &mocks.Persister{}, votedAt(curView-1))
+
+	_, err := voter.ProduceTimeout(curView, qc)
+	require.Error(t, err)
+	signer.AssertNotCalled(t, "CreateTimeout", mock.Anything, mock.Anything)
+}
+
+func votedAt(view uint64) *model.SafetyData {
+	return &model.SafetyData{LastVote: &model.Vote{View: view}}
+}
+
 func makeVote(block *model.Block) *model.Vote {
 	return &model.Vote{
 		BlockID: block.BlockID,
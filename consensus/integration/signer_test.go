@@ -38,6 +38,32 @@ func (*Signer) CreateQC(votes []*model.Vote) (*flow.QuorumCertificate, error) {
 	}
 	return qc, nil
 }
+func (s *Signer) CreateTimeout(view uint64, highestQC *flow.QuorumCertificate) (*model.TimeoutObject, error) {
+	timeout := &model.TimeoutObject{
+		View:      view,
+		HighestQC: highestQC,
+		SignerID:  s.localID,
+		SigData:   nil,
+	}
+	return timeout, nil
+}
+func (*Signer) CreateTC(timeouts []*model.TimeoutObject) (*model.TimeoutCertificate, error) {
+	signerIDs := make([]flow.Identifier, 0, len(timeouts))
+	highestQC := timeouts[0].HighestQC
+	for _, timeout := range timeouts {
+		signerIDs = append(signerIDs, timeout.SignerID)
+		if timeout.HighestQC.View > highestQC.View {
+			highestQC = timeout.HighestQC
+		}
+	}
+	tc := &model.TimeoutCertificate{
+		View:      timeouts[0].View,
+		HighestQC: highestQC,
+		SignerIDs: signerIDs,
+		SigData:   nil,
+	}
+	return tc, nil
+}
 
 func (*Signer) VerifyVote(voterID flow.Identifier, sigData []byte, block *model.Block) (bool, error) {
 	return true, nil
@@ -46,3 +72,7 @@ func (*Signer) VerifyVote(voterID flow.Identifier, sigData []byte, block *model.
 func (*Signer) VerifyQC(voterIDs []flow.Identifier, sigData []byte, block *model.Block) (bool, error) {
 	return true, nil
 }
+
+func (*Signer) VerifyTimeout(signerID flow.Identifier, sigData []byte, view uint64, highestQC *flow.QuorumCertificate, block *model.Block) (bool, error) {
+	return true, nil
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/onflow/flow-go/consensus/hotstuff/model"
 	"github.com/onflow/flow-go/consensus/hotstuff/pacemaker"
 	"github.com/onflow/flow-go/consensus/hotstuff/pacemaker/timeout"
+	"github.com/onflow/flow-go/consensus/hotstuff/timeoutaggregator"
 	validatorImpl "github.com/onflow/flow-go/consensus/hotstuff/validator"
 	"github.com/onflow/flow-go/consensus/hotstuff/voteaggregator"
 	"github.com/onflow/flow-go/consensus/hotstuff/voter"
@@ -77,22 +78,34 @@ func NewParticipant(
 		return nil, fmt.Errorf("could not recover last started: %w", err)
 	}
 
-	// get the last view we voted
-	voted, err := persist.GetVoted()
+	// get the last vote and QCs we persisted
+	safetyData, err := persist.GetSafetyData()
 	if err != nil {
-		return nil, fmt.Errorf("could not recover last voted: %w", err)
+		return nil, fmt.Errorf("could not recover safety data: %w", err)
+	}
+
+	// get the votes we collected for views that are not finalized yet
+	votes, err := persist.GetVotes(finalized.View + 1)
+	if err != nil {
+		return nil, fmt.Errorf("could not recover collected votes: %w", err)
 	}
 
 	// initialize the vote aggregator
-	aggregator := voteaggregator.New(notifier, 0, committee, validator, signer)
+	aggregator := voteaggregator.New(notifier, 0, committee, validator, signer, persist)
 
 	// recover the hotstuff state, mainly to recover all pending blocks
-	// in forks
-	err = recovery.Participant(log, forks, aggregator, validator, finalized, pending)
+	// in forks, as well as the collected votes
+	err = recovery.Participant(log, forks, aggregator, validator, finalized, pending, safetyData, votes)
 	if err != nil {
 		return nil, fmt.Errorf("could not recover hotstuff state: %w", err)
 	}
 
+	// the recovered votes might have completed a QC for the view we were in
+	startView := started + 1
+	if forks.HighestQC().View >= startView {
+		startView = forks.HighestQC().View + 1
+	}
+
 	// initialize the timeout config
 	timeoutConfig, err := timeout.NewConfig(
 		cfg.TimeoutInitial,
@@ -108,7 +121,7 @@ func NewParticipant(
 
 	// initialize the pacemaker
 	controller := timeout.NewController(timeoutConfig)
-	pacemaker, err := pacemaker.New(started+1, controller, notifier)
+	pacemaker, err := pacemaker.New(startView, controller, notifier)
 	if err != nil {
 		return nil, fmt.Errorf("could not initialize flow pacemaker: %w", err)
 	}
@@ -119,11 +132,14 @@ func NewParticipant(
 		return nil, fmt.Errorf("could not initialize block producer: %w", err)
 	}
 
+	// initialize the timeout aggregator
+	timeouts := timeoutaggregator.New(notifier, 0, committee, forks, pacemaker, validator, signer)
+
 	// initialize the voter
-	voter := voter.New(signer, forks, persist, voted)
+	voter := voter.New(signer, forks, persist, safetyData)
 
 	// initialize the event handler
-	handler, err := eventhandler.New(log, pacemaker, producer, forks, persist, communicator, committee, aggregator, voter, validator, notifier)
+	handler, err := eventhandler.New(log, pacemaker, producer, forks, persist, communicator, committee, aggregator, timeouts, voter, validator, notifier)
 	if err != nil {
 		return nil, fmt.Errorf("could not initialize event handler: %w", err)
 	}
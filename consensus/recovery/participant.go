@@ -12,7 +12,8 @@ import (
 
 // Participant recovers the HotStuff state for a consensus participant.
 // It reads the pending blocks from storage and pass them to the input Forks
-// instance to recover its state from before the restart.
+// instance to recover its state from before the restart. Afterwards, it
+// restores the persisted QCs and the votes collected before the restart.
 func Participant(
 	log zerolog.Logger,
 	forks hotstuff.Forks,
@@ -20,8 +21,10 @@ func Participant(
 	validator hotstuff.Validator,
 	finalized *flow.Header,
 	pending []*flow.Header,
+	safetyData *model.SafetyData,
+	votes []*model.Vote,
 ) error {
-	return Recover(log, finalized, pending, validator, func(proposal *model.Proposal) error {
+	err := Recover(log, finalized, pending, validator, func(proposal *model.Proposal) error {
 		// add it to forks
 		err := forks.AddBlock(proposal.Block)
 		if err != nil {
@@ -33,4 +36,70 @@ func Participant(
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	// the locked block is derived from blocks that were stored before we voted;
+	// if forks is locked on an older block, we lost blocks and could vote unsafely
+	if safetyData.LockedQC != nil && safetyData.LockedQC.View > forks.LockedQC().View {
+		return fmt.Errorf("recovered locked qc (view: %d) is older than the persisted one (view: %d)",
+			forks.LockedQC().View, safetyData.LockedQC.View)
+	}
+
+	// the highest QC might have been built from votes, in which case it is not
+	// included in any of the pending blocks
+	if safetyData.HighestQC != nil && safetyData.HighestQC.View > forks.FinalizedView() {
+		_, found := forks.GetBlock(safetyData.HighestQC.BlockID)
+		if found {
+			err := forks.AddQC(safetyData.HighestQC)
+			if err != nil {
+				return fmt.Errorf("could not add persisted highest qc: %w", err)
+			}
+		}
+	}
+
+	// restore the collected votes, including our own last vote, which is only
+	// persisted as part of the safety data
+	if safetyData.LastVote != nil {
+		votes = append(votes, safetyData.LastVote)
+	}
+	for _, vote := range votes {
+		if vote.View <= forks.FinalizedView() {
+			continue
+		}
+		err := recoverVote(forks, voteAggregator, vote)
+		if err != nil {
+			return fmt.Errorf("could not recover vote (view: %d, signer: %x): %w", vote.View, vote.SignerID, err)
+		}
+	}
+
+	return nil
+}
+
+// recoverVote stores the vote in the vote aggregator, and adds the QC to forks if
+// the vote completes one.
+func recoverVote(forks hotstuff.Forks, voteAggregator hotstuff.VoteAggregator, vote *model.Vote) error {
+	block, found := forks.GetBlock(vote.BlockID)
+	if !found {
+		_, err := voteAggregator.StorePendingVote(vote)
+		if err != nil {
+			return fmt.Errorf("could not store pending vote: %w", err)
+		}
+		return nil
+	}
+
+	qc, built, err := voteAggregator.StoreVoteAndBuildQC(vote, block)
+	if err != nil {
+		return fmt.Errorf("could not store vote: %w", err)
+	}
+	if !built {
+		return nil
+	}
+
+	err = forks.AddQC(qc)
+	if err != nil {
+		return fmt.Errorf("could not add qc built from recovered votes: %w", err)
+	}
+	return nil
 }
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/proto"
 	accessproto "github.com/onflow/flow/protobuf/go/flow/access"
 	entitiesproto "github.com/onflow/flow/protobuf/go/flow/entities"
 	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
@@ -15,6 +16,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
 
 	"github.com/onflow/flow-go/access"
 	"github.com/onflow/flow-go/consensus/hotstuff/model"
@@ -109,6 +111,16 @@ func (suite *Suite) RunTest(
 			uint(9000),
 			nil,
 			false,
+			nil,
+			backend.EventQueryModeExecutionNode,
+			backend.DefaultMaxHeightRange,
+			0,
+			0,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		)
 
 		handler := access.NewHandler(suite.backend, suite.chainID.Chain())
@@ -287,6 +299,16 @@ func (suite *Suite) TestSendTransactionToRandomCollectionNode() {
 			collectionGrpcPort,
 			connFactory, // passing in the connection factory
 			false,
+			nil,
+			backend.EventQueryModeExecutionNode,
+			backend.DefaultMaxHeightRange,
+			0,
+			0,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		)
 
 		handler := access.NewHandler(backend, suite.chainID.Chain())
@@ -430,8 +452,13 @@ func (suite *Suite) TestGetSealedTransaction() {
 		exeEventResp := execproto.GetTransactionResultResponse{
 			Events: nil,
 		}
-		// assume execution node returns an empty list of events
-		suite.execClient.On("GetTransactionResult", mock.Anything, mock.Anything).Return(&exeEventResp, nil)
+		// assume execution node returns an empty list of events, and the computation used in the response header
+		suite.execClient.On("GetTransactionResult", mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				header := args.Get(2).(grpc.HeaderCallOption)
+				*header.HeaderAddr = convert.ComputationUsedToHeader(42)
+			}).
+			Return(&exeEventResp, nil)
 
 		// initialize storage
 		metrics := metrics.NewNoopCollector()
@@ -444,18 +471,21 @@ func (suite *Suite) TestGetSealedTransaction() {
 		blocksToMarkExecuted, err := stdmap.NewTimes(100)
 		require.NoError(suite.T(), err)
 
+		receipts := storage.NewExecutionReceipts(db, storage.NewExecutionResults(db))
+		seals := storage.NewSeals(metrics, db)
+
 		rpcEng := rpc.New(suite.log, suite.state, rpc.Config{}, nil, nil, blocks, headers, collections, transactions,
-			suite.chainID, metrics, 0, false)
+			suite.chainID, metrics, 0, false, nil, nil, nil, nil, receipts, seals)
 
 		// create the ingest engine
 		ingestEng, err := ingestion.New(suite.log, suite.net, suite.state, suite.me, suite.request, blocks, headers, collections,
-			transactions, metrics, collectionsToMarkFinalized, collectionsToMarkExecuted, blocksToMarkExecuted, rpcEng)
+			transactions, metrics, collectionsToMarkFinalized, collectionsToMarkExecuted, blocksToMarkExecuted, rpcEng, nil, nil, nil, receipts, seals)
 		require.NoError(suite.T(), err)
 
 		// 1. Assume that follower engine updated the block storage and the protocol state. The block is reported as sealed
 		err = blocks.Store(&block)
 		require.NoError(suite.T(), err)
-		suite.snapshot.On("Head").Return(block.Header, nil).Once()
+		suite.snapshot.On("Head").Return(block.Header, nil).Twice()
 
 		// 2. Ingest engine was notified by the follower engine about a new block.
 		// Follower engine --> Ingest engine
@@ -481,6 +511,20 @@ func (suite *Suite) TestGetSealedTransaction() {
 		require.NoError(suite.T(), err)
 		// assert that the transaction is reported as Sealed
 		require.Equal(suite.T(), entitiesproto.TransactionStatus_SEALED, gResp.GetStatus())
+
+		// 6. client requests the transaction result including the computation used
+		eResp, err := handler.GetExtendedTransactionResult(context.Background(), getReq)
+		require.NoError(suite.T(), err)
+		require.Equal(suite.T(), entitiesproto.TransactionStatus_SEALED, eResp.Status)
+		require.Equal(suite.T(), uint64(42), eResp.GetComputationUsed())
+
+		// assert that clients which only know the standard response can decode the extended response
+		encoded, err := proto.Marshal(eResp)
+		require.NoError(suite.T(), err)
+		var decoded accessproto.TransactionResultResponse
+		err = proto.Unmarshal(encoded, &decoded)
+		require.NoError(suite.T(), err)
+		require.Equal(suite.T(), entitiesproto.TransactionStatus_SEALED, decoded.GetStatus())
 	})
 }
 
@@ -569,6 +613,110 @@ func (suite *Suite) TestExecuteScript() {
 	})
 }
 
+// TestGetSlashingEvidence tests that the stored slashing evidence is served through the extended Access API
+// without losing any of the data needed to verify it
+func (suite *Suite) TestGetSlashingEvidence() {
+	suite.RunTest(func(_ *access.Handler, db *badger.DB, blocks *storage.Blocks, headers *storage.Headers) {
+
+		offenderID := unittest.IdentifierFixture()
+		proposal1 := unittest.BlockHeaderFixture()
+		proposal2 := unittest.BlockHeaderFixture()
+		doubleProposal := &flow.SlashingEvidence{
+			Offence:    flow.OffenceDoubleProposal,
+			OffenderID: offenderID,
+			View:       proposal1.View,
+			Proposals:  []*flow.Header{&proposal1, &proposal2},
+		}
+		doubleVote := &flow.SlashingEvidence{
+			Offence:    flow.OffenceDoubleVote,
+			OffenderID: unittest.IdentifierFixture(),
+			View:       proposal1.View + 1,
+			Votes: []*flow.SignedVote{
+				{View: proposal1.View + 1, BlockID: unittest.IdentifierFixture(), SignerID: offenderID, SigData: []byte("signature 1")},
+				{View: proposal1.View + 1, BlockID: unittest.IdentifierFixture(), SignerID: offenderID, SigData: []byte("signature 2")},
+			},
+		}
+
+		evidence := storage.NewSlashingEvidence(db)
+		require.NoError(suite.T(), evidence.Store(doubleProposal))
+		require.NoError(suite.T(), evidence.Store(doubleVote))
+
+		backend := backend.New(suite.state, nil, nil, blocks, headers, nil, nil, suite.chainID, suite.metrics, 0,
+			nil, false, nil, backend.EventQueryModeExecutionNode, backend.DefaultMaxHeightRange, 0, 0, nil, nil, evidence, nil, nil)
+		handler := access.NewHandler(backend, suite.chainID.Chain())
+
+		// get the evidence against the offender, as decoded by a gRPC client
+		resp, err := handler.GetSlashingEvidence(context.Background(), &access.GetSlashingEvidenceRequest{
+			OffenderId: offenderID[:],
+		})
+		require.NoError(suite.T(), err)
+		encoded, err := proto.Marshal(resp)
+		require.NoError(suite.T(), err)
+		var decoded access.GetSlashingEvidenceResponse
+		require.NoError(suite.T(), proto.Unmarshal(encoded, &decoded))
+
+		stored, err := evidence.ByOffender(offenderID)
+		require.NoError(suite.T(), err)
+		require.Len(suite.T(), decoded.GetEvidence(), 1)
+		actual := access.MessageToSlashingEvidence(decoded.GetEvidence()[0])
+		require.Equal(suite.T(), stored[0].ID(), actual.ID())
+		require.Equal(suite.T(), proposal1.ID(), actual.Proposals[0].ID())
+		require.Equal(suite.T(), proposal2.ID(), actual.Proposals[1].ID())
+		require.Equal(suite.T(), proposal1.ProposerSig, actual.Proposals[0].ProposerSig)
+
+		// get the evidence against all nodes
+		resp, err = handler.GetSlashingEvidence(context.Background(), &access.GetSlashingEvidenceRequest{})
+		require.NoError(suite.T(), err)
+		require.Len(suite.T(), resp.GetEvidence(), 2)
+		for _, m := range resp.GetEvidence() {
+			if flow.Offence(m.Offence) == flow.OffenceDoubleVote {
+				require.Equal(suite.T(), doubleVote.Votes, access.MessageToSlashingEvidence(m).Votes)
+			}
+		}
+	})
+}
+
+// TestGetTransactionsByAccount tests that the transactions indexed by account are served through the
+// extended Access API
+func (suite *Suite) TestGetTransactionsByAccount() {
+	suite.RunTest(func(_ *access.Handler, db *badger.DB, blocks *storage.Blocks, headers *storage.Headers) {
+
+		transactions := storage.NewTransactions(suite.metrics, db)
+		accountTransactions := storage.NewAccountTransactions(db)
+
+		payer, err := suite.chainID.Chain().AddressAtIndex(5)
+		require.NoError(suite.T(), err)
+		txs := make([]*flow.TransactionBody, 0, 3)
+		for height := uint64(10); height < 13; height++ {
+			tx := unittest.TransactionBodyFixture()
+			tx.Payer = payer
+			require.NoError(suite.T(), transactions.Store(&tx))
+			require.NoError(suite.T(), accountTransactions.Index(height, []*flow.TransactionBody{&tx}))
+			txs = append(txs, &tx)
+		}
+
+		backend := backend.New(suite.state, nil, nil, blocks, headers, nil, transactions, suite.chainID, suite.metrics, 0,
+			nil, false, nil, backend.EventQueryModeExecutionNode, backend.DefaultMaxHeightRange, 0, 0, nil, accountTransactions, nil, nil, nil)
+		handler := access.NewHandler(backend, suite.chainID.Chain())
+
+		// skip the first transaction and get the next one
+		resp, err := handler.GetTransactionsByAccount(context.Background(), &access.GetTransactionsByAccountRequest{
+			Address:     payer.Bytes(),
+			StartHeight: 10,
+			EndHeight:   20,
+			Offset:      1,
+			Limit:       1,
+		})
+		require.NoError(suite.T(), err)
+		require.Len(suite.T(), resp.GetTransactions(), 1)
+		require.Equal(suite.T(), uint64(11), resp.GetTransactions()[0].BlockHeight)
+
+		tx, err := convert.MessageToTransaction(resp.GetTransactions()[0].Transaction, suite.chainID.Chain())
+		require.NoError(suite.T(), err)
+		require.Equal(suite.T(), txs[1].ID(), tx.ID())
+	})
+}
+
 func (suite *Suite) createChain() (flow.Block, flow.Collection) {
 	collection := unittest.CollectionFixture(10)
 	guarantee := &flow.CollectionGuarantee{
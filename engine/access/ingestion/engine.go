@@ -9,10 +9,14 @@ import (
 	"time"
 
 	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/onflow/flow-go/consensus/hotstuff/model"
 	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/engine/access/rpc"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	extended "github.com/onflow/flow-go/engine/execution/rpc/protobuf"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/filter"
 	"github.com/onflow/flow-go/module"
@@ -34,10 +38,21 @@ const fullBlockUpdateInterval = 1 * time.Minute
 // a threshold of number of blocks with missing collections beyond which collections should be re-requested
 const missingCollsForBlkThreshold = 100
 
+// time to update the local event index
+const eventIndexUpdateInterval = 1 * time.Second
+
+// time to wait for the execution node to return the events of a batch of blocks while indexing events
+const eventIndexRequestTimeout = 10 * time.Second
+
+// the maximum number of blocks for which the events are retrieved with a single request while indexing events
+const eventIndexBatchSize = 50
+
 var defaultCollectionCatchupTimeout = collectionCatchupTimeout
 var defaultCollectionCatchupDBPollInterval = collectionCatchupDBPollInterval
 var defaultFullBlockUpdateInterval = fullBlockUpdateInterval
 var defaultMissingCollsForBlkThreshold = missingCollsForBlkThreshold
+var defaultEventIndexUpdateInterval = eventIndexUpdateInterval
+var defaultEventIndexBatchSize uint64 = eventIndexBatchSize
 
 // Engine represents the ingestion engine, used to funnel data from other nodes
 // to a centralized location that can be queried by a user
@@ -54,6 +69,18 @@ type Engine struct {
 	headers      storage.Headers
 	collections  storage.Collections
 	transactions storage.Transactions
+	eventIndex   storage.EventIndex // nil if events are not indexed locally
+
+	// index of transactions by account, nil if transactions are not indexed by account
+	accountTransactions storage.AccountTransactions
+
+	// execution node client used to retrieve the events to index, which requires the extended execution API
+	executionRPC extended.ExtendedExecutionAPIClient
+
+	// receipts of all execution nodes and the seals of finalized blocks, used to select the
+	// execution nodes that committed to the sealed results
+	receipts storage.ExecutionReceipts
+	seals    storage.Seals
 
 	// metrics
 	transactionMetrics         module.TransactionMetrics
@@ -80,6 +107,11 @@ func New(
 	collectionsToMarkExecuted *stdmap.Times,
 	blocksToMarkExecuted *stdmap.Times,
 	rpcEngine *rpc.Engine,
+	eventIndex storage.EventIndex,
+	executionRPC extended.ExtendedExecutionAPIClient,
+	accountTransactions storage.AccountTransactions,
+	receipts storage.ExecutionReceipts,
+	seals storage.Seals,
 ) (*Engine, error) {
 
 	// initialize the propagation engine with its dependencies
@@ -93,6 +125,11 @@ func New(
 		headers:                    headers,
 		collections:                collections,
 		transactions:               transactions,
+		eventIndex:                 eventIndex,
+		executionRPC:               executionRPC,
+		accountTransactions:        accountTransactions,
+		receipts:                   receipts,
+		seals:                      seals,
 		transactionMetrics:         transactionMetrics,
 		collectionsToMarkFinalized: collectionsToMarkFinalized,
 		collectionsToMarkExecuted:  collectionsToMarkExecuted,
@@ -123,6 +160,9 @@ func (e *Engine) Ready() <-chan struct{} {
 		}
 	})
 	e.unit.LaunchPeriodically(e.updateLastFullBlockReceivedIndex, defaultFullBlockUpdateInterval, time.Duration(0))
+	if e.eventIndex != nil {
+		e.unit.LaunchPeriodically(e.updateEventIndex, defaultEventIndexUpdateInterval, time.Duration(0))
+	}
 	return readyChan
 }
 
@@ -198,6 +238,15 @@ func (e *Engine) processFinalizedBlock(blockID flow.Identifier) error {
 	// Notify rpc handler of new finalized block height
 	e.rpcEngine.SubmitLocal(block)
 
+	// index the seals by the blocks they sealed, so that only the execution nodes which committed
+	// to the sealed results are queried for these blocks
+	for _, seal := range block.Payload.Seals {
+		err = e.seals.IndexBySealedBlockID(seal)
+		if err != nil && !errors.Is(err, storage.ErrAlreadyExists) {
+			return fmt.Errorf("could not index seal (%x): %w", seal.ID(), err)
+		}
+	}
+
 	// FIX: we can't index guarantees here, as we might have more than one block
 	// with the same collection as long as it is not finalized
 
@@ -252,6 +301,32 @@ func (e *Engine) trackFinalizedMetricForBlock(hb *model.Block) {
 
 func (e *Engine) handleExecutionReceipt(originID flow.Identifier, r *flow.ExecutionReceipt) error {
 	e.trackExecutedMetricForReceipt(r)
+
+	// only execution nodes can commit to results, and only to their own
+	if originID != r.ExecutorID {
+		return engine.NewInvalidInputErrorf("receipt of executor %x received from %x", r.ExecutorID, originID)
+	}
+	executor, err := e.state.Final().Identity(originID)
+	if protocol.IsIdentityNotFound(err) {
+		return engine.NewInvalidInputErrorf("receipt from unknown node %x", originID)
+	}
+	if err != nil {
+		return fmt.Errorf("could not look up executor: %w", err)
+	}
+	if executor.Role != flow.RoleExecution || executor.Stake == 0 {
+		return engine.NewInvalidInputErrorf("receipt from %x, which is not a staked execution node", originID)
+	}
+
+	// store the result the execution node committed to
+	err = e.receipts.Store(r)
+	if err != nil && !errors.Is(err, storage.ErrAlreadyExists) {
+		return fmt.Errorf("could not store receipt: %w", err)
+	}
+	err = e.receipts.IndexAll(r.ExecutionResult.BlockID, r.ID())
+	if err != nil && !errors.Is(err, storage.ErrAlreadyExists) {
+		return fmt.Errorf("could not index receipt: %w", err)
+	}
+
 	return nil
 }
 
@@ -342,9 +417,28 @@ func (e *Engine) handleCollection(originID flow.Identifier, entity flow.Entity)
 		}
 	}
 
+	// index the transactions by the accounts involved in them
+	if e.accountTransactions != nil {
+		err = e.indexAccountTransactions(light.ID(), collection.Transactions)
+		if err != nil {
+			return fmt.Errorf("could not index transactions of collection (%x) by account: %w", light.ID(), err)
+		}
+	}
+
 	return nil
 }
 
+// indexAccountTransactions indexes the transactions of the given collection by their payer, proposer
+// and authorizers at the height of the finalized block that included the collection.
+func (e *Engine) indexAccountTransactions(collectionID flow.Identifier, txs []*flow.TransactionBody) error {
+	block, err := e.blocks.ByCollectionID(collectionID)
+	if err != nil {
+		return fmt.Errorf("could not find block for collection: %w", err)
+	}
+
+	return e.accountTransactions.Index(block.Header.Height, txs)
+}
+
 func (e *Engine) OnCollection(originID flow.Identifier, entity flow.Entity) {
 	err := e.handleCollection(originID, entity)
 	if err != nil {
@@ -555,6 +649,131 @@ func (e *Engine) updateLastFullBlockReceivedIndex() {
 	e.log.Debug().Uint64("last_full_blk_height", latestFullHeight).Msg("updated LastFullBlockReceived index")
 }
 
+// updateEventIndex indexes the events of all sealed blocks for which all collections have been received and
+// which have not been indexed yet. The events are retrieved from the execution node in batches of blocks.
+func (e *Engine) updateEventIndex() {
+
+	logError := func(err error) {
+		e.log.Error().Err(err).Msg("failed to update the event index")
+	}
+
+	lastIndexedHeight, err := e.eventIndex.LastIndexedHeight()
+	if err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			logError(err)
+			return
+		}
+		// the root block has no events, start indexing from the block after it
+		header, err := e.state.Params().Root()
+		if err != nil {
+			logError(err)
+			return
+		}
+		lastIndexedHeight = header.Height
+	}
+
+	// only blocks that have been sealed have events that can be trusted to be final
+	sealed, err := e.state.Sealed().Head()
+	if err != nil {
+		logError(err)
+		return
+	}
+
+	// only blocks for which all collections have been received can be indexed
+	lastFullHeight, err := e.blocks.GetLastFullBlockHeight()
+	if err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			logError(err)
+		}
+		return
+	}
+
+	endHeight := sealed.Height
+	if lastFullHeight < endHeight {
+		endHeight = lastFullHeight
+	}
+
+	for startHeight := lastIndexedHeight + 1; startHeight <= endHeight; startHeight += defaultEventIndexBatchSize {
+
+		// stop indexing if the engine is shutting down
+		if e.unit.Ctx().Err() != nil {
+			return
+		}
+
+		batchEndHeight := startHeight + defaultEventIndexBatchSize - 1
+		if batchEndHeight > endHeight {
+			batchEndHeight = endHeight
+		}
+
+		events, err := e.eventsForHeights(startHeight, batchEndHeight)
+		if status.Code(err) == codes.Unimplemented {
+			// never index the events of an execution node which cannot return all events of blocks
+			e.log.Error().Err(err).Msg("execution node does not support retrieving all events of blocks, events are not indexed")
+			return
+		}
+		if err != nil {
+			logError(fmt.Errorf("could not retrieve events for heights %d to %d: %w", startHeight, batchEndHeight, err))
+			return
+		}
+
+		// store the events in the order of the heights, so that the last indexed height has no gaps
+		for height := startHeight; height <= batchEndHeight; height++ {
+			err = e.eventIndex.Store(height, events[height-startHeight])
+			if err != nil {
+				logError(fmt.Errorf("could not index events at height %d: %w", height, err))
+				return
+			}
+		}
+	}
+
+	e.log.Debug().Uint64("last_indexed_height", endHeight).Msg("updated event index")
+}
+
+// eventsForHeights retrieves all events emitted by the blocks within the given height range (inclusive) from the
+// execution node with a single request. The events are returned in the order of the heights.
+func (e *Engine) eventsForHeights(startHeight uint64, endHeight uint64) ([][]flow.Event, error) {
+	blockIDs := make([][]byte, 0, endHeight-startHeight+1)
+	indexes := make(map[flow.Identifier]int, endHeight-startHeight+1)
+	for height := startHeight; height <= endHeight; height++ {
+		header, err := e.headers.ByHeight(height)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve block at height %d: %w", height, err)
+		}
+		blockID := header.ID()
+		indexes[blockID] = len(blockIDs)
+		blockIDs = append(blockIDs, blockID[:])
+	}
+
+	ctx, cancel := context.WithTimeout(e.unit.Ctx(), eventIndexRequestTimeout)
+	defer cancel()
+
+	req := &extended.GetAllEventsForBlockIDsRequest{
+		BlockIds: blockIDs,
+	}
+	resp, err := e.executionRPC.GetAllEventsForBlockIDs(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([][]flow.Event, len(blockIDs))
+	found := make([]bool, len(blockIDs))
+	for _, result := range resp.GetResults() {
+		index, ok := indexes[flow.HashToID(result.GetBlockId())]
+		if !ok {
+			return nil, fmt.Errorf("received events for unexpected block %x", result.GetBlockId())
+		}
+		events[index] = convert.MessagesToEvents(result.GetEvents())
+		found[index] = true
+	}
+	for index, ok := range found {
+		if !ok {
+			return nil, fmt.Errorf("missing events for block %x", blockIDs[index])
+		}
+	}
+
+	return events, nil
+}
+
 // missingCollectionsAtHeight returns all missing collection guarantees at a given height
 func (e *Engine) missingCollectionsAtHeight(h uint64) ([]*flow.CollectionGuarantee, error) {
 	blk, err := e.blocks.ByHeight(h)
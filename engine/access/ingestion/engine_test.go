@@ -7,15 +7,21 @@ import (
 	"testing"
 	"time"
 
+	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/onflow/flow-go/consensus/hotstuff/model"
 	"github.com/onflow/flow-go/engine"
+	accessmock "github.com/onflow/flow-go/engine/access/mock"
 	"github.com/onflow/flow-go/engine/access/rpc"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	extended "github.com/onflow/flow-go/engine/execution/rpc/protobuf"
 	"github.com/onflow/flow-go/model/flow"
 
 	"github.com/onflow/flow-go/module/mempool/stdmap"
@@ -46,6 +52,8 @@ type Suite struct {
 	headers      *storage.Headers
 	collections  *storage.Collections
 	transactions *storage.Transactions
+	receipts     *storage.ExecutionReceipts
+	seals        *storage.Seals
 
 	eng *Engine
 }
@@ -82,6 +90,8 @@ func (suite *Suite) SetupTest() {
 	suite.headers = new(storage.Headers)
 	suite.collections = new(storage.Collections)
 	suite.transactions = new(storage.Transactions)
+	suite.receipts = new(storage.ExecutionReceipts)
+	suite.seals = new(storage.Seals)
 	collectionsToMarkFinalized, err := stdmap.NewTimes(100)
 	require.NoError(suite.T(), err)
 	collectionsToMarkExecuted, err := stdmap.NewTimes(100)
@@ -90,11 +100,11 @@ func (suite *Suite) SetupTest() {
 	require.NoError(suite.T(), err)
 
 	rpcEng := rpc.New(log, suite.proto.state, rpc.Config{}, nil, nil, suite.blocks, suite.headers, suite.collections,
-		suite.transactions, flow.Testnet, metrics.NewNoopCollector(), 0, false)
+		suite.transactions, flow.Testnet, metrics.NewNoopCollector(), 0, false, nil, nil, nil, nil, nil, nil)
 
 	eng, err := New(log, net, suite.proto.state, suite.me, suite.request, suite.blocks, suite.headers, suite.collections,
 		suite.transactions, metrics.NewNoopCollector(), collectionsToMarkFinalized, collectionsToMarkExecuted,
-		blocksToMarkExecuted, rpcEng)
+		blocksToMarkExecuted, rpcEng, nil, nil, nil, suite.receipts, suite.seals)
 	require.NoError(suite.T(), err)
 
 	suite.eng = eng
@@ -105,10 +115,16 @@ func (suite *Suite) SetupTest() {
 func (suite *Suite) TestOnFinalizedBlock() {
 
 	block := unittest.BlockFixture()
+	block.Payload.Seals = unittest.BlockSealsFixture(2)
 	modelBlock := model.Block{
 		BlockID: block.ID(),
 	}
 
+	// expect that each of the seals is indexed by the block it sealed
+	for _, seal := range block.Payload.Seals {
+		suite.seals.On("IndexBySealedBlockID", seal).Return(nil).Once()
+	}
+
 	// we should query the block once and index the guarantee payload once
 	suite.blocks.On("ByID", block.ID()).Return(&block, nil).Twice()
 	for _, g := range block.Payload.Guarantees {
@@ -150,9 +166,53 @@ func (suite *Suite) TestOnFinalizedBlock() {
 
 	// assert that the block was retrieved and all collections were requested
 	suite.headers.AssertExpectations(suite.T())
+	suite.seals.AssertExpectations(suite.T())
 	suite.request.AssertNumberOfCalls(suite.T(), "EntityByID", len(block.Payload.Guarantees))
 }
 
+// TestOnExecutionReceipt checks that the receipts of staked execution nodes are stored and indexed by
+// the executed block
+func (suite *Suite) TestOnExecutionReceipt() {
+
+	executor := unittest.IdentityFixture(unittest.WithRole(flow.RoleExecution))
+	receipt := unittest.ExecutionReceiptFixture()
+	receipt.ExecutorID = executor.NodeID
+
+	suite.proto.snapshot.On("Identity", executor.NodeID).Return(executor, nil)
+	suite.blocks.On("ByID", receipt.ExecutionResult.BlockID).Return(nil, storerr.ErrNotFound)
+	suite.receipts.On("Store", receipt).Return(nil).Once()
+	suite.receipts.On("IndexAll", receipt.ExecutionResult.BlockID, receipt.ID()).Return(nil).Once()
+
+	err := suite.eng.process(executor.NodeID, receipt)
+	require.NoError(suite.T(), err)
+
+	suite.receipts.AssertExpectations(suite.T())
+}
+
+// TestOnExecutionReceiptInvalidOrigin checks that receipts are rejected if they are not sent by the
+// executor, or if the executor is not a staked execution node
+func (suite *Suite) TestOnExecutionReceiptInvalidOrigin() {
+
+	executor := unittest.IdentityFixture(unittest.WithRole(flow.RoleExecution))
+	verifier := unittest.IdentityFixture(unittest.WithRole(flow.RoleVerification))
+	suite.proto.snapshot.On("Identity", verifier.NodeID).Return(verifier, nil)
+	suite.blocks.On("ByID", mock.Anything).Return(nil, storerr.ErrNotFound)
+
+	// receipt relayed by another node
+	receipt := unittest.ExecutionReceiptFixture()
+	receipt.ExecutorID = executor.NodeID
+	err := suite.eng.process(verifier.NodeID, receipt)
+	require.True(suite.T(), engine.IsInvalidInputError(err))
+
+	// receipt by a node that is not an execution node
+	receipt = unittest.ExecutionReceiptFixture()
+	receipt.ExecutorID = verifier.NodeID
+	err = suite.eng.process(verifier.NodeID, receipt)
+	require.True(suite.T(), engine.IsInvalidInputError(err))
+
+	suite.receipts.AssertNotCalled(suite.T(), "Store", mock.Anything)
+}
+
 // TestOnCollection checks that when a Collection is received, it is persisted
 func (suite *Suite) TestOnCollection() {
 
@@ -195,6 +255,30 @@ func (suite *Suite) TestOnCollection() {
 	suite.transactions.AssertNumberOfCalls(suite.T(), "Store", len(collection.Transactions))
 }
 
+// TestOnCollectionIndexAccountTransactions checks that when a Collection is received, its transactions
+// are indexed by account at the height of the block that included the collection
+func (suite *Suite) TestOnCollectionIndexAccountTransactions() {
+	accountTransactions := new(storage.AccountTransactions)
+	suite.eng.accountTransactions = accountTransactions
+
+	originID := unittest.IdentifierFixture()
+	collection := unittest.CollectionFixture(5)
+	light := collection.Light()
+	block := unittest.BlockFixture()
+
+	suite.collections.On("StoreLightAndIndexByTransaction", &light).Return(nil).Once()
+	suite.transactions.On("Store", mock.Anything).Return(nil)
+	suite.blocks.On("ByCollectionID", light.ID()).Return(&block, nil).Once()
+	accountTransactions.On("Index", block.Header.Height, collection.Transactions).Return(nil).Once()
+
+	// process the block through the collection callback
+	suite.eng.OnCollection(originID, &collection)
+
+	// check that all transactions were indexed at the height of the block
+	suite.blocks.AssertExpectations(suite.T())
+	accountTransactions.AssertExpectations(suite.T())
+}
+
 // TestOnCollection checks that when a duplicate collection is received, the node doesn't
 // crash but just ignores its transactions.
 func (suite *Suite) TestOnCollectionDuplicate() {
@@ -512,3 +596,100 @@ func (suite *Suite) TestUpdateLastFullBlockReceivedIndex() {
 		suite.blocks.AssertExpectations(suite.T()) // not new call to UpdateLastFullBlockHeight should be made
 	})
 }
+
+// TestUpdateEventIndex tests that the events of all sealed blocks with complete collections are retrieved from the
+// execution node in batches of blocks and indexed
+func (suite *Suite) TestUpdateEventIndex() {
+	eventIndex := new(storage.EventIndex)
+	execClient := new(accessmock.ExtendedExecutionAPIClient)
+	suite.eng.eventIndex = eventIndex
+	suite.eng.executionRPC = execClient
+
+	// the five heights to index are retrieved with three requests
+	batchSize := defaultEventIndexBatchSize
+	defaultEventIndexBatchSize = 2
+	defer func() { defaultEventIndexBatchSize = batchSize }()
+
+	var lastIndexedHeight uint64 = 10
+	var lastFullHeight uint64 = 15
+
+	// the sealed height is above the last full height, so indexing stops at the last full height
+	sealed := unittest.BlockHeaderFixture()
+	sealed.Height = lastFullHeight + 5
+	sealedSnapshot := new(protocol.Snapshot)
+	sealedSnapshot.On("Head").Return(&sealed, nil)
+	suite.proto.state.On("Sealed").Return(sealedSnapshot)
+
+	eventIndex.On("LastIndexedHeight").Return(lastIndexedHeight, nil).Once()
+	suite.blocks.On("GetLastFullBlockHeight").Return(lastFullHeight, nil).Once()
+
+	req := &extended.GetAllEventsForBlockIDsRequest{}
+	resp := &execproto.GetEventsForBlockIDsResponse{}
+	for height := lastIndexedHeight + 1; height <= lastFullHeight; height++ {
+		header := unittest.BlockHeaderFixture()
+		header.Height = height
+		blockID := header.ID()
+		suite.headers.On("ByHeight", height).Return(&header, nil).Once()
+
+		expected := []flow.Event{
+			unittest.EventFixture(flow.EventAccountCreated, 0, 0, unittest.IdentifierFixture()),
+			unittest.EventFixture(flow.EventAccountUpdated, 1, 0, unittest.IdentifierFixture()),
+		}
+		eventIndex.On("Store", height, expected).Return(nil).Once()
+
+		req.BlockIds = append(req.BlockIds, blockID[:])
+		resp.Results = append(resp.Results, &execproto.GetEventsForBlockIDsResponse_Result{
+			BlockId:     blockID[:],
+			BlockHeight: height,
+			Events:      convert.EventsToMessages(expected),
+		})
+
+		// a request for each batch of blocks, or for the remaining blocks
+		if uint64(len(req.BlockIds)) == defaultEventIndexBatchSize || height == lastFullHeight {
+			execClient.On("GetAllEventsForBlockIDs", mock.Anything, req).Return(resp, nil).Once()
+			req = &extended.GetAllEventsForBlockIDsRequest{}
+			resp = &execproto.GetEventsForBlockIDsResponse{}
+		}
+	}
+
+	suite.eng.updateEventIndex()
+
+	eventIndex.AssertExpectations(suite.T())
+	execClient.AssertExpectations(suite.T())
+	suite.headers.AssertExpectations(suite.T())
+	execClient.AssertNumberOfCalls(suite.T(), "GetAllEventsForBlockIDs", 3)
+}
+
+// TestUpdateEventIndexUnsupported tests that no events are indexed if the execution node cannot return all events
+// of blocks
+func (suite *Suite) TestUpdateEventIndexUnsupported() {
+	eventIndex := new(storage.EventIndex)
+	execClient := new(accessmock.ExtendedExecutionAPIClient)
+	suite.eng.eventIndex = eventIndex
+	suite.eng.executionRPC = execClient
+
+	var lastIndexedHeight uint64 = 10
+	var lastFullHeight uint64 = 11
+
+	sealed := unittest.BlockHeaderFixture()
+	sealed.Height = lastFullHeight
+	sealedSnapshot := new(protocol.Snapshot)
+	sealedSnapshot.On("Head").Return(&sealed, nil)
+	suite.proto.state.On("Sealed").Return(sealedSnapshot)
+
+	eventIndex.On("LastIndexedHeight").Return(lastIndexedHeight, nil).Once()
+	suite.blocks.On("GetLastFullBlockHeight").Return(lastFullHeight, nil).Once()
+
+	header := unittest.BlockHeaderFixture()
+	header.Height = lastFullHeight
+	suite.headers.On("ByHeight", lastFullHeight).Return(&header, nil).Once()
+
+	// an execution node without the extended execution API
+	execClient.On("GetAllEventsForBlockIDs", mock.Anything, mock.Anything).
+		Return(nil, status.Error(codes.Unimplemented, "unknown service flow.execution.ExtendedExecutionAPI")).Once()
+
+	suite.eng.updateEventIndex()
+
+	execClient.AssertExpectations(suite.T())
+	eventIndex.AssertNotCalled(suite.T(), "Store", mock.Anything, mock.Anything)
+}
@@ -22,11 +22,16 @@ import (
 // It is composed of several sub-backends that implement part of the Access API.
 //
 // Script related calls are handled by backendScripts.
-// Transaction related calls are handled by backendTransactions.
-// Block Header related calls are handled by backendBlockHeaders.
+// Transaction related calls, including transaction status subscriptions and account transaction history, are
+// handled by backendTransactions.
+// Block Header related calls, including header subscriptions, are handled by backendBlockHeaders.
 // Block details related calls are handled by backendBlockDetails.
-// Event related calls are handled by backendEvents.
+// Event related calls, including event subscriptions, are handled by backendEvents.
 // Account related calls are handled by backendAccounts.
+// Slashing evidence related calls are handled by backendSlashing.
+//
+// If an execution quorum is configured, responses of execution nodes for events, scripts and transaction
+// results are only accepted once enough execution nodes agree on them (see executionQuorum).
 //
 // All remaining calls are handled by the base Backend in this file.
 type Backend struct {
@@ -36,11 +41,13 @@ type Backend struct {
 	backendBlockHeaders
 	backendBlockDetails
 	backendAccounts
+	backendSlashing
 
 	executionRPC execproto.ExecutionAPIClient
 	state        protocol.State
 	chainID      flow.ChainID
 	collections  storage.Collections
+	notifier     *heightNotifier
 }
 
 func New(
@@ -56,24 +63,58 @@ func New(
 	collectionGRPCPort uint,
 	connFactory ConnectionFactory,
 	retryEnabled bool,
+	eventIndex storage.EventIndex,
+	eventQueryMode EventQueryMode,
+	maxHeightRange uint,
+	executionGRPCPort uint,
+	executionQuorumSize uint,
+	accessMetrics module.AccessMetrics,
+	accountTransactions storage.AccountTransactions,
+	slashingEvidence storage.SlashingEvidence,
+	receipts storage.ExecutionReceipts,
+	seals storage.Seals,
 ) *Backend {
 	retry := newRetry()
 	if retryEnabled {
 		retry.Activate()
 	}
 
+	if maxHeightRange == 0 {
+		maxHeightRange = DefaultMaxHeightRange
+	}
+
+	// notifies subscriptions about newly finalized blocks
+	notifier := newHeightNotifier()
+
+	// if a quorum is configured, responses of execution nodes are only accepted once enough
+	// execution nodes agree, otherwise the static execution node is trusted
+	var quorum *executionQuorum
+	if executionQuorumSize > 0 {
+		quorum = &executionQuorum{
+			state:       state,
+			connFactory: connFactory,
+			grpcPort:    executionGRPCPort,
+			quorum:      executionQuorumSize,
+			receipts:    receipts,
+			seals:       seals,
+			metrics:     accessMetrics,
+		}
+	}
+
 	b := &Backend{
 		executionRPC: executionRPC,
 		state:        state,
 		// create the sub-backends
 		backendScripts: backendScripts{
-			headers:      headers,
-			executionRPC: executionRPC,
-			state:        state,
+			headers:         headers,
+			executionRPC:    executionRPC,
+			executionQuorum: quorum,
+			state:           state,
 		},
 		backendTransactions: backendTransactions{
 			staticCollectionRPC:  collectionRPC,
 			executionRPC:         executionRPC,
+			executionQuorum:      quorum,
 			state:                state,
 			chainID:              chainID,
 			collections:          collections,
@@ -84,15 +125,24 @@ func New(
 			retry:                retry,
 			collectionGRPCPort:   collectionGRPCPort,
 			connFactory:          connFactory,
+			notifier:             notifier,
+			accountTransactions:  accountTransactions,
 		},
 		backendEvents: backendEvents{
-			executionRPC: executionRPC,
-			state:        state,
-			blocks:       blocks,
+			executionRPC:    executionRPC,
+			executionQuorum: quorum,
+			state:           state,
+			blocks:          blocks,
+			headers:         headers,
+			eventIndex:      eventIndex,
+			notifier:        notifier,
+			queryMode:       eventQueryMode,
+			maxHeightRange:  maxHeightRange,
 		},
 		backendBlockHeaders: backendBlockHeaders{
-			headers: headers,
-			state:   state,
+			headers:  headers,
+			state:    state,
+			notifier: notifier,
 		},
 		backendBlockDetails: backendBlockDetails{
 			blocks: blocks,
@@ -103,8 +153,12 @@ func New(
 			state:        state,
 			headers:      headers,
 		},
+		backendSlashing: backendSlashing{
+			evidence: slashingEvidence,
+		},
 		collections: collections,
 		chainID:     chainID,
+		notifier:    notifier,
 	}
 
 	retry.SetBackend(b)
@@ -161,6 +215,13 @@ func (b *Backend) GetNetworkParameters(_ context.Context) access.NetworkParamete
 	}
 }
 
+// NotifyFinalizedBlockHeight is called whenever a new block is finalized. It triggers the retry of
+// pending transactions and wakes up all active subscriptions.
+func (b *Backend) NotifyFinalizedBlockHeight(height uint64) {
+	b.backendTransactions.NotifyFinalizedBlockHeight(height)
+	b.notifier.Notify()
+}
+
 func convertStorageError(err error) error {
 	if errors.Is(err, storage.ErrNotFound) {
 		return status.Errorf(codes.NotFound, "not found: %v", err)
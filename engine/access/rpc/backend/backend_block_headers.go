@@ -3,14 +3,19 @@ package backend
 import (
 	"context"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/storage"
 )
 
 type backendBlockHeaders struct {
-	headers storage.Headers
-	state   protocol.State
+	headers  storage.Headers
+	state    protocol.State
+	notifier *heightNotifier
 }
 
 func (b *backendBlockHeaders) GetLatestBlockHeader(_ context.Context, isSealed bool) (*flow.Header, error) {
@@ -52,3 +57,48 @@ func (b *backendBlockHeaders) GetBlockHeaderByHeight(_ context.Context, height u
 
 	return header, nil
 }
+
+// SubscribeBlockHeaders streams all finalized block headers starting at the given height to the
+// handler. Headers below the latest finalized height are backfilled from storage, afterwards the
+// subscription follows finalization until the context is cancelled or the handler returns an error.
+func (b *backendBlockHeaders) SubscribeBlockHeaders(
+	ctx context.Context,
+	startHeight uint64,
+	handler access.HeaderHandler,
+) error {
+
+	height := startHeight
+
+	for {
+		// grab the notification channel before checking the finalized height, so that we can not
+		// miss a block being finalized in between
+		notified := b.notifier.Channel()
+
+		final, err := b.state.Final().Head()
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to get latest finalized block: %v", err)
+		}
+
+		for ; height <= final.Height; height++ {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			header, err := b.headers.ByHeight(height)
+			if err != nil {
+				return convertStorageError(err)
+			}
+
+			err = handler(header)
+			if err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-notified:
+		}
+	}
+}
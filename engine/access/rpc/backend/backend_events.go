@@ -6,20 +6,57 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/golang/protobuf/proto"
 	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/onflow/flow-go/access"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/storage"
 )
 
+// subscriptionEventsBatchSize is the maximum number of blocks for which a subscription requests
+// events from the execution node at once while catching up with the sealed height.
+const subscriptionEventsBatchSize = 50
+
+// DefaultMaxHeightRange is the default maximum number of blocks a single event query may span.
+const DefaultMaxHeightRange = 250
+
+// EventQueryMode determines where the access node looks up events.
+type EventQueryMode int
+
+const (
+	// EventQueryModeExecutionNode forwards event queries to an execution node.
+	EventQueryModeExecutionNode EventQueryMode = iota
+	// EventQueryModeLocalIndex answers event queries from the local event index.
+	EventQueryModeLocalIndex
+)
+
+// ParseEventQueryMode parses the command line representation of an event query mode.
+func ParseEventQueryMode(s string) (EventQueryMode, error) {
+	switch s {
+	case "execution-node":
+		return EventQueryModeExecutionNode, nil
+	case "local-index":
+		return EventQueryModeLocalIndex, nil
+	default:
+		return 0, fmt.Errorf("invalid event query mode: %s", s)
+	}
+}
+
 type backendEvents struct {
-	executionRPC execproto.ExecutionAPIClient
-	blocks       storage.Blocks
-	state        protocol.State
+	executionRPC    execproto.ExecutionAPIClient
+	executionQuorum *executionQuorum // nil if execution node responses are not verified
+	blocks          storage.Blocks
+	headers         storage.Headers
+	eventIndex      storage.EventIndex
+	state           protocol.State
+	notifier        *heightNotifier
+	queryMode       EventQueryMode
+	maxHeightRange  uint
 }
 
 // GetEventsForHeightRange retrieves events for all sealed blocks between the start block height and
@@ -34,6 +71,11 @@ func (b *backendEvents) GetEventsForHeightRange(
 		return nil, status.Error(codes.InvalidArgument, "invalid start or end height")
 	}
 
+	rangeSize := endHeight - startHeight + 1 // range is inclusive on both ends
+	if rangeSize > uint64(b.maxHeightRange) {
+		return nil, status.Errorf(codes.InvalidArgument, "requested block range (%d) exceeded maximum (%d)", rangeSize, b.maxHeightRange)
+	}
+
 	// get the latest sealed block header
 	head, err := b.state.Sealed().Head()
 	if err != nil {
@@ -45,6 +87,22 @@ func (b *backendEvents) GetEventsForHeightRange(
 		endHeight = head.Height
 	}
 
+	return b.getEventsForSealedHeightRange(ctx, startHeight, endHeight, eventType)
+}
+
+// getEventsForSealedHeightRange retrieves the events of the given type for the sealed blocks between
+// the start and end height (inclusive), either from the local event index or from the execution node.
+// When using the local index, the result is truncated to the blocks that have already been indexed.
+func (b *backendEvents) getEventsForSealedHeightRange(
+	ctx context.Context,
+	startHeight, endHeight uint64,
+	eventType string,
+) ([]flow.BlockEvents, error) {
+
+	if b.queryMode == EventQueryModeLocalIndex {
+		return b.getEventsForHeightRangeFromIndex(startHeight, endHeight, eventType)
+	}
+
 	// find the block IDs for all the blocks between min and max height (inclusive)
 	blockIDs := make([]flow.Identifier, 0)
 
@@ -66,10 +124,165 @@ func (b *backendEvents) GetEventsForBlockIDs(
 	eventType string,
 	blockIDs []flow.Identifier,
 ) ([]flow.BlockEvents, error) {
+
+	if uint(len(blockIDs)) > b.maxHeightRange {
+		return nil, status.Errorf(codes.InvalidArgument, "requested block count (%d) exceeded maximum (%d)", len(blockIDs), b.maxHeightRange)
+	}
+
+	if b.queryMode == EventQueryModeLocalIndex {
+		return b.getEventsForBlockIDsFromIndex(blockIDs, eventType)
+	}
+
 	// forward the request to the execution node
 	return b.getBlockEventsFromExecutionNode(ctx, blockIDs, eventType)
 }
 
+// getEventsForHeightRangeFromIndex looks up the events of the given type for all blocks between
+// the start and end height (inclusive) in the local event index. Blocks that were not indexed yet
+// are omitted from the result.
+func (b *backendEvents) getEventsForHeightRangeFromIndex(
+	startHeight, endHeight uint64,
+	eventType string,
+) ([]flow.BlockEvents, error) {
+
+	lastIndexed, err := b.eventIndex.LastIndexedHeight()
+	if errors.Is(err, storage.ErrNotFound) {
+		return []flow.BlockEvents{}, nil
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get last indexed height: %v", err)
+	}
+
+	// limit max height to the last block in the local index
+	if lastIndexed < endHeight {
+		endHeight = lastIndexed
+	}
+	if endHeight < startHeight {
+		return []flow.BlockEvents{}, nil
+	}
+
+	events, err := b.eventIndex.ByHeightRangeEventType(startHeight, endHeight, flow.EventType(eventType))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get events from local index: %v", err)
+	}
+
+	results := make([]flow.BlockEvents, 0, endHeight-startHeight+1)
+	for height := startHeight; height <= endHeight; height++ {
+		header, err := b.headers.ByHeight(height)
+		if err != nil {
+			return nil, convertStorageError(err)
+		}
+
+		results = append(results, flow.BlockEvents{
+			BlockID:     header.ID(),
+			BlockHeight: height,
+			Events:      events[height],
+		})
+	}
+
+	return results, nil
+}
+
+// getEventsForBlockIDsFromIndex looks up the events of the given type for all the given blocks in
+// the local event index. It fails if any of the blocks was not indexed yet.
+func (b *backendEvents) getEventsForBlockIDsFromIndex(
+	blockIDs []flow.Identifier,
+	eventType string,
+) ([]flow.BlockEvents, error) {
+
+	lastIndexed, err := b.eventIndex.LastIndexedHeight()
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return nil, status.Errorf(codes.Internal, "failed to get last indexed height: %v", err)
+	}
+	indexed := err == nil
+
+	results := make([]flow.BlockEvents, 0, len(blockIDs))
+	for _, blockID := range blockIDs {
+		header, err := b.headers.ByBlockID(blockID)
+		if err != nil {
+			return nil, convertStorageError(err)
+		}
+
+		if !indexed || header.Height > lastIndexed {
+			return nil, status.Errorf(codes.NotFound, "events for block %x are not indexed yet", blockID)
+		}
+
+		events, err := b.eventIndex.ByHeightRangeEventType(header.Height, header.Height, flow.EventType(eventType))
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get events from local index: %v", err)
+		}
+
+		results = append(results, flow.BlockEvents{
+			BlockID:     blockID,
+			BlockHeight: header.Height,
+			Events:      events[header.Height],
+		})
+	}
+
+	return results, nil
+}
+
+// SubscribeEvents streams the events of the given type for all sealed blocks starting at the given
+// height to the handler, one call per block. Blocks below the latest sealed height are backfilled
+// first, afterwards the subscription follows sealing until the context is cancelled or the handler
+// returns an error.
+func (b *backendEvents) SubscribeEvents(
+	ctx context.Context,
+	eventType string,
+	startHeight uint64,
+	handler access.BlockEventsHandler,
+) error {
+
+	height := startHeight
+
+	for {
+		// grab the notification channel before checking the sealed height, so that we can not
+		// miss a block being sealed in between
+		notified := b.notifier.Channel()
+
+		sealed, err := b.state.Sealed().Head()
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to get latest sealed block: %v", err)
+		}
+
+		for height <= sealed.Height {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			endHeight := height + subscriptionEventsBatchSize - 1
+			if endHeight > sealed.Height {
+				endHeight = sealed.Height
+			}
+
+			results, err := b.getEventsForSealedHeightRange(ctx, height, endHeight, eventType)
+			if err != nil {
+				return err
+			}
+
+			// the local index may lag behind the sealed height, wait for more blocks to be indexed
+			if len(results) == 0 {
+				break
+			}
+
+			for _, result := range results {
+				err = handler(result)
+				if err != nil {
+					return err
+				}
+			}
+
+			height += uint64(len(results))
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-notified:
+		}
+	}
+}
+
 func (b *backendEvents) getBlockEventsFromExecutionNode(
 	ctx context.Context,
 	blockIDs []flow.Identifier,
@@ -82,11 +295,24 @@ func (b *backendEvents) getBlockEventsFromExecutionNode(
 		BlockIds: convert.IdentifiersToMessages(blockIDs),
 	}
 
-	// call the execution node gRPC
-	resp, err := b.executionRPC.GetEventsForBlockIDs(ctx, &req)
-
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to retrieve events from execution node: %v", err)
+	var resp *execproto.GetEventsForBlockIDsResponse
+	if b.executionQuorum != nil {
+		// call a quorum of execution nodes
+		agreed, err := b.executionQuorum.query(ctx, "GetEventsForBlockIDs", blockIDs,
+			func(ctx context.Context, client execproto.ExecutionAPIClient) (proto.Message, error) {
+				return client.GetEventsForBlockIDs(ctx, &req)
+			})
+		if err != nil {
+			return nil, err
+		}
+		resp = agreed.(*execproto.GetEventsForBlockIDsResponse)
+	} else {
+		// call the execution node gRPC
+		var err error
+		resp, err = b.executionRPC.GetEventsForBlockIDs(ctx, &req)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to retrieve events from execution node: %v", err)
+		}
 	}
 
 	// convert execution node api result to access node api result
@@ -3,6 +3,7 @@ package backend
 import (
 	"context"
 
+	"github.com/golang/protobuf/proto"
 	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -13,9 +14,10 @@ import (
 )
 
 type backendScripts struct {
-	headers      storage.Headers
-	state        protocol.State
-	executionRPC execproto.ExecutionAPIClient
+	headers         storage.Headers
+	state           protocol.State
+	executionRPC    execproto.ExecutionAPIClient
+	executionQuorum *executionQuorum // nil if execution node responses are not verified
 }
 
 func (b *backendScripts) ExecuteScriptAtLatestBlock(
@@ -81,6 +83,18 @@ func (b *backendScripts) executeScriptOnExecutionNode(
 		Arguments: arguments,
 	}
 
+	if b.executionQuorum != nil {
+		// execute the script on a quorum of execution nodes
+		agreed, err := b.executionQuorum.query(ctx, "ExecuteScriptAtBlockID", []flow.Identifier{blockID},
+			func(ctx context.Context, client execproto.ExecutionAPIClient) (proto.Message, error) {
+				return client.ExecuteScriptAtBlockID(ctx, &execReq)
+			})
+		if err != nil {
+			return nil, err
+		}
+		return agreed.(*execproto.ExecuteScriptAtBlockIDResponse).GetValue(), nil
+	}
+
 	execResp, err := b.executionRPC.ExecuteScriptAtBlockID(ctx, &execReq)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to execute the script on the execution node: %v", err)
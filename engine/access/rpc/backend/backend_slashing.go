@@ -0,0 +1,37 @@
+package backend
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+type backendSlashing struct {
+	evidence storage.SlashingEvidence
+}
+
+// GetSlashingEvidence returns the evidence of slashable offences committed by the given node, ordered
+// by view, or the evidence against all nodes if the given node ID is zero.
+func (b *backendSlashing) GetSlashingEvidence(_ context.Context, offenderID flow.Identifier) ([]*flow.SlashingEvidence, error) {
+
+	if b.evidence == nil {
+		return nil, status.Errorf(codes.Unimplemented, "slashing evidence is not stored on this node")
+	}
+
+	var evidence []*flow.SlashingEvidence
+	var err error
+	if offenderID == flow.ZeroID {
+		evidence, err = b.evidence.All()
+	} else {
+		evidence, err = b.evidence.ByOffender(offenderID)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to look up slashing evidence: %v", err)
+	}
+
+	return evidence, nil
+}
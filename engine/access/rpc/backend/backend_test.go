@@ -2,6 +2,7 @@ package backend
 
 import (
 	"context"
+	"errors"
 	"math/rand"
 	"testing"
 	"time"
@@ -12,9 +13,11 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	flowaccess "github.com/onflow/flow-go/access"
 	access "github.com/onflow/flow-go/engine/access/mock"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
 	"github.com/onflow/flow-go/model/flow"
@@ -80,6 +83,16 @@ func (suite *Suite) TestPing() {
 		0,
 		nil,
 		false,
+		nil,
+		EventQueryModeExecutionNode,
+		DefaultMaxHeightRange,
+		0,
+		0,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	err := backend.Ping(context.Background())
@@ -102,6 +115,16 @@ func (suite *Suite) TestGetLatestFinalizedBlockHeader() {
 		0,
 		nil,
 		false,
+		nil,
+		EventQueryModeExecutionNode,
+		DefaultMaxHeightRange,
+		0,
+		0,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	// query the handler for the latest finalized block
@@ -130,6 +153,16 @@ func (suite *Suite) TestGetLatestSealedBlockHeader() {
 		0,
 		nil,
 		false,
+		nil,
+		EventQueryModeExecutionNode,
+		DefaultMaxHeightRange,
+		0,
+		0,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	// query the handler for the latest sealed block
@@ -162,6 +195,16 @@ func (suite *Suite) TestGetTransaction() {
 		0,
 		nil,
 		false,
+		nil,
+		EventQueryModeExecutionNode,
+		DefaultMaxHeightRange,
+		0,
+		0,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	actual, err := backend.GetTransaction(context.Background(), transaction.ID())
@@ -172,6 +215,119 @@ func (suite *Suite) TestGetTransaction() {
 	suite.assertAllExpectations()
 }
 
+func (suite *Suite) TestGetTransactionsByAccount() {
+	address := unittest.AddressFixture()
+	accountTransactions := new(storagemock.AccountTransactions)
+
+	first := unittest.TransactionBodyFixture()
+	second := unittest.TransactionBodyFixture()
+	indexed := []storage.AccountTransaction{
+		{Height: 11, TransactionID: first.ID()},
+		{Height: 13, TransactionID: second.ID()},
+	}
+
+	// the default page size is used if no limit is requested
+	accountTransactions.
+		On("ByAddress", address, uint64(10), uint64(20), uint(5), DefaultAccountTransactionsPageSize).
+		Return(indexed, nil).
+		Once()
+	suite.transactions.On("ByID", first.ID()).Return(&first, nil).Once()
+	suite.transactions.On("ByID", second.ID()).Return(&second, nil).Once()
+
+	backend := New(
+		suite.state,
+		nil, nil, nil, nil, nil,
+		suite.transactions,
+		suite.chainID,
+		metrics.NewNoopCollector(),
+		0,
+		nil,
+		false,
+		nil,
+		EventQueryModeExecutionNode,
+		DefaultMaxHeightRange,
+		0,
+		0,
+		nil,
+		accountTransactions,
+		nil,
+		nil,
+		nil,
+	)
+
+	actual, err := backend.GetTransactionsByAccount(context.Background(), address, 10, 20, flowaccess.Pagination{Offset: 5})
+	suite.checkResponse(actual, err)
+
+	suite.Require().Equal([]flowaccess.AccountTransaction{
+		{Transaction: &first, BlockHeight: 11},
+		{Transaction: &second, BlockHeight: 13},
+	}, actual)
+
+	// requesting too large pages fails
+	_, err = backend.GetTransactionsByAccount(context.Background(), address, 10, 20,
+		flowaccess.Pagination{Limit: MaxAccountTransactionsPageSize + 1})
+	suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+
+	// an inverted height range fails
+	_, err = backend.GetTransactionsByAccount(context.Background(), address, 20, 10, flowaccess.Pagination{})
+	suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+
+	accountTransactions.AssertExpectations(suite.T())
+	suite.assertAllExpectations()
+}
+
+func (suite *Suite) TestGetSlashingEvidence() {
+	offenderID := unittest.IdentifierFixture()
+	evidence := []*flow.SlashingEvidence{
+		{Offence: flow.OffenceDoubleProposal, OffenderID: offenderID, View: 7},
+		{Offence: flow.OffenceDoubleVote, OffenderID: offenderID, View: 9},
+	}
+	all := append(evidence, &flow.SlashingEvidence{Offence: flow.OffenceDoubleProposal, OffenderID: unittest.IdentifierFixture()})
+
+	slashingEvidence := new(storagemock.SlashingEvidence)
+	slashingEvidence.On("ByOffender", offenderID).Return(evidence, nil).Once()
+	slashingEvidence.On("All").Return(all, nil).Once()
+
+	newBackend := func(slashingEvidence storage.SlashingEvidence) *Backend {
+		return New(
+			suite.state,
+			nil, nil, nil, nil, nil, nil,
+			suite.chainID,
+			metrics.NewNoopCollector(),
+			0,
+			nil,
+			false,
+			nil,
+			EventQueryModeExecutionNode,
+			DefaultMaxHeightRange,
+			0,
+			0,
+			nil,
+			nil,
+			slashingEvidence,
+			nil,
+			nil,
+		)
+	}
+	backend := newBackend(slashingEvidence)
+
+	actual, err := backend.GetSlashingEvidence(context.Background(), offenderID)
+	suite.checkResponse(actual, err)
+	suite.Require().Equal(evidence, actual)
+
+	// the evidence against all nodes is returned for the zero ID
+	actual, err = backend.GetSlashingEvidence(context.Background(), flow.ZeroID)
+	suite.checkResponse(actual, err)
+	suite.Require().Equal(all, actual)
+
+	// nodes that do not store slashing evidence do not support the query
+	_, err = newBackend(nil).GetSlashingEvidence(context.Background(), offenderID)
+	suite.Require().Equal(codes.Unimplemented, status.Code(err))
+
+	slashingEvidence.AssertExpectations(suite.T())
+	suite.assertAllExpectations()
+}
+
 func (suite *Suite) TestGetCollection() {
 	expected := unittest.CollectionFixture(1).Light()
 
@@ -190,6 +346,16 @@ func (suite *Suite) TestGetCollection() {
 		0,
 		nil,
 		false,
+		nil,
+		EventQueryModeExecutionNode,
+		DefaultMaxHeightRange,
+		0,
+		0,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	actual, err := backend.GetCollectionByID(context.Background(), expected.ID())
@@ -258,11 +424,21 @@ func (suite *Suite) TestTransactionStatusTransition() {
 		0,
 		nil,
 		false,
+		nil,
+		EventQueryModeExecutionNode,
+		DefaultMaxHeightRange,
+		0,
+		0,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	// Successfully return empty event list
 	suite.execClient.
-		On("GetTransactionResult", ctx, &exeEventReq).
+		On("GetTransactionResult", ctx, &exeEventReq, mock.Anything).
 		Return(&exeEventResp, status.Errorf(codes.NotFound, "not found")).
 		Once()
 
@@ -273,9 +449,13 @@ func (suite *Suite) TestTransactionStatusTransition() {
 	// status should be finalized since the sealed blocks is smaller in height
 	suite.Assert().Equal(flow.TransactionStatusFinalized, result.Status)
 
-	// Successfully return empty event list from here on
+	// Successfully return empty event list from here on, with the computation used in the response header
 	suite.execClient.
-		On("GetTransactionResult", ctx, &exeEventReq).
+		On("GetTransactionResult", ctx, &exeEventReq, mock.Anything).
+		Run(func(args mock.Arguments) {
+			header := args.Get(2).(grpc.HeaderCallOption)
+			*header.HeaderAddr = convert.ComputationUsedToHeader(42)
+		}).
 		Return(&exeEventResp, nil)
 
 	// second call - when block under test's height is greater height than the sealed head
@@ -284,6 +464,7 @@ func (suite *Suite) TestTransactionStatusTransition() {
 
 	// status should be executed since no `NotFound` error in the `GetTransactionResult` call
 	suite.Assert().Equal(flow.TransactionStatusExecuted, result.Status)
+	suite.Assert().Equal(uint64(42), result.ComputationUsed)
 
 	// now let the head block be finalized
 	headBlock.Header.Height = block.Header.Height + 1
@@ -359,6 +540,16 @@ func (suite *Suite) TestTransactionExpiredStatusTransition() {
 		0,
 		nil,
 		false,
+		nil,
+		EventQueryModeExecutionNode,
+		DefaultMaxHeightRange,
+		0,
+		0,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	// first call - referenced block isn't known yet, so should return pending status
@@ -379,6 +570,88 @@ func (suite *Suite) TestTransactionExpiredStatusTransition() {
 	suite.assertAllExpectations()
 }
 
+// TestSubscribeTransactionStatus tests that a transaction status subscription pushes each status
+// transition and ends once the transaction has expired
+func (suite *Suite) TestSubscribeTransactionStatus() {
+
+	collection := unittest.CollectionFixture(1)
+	transactionBody := collection.Transactions[0]
+	block := unittest.BlockFixture()
+	block.Header.Height = 2
+	transactionBody.SetReferenceBlockID(block.ID())
+
+	headBlock := unittest.BlockFixture()
+	headBlock.Header.Height = block.Header.Height - 1 // head is behind the current block
+
+	suite.snapshot.
+		On("Head").
+		Return(headBlock.Header, nil)
+
+	snapshotAtBlock := new(protocol.Snapshot)
+	snapshotAtBlock.On("Head").Return(block.Header, nil)
+
+	suite.state.
+		On("AtBlockID", block.ID()).
+		Return(snapshotAtBlock, nil)
+
+	// transaction storage returns the corresponding transaction
+	suite.transactions.
+		On("ByID", transactionBody.ID()).
+		Return(transactionBody, nil)
+
+	// collection storage returns a not found error
+	suite.collections.
+		On("LightByTransactionID", transactionBody.ID()).
+		Return(nil, storage.ErrNotFound)
+
+	txID := transactionBody.ID()
+
+	backend := New(
+		suite.state,
+		suite.execClient,
+		nil,
+		suite.blocks,
+		suite.headers,
+		suite.collections,
+		suite.transactions,
+		suite.chainID,
+		metrics.NewNoopCollector(),
+		0,
+		nil,
+		false,
+		nil,
+		EventQueryModeExecutionNode,
+		DefaultMaxHeightRange,
+		0,
+		0,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	var statuses []flow.TransactionStatus
+	err := backend.SubscribeTransactionStatus(context.Background(), txID, func(result *flowaccess.TransactionResult) error {
+		statuses = append(statuses, result.Status)
+
+		// go far into the future and notify the subscription about the newly finalized block
+		headBlock.Header.Height = block.Header.Height + flow.DefaultTransactionExpiry + 1
+		backend.NotifyFinalizedBlockHeight(headBlock.Header.Height)
+
+		return nil
+	})
+	suite.Require().NoError(err)
+
+	// the subscription ends after the transaction expired
+	suite.Assert().Equal([]flow.TransactionStatus{
+		flow.TransactionStatusPending,
+		flow.TransactionStatusExpired,
+	}, statuses)
+
+	suite.assertAllExpectations()
+}
+
 func (suite *Suite) TestGetLatestFinalizedBlock() {
 	// setup the mocks
 	expected := unittest.BlockFixture()
@@ -404,6 +677,16 @@ func (suite *Suite) TestGetLatestFinalizedBlock() {
 		0,
 		nil,
 		false,
+		nil,
+		EventQueryModeExecutionNode,
+		DefaultMaxHeightRange,
+		0,
+		0,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	// query the handler for the latest finalized header
@@ -470,6 +753,16 @@ func (suite *Suite) TestGetEventsForBlockIDs() {
 		0,
 		nil,
 		false,
+		nil,
+		EventQueryModeExecutionNode,
+		DefaultMaxHeightRange,
+		0,
+		0,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	// execute request
@@ -556,6 +849,16 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			0,
 			nil,
 			false,
+			nil,
+			EventQueryModeExecutionNode,
+			DefaultMaxHeightRange,
+			0,
+			0,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		)
 
 		_, err := backend.GetEventsForHeightRange(ctx, string(flow.EventAccountCreated), maxHeight, minHeight)
@@ -586,6 +889,16 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			0,
 			nil,
 			false,
+			nil,
+			EventQueryModeExecutionNode,
+			DefaultMaxHeightRange,
+			0,
+			0,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		)
 
 		// execute request
@@ -615,6 +928,16 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			0,
 			nil,
 			false,
+			nil,
+			EventQueryModeExecutionNode,
+			DefaultMaxHeightRange,
+			0,
+			0,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		)
 
 		actualResp, err := backend.GetEventsForHeightRange(ctx, string(flow.EventAccountCreated), minHeight, maxHeight)
@@ -626,6 +949,267 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 
 }
 
+// TestGetEventsFromLocalIndex tests that events are looked up in the local event index instead of being
+// requested from the execution node when running in local index mode
+func (suite *Suite) TestGetEventsFromLocalIndex() {
+	ctx := context.Background()
+	eventType := flow.EventAccountCreated
+	var minHeight uint64 = 5
+	var maxHeight uint64 = 10
+	var lastIndexedHeight uint64 = 8
+
+	// the sealed height is above the indexed height
+	sealed := unittest.BlockHeaderFixture()
+	sealed.Height = maxHeight
+	suite.snapshot.On("Head").Return(&sealed, nil)
+
+	eventIndex := new(storagemock.EventIndex)
+	eventIndex.On("LastIndexedHeight").Return(lastIndexedHeight, nil)
+
+	headers := make(map[uint64]*flow.Header)
+	for height := minHeight; height <= lastIndexedHeight; height++ {
+		header := unittest.BlockHeaderFixture()
+		header.Height = height
+		headers[height] = &header
+		suite.headers.On("ByHeight", height).Return(&header, nil).Once()
+	}
+
+	// only some of the blocks emitted events of the requested type
+	events := map[uint64][]flow.Event{
+		minHeight + 1: getEvents(2),
+		minHeight + 3: getEvents(1),
+	}
+	eventIndex.On("ByHeightRangeEventType", minHeight, lastIndexedHeight, eventType).Return(events, nil).Once()
+
+	backend := New(
+		suite.state,
+		suite.execClient,
+		nil, nil,
+		suite.headers, nil, nil,
+		suite.chainID,
+		metrics.NewNoopCollector(),
+		0,
+		nil,
+		false,
+		eventIndex,
+		EventQueryModeLocalIndex,
+		DefaultMaxHeightRange,
+		0,
+		0,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	suite.Run("height range", func() {
+		actual, err := backend.GetEventsForHeightRange(ctx, string(eventType), minHeight, maxHeight)
+		suite.checkResponse(actual, err)
+
+		// the result is truncated to the indexed height
+		suite.Require().Len(actual, int(lastIndexedHeight-minHeight+1))
+		for i, blockEvents := range actual {
+			height := minHeight + uint64(i)
+			suite.Require().Equal(headers[height].ID(), blockEvents.BlockID)
+			suite.Require().Equal(height, blockEvents.BlockHeight)
+			suite.Require().Equal(events[height], blockEvents.Events)
+		}
+	})
+
+	suite.Run("block not indexed yet", func() {
+		header := unittest.BlockHeaderFixture()
+		header.Height = lastIndexedHeight + 1
+		suite.headers.On("ByBlockID", header.ID()).Return(&header, nil).Once()
+
+		_, err := backend.GetEventsForBlockIDs(ctx, string(eventType), []flow.Identifier{header.ID()})
+		suite.Require().Error(err)
+		suite.Require().Equal(codes.NotFound, status.Code(err))
+	})
+
+	// the execution node was never called
+	suite.assertAllExpectations()
+	eventIndex.AssertExpectations(suite.T())
+}
+
+// TestGetEventsMaxHeightRange tests that event queries spanning more blocks than allowed are rejected
+func (suite *Suite) TestGetEventsMaxHeightRange() {
+	ctx := context.Background()
+
+	backend := New(
+		suite.state,
+		suite.execClient,
+		nil, nil, nil, nil, nil,
+		suite.chainID,
+		metrics.NewNoopCollector(),
+		0,
+		nil,
+		false,
+		nil,
+		EventQueryModeExecutionNode,
+		10,
+		0,
+		0,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	_, err := backend.GetEventsForHeightRange(ctx, string(flow.EventAccountCreated), 1, 11)
+	suite.Require().Error(err)
+	suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+
+	_, err = backend.GetEventsForBlockIDs(ctx, string(flow.EventAccountCreated), getIDs(11))
+	suite.Require().Error(err)
+	suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+
+	// the execution node was never called
+	suite.assertAllExpectations()
+}
+
+func (suite *Suite) TestSubscribeBlockHeaders() {
+	var startHeight uint64 = 5
+
+	headers := make([]flow.Header, 4)
+	for i := range headers {
+		headers[i] = unittest.BlockHeaderFixture()
+		headers[i].Height = startHeight + uint64(i)
+		suite.headers.On("ByHeight", headers[i].Height).Return(&headers[i], nil).Once()
+	}
+
+	// the first three headers are already finalized when subscribing, the last one is finalized
+	// after the backfill has completed
+	suite.snapshot.On("Head").Return(&headers[2], nil).Once()
+	suite.snapshot.On("Head").Return(&headers[3], nil)
+
+	backend := New(
+		suite.state,
+		nil, nil, nil,
+		suite.headers, nil, nil,
+		suite.chainID,
+		metrics.NewNoopCollector(),
+		0,
+		nil,
+		false,
+		nil,
+		EventQueryModeExecutionNode,
+		DefaultMaxHeightRange,
+		0,
+		0,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var received []flow.Identifier
+	err := backend.SubscribeBlockHeaders(ctx, startHeight, func(header *flow.Header) error {
+		received = append(received, header.ID())
+		switch header.Height {
+		case headers[2].Height:
+			backend.NotifyFinalizedBlockHeight(headers[3].Height)
+		case headers[3].Height:
+			cancel()
+		}
+		return nil
+	})
+	suite.Require().True(errors.Is(err, context.Canceled))
+
+	suite.Require().Len(received, len(headers))
+	for i, header := range headers {
+		suite.Require().Equal(header.ID(), received[i])
+	}
+
+	suite.assertAllExpectations()
+}
+
+func (suite *Suite) TestSubscribeEvents() {
+	var startHeight uint64 = 5
+	var sealedHeight uint64 = 7
+
+	blocks := make([]flow.Block, sealedHeight-startHeight+1)
+	blockIDs := make([]flow.Identifier, len(blocks))
+	for i := range blocks {
+		blocks[i] = unittest.BlockFixture()
+		blocks[i].Header.Height = startHeight + uint64(i)
+		blockIDs[i] = blocks[i].ID()
+		suite.blocks.On("ByHeight", blocks[i].Header.Height).Return(&blocks[i], nil).Once()
+	}
+
+	sealed := unittest.BlockHeaderFixture()
+	sealed.Height = sealedHeight
+	suite.snapshot.On("Head").Return(&sealed, nil)
+
+	events := getEvents(2)
+	exeResults := make([]*execproto.GetEventsForBlockIDsResponse_Result, len(blocks))
+	for i := range blocks {
+		exeResults[i] = &execproto.GetEventsForBlockIDsResponse_Result{
+			BlockId:     convert.IdentifierToMessage(blockIDs[i]),
+			BlockHeight: blocks[i].Header.Height,
+			Events:      convert.EventsToMessages(events),
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// all sealed blocks are requested from the execution node at once
+	exeReq := &execproto.GetEventsForBlockIDsRequest{
+		BlockIds: convert.IdentifiersToMessages(blockIDs),
+		Type:     string(flow.EventAccountCreated),
+	}
+	suite.execClient.
+		On("GetEventsForBlockIDs", ctx, exeReq).
+		Return(&execproto.GetEventsForBlockIDsResponse{Results: exeResults}, nil).
+		Once()
+
+	backend := New(
+		suite.state,
+		suite.execClient,
+		nil, suite.blocks, nil, nil, nil,
+		suite.chainID,
+		metrics.NewNoopCollector(),
+		0,
+		nil,
+		false,
+		nil,
+		EventQueryModeExecutionNode,
+		DefaultMaxHeightRange,
+		0,
+		0,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	var received []flow.BlockEvents
+	err := backend.SubscribeEvents(ctx, string(flow.EventAccountCreated), startHeight, func(blockEvents flow.BlockEvents) error {
+		received = append(received, blockEvents)
+		if blockEvents.BlockHeight == sealedHeight {
+			cancel()
+		}
+		return nil
+	})
+	suite.Require().True(errors.Is(err, context.Canceled))
+
+	suite.Require().Len(received, len(blocks))
+	for i, blockEvents := range received {
+		suite.Require().Equal(blockIDs[i], blockEvents.BlockID)
+		suite.Require().Equal(blocks[i].Header.Height, blockEvents.BlockHeight)
+		suite.Require().Equal(events, blockEvents.Events)
+	}
+
+	suite.assertAllExpectations()
+}
+
 func (suite *Suite) TestGetAccount() {
 
 	address, err := suite.chainID.Chain().NewAddressGenerator().NextAddress()
@@ -676,6 +1260,16 @@ func (suite *Suite) TestGetAccount() {
 		0,
 		nil,
 		false,
+		nil,
+		EventQueryModeExecutionNode,
+		DefaultMaxHeightRange,
+		0,
+		0,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	suite.Run("happy path - valid request and valid response", func() {
@@ -735,6 +1329,16 @@ func (suite *Suite) TestGetAccountAtBlockHeight() {
 		0,
 		nil,
 		false,
+		nil,
+		EventQueryModeExecutionNode,
+		DefaultMaxHeightRange,
+		0,
+		0,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	suite.Run("happy path - valid request and valid response", func() {
@@ -757,6 +1361,16 @@ func (suite *Suite) TestGetNetworkParameters() {
 		0,
 		nil,
 		false,
+		nil,
+		EventQueryModeExecutionNode,
+		DefaultMaxHeightRange,
+		0,
+		0,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	params := backend.GetNetworkParameters(context.Background())
@@ -7,10 +7,14 @@ import (
 	"net"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hashicorp/go-multierror"
 	accessproto "github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
 	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	"github.com/onflow/flow-go/access"
@@ -23,9 +27,18 @@ import (
 
 const collectionNodesToTry uint = 3
 
+// DefaultAccountTransactionsPageSize is the number of transactions returned per page of an account
+// transaction query if no limit is requested.
+const DefaultAccountTransactionsPageSize uint = 50
+
+// MaxAccountTransactionsPageSize is the maximum number of transactions returned per page of an account
+// transaction query.
+const MaxAccountTransactionsPageSize uint = 500
+
 type backendTransactions struct {
 	staticCollectionRPC  accessproto.AccessAPIClient // rpc client tied to a fixed collection node
 	executionRPC         execproto.ExecutionAPIClient
+	executionQuorum      *executionQuorum // nil if execution node responses are not verified
 	transactions         storage.Transactions
 	collections          storage.Collections
 	blocks               storage.Blocks
@@ -36,6 +49,8 @@ type backendTransactions struct {
 	retry                *Retry
 	collectionGRPCPort   uint
 	connFactory          ConnectionFactory
+	notifier             *heightNotifier
+	accountTransactions  storage.AccountTransactions // nil if transactions are not indexed by account
 }
 
 // SendTransaction forwards the transaction to the collection node
@@ -183,6 +198,53 @@ func (b *backendTransactions) GetTransaction(_ context.Context, txID flow.Identi
 	return tx, nil
 }
 
+// GetTransactionsByAccount returns a page of the transactions of finalized blocks within the given
+// height range (inclusive) that involve the given account as payer, proposer or authorizer, ordered
+// by block height.
+func (b *backendTransactions) GetTransactionsByAccount(
+	_ context.Context,
+	address flow.Address,
+	startHeight uint64,
+	endHeight uint64,
+	pagination access.Pagination,
+) ([]access.AccountTransaction, error) {
+
+	if b.accountTransactions == nil {
+		return nil, status.Errorf(codes.Unimplemented, "transactions are not indexed by account on this node")
+	}
+
+	if endHeight < startHeight {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid start or end height")
+	}
+
+	limit := pagination.Limit
+	if limit == 0 {
+		limit = DefaultAccountTransactionsPageSize
+	}
+	if limit > MaxAccountTransactionsPageSize {
+		return nil, status.Errorf(codes.InvalidArgument, "page size must not exceed %d", MaxAccountTransactionsPageSize)
+	}
+
+	indexed, err := b.accountTransactions.ByAddress(address, startHeight, endHeight, pagination.Offset, limit)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to look up transactions of account: %v", err)
+	}
+
+	results := make([]access.AccountTransaction, 0, len(indexed))
+	for _, entry := range indexed {
+		tx, err := b.transactions.ByID(entry.TransactionID)
+		if err != nil {
+			return nil, convertStorageError(err)
+		}
+		results = append(results, access.AccountTransaction{
+			Transaction: tx,
+			BlockHeight: entry.Height,
+		})
+	}
+
+	return results, nil
+}
+
 func (b *backendTransactions) GetTransactionResult(
 	ctx context.Context,
 	txID flow.Identifier,
@@ -193,25 +255,91 @@ func (b *backendTransactions) GetTransactionResult(
 		return nil, convertStorageError(err)
 	}
 
+	return b.transactionResult(ctx, tx)
+}
+
+// SubscribeTransactionStatus streams the result of the given transaction to the handler every time
+// its status changes, starting with the current status. The status is re-evaluated whenever a new
+// block is finalized. The subscription ends once the transaction is sealed or expired, when the
+// context is cancelled or when the handler returns an error.
+func (b *backendTransactions) SubscribeTransactionStatus(
+	ctx context.Context,
+	txID flow.Identifier,
+	handler access.TransactionResultHandler,
+) error {
+	// look up transaction from storage
+	tx, err := b.transactions.ByID(txID)
+	if err != nil {
+		return convertStorageError(err)
+	}
+
+	lastStatus := flow.TransactionStatusUnknown
+
+	for {
+		// grab the notification channel before deriving the status, so that we can not miss a
+		// block being finalized in between
+		notified := b.notifier.Channel()
+
+		result, err := b.transactionResult(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		if result.Status != lastStatus {
+			err = handler(result)
+			if err != nil {
+				return err
+			}
+			lastStatus = result.Status
+		}
+
+		// sealed and expired are final, the status of the transaction will not change anymore
+		if lastStatus == flow.TransactionStatusSealed || lastStatus == flow.TransactionStatusExpired {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-notified:
+		}
+	}
+}
+
+// transactionResult looks up the execution result of the given transaction and derives its
+// status from the current protocol state.
+func (b *backendTransactions) transactionResult(
+	ctx context.Context,
+	tx *flow.TransactionBody,
+) (*access.TransactionResult, error) {
+	txID := tx.ID()
+
 	// get events for the transaction
-	executed, events, statusCode, txError, err := b.lookupTransactionResult(ctx, txID)
+	result, err := b.lookupTransactionResult(ctx, txID)
 	if err != nil {
 		return nil, convertStorageError(err)
 	}
 
 	// derive status of the transaction
-	status, err := b.DeriveTransactionStatus(tx, executed)
+	status, err := b.DeriveTransactionStatus(tx, result != nil)
 	if err != nil {
 		return nil, convertStorageError(err)
 	}
 
+	if result == nil {
+		return &access.TransactionResult{
+			Status: status,
+		}, nil
+	}
+
 	// TODO: Set correct values for StatusCode and ErrorMessage
 
 	return &access.TransactionResult{
-		Status:       status,
-		StatusCode:   uint(statusCode),
-		Events:       events,
-		ErrorMessage: txError,
+		Status:          status,
+		StatusCode:      uint(result.StatusCode),
+		Events:          convert.MessagesToEvents(result.Events),
+		ErrorMessage:    result.ErrorMessage,
+		ComputationUsed: result.ComputationUsed,
 	}, nil
 }
 
@@ -286,35 +414,37 @@ func (b *backendTransactions) lookupBlock(txID flow.Identifier) (*flow.Block, er
 	return block, nil
 }
 
+// lookupTransactionResult returns the result of the transaction from the execution nodes, or nil if
+// the transaction was not executed yet.
 func (b *backendTransactions) lookupTransactionResult(
 	ctx context.Context,
 	txID flow.Identifier,
-) (bool, []flow.Event, uint32, string, error) {
+) (*executionTransactionResult, error) {
 
 	// find the block ID for the transaction
 	block, err := b.lookupBlock(txID)
 	if err != nil {
 		if errors.Is(err, storage.ErrNotFound) {
 			// access node may not have the block if it hasn't yet been finalized
-			return false, nil, 0, "", nil
+			return nil, nil
 		}
-		return false, nil, 0, "", convertStorageError(err)
+		return nil, convertStorageError(err)
 	}
 
 	blockID := block.ID()
 
-	events, txStatus, message, err := b.getTransactionResultFromExecutionNode(ctx, blockID[:], txID[:])
+	result, err := b.getTransactionResultFromExecutionNode(ctx, blockID[:], txID[:])
 	if err != nil {
 		if status.Code(err) == codes.NotFound {
 			// No result yet, indicate that it has not been executed
-			return false, nil, 0, "", nil
+			return nil, nil
 		}
 		// Other Error trying to retrieve the result, return with err
-		return false, nil, 0, "", err
+		return nil, err
 	}
 
 	// considered executed as long as some result is returned, even if it's an error message
-	return true, events, txStatus, message, nil
+	return result, nil
 }
 
 func (b *backendTransactions) registerTransactionForRetry(tx *flow.TransactionBody) {
@@ -330,7 +460,7 @@ func (b *backendTransactions) getTransactionResultFromExecutionNode(
 	ctx context.Context,
 	blockID []byte,
 	transactionID []byte,
-) ([]flow.Event, uint32, string, error) {
+) (*executionTransactionResult, error) {
 
 	// create an execution API request for events at blockID and transactionID
 	req := execproto.GetTransactionResultRequest{
@@ -338,21 +468,72 @@ func (b *backendTransactions) getTransactionResultFromExecutionNode(
 		TransactionId: transactionID,
 	}
 
+	if b.executionQuorum != nil {
+		// call a quorum of execution nodes
+		agreed, err := b.executionQuorum.query(ctx, "GetTransactionResult", []flow.Identifier{flow.HashToID(blockID)},
+			func(ctx context.Context, client execproto.ExecutionAPIClient) (proto.Message, error) {
+				return getTransactionResult(ctx, client, &req)
+			})
+		if err != nil {
+			return nil, err
+		}
+		return agreed.(*executionTransactionResult), nil
+	}
+
 	// call the execution node gRPC
-	resp, err := b.executionRPC.GetTransactionResult(ctx, &req)
+	result, err := getTransactionResult(ctx, b.executionRPC, &req)
 	if err != nil {
 		if status.Code(err) == codes.NotFound {
-			return nil, 0, "", err
+			return nil, err
 		}
 
-		return nil, 0, "", status.Errorf(codes.Internal, "failed to retrieve result from execution node: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to retrieve result from execution node: %v", err)
 	}
 
-	events := convert.MessagesToEvents(resp.GetEvents())
+	return result, nil
+}
 
-	return events, resp.GetStatusCode(), resp.GetErrorMessage(), nil
+// getTransactionResult requests the result of a transaction from an execution node, together with
+// the computation used, which the execution node returns in a response header.
+func getTransactionResult(
+	ctx context.Context,
+	client execproto.ExecutionAPIClient,
+	req *execproto.GetTransactionResultRequest,
+) (*executionTransactionResult, error) {
+
+	var header metadata.MD
+	resp, err := client.GetTransactionResult(ctx, req, grpc.Header(&header))
+	if err != nil {
+		return nil, err
+	}
+
+	computationUsed, err := convert.HeaderToComputationUsed(header)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read computation used: %v", err)
+	}
+
+	return &executionTransactionResult{
+		StatusCode:      resp.GetStatusCode(),
+		ErrorMessage:    resp.GetErrorMessage(),
+		Events:          resp.GetEvents(),
+		ComputationUsed: computationUsed,
+	}, nil
+}
+
+// executionTransactionResult is the result of a transaction returned by an execution node, including
+// the computation used from the response header. It is a protobuf message, so that a quorum of
+// execution nodes has to agree on the computation used as well.
+type executionTransactionResult struct {
+	StatusCode      uint32            `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	ErrorMessage    string            `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	Events          []*entities.Event `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
+	ComputationUsed uint64            `protobuf:"varint,4,opt,name=computation_used,json=computationUsed,proto3" json:"computation_used,omitempty"`
 }
 
+func (m *executionTransactionResult) Reset()         { *m = executionTransactionResult{} }
+func (m *executionTransactionResult) String() string { return proto.CompactTextString(m) }
+func (*executionTransactionResult) ProtoMessage()    {}
+
 func (b *backendTransactions) NotifyFinalizedBlockHeight(height uint64) {
 	b.retry.Retry(height)
 }
@@ -5,14 +5,16 @@ import (
 	"io"
 
 	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/onflow/flow/protobuf/go/flow/execution"
 	"google.golang.org/grpc"
 
 	grpcutils "github.com/onflow/flow-go/utils/grpc"
 )
 
-// ConnectionFactory is used to create an access api client
+// ConnectionFactory is used to create access and execution api clients
 type ConnectionFactory interface {
 	GetAccessAPIClient(address string) (access.AccessAPIClient, io.Closer, error)
+	GetExecutionAPIClient(address string) (execution.ExecutionAPIClient, io.Closer, error)
 }
 
 type ConnectionFactoryImpl struct {
@@ -39,3 +41,13 @@ func (cf *ConnectionFactoryImpl) GetAccessAPIClient(address string) (access.Acce
 	closer := io.Closer(conn)
 	return accessAPIClient, closer, nil
 }
+
+func (cf *ConnectionFactoryImpl) GetExecutionAPIClient(address string) (execution.ExecutionAPIClient, io.Closer, error) {
+	conn, err := cf.createConnection(address)
+	if err != nil {
+		return nil, nil, err
+	}
+	executionAPIClient := execution.NewExecutionAPIClient(conn)
+	closer := io.Closer(conn)
+	return executionAPIClient, closer, nil
+}
@@ -0,0 +1,329 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+// executionRequest sends a request to a single execution node.
+type executionRequest func(ctx context.Context, client execproto.ExecutionAPIClient) (proto.Message, error)
+
+// executionQuorum sends requests to several execution nodes and only accepts a response once a
+// quorum of execution nodes returned identical responses. The quorum has to be reached among execution
+// nodes whose stored receipts commit to the same results of the requested blocks, which are the sealed
+// results for blocks that are sealed. Responses that disagree with the quorum are discarded and
+// reported to the metrics.
+type executionQuorum struct {
+	state       protocol.State
+	connFactory ConnectionFactory
+	grpcPort    uint
+	quorum      uint
+	receipts    storage.ExecutionReceipts
+	seals       storage.Seals
+	metrics     module.AccessMetrics
+}
+
+// executionResponse groups the execution nodes that returned identical responses.
+type executionResponse struct {
+	response proto.Message
+	err      error // a deterministic error returned instead of a response
+	nodes    []string
+}
+
+func (r *executionResponse) matches(response proto.Message, err error) bool {
+	if r.err != nil || err != nil {
+		return r.err != nil && err != nil && status.Code(r.err) == status.Code(err)
+	}
+	return proto.Equal(r.response, response)
+}
+
+// query sends the request to execution nodes for the given blocks until a quorum of execution nodes
+// committed to the same results returned identical responses, and returns the agreed upon response.
+// The groups of execution nodes committed to different results are queried one after the other, the
+// largest group first.
+func (q *executionQuorum) query(
+	ctx context.Context,
+	method string,
+	blockIDs []flow.Identifier,
+	request executionRequest,
+) (proto.Message, error) {
+
+	groups, err := q.executionNodes(blockIDs)
+	if err != nil {
+		q.metrics.ExecutionQuorumFailed(method)
+		return nil, err
+	}
+
+	for _, addrs := range groups {
+		agreed, err := q.queryGroup(ctx, method, addrs, request)
+		if err != nil {
+			return nil, err
+		}
+		if agreed == nil {
+			continue
+		}
+		if agreed.err != nil {
+			return nil, agreed.err
+		}
+		return agreed.response, nil
+	}
+
+	q.metrics.ExecutionQuorumFailed(method)
+	return nil, status.Errorf(codes.Unavailable,
+		"failed to reach a quorum of %d execution nodes committed to the same results", q.quorum)
+}
+
+// queryGroup sends the request to the given execution nodes, which committed to the same results,
+// until a quorum of them returned identical responses. It returns the agreed upon response, or nil
+// if the execution nodes can not reach a quorum.
+func (q *executionQuorum) queryGroup(
+	ctx context.Context,
+	method string,
+	addrs []string,
+	request executionRequest,
+) (*executionResponse, error) {
+
+	var responses []*executionResponse
+	var agreed *executionResponse
+	next := uint(0)
+	for {
+		agreed = nil
+		for _, r := range responses {
+			if agreed == nil || len(r.nodes) > len(agreed.nodes) {
+				agreed = r
+			}
+		}
+
+		votes := uint(0)
+		if agreed != nil {
+			votes = uint(len(agreed.nodes))
+		}
+		if votes >= q.quorum {
+			break
+		}
+
+		// only query as many additional execution nodes as are needed to reach the quorum
+		missing := q.quorum - votes
+		if next+missing > uint(len(addrs)) {
+			return nil, nil
+		}
+
+		batch := addrs[next : next+missing]
+		next += missing
+
+		var err error
+		responses, err = q.queryNodes(ctx, batch, request, responses)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// flag all execution nodes that disagreed with the quorum
+	for _, r := range responses {
+		if r == agreed {
+			continue
+		}
+		for range r.nodes {
+			q.metrics.ExecutionResponseMismatch(method)
+		}
+	}
+
+	return agreed, nil
+}
+
+// queryNodes sends the request to the given execution nodes concurrently and adds their responses
+// to the given list of responses. Execution nodes that could not be reached are skipped.
+func (q *executionQuorum) queryNodes(
+	ctx context.Context,
+	addrs []string,
+	request executionRequest,
+	responses []*executionResponse,
+) ([]*executionResponse, error) {
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, addr := range addrs {
+		wg.Add(1)
+		go func(addr string) {
+			defer wg.Done()
+
+			response, err := q.queryNode(ctx, addr, request)
+			if err != nil && !isDeterministicError(err) {
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			for _, r := range responses {
+				if r.matches(response, err) {
+					r.nodes = append(r.nodes, addr)
+					return
+				}
+			}
+			responses = append(responses, &executionResponse{
+				response: response,
+				err:      err,
+				nodes:    []string{addr},
+			})
+		}(addr)
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	return responses, nil
+}
+
+func (q *executionQuorum) queryNode(ctx context.Context, addr string, request executionRequest) (proto.Message, error) {
+
+	// TODO: Use a connection pool to cache connections
+	client, conn, err := q.connFactory.GetExecutionAPIClient(addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to execution node at %s: %w", addr, err)
+	}
+	defer conn.Close()
+
+	return request(ctx, client)
+}
+
+// executionNodes returns the addresses of the execution nodes to query for the given blocks, grouped
+// by the results of the blocks they committed to, with the largest group first and in random order
+// within a group. Only groups large enough to reach the quorum are returned. If there is none, the
+// request fails rather than falling back to execution nodes that might have executed the blocks
+// differently.
+func (q *executionQuorum) executionNodes(blockIDs []flow.Identifier) ([][]string, error) {
+
+	identities, err := q.state.Final().Identities(filter.HasRole(flow.RoleExecution))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not retrieve execution nodes: %v", err)
+	}
+
+	results := make([]map[flow.Identifier]flow.Identifier, 0, len(blockIDs))
+	for _, blockID := range blockIDs {
+		executors, err := q.executors(blockID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "could not look up executors of block %x: %v", blockID, err)
+		}
+		results = append(results, executors)
+	}
+
+	// group the execution nodes by the results of all blocks they committed to
+	groups := make(map[flow.Identifier]flow.IdentityList)
+	for _, identity := range identities {
+		resultIDs := make([]flow.Identifier, 0, len(results))
+		for _, executors := range results {
+			resultID, ok := executors[identity.NodeID]
+			if !ok {
+				break
+			}
+			resultIDs = append(resultIDs, resultID)
+		}
+		if len(resultIDs) < len(results) {
+			continue
+		}
+		key := flow.MakeID(resultIDs)
+		groups[key] = append(groups[key], identity)
+	}
+
+	largest := uint(0)
+	eligible := make([]flow.IdentityList, 0, len(groups))
+	for _, group := range groups {
+		if group.Count() > largest {
+			largest = group.Count()
+		}
+		if group.Count() >= q.quorum {
+			eligible = append(eligible, group)
+		}
+	}
+	if len(eligible) == 0 {
+		return nil, status.Errorf(codes.Unavailable,
+			"only %d execution nodes committed to the same results of the requested blocks, %d required",
+			largest, q.quorum)
+	}
+	sort.Slice(eligible, func(i, j int) bool {
+		return eligible[i].Count() > eligible[j].Count()
+	})
+
+	// convert the node addresses of the execution nodes to the GRPC address
+	addrs := make([][]string, 0, len(eligible))
+	for _, group := range eligible {
+		group = group.Sample(group.Count())
+		groupAddrs := make([]string, 0, len(group))
+		for _, id := range group {
+			hostnameOrIP, _, err := net.SplitHostPort(id.Address)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "invalid address of execution node %x: %v", id.NodeID, err)
+			}
+			groupAddrs = append(groupAddrs, fmt.Sprintf("%s:%d", hostnameOrIP, q.grpcPort))
+		}
+		addrs = append(addrs, groupAddrs)
+	}
+
+	return addrs, nil
+}
+
+// executors returns the IDs of the results of the given block that execution nodes committed to, by
+// the IDs of the execution nodes. If the block is sealed, only execution nodes that committed to the
+// sealed result are included. Execution nodes that committed to several results of the block are
+// excluded.
+func (q *executionQuorum) executors(blockID flow.Identifier) (map[flow.Identifier]flow.Identifier, error) {
+
+	receipts, err := q.receipts.AllByBlockID(blockID)
+	if err != nil {
+		return nil, fmt.Errorf("could not look up execution receipts: %w", err)
+	}
+
+	seal, err := q.seals.BySealedBlockID(blockID)
+	sealed := err == nil
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("could not look up seal: %w", err)
+	}
+
+	executors := make(map[flow.Identifier]flow.Identifier, len(receipts))
+	conflicting := make(map[flow.Identifier]struct{})
+	for _, receipt := range receipts {
+		resultID := receipt.ExecutionResult.ID()
+		committed, ok := executors[receipt.ExecutorID]
+		if ok && committed != resultID {
+			conflicting[receipt.ExecutorID] = struct{}{}
+		}
+		executors[receipt.ExecutorID] = resultID
+	}
+	for executorID, resultID := range executors {
+		_, ok := conflicting[executorID]
+		if ok || (sealed && resultID != seal.ResultID) {
+			delete(executors, executorID)
+		}
+	}
+
+	return executors, nil
+}
+
+// isDeterministicError returns true if the error is an answer of the execution node which honest
+// execution nodes agree on, rather than a failure to answer.
+func isDeterministicError(err error) bool {
+	switch status.Code(err) {
+	case codes.NotFound, codes.InvalidArgument:
+		return true
+	default:
+		return false
+	}
+}
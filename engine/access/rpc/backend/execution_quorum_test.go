@@ -0,0 +1,332 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	access "github.com/onflow/flow-go/engine/access/mock"
+	backendmock "github.com/onflow/flow-go/engine/access/rpc/backend/mock"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	modulemock "github.com/onflow/flow-go/module/mock"
+	"github.com/onflow/flow-go/storage"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+const executionGRPCPort = 9000
+
+type mockCloser struct{}
+
+func (mc *mockCloser) Close() error { return nil }
+
+// setupExecutionNodes creates an execution node client for each of the given identities and returns a
+// connection factory that connects to them.
+func (suite *Suite) setupExecutionNodes(identities flow.IdentityList) (*backendmock.ConnectionFactory, []*access.ExecutionAPIClient) {
+	suite.snapshot.On("Identities", mock.Anything).Return(identities, nil)
+
+	connFactory := new(backendmock.ConnectionFactory)
+	clients := make([]*access.ExecutionAPIClient, 0, len(identities))
+	for _, identity := range identities {
+		host, _, err := net.SplitHostPort(identity.Address)
+		suite.Require().NoError(err)
+
+		client := new(access.ExecutionAPIClient)
+		connFactory.On("GetExecutionAPIClient", fmt.Sprintf("%s:%d", host, executionGRPCPort)).
+			Return(client, &mockCloser{}, nil).Maybe()
+		clients = append(clients, client)
+	}
+
+	return connFactory, clients
+}
+
+// executionReceipts returns execution receipts of the given execution nodes, committing to the given result.
+func executionReceipts(identities flow.IdentityList, result *flow.ExecutionResult) []*flow.ExecutionReceipt {
+	receipts := make([]*flow.ExecutionReceipt, 0, len(identities))
+	for _, identity := range identities {
+		receipt := unittest.ExecutionReceiptFixture()
+		receipt.ExecutorID = identity.NodeID
+		receipt.ExecutionResult = *result
+		receipts = append(receipts, receipt)
+	}
+	return receipts
+}
+
+// unsealedStorage returns receipt and seal storage in which all given execution nodes committed to the
+// same result for the given block, which is not sealed yet.
+func unsealedStorage(blockID flow.Identifier, identities flow.IdentityList) (*storagemock.ExecutionReceipts, *storagemock.Seals) {
+	result := unittest.ExecutionResultFixture()
+	result.BlockID = blockID
+
+	receipts := new(storagemock.ExecutionReceipts)
+	receipts.On("AllByBlockID", blockID).Return(executionReceipts(identities, result), nil)
+	seals := new(storagemock.Seals)
+	seals.On("BySealedBlockID", blockID).Return(nil, storage.ErrNotFound)
+
+	return receipts, seals
+}
+
+func (suite *Suite) newQuorumBackend(
+	connFactory ConnectionFactory,
+	quorum uint,
+	accessMetrics *modulemock.AccessMetrics,
+	receipts storage.ExecutionReceipts,
+	seals storage.Seals,
+) *Backend {
+	return New(
+		suite.state,
+		nil,
+		nil,
+		suite.blocks,
+		suite.headers,
+		suite.collections,
+		suite.transactions,
+		suite.chainID,
+		metrics.NewNoopCollector(),
+		0,
+		connFactory,
+		false,
+		nil,
+		EventQueryModeExecutionNode,
+		DefaultMaxHeightRange,
+		executionGRPCPort,
+		quorum,
+		accessMetrics,
+		nil,
+		nil,
+		receipts,
+		seals,
+	)
+}
+
+// TestExecuteScriptWithExecutionQuorum tests that a script result is only accepted once a quorum of
+// execution nodes returned the same value.
+func (suite *Suite) TestExecuteScriptWithExecutionQuorum() {
+	ctx := context.Background()
+	blockID := unittest.IdentifierFixture()
+	script := []byte("dummy script")
+
+	identities := unittest.IdentityListFixture(3, unittest.WithRole(flow.RoleExecution))
+	connFactory, clients := suite.setupExecutionNodes(identities)
+
+	req := &execproto.ExecuteScriptAtBlockIDRequest{
+		BlockId: blockID[:],
+		Script:  script,
+	}
+	clients[0].On("ExecuteScriptAtBlockID", ctx, req).Return(&execproto.ExecuteScriptAtBlockIDResponse{Value: []byte("good")}, nil).Maybe()
+	clients[1].On("ExecuteScriptAtBlockID", ctx, req).Return(&execproto.ExecuteScriptAtBlockIDResponse{Value: []byte("good")}, nil).Maybe()
+	clients[2].On("ExecuteScriptAtBlockID", ctx, req).Return(&execproto.ExecuteScriptAtBlockIDResponse{Value: []byte("bad")}, nil).Maybe()
+
+	// the disagreeing execution node is only flagged if it was queried before the quorum was reached
+	accessMetrics := new(modulemock.AccessMetrics)
+	accessMetrics.On("ExecutionResponseMismatch", "ExecuteScriptAtBlockID").Maybe()
+
+	receipts, seals := unsealedStorage(blockID, identities)
+	backend := suite.newQuorumBackend(connFactory, 2, accessMetrics, receipts, seals)
+
+	value, err := backend.ExecuteScriptAtBlockID(ctx, blockID, script, nil)
+	suite.checkResponse(value, err)
+	suite.Require().Equal([]byte("good"), value)
+
+	accessMetrics.AssertNotCalled(suite.T(), "ExecutionQuorumFailed", mock.Anything)
+	suite.Require().LessOrEqual(len(accessMetrics.Calls), 1)
+}
+
+// TestExecutionQuorumFailed tests that the request fails if the execution nodes disagree.
+func (suite *Suite) TestExecutionQuorumFailed() {
+	ctx := context.Background()
+	blockID := unittest.IdentifierFixture()
+	script := []byte("dummy script")
+
+	identities := unittest.IdentityListFixture(2, unittest.WithRole(flow.RoleExecution))
+	connFactory, clients := suite.setupExecutionNodes(identities)
+
+	req := &execproto.ExecuteScriptAtBlockIDRequest{
+		BlockId: blockID[:],
+		Script:  script,
+	}
+	clients[0].On("ExecuteScriptAtBlockID", ctx, req).Return(&execproto.ExecuteScriptAtBlockIDResponse{Value: []byte("good")}, nil).Once()
+	clients[1].On("ExecuteScriptAtBlockID", ctx, req).Return(&execproto.ExecuteScriptAtBlockIDResponse{Value: []byte("bad")}, nil).Once()
+
+	accessMetrics := new(modulemock.AccessMetrics)
+	accessMetrics.On("ExecutionQuorumFailed", "ExecuteScriptAtBlockID").Once()
+
+	receipts, seals := unsealedStorage(blockID, identities)
+	backend := suite.newQuorumBackend(connFactory, 2, accessMetrics, receipts, seals)
+
+	_, err := backend.ExecuteScriptAtBlockID(ctx, blockID, script, nil)
+	suite.Require().Error(err)
+	suite.Require().Equal(codes.Unavailable, status.Code(err))
+
+	clients[0].AssertExpectations(suite.T())
+	clients[1].AssertExpectations(suite.T())
+	accessMetrics.AssertExpectations(suite.T())
+}
+
+// TestExecutionQuorumQueriesSealedExecutors tests that only execution nodes which committed to the
+// sealed result of a block are queried, and that agreeing errors are returned as is.
+func (suite *Suite) TestExecutionQuorumQueriesSealedExecutors() {
+	ctx := context.Background()
+	blockID := unittest.IdentifierFixture()
+	txID := unittest.IdentifierFixture()
+
+	identities := unittest.IdentityListFixture(3, unittest.WithRole(flow.RoleExecution))
+	connFactory, clients := suite.setupExecutionNodes(identities)
+
+	// the first two execution nodes committed to the sealed result, the third one did not
+	sealed := unittest.ExecutionResultFixture()
+	sealed.BlockID = blockID
+	other := unittest.ExecutionResultFixture()
+	other.BlockID = blockID
+	committed := append(executionReceipts(identities[:2], sealed), executionReceipts(identities[2:], other)...)
+
+	receipts := new(storagemock.ExecutionReceipts)
+	receipts.On("AllByBlockID", blockID).Return(committed, nil)
+	seals := new(storagemock.Seals)
+	seals.On("BySealedBlockID", blockID).Return(&flow.Seal{BlockID: blockID, ResultID: sealed.ID()}, nil)
+
+	accessMetrics := new(modulemock.AccessMetrics)
+	backend := suite.newQuorumBackend(connFactory, 2, accessMetrics, receipts, seals)
+
+	req := &execproto.GetTransactionResultRequest{
+		BlockId:       blockID[:],
+		TransactionId: txID[:],
+	}
+	notFound := status.Errorf(codes.NotFound, "not found")
+	clients[0].On("GetTransactionResult", ctx, req, mock.Anything).Return(nil, notFound).Once()
+	clients[1].On("GetTransactionResult", ctx, req, mock.Anything).Return(nil, notFound).Once()
+
+	_, err := backend.getTransactionResultFromExecutionNode(ctx, blockID[:], txID[:])
+	suite.Require().Error(err)
+	suite.Require().Equal(codes.NotFound, status.Code(err))
+
+	clients[0].AssertExpectations(suite.T())
+	clients[1].AssertExpectations(suite.T())
+	clients[2].AssertNotCalled(suite.T(), "GetTransactionResult", mock.Anything, mock.Anything)
+	accessMetrics.AssertExpectations(suite.T())
+}
+
+// TestExecutionQuorumNotEnoughSealedExecutors tests that the request fails, rather than falling back to
+// other execution nodes, if too few execution nodes committed to the sealed result of a block.
+func (suite *Suite) TestExecutionQuorumNotEnoughSealedExecutors() {
+	ctx := context.Background()
+	blockID := unittest.IdentifierFixture()
+	script := []byte("dummy script")
+
+	identities := unittest.IdentityListFixture(3, unittest.WithRole(flow.RoleExecution))
+	connFactory, clients := suite.setupExecutionNodes(identities)
+
+	// only the first execution node committed to the sealed result
+	sealed := unittest.ExecutionResultFixture()
+	sealed.BlockID = blockID
+	other := unittest.ExecutionResultFixture()
+	other.BlockID = blockID
+	committed := append(executionReceipts(identities[:1], sealed), executionReceipts(identities[1:], other)...)
+
+	receipts := new(storagemock.ExecutionReceipts)
+	receipts.On("AllByBlockID", blockID).Return(committed, nil)
+	seals := new(storagemock.Seals)
+	seals.On("BySealedBlockID", blockID).Return(&flow.Seal{BlockID: blockID, ResultID: sealed.ID()}, nil)
+
+	accessMetrics := new(modulemock.AccessMetrics)
+	accessMetrics.On("ExecutionQuorumFailed", "ExecuteScriptAtBlockID").Once()
+
+	backend := suite.newQuorumBackend(connFactory, 2, accessMetrics, receipts, seals)
+
+	_, err := backend.ExecuteScriptAtBlockID(ctx, blockID, script, nil)
+	suite.Require().Error(err)
+	suite.Require().Equal(codes.Unavailable, status.Code(err))
+
+	for _, client := range clients {
+		client.AssertNotCalled(suite.T(), "ExecuteScriptAtBlockID", mock.Anything, mock.Anything)
+	}
+	accessMetrics.AssertExpectations(suite.T())
+}
+
+// TestExecutionQuorumGroupsUnsealedExecutors tests that, for a block which is not sealed yet, the quorum
+// has to be reached among execution nodes that committed to the same result.
+func (suite *Suite) TestExecutionQuorumGroupsUnsealedExecutors() {
+	ctx := context.Background()
+	blockID := unittest.IdentifierFixture()
+	script := []byte("dummy script")
+
+	identities := unittest.IdentityListFixture(3, unittest.WithRole(flow.RoleExecution))
+	connFactory, clients := suite.setupExecutionNodes(identities)
+
+	// the first execution node committed to a different result than the other two
+	first := unittest.ExecutionResultFixture()
+	first.BlockID = blockID
+	second := unittest.ExecutionResultFixture()
+	second.BlockID = blockID
+	committed := append(executionReceipts(identities[:1], first), executionReceipts(identities[1:], second)...)
+
+	receipts := new(storagemock.ExecutionReceipts)
+	receipts.On("AllByBlockID", blockID).Return(committed, nil)
+	seals := new(storagemock.Seals)
+	seals.On("BySealedBlockID", blockID).Return(nil, storage.ErrNotFound)
+
+	req := &execproto.ExecuteScriptAtBlockIDRequest{
+		BlockId: blockID[:],
+		Script:  script,
+	}
+	clients[1].On("ExecuteScriptAtBlockID", ctx, req).Return(&execproto.ExecuteScriptAtBlockIDResponse{Value: []byte("good")}, nil).Once()
+	clients[2].On("ExecuteScriptAtBlockID", ctx, req).Return(&execproto.ExecuteScriptAtBlockIDResponse{Value: []byte("good")}, nil).Once()
+
+	accessMetrics := new(modulemock.AccessMetrics)
+	backend := suite.newQuorumBackend(connFactory, 2, accessMetrics, receipts, seals)
+
+	value, err := backend.ExecuteScriptAtBlockID(ctx, blockID, script, nil)
+	suite.checkResponse(value, err)
+	suite.Require().Equal([]byte("good"), value)
+
+	clients[0].AssertNotCalled(suite.T(), "ExecuteScriptAtBlockID", mock.Anything, mock.Anything)
+	clients[1].AssertExpectations(suite.T())
+	clients[2].AssertExpectations(suite.T())
+	accessMetrics.AssertExpectations(suite.T())
+}
+
+// TestExecutionQuorumNoCommonUnsealedResult tests that the request fails, for a block which is not sealed
+// yet, if too few execution nodes committed to the same result, even if they would return identical
+// responses. Execution nodes that committed to several results of the block are not queried either.
+func (suite *Suite) TestExecutionQuorumNoCommonUnsealedResult() {
+	ctx := context.Background()
+	blockID := unittest.IdentifierFixture()
+	script := []byte("dummy script")
+
+	identities := unittest.IdentityListFixture(3, unittest.WithRole(flow.RoleExecution))
+	connFactory, clients := suite.setupExecutionNodes(identities)
+
+	// the first two execution nodes committed to different results, the third one to both of them
+	first := unittest.ExecutionResultFixture()
+	first.BlockID = blockID
+	second := unittest.ExecutionResultFixture()
+	second.BlockID = blockID
+	committed := append(executionReceipts(identities[:1], first), executionReceipts(identities[1:2], second)...)
+	committed = append(committed, executionReceipts(identities[2:], first)...)
+	committed = append(committed, executionReceipts(identities[2:], second)...)
+
+	receipts := new(storagemock.ExecutionReceipts)
+	receipts.On("AllByBlockID", blockID).Return(committed, nil)
+	seals := new(storagemock.Seals)
+	seals.On("BySealedBlockID", blockID).Return(nil, storage.ErrNotFound)
+
+	accessMetrics := new(modulemock.AccessMetrics)
+	accessMetrics.On("ExecutionQuorumFailed", "ExecuteScriptAtBlockID").Once()
+
+	backend := suite.newQuorumBackend(connFactory, 2, accessMetrics, receipts, seals)
+
+	_, err := backend.ExecuteScriptAtBlockID(ctx, blockID, script, nil)
+	suite.Require().Error(err)
+	suite.Require().Equal(codes.Unavailable, status.Code(err))
+
+	for _, client := range clients {
+		client.AssertNotCalled(suite.T(), "ExecuteScriptAtBlockID", mock.Anything, mock.Anything)
+	}
+	accessMetrics.AssertExpectations(suite.T())
+}
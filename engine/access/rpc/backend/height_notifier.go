@@ -0,0 +1,38 @@
+package backend
+
+import (
+	"sync"
+)
+
+// heightNotifier broadcasts the arrival of newly finalized blocks to any
+// number of waiting subscribers. Subscribers grab the current notification
+// channel, check the state they are interested in, and then block on the
+// channel. The channel is closed (and replaced) on every notification, which
+// wakes up all subscribers at once without the notifier ever blocking.
+type heightNotifier struct {
+	mu       sync.Mutex
+	notifyCh chan struct{}
+}
+
+func newHeightNotifier() *heightNotifier {
+	return &heightNotifier{
+		notifyCh: make(chan struct{}),
+	}
+}
+
+// Notify wakes up all subscribers currently waiting for a new height.
+func (n *heightNotifier) Notify() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	close(n.notifyCh)
+	n.notifyCh = make(chan struct{})
+}
+
+// Channel returns a channel that is closed on the next notification.
+func (n *heightNotifier) Channel() <-chan struct{} {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.notifyCh
+}
@@ -41,7 +41,8 @@ func (suite *Suite) TestTransactionRetry() {
 	// blockID := block.ID()
 	// Setup Handler + Retry
 	backend := New(suite.state, suite.execClient, suite.colClient, suite.blocks, suite.headers,
-		suite.collections, suite.transactions, suite.chainID, metrics.NewNoopCollector(), 0, nil, false)
+		suite.collections, suite.transactions, suite.chainID, metrics.NewNoopCollector(), 0, nil, false,
+		nil, EventQueryModeExecutionNode, DefaultMaxHeightRange, 0, 0, nil, nil, nil, nil, nil)
 	retry := newRetry().SetBackend(backend).Activate()
 	backend.retry = retry
 
@@ -99,7 +100,8 @@ func (suite *Suite) TestSuccessfulTransactionsDontRetry() {
 
 	// Setup Handler + Retry
 	backend := New(suite.state, suite.execClient, suite.colClient, suite.blocks, suite.headers,
-		suite.collections, suite.transactions, suite.chainID, metrics.NewNoopCollector(), 0, nil, false)
+		suite.collections, suite.transactions, suite.chainID, metrics.NewNoopCollector(), 0, nil, false,
+		nil, EventQueryModeExecutionNode, DefaultMaxHeightRange, 0, 0, nil, nil, nil, nil, nil)
 	retry := newRetry().SetBackend(backend).Activate()
 	backend.retry = retry
 
@@ -108,7 +110,7 @@ func (suite *Suite) TestSuccessfulTransactionsDontRetry() {
 	suite.colClient.On("SendTransaction", mock.Anything, mock.Anything).Return(&access.SendTransactionResponse{}, nil)
 
 	// return not found to return finalized status
-	suite.execClient.On("GetTransactionResult", ctx, &exeEventReq).Return(&exeEventResp, status.Errorf(codes.NotFound, "not found")).Once()
+	suite.execClient.On("GetTransactionResult", ctx, &exeEventReq, mock.Anything).Return(&exeEventResp, status.Errorf(codes.NotFound, "not found")).Once()
 	// first call - when block under test is greater height than the sealed head, but execution node does not know about Tx
 	result, err := backend.GetTransactionResult(ctx, txID)
 	suite.checkResponse(result, err)
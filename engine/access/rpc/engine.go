@@ -31,7 +31,12 @@ type Config struct {
 	HTTPListenAddr string
 	ExecutionAddr  string
 	CollectionAddr string
-	MaxMsgSize     int // In bytes
+	MaxMsgSize     int                    // In bytes
+	EventQueryMode backend.EventQueryMode // where to look up events
+	MaxHeightRange uint                   // maximum number of blocks per event query
+
+	ExecutionGRPCPort uint // the grpc port of all execution nodes
+	ExecutionQuorum   uint // number of execution nodes that must agree on a response, 0 to trust ExecutionAddr
 }
 
 // Engine implements a gRPC server with a simplified version of the Observation API.
@@ -58,6 +63,12 @@ func New(log zerolog.Logger,
 	transactionMetrics module.TransactionMetrics,
 	collectionGRPCPort uint,
 	retryEnabled bool,
+	eventIndex storage.EventIndex,
+	accessMetrics module.AccessMetrics,
+	accountTransactions storage.AccountTransactions,
+	slashingEvidence storage.SlashingEvidence,
+	receipts storage.ExecutionReceipts,
+	seals storage.Seals,
 ) *Engine {
 
 	log = log.With().Str("engine", "rpc").Logger()
@@ -88,6 +99,16 @@ func New(log zerolog.Logger,
 		collectionGRPCPort,
 		&backend.ConnectionFactoryImpl{},
 		retryEnabled,
+		eventIndex,
+		config.EventQueryMode,
+		config.MaxHeightRange,
+		config.ExecutionGRPCPort,
+		config.ExecutionQuorum,
+		accessMetrics,
+		accountTransactions,
+		slashingEvidence,
+		receipts,
+		seals,
 	)
 
 	eng := &Engine{
@@ -99,10 +120,15 @@ func New(log zerolog.Logger,
 		config:     config,
 	}
 
-	accessproto.RegisterAccessAPIServer(
-		eng.grpcServer,
-		access.NewHandler(backend, chainID.Chain()),
-	)
+	handler := access.NewHandler(backend, chainID.Chain())
+
+	accessproto.RegisterAccessAPIServer(eng.grpcServer, handler)
+
+	// Register the streaming extension of the Access API
+	access.RegisterStreamingAccessAPIServer(eng.grpcServer, handler)
+
+	// Register the extension of the Access API
+	access.RegisterExtendedAccessAPIServer(eng.grpcServer, handler)
 
 	// Register legacy gRPC handlers for backwards compatibility, to be removed at a later date
 	legacyaccessproto.RegisterAccessAPIServer(
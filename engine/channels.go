@@ -17,10 +17,14 @@ const (
 	ConsensusCommittee = "consensus-committee"
 	ConsensusCluster   = "consensus-cluster"
 
+	// Channels for distributed key generation
+	DKGCommittee = "dkg-committee"
+
 	// Channels for protocols actively synchronizing state across nodes
-	SyncCommittee = "sync-committee"
-	SyncCluster   = "sync-cluster"
-	SyncExecution = "sync-execution"
+	SyncCommittee   = "sync-committee"
+	SyncCluster     = "sync-cluster"
+	SyncExecution   = "sync-execution"
+	SyncCheckpoints = "sync-checkpoints"
 
 	// Channels for actively pushing entities to subscribers
 	PushTransactions = "push-transactions"
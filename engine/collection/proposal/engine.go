@@ -275,6 +275,42 @@ func (e *Engine) BroadcastProposalWithDelay(header *flow.Header, delay time.Dura
 	return nil
 }
 
+// BroadcastTimeout submits a timeout for the given view to all the collection
+// nodes in our cluster.
+func (e *Engine) BroadcastTimeout(view uint64, highestQC *flow.QuorumCertificate, sigData []byte) error {
+
+	// retrieve all collection nodes in our cluster except for ourselves
+	recipients, err := e.protoState.Final().Identities(filter.And(
+		filter.In(e.cluster),
+		filter.Not(filter.HasNodeID(e.me.NodeID())),
+	))
+	if err != nil {
+		return fmt.Errorf("could not get cluster members: %w", err)
+	}
+
+	// build the timeout message
+	timeout := &messages.ClusterBlockTimeout{
+		View:      view,
+		HighestQC: highestQC,
+		SigData:   sigData,
+	}
+
+	err = e.conduit.Publish(timeout, recipients.NodeIDs()...)
+	if err != nil {
+		return fmt.Errorf("could not broadcast timeout: %w", err)
+	}
+
+	e.log.Debug().
+		Uint64("view", view).
+		Uint64("qc_view", highestQC.View).
+		Str("recipients", fmt.Sprintf("%v", recipients.NodeIDs())).
+		Msg("broadcasting timeout")
+
+	e.engMetrics.MessageSent(metrics.EngineProposal, metrics.MessageClusterBlockTimeout)
+
+	return nil
+}
+
 // process processes events for the proposal engine on the collection node.
 func (e *Engine) process(originID flow.Identifier, event interface{}) error {
 
@@ -302,6 +338,12 @@ func (e *Engine) process(originID flow.Identifier, event interface{}) error {
 		e.engMetrics.MessageReceived(metrics.EngineProposal, metrics.MessageClusterBlockVote)
 		defer e.engMetrics.MessageHandled(metrics.EngineProposal, metrics.MessageClusterBlockVote)
 		return e.onBlockVote(originID, ev)
+	case *messages.ClusterBlockTimeout:
+		// like votes, timeouts are passed directly to HotStuff with no extra
+		// validation by compliance layer, so we don't lock the engine.
+		e.engMetrics.MessageReceived(metrics.EngineProposal, metrics.MessageClusterBlockTimeout)
+		defer e.engMetrics.MessageHandled(metrics.EngineProposal, metrics.MessageClusterBlockTimeout)
+		return e.onBlockTimeout(originID, ev)
 	default:
 		return fmt.Errorf("invalid event type (%T)", event)
 	}
@@ -551,6 +593,24 @@ func (e *Engine) onBlockVote(originID flow.Identifier, vote *messages.ClusterBlo
 	return nil
 }
 
+// onBlockTimeout handles timeouts of other cluster members by passing them to
+// the core consensus algorithm
+func (e *Engine) onBlockTimeout(originID flow.Identifier, timeout *messages.ClusterBlockTimeout) error {
+
+	e.log.Debug().
+		Hex("origin_id", originID[:]).
+		Uint64("view", timeout.View).
+		Msg("received timeout")
+
+	// the timeout message is malformed without a QC, so we drop it here
+	if timeout.HighestQC == nil {
+		return fmt.Errorf("cluster block timeout is missing highest qc")
+	}
+
+	e.hotstuff.SubmitTimeout(originID, timeout.View, timeout.HighestQC, timeout.SigData)
+	return nil
+}
+
 // prunePendingCache prunes the pending block cache by removing any blocks that
 // are below the finalized height.
 func (e *Engine) prunePendingCache() {
@@ -361,3 +361,20 @@ func (suite *Suite) TestReceiveVote() {
 
 	suite.hotstuff.AssertExpectations(suite.T())
 }
+
+func (suite *Suite) TestReceiveTimeout() {
+
+	originID := unittest.IdentifierFixture()
+	timeout := &messages.ClusterBlockTimeout{
+		View:      1,
+		HighestQC: unittest.QuorumCertificateFixture(),
+		SigData:   nil,
+	}
+
+	suite.hotstuff.On("SubmitTimeout", originID, timeout.View, timeout.HighestQC, timeout.SigData).Once()
+
+	err := suite.eng.Process(originID, timeout)
+	suite.Assert().Nil(err)
+
+	suite.hotstuff.AssertExpectations(suite.T())
+}
@@ -0,0 +1,33 @@
+package convert
+
+import (
+	"fmt"
+	"strconv"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// ComputationUsedHeader is the gRPC response header of the execution node's GetTransactionResult that
+// holds the computation used by the transaction, as the response message has no field for it.
+const ComputationUsedHeader = "computation-used"
+
+// ComputationUsedToHeader returns the response header that holds the given computation used.
+func ComputationUsedToHeader(computationUsed uint64) metadata.MD {
+	return metadata.Pairs(ComputationUsedHeader, strconv.FormatUint(computationUsed, 10))
+}
+
+// HeaderToComputationUsed returns the computation used held by the given response header, or 0 if
+// the header does not hold it.
+func HeaderToComputationUsed(header metadata.MD) (uint64, error) {
+	values := header.Get(ComputationUsedHeader)
+	if len(values) == 0 {
+		return 0, nil
+	}
+
+	computationUsed, err := strconv.ParseUint(values[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s header: %w", ComputationUsedHeader, err)
+	}
+
+	return computationUsed, nil
+}
@@ -277,6 +277,47 @@ func (e *Engine) BroadcastProposal(header *flow.Header) error {
 	return e.BroadcastProposalWithDelay(header, 0)
 }
 
+// BroadcastTimeout will propagate a timeout to all non-local consensus nodes.
+func (e *Engine) BroadcastTimeout(view uint64, highestQC *flow.QuorumCertificate, sigData []byte) error {
+
+	log := e.log.With().
+		Uint64("timeout_view", view).
+		Uint64("qc_view", highestQC.View).
+		Hex("qc_block_id", highestQC.BlockID[:]).
+		Logger()
+
+	log.Info().Msg("processing timeout broadcast request from hotstuff")
+
+	// retrieve all consensus nodes without our ID; like hotstuff, we use the
+	// latest finalized state, as timeouts are not tied to a block
+	recipients, err := e.state.Final().Identities(filter.And(
+		filter.HasRole(flow.RoleConsensus),
+		filter.Not(filter.HasNodeID(e.me.NodeID())),
+	))
+	if err != nil {
+		return fmt.Errorf("could not get consensus recipients: %w", err)
+	}
+
+	// build the timeout message
+	timeout := &messages.BlockTimeout{
+		View:      view,
+		HighestQC: highestQC,
+		SigData:   sigData,
+	}
+
+	// broadcast the timeout to consensus nodes
+	err = e.con.Publish(timeout, recipients.NodeIDs()...)
+	if err != nil {
+		return fmt.Errorf("could not send timeout: %w", err)
+	}
+
+	e.metrics.MessageSent(metrics.EngineCompliance, metrics.MessageBlockTimeout)
+
+	log.Info().Msg("block timeout broadcasted")
+
+	return nil
+}
+
 // process processes events for the propagation engine on the consensus node.
 func (e *Engine) process(originID flow.Identifier, event interface{}) error {
 
@@ -304,6 +345,12 @@ func (e *Engine) process(originID flow.Identifier, event interface{}) error {
 		e.metrics.MessageReceived(metrics.EngineCompliance, metrics.MessageBlockVote)
 		defer e.metrics.MessageHandled(metrics.EngineCompliance, metrics.MessageBlockVote)
 		return e.onBlockVote(originID, ev)
+	case *messages.BlockTimeout:
+		// like votes, timeouts are passed directly to HotStuff with no extra
+		// validation by compliance layer, so we don't lock the engine.
+		e.metrics.MessageReceived(metrics.EngineCompliance, metrics.MessageBlockTimeout)
+		defer e.metrics.MessageHandled(metrics.EngineCompliance, metrics.MessageBlockTimeout)
+		return e.onBlockTimeout(originID, ev)
 	default:
 		return fmt.Errorf("invalid event type (%T)", event)
 	}
@@ -545,6 +592,29 @@ func (e *Engine) onBlockVote(originID flow.Identifier, vote *messages.BlockVote)
 	return nil
 }
 
+// onBlockTimeout handles incoming block timeouts.
+func (e *Engine) onBlockTimeout(originID flow.Identifier, timeout *messages.BlockTimeout) error {
+
+	log := e.log.With().
+		Uint64("timeout_view", timeout.View).
+		Hex("signer", originID[:]).
+		Logger()
+
+	log.Info().Msg("block timeout received")
+
+	// the timeout message is malformed without a QC, so we drop it here
+	if timeout.HighestQC == nil {
+		return fmt.Errorf("block timeout is missing highest qc")
+	}
+
+	log.Info().Msg("forwarding block timeout to hotstuff")
+
+	// forward the timeout to hotstuff for processing
+	e.hotstuff.SubmitTimeout(originID, timeout.View, timeout.HighestQC, timeout.SigData)
+
+	return nil
+}
+
 // processPendingChildren checks if there are proposals connected to the given
 // parent block that was just processed; if this is the case, they should now
 // all be validly connected to the finalized state and we should process them.
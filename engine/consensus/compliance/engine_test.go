@@ -237,6 +237,7 @@ func (cs *ComplianceSuite) SetupTest() {
 	cs.hotstuff = &module.HotStuff{}
 	cs.hotstuff.On("SubmitProposal", mock.Anything, mock.Anything).Return()
 	cs.hotstuff.On("SubmitVote", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+	cs.hotstuff.On("SubmitTimeout", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
 	cs.hotstuff.On("Done", mock.Anything).Return(closed)
 
 	// set up synchronization module mock
@@ -339,6 +340,28 @@ func (cs *ComplianceSuite) TestBroadcastProposalWithDelay() {
 	header.View--
 }
 
+func (cs *ComplianceSuite) TestBroadcastTimeout() {
+
+	// add execution node to participants to make sure we exclude them from broadcast
+	cs.participants = append(cs.participants, unittest.IdentityFixture(unittest.WithRole(flow.RoleExecution)))
+
+	view := rand.Uint64()
+	qc := unittest.QuorumCertificateFixture()
+	sig := unittest.SignatureFixture()
+
+	// submit to broadcast timeout
+	err := cs.e.BroadcastTimeout(view, qc, sig)
+	require.NoError(cs.T(), err, "timeout broadcast should pass")
+
+	// make sure we broadcast to the other consensus nodes
+	timeout := &messages.BlockTimeout{
+		View:      view,
+		HighestQC: qc,
+		SigData:   sig,
+	}
+	cs.con.AssertCalled(cs.T(), "Publish", timeout, cs.participants[1].NodeID, cs.participants[2].NodeID)
+}
+
 func (cs *ComplianceSuite) TestOnBlockProposalValidParent() {
 
 	// create a proposal that directly descends from the latest finalized header
@@ -433,6 +456,29 @@ func (cs *ComplianceSuite) TestOnSubmitVote() {
 	cs.hotstuff.AssertCalled(cs.T(), "SubmitVote", originID, vote.BlockID, vote.View, vote.SigData)
 }
 
+func (cs *ComplianceSuite) TestOnSubmitTimeout() {
+
+	// create a timeout
+	originID := unittest.IdentifierFixture()
+	timeout := messages.BlockTimeout{
+		View:      rand.Uint64(),
+		HighestQC: unittest.QuorumCertificateFixture(),
+		SigData:   unittest.SignatureFixture(),
+	}
+
+	// execute the timeout submission
+	err := cs.e.onBlockTimeout(originID, &timeout)
+	require.NoError(cs.T(), err, "block timeout should pass")
+
+	// check the submit timeout was called with correct parameters
+	cs.hotstuff.AssertCalled(cs.T(), "SubmitTimeout", originID, timeout.View, timeout.HighestQC, timeout.SigData)
+
+	// a timeout without QC should be rejected
+	timeout.HighestQC = nil
+	err = cs.e.onBlockTimeout(originID, &timeout)
+	require.Error(cs.T(), err, "block timeout without qc should fail")
+}
+
 func (cs *ComplianceSuite) TestProcessPendingChildrenNone() {
 
 	// generate random block ID
@@ -0,0 +1,161 @@
+package dkg
+
+import (
+	"github.com/onflow/flow-go/crypto/hash"
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// broadcast implements the consistent broadcast of the messages of a DKG instance by echoes.
+// A dealer sends its message to all participants, which echo the hash of the first message
+// they receive for each sequence number of the dealer to all participants. A message is only
+// delivered once a quorum of the participants echoed its hash. Any two quorums intersect in
+// an honest participant, which echoes a single message per sequence number, so no two honest
+// participants deliver different messages of a dealer for the same sequence number, as long
+// as less than a third of the participants are malicious.
+type broadcast struct {
+	size     int    // number of participants
+	index    int    // our index among the participants
+	quorum   int    // number of matching echoes required to deliver a message
+	sequence uint64 // sequence number of our next broadcast
+	messages map[broadcastKey]*broadcastState
+}
+
+type broadcastKey struct {
+	dealer   int
+	sequence uint64
+}
+
+type broadcastState struct {
+	data      []byte                  // data received from the dealer, nil until then
+	hash      flow.Identifier         // hash of the data received from the dealer
+	echoes    map[int]flow.Identifier // hash echoed by each participant
+	delivered bool
+}
+
+func newBroadcast(size int, index int) *broadcast {
+	return &broadcast{
+		size:     size,
+		index:    index,
+		quorum:   echoQuorum(size),
+		messages: make(map[broadcastKey]*broadcastState),
+	}
+}
+
+// echoQuorum returns the number of matching echoes required to deliver a message broadcast
+// among the given number of participants, which is more than half of the participants plus
+// the number of malicious participants tolerated.
+func echoQuorum(size int) int {
+	faulty := (size - 1) / 3
+	return (size+faulty)/2 + 1
+}
+
+// maxSequence returns the number of messages a dealer broadcasts at most in the course of the
+// DKG: its verification vector, a complaint against every other dealer and an answer to the
+// complaint of every other participant.
+func (b *broadcast) maxSequence() uint64 {
+	return uint64(2*b.size + 1)
+}
+
+// next returns the sequence number of our next broadcast.
+func (b *broadcast) next() uint64 {
+	sequence := b.sequence
+	b.sequence++
+	return sequence
+}
+
+// receive processes a message broadcast by the dealer. The dealer sending the message counts
+// as its echo, and so do we. It returns the hash of the data and whether we have to echo it,
+// which is the case for the first message we receive for the sequence number.
+func (b *broadcast) receive(dealer int, sequence uint64, data []byte) (flow.Identifier, bool, error) {
+	state, err := b.state(dealer, sequence)
+	if err != nil {
+		return flow.ZeroID, false, err
+	}
+
+	dataHash := flow.HashToID(hash.NewSHA3_256().ComputeHash(data))
+	if state.data != nil {
+		if state.hash != dataHash {
+			return flow.ZeroID, false, engine.NewInvalidInputErrorf("conflicting dkg broadcasts of dealer %d for sequence %d", dealer, sequence)
+		}
+		return dataHash, false, nil
+	}
+
+	state.data = data
+	state.hash = dataHash
+	err = b.addEcho(state, dealer, dataHash)
+	if err != nil {
+		return flow.ZeroID, false, err
+	}
+	err = b.addEcho(state, b.index, dataHash)
+	if err != nil {
+		return flow.ZeroID, false, err
+	}
+
+	return dataHash, true, nil
+}
+
+// echo processes the echo of a message of the dealer by the given participant.
+func (b *broadcast) echo(echoer int, dealer int, sequence uint64, dataHash flow.Identifier) error {
+	state, err := b.state(dealer, sequence)
+	if err != nil {
+		return err
+	}
+	return b.addEcho(state, echoer, dataHash)
+}
+
+// deliver returns the data of the message of the dealer with the given sequence number, once
+// it was received and echoed by a quorum of the participants. The data is returned only once.
+func (b *broadcast) deliver(dealer int, sequence uint64) ([]byte, bool) {
+	state, ok := b.messages[broadcastKey{dealer: dealer, sequence: sequence}]
+	if !ok || state.delivered || state.data == nil {
+		return nil, false
+	}
+
+	matching := 0
+	for _, echoed := range state.echoes {
+		if echoed == state.hash {
+			matching++
+		}
+	}
+	if matching < b.quorum {
+		return nil, false
+	}
+
+	state.delivered = true
+	return state.data, true
+}
+
+// state returns the state of the message of the dealer with the given sequence number.
+func (b *broadcast) state(dealer int, sequence uint64) (*broadcastState, error) {
+	if dealer < 0 || dealer >= b.size || dealer == b.index {
+		return nil, engine.NewInvalidInputErrorf("invalid dkg broadcast dealer %d", dealer)
+	}
+	if sequence >= b.maxSequence() {
+		return nil, engine.NewInvalidInputErrorf("invalid dkg broadcast sequence %d of dealer %d", sequence, dealer)
+	}
+
+	key := broadcastKey{dealer: dealer, sequence: sequence}
+	state, ok := b.messages[key]
+	if !ok {
+		state = &broadcastState{
+			echoes: make(map[int]flow.Identifier),
+		}
+		b.messages[key] = state
+	}
+	return state, nil
+}
+
+// addEcho records the hash echoed by the participant. Every participant echoes a single hash for
+// each message.
+func (b *broadcast) addEcho(state *broadcastState, echoer int, dataHash flow.Identifier) error {
+	echoed, ok := state.echoes[echoer]
+	if ok {
+		if echoed != dataHash {
+			return engine.NewInvalidInputErrorf("conflicting dkg echoes of participant %d", echoer)
+		}
+		return nil
+	}
+	state.echoes[echoer] = dataHash
+	return nil
+}
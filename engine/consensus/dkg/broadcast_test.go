@@ -0,0 +1,112 @@
+package dkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestEchoQuorum(t *testing.T) {
+	require.Equal(t, 1, echoQuorum(1))
+	require.Equal(t, 3, echoQuorum(4))
+	require.Equal(t, 5, echoQuorum(7))
+	require.Equal(t, 7, echoQuorum(10))
+}
+
+func TestBroadcast(t *testing.T) {
+	data := []byte{1, 2, 3}
+	other := []byte{4, 5, 6}
+
+	t.Run("delivery after quorum", func(t *testing.T) {
+		b := newBroadcast(4, 0)
+		dataHash, echo, err := b.receive(1, 0, data)
+		require.NoError(t, err)
+		require.True(t, echo)
+
+		_, ok := b.deliver(1, 0)
+		require.False(t, ok)
+
+		err = b.echo(2, 1, 0, dataHash)
+		require.NoError(t, err)
+		delivered, ok := b.deliver(1, 0)
+		require.True(t, ok)
+		require.Equal(t, data, delivered)
+
+		// the message is delivered only once
+		err = b.echo(3, 1, 0, dataHash)
+		require.NoError(t, err)
+		_, ok = b.deliver(1, 0)
+		require.False(t, ok)
+	})
+
+	t.Run("echoes before message", func(t *testing.T) {
+		b := newBroadcast(4, 0)
+		dataHash, _, err := (newBroadcast(4, 0)).receive(1, 0, data)
+		require.NoError(t, err)
+
+		err = b.echo(2, 1, 0, dataHash)
+		require.NoError(t, err)
+		err = b.echo(3, 1, 0, dataHash)
+		require.NoError(t, err)
+		_, ok := b.deliver(1, 0)
+		require.False(t, ok)
+
+		_, echo, err := b.receive(1, 0, data)
+		require.NoError(t, err)
+		require.True(t, echo)
+		delivered, ok := b.deliver(1, 0)
+		require.True(t, ok)
+		require.Equal(t, data, delivered)
+	})
+
+	t.Run("no quorum for equivocating dealer", func(t *testing.T) {
+		b := newBroadcast(4, 0)
+		_, _, err := b.receive(1, 0, data)
+		require.NoError(t, err)
+
+		// the other participants received a different message from the dealer
+		otherHash, _, err := (newBroadcast(4, 2)).receive(1, 0, other)
+		require.NoError(t, err)
+		err = b.echo(2, 1, 0, otherHash)
+		require.NoError(t, err)
+		err = b.echo(3, 1, 0, otherHash)
+		require.NoError(t, err)
+
+		_, ok := b.deliver(1, 0)
+		require.False(t, ok)
+	})
+
+	t.Run("conflicting broadcasts", func(t *testing.T) {
+		b := newBroadcast(4, 0)
+		_, _, err := b.receive(1, 0, data)
+		require.NoError(t, err)
+
+		_, echo, err := b.receive(1, 0, data)
+		require.NoError(t, err)
+		require.False(t, echo)
+
+		_, _, err = b.receive(1, 0, other)
+		require.True(t, engine.IsInvalidInputError(err))
+	})
+
+	t.Run("conflicting echoes", func(t *testing.T) {
+		b := newBroadcast(4, 0)
+		err := b.echo(2, 1, 0, unittest.IdentifierFixture())
+		require.NoError(t, err)
+		err = b.echo(2, 1, 0, unittest.IdentifierFixture())
+		require.True(t, engine.IsInvalidInputError(err))
+	})
+
+	t.Run("invalid dealer or sequence", func(t *testing.T) {
+		b := newBroadcast(4, 0)
+		_, _, err := b.receive(0, 0, data)
+		require.True(t, engine.IsInvalidInputError(err))
+		_, _, err = b.receive(4, 0, data)
+		require.True(t, engine.IsInvalidInputError(err))
+		_, _, err = b.receive(1, b.maxSequence(), data)
+		require.True(t, engine.IsInvalidInputError(err))
+	})
+}
@@ -0,0 +1,603 @@
+package dkg
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/engine"
+	dkgmodel "github.com/onflow/flow-go/model/dkg"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/model/messages"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/signature"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/state/protocol/events"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/utils/logging"
+)
+
+// DefaultPhaseViews is the default number of views of each of the three phases of the DKG.
+const DefaultPhaseViews = 250
+
+// shareMsgTag is the tag of the share a dealer of the Joint-Feldman DKG sends to each
+// participant, which is the only message of the DKG which is sent privately.
+const shareMsgTag = byte(0)
+
+// Engine runs the Joint-Feldman distributed key generation (DKG) for the random beacon
+// of the next epoch among the consensus nodes of that epoch, during the epoch setup phase.
+// Messages of the DKG are exchanged on a dedicated channel: private messages are sent to
+// their recipient only, while broadcast messages are sent to all participants with a
+// consistent broadcast, which only delivers a message once a quorum of the participants
+// echoed it. The protocol proceeds in three phases of a fixed number of views, counted
+// from the first block of the epoch setup phase, so that all participants end the phases
+// with the same finalized blocks. The resulting key share is persisted for the random
+// beacon of the epoch, for which it is used once it matches the EpochCommit.
+//
+// The state of a DKG can not be persisted, so a node which is restarted while the DKG is
+// running does not rejoin it, as it would contradict the messages it sent before. Nodes
+// which observe the setup phase only after its first DKG phase ended do not join either.
+type Engine struct {
+	unit       *engine.Unit
+	log        zerolog.Logger
+	me         module.Local
+	state      protocol.State
+	results    storage.DKGResults
+	con        network.Conduit
+	phaseViews uint64 // number of views of each phase of the DKG
+
+	mu             sync.Mutex
+	current        *instance         // the DKG of the latest epoch we participated in
+	pendingCounter uint64            // the epoch of the pending messages
+	pending        []*pendingMessage // messages received for the next epoch before we started its DKG
+
+	events.Noop // satisfy protocol events consumer interface
+}
+
+// instance is a DKG run by this node for an epoch.
+type instance struct {
+	counter      uint64
+	participants flow.IdentityList
+	index        int
+	state        crypto.DKGState
+	proc         *processor
+	bcast        *broadcast
+	startView    uint64 // view of the first block of the epoch setup phase
+	phase        uint64 // current phase of the DKG, from 1 to 3, or 4 once it ended
+}
+
+type pendingMessage struct {
+	originID flow.Identifier
+	event    interface{}
+}
+
+// New creates a new DKG engine, where each phase of the DKG lasts for the given number of views.
+func New(
+	log zerolog.Logger,
+	net module.Network,
+	me module.Local,
+	state protocol.State,
+	results storage.DKGResults,
+	phaseViews uint64,
+) (*Engine, error) {
+
+	e := &Engine{
+		unit:       engine.NewUnit(),
+		log:        log.With().Str("engine", "dkg").Logger(),
+		me:         me,
+		state:      state,
+		results:    results,
+		phaseViews: phaseViews,
+	}
+
+	con, err := net.Register(engine.DKGCommittee, e)
+	if err != nil {
+		return nil, fmt.Errorf("could not register dkg engine: %w", err)
+	}
+	e.con = con
+
+	return e, nil
+}
+
+// Ready returns a ready channel that is closed once the engine has fully
+// started. If we are in the epoch setup phase, the DKG for the next epoch
+// is started.
+func (e *Engine) Ready() <-chan struct{} {
+	return e.unit.Ready(func() {
+		// check the current phase on startup, in case we are in setup phase
+		// and haven't yet completed the DKG for the next epoch
+		phase, err := e.state.Final().Phase()
+		if err != nil {
+			e.log.Error().Err(err).Msg("could not check phase")
+			return
+		}
+		if phase != flow.EpochPhaseSetup {
+			return
+		}
+		first, err := e.setupPhaseStart()
+		if err != nil {
+			e.log.Error().Err(err).Msg("could not find start of setup phase")
+			return
+		}
+		e.unit.Launch(func() { e.runDKG(first) })
+	})
+}
+
+// Done returns a done channel that is closed once the engine has fully stopped.
+func (e *Engine) Done() <-chan struct{} {
+	return e.unit.Done()
+}
+
+// EpochSetupPhaseStarted handles the epoch setup phase started protocol event.
+func (e *Engine) EpochSetupPhaseStarted(_ uint64, first *flow.Header) {
+	e.unit.Launch(func() { e.runDKG(first) })
+}
+
+// BlockFinalized handles the block finalized protocol event, which ends the phases
+// of the running DKG.
+func (e *Engine) BlockFinalized(block *flow.Header) {
+	e.unit.Launch(func() { e.onBlockFinalized(block.View) })
+}
+
+// EpochCommittedPhaseStarted handles the epoch committed phase started protocol event,
+// upon which the result of the DKG is checked against the EpochCommit of the next epoch.
+func (e *Engine) EpochCommittedPhaseStarted(_ uint64, first *flow.Header) {
+	e.unit.Launch(func() { e.checkCommit(first) })
+}
+
+// SubmitLocal submits an event originating on the local node.
+func (e *Engine) SubmitLocal(event interface{}) {
+	e.Submit(e.me.NodeID(), event)
+}
+
+// Submit submits the given event from the node with the given origin ID
+// for processing in a non-blocking manner.
+func (e *Engine) Submit(originID flow.Identifier, event interface{}) {
+	e.unit.Launch(func() {
+		err := e.Process(originID, event)
+		if err != nil {
+			engine.LogError(e.log, err)
+		}
+	})
+}
+
+// ProcessLocal processes an event originating on the local node.
+func (e *Engine) ProcessLocal(event interface{}) error {
+	return e.Process(e.me.NodeID(), event)
+}
+
+// Process processes the given event from the node with the given origin ID
+// in a blocking manner.
+func (e *Engine) Process(originID flow.Identifier, event interface{}) error {
+	return e.unit.Do(func() error {
+		return e.process(originID, event)
+	})
+}
+
+func (e *Engine) process(originID flow.Identifier, event interface{}) error {
+	switch v := event.(type) {
+	case *messages.DKGMessage:
+		return e.onDKGMessage(originID, v.EpochCounter, v)
+	case *messages.DKGBroadcast:
+		return e.onDKGMessage(originID, v.EpochCounter, v)
+	case *messages.DKGEcho:
+		return e.onDKGMessage(originID, v.EpochCounter, v)
+	default:
+		return fmt.Errorf("invalid event type (%T)", event)
+	}
+}
+
+// onDKGMessage hands the message over to the DKG of its epoch. Messages for the next epoch
+// which arrive before we started its DKG are kept until then, as the other participants
+// might have observed the start of the epoch setup phase before us.
+func (e *Engine) onDKGMessage(originID flow.Identifier, counter uint64, event interface{}) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.current != nil && e.current.counter == counter {
+		if !e.current.state.Running() {
+			e.log.Debug().
+				Hex("origin_id", logging.ID(originID)).
+				Uint64("epoch", counter).
+				Msg("dropping message for completed dkg")
+			return nil
+		}
+		return e.current.handle(originID, event)
+	}
+
+	epoch := e.state.Final().Epochs().Next()
+	next, err := epoch.Counter()
+	if err != nil || next != counter {
+		e.log.Debug().
+			Hex("origin_id", logging.ID(originID)).
+			Uint64("epoch", counter).
+			Msg("dropping dkg message for unexpected epoch")
+		return nil
+	}
+	participants, err := participantsOf(epoch)
+	if err != nil {
+		return fmt.Errorf("could not get dkg participants: %w", err)
+	}
+	_, ok := indexOf(participants, originID)
+	if !ok {
+		return engine.NewInvalidInputErrorf("dkg message from non-participant (%x)", originID)
+	}
+
+	if e.pendingCounter != counter {
+		e.pendingCounter = counter
+		e.pending = nil
+	}
+	// every participant sends a few messages and echoes of the broadcasts of every other
+	// participant in the course of the DKG, anything beyond that is dropped
+	if len(e.pending) >= 4*len(participants)*len(participants)*len(participants) {
+		e.log.Warn().
+			Hex("origin_id", logging.ID(originID)).
+			Uint64("epoch", counter).
+			Msg("dropping dkg message, too many pending messages")
+		return nil
+	}
+	e.pending = append(e.pending, &pendingMessage{originID: originID, event: event})
+
+	return nil
+}
+
+// runDKG runs the DKG for the next epoch, starting with the given first block of the epoch
+// setup phase, unless this node is not a participant or has started it before. It is called
+// either when we transition into the epoch setup phase, or when the node is started during
+// the epoch setup phase.
+func (e *Engine) runDKG(first *flow.Header) {
+
+	epoch := e.state.Final().Epochs().Next()
+	counter, err := epoch.Counter()
+	if err != nil {
+		e.log.Error().Err(err).Msg("could not get next epoch counter")
+		return
+	}
+	log := e.log.With().Uint64("epoch", counter).Logger()
+
+	_, err = e.results.ByEpochCounter(counter)
+	if err == nil {
+		log.Info().Msg("dkg for next epoch completed already")
+		return
+	}
+	if !errors.Is(err, storage.ErrNotFound) {
+		log.Error().Err(err).Msg("could not check for dkg result")
+		return
+	}
+
+	// a DKG which was interrupted by a restart can not be resumed, and rerunning it
+	// with a new seed would contradict the messages we sent before
+	started, err := e.results.Started(counter)
+	if err != nil {
+		log.Error().Err(err).Msg("could not check for started dkg")
+		return
+	}
+	if started {
+		log.Error().Msg("dkg for next epoch was interrupted, no random beacon key share for next epoch")
+		return
+	}
+
+	participants, err := participantsOf(epoch)
+	if err != nil {
+		log.Error().Err(err).Msg("could not get dkg participants")
+		return
+	}
+	index, ok := indexOf(participants, e.me.NodeID())
+	if !ok {
+		log.Debug().Msg("not a participant of the dkg for next epoch")
+		return
+	}
+
+	// the shares are distributed in the first phase, which the other participants
+	// end without us if we see the setup phase too late
+	final, err := e.state.Final().Head()
+	if err != nil {
+		log.Error().Err(err).Msg("could not get finalized block")
+		return
+	}
+	if final.View >= first.View+e.phaseViews {
+		log.Error().
+			Uint64("start_view", first.View).
+			Uint64("final_view", final.View).
+			Msg("first phase of dkg for next epoch ended, no random beacon key share for next epoch")
+		return
+	}
+
+	bcast := newBroadcast(len(participants), index)
+	proc := &processor{
+		log:          log,
+		con:          e.con,
+		counter:      counter,
+		participants: participants,
+		index:        index,
+		bcast:        bcast,
+	}
+	state, err := crypto.NewJointFeldman(len(participants), signature.RandomBeaconThreshold(len(participants)), index, proc)
+	if err != nil {
+		log.Error().Err(err).Msg("could not create dkg instance")
+		return
+	}
+	inst := &instance{
+		counter:      counter,
+		participants: participants,
+		index:        index,
+		state:        state,
+		proc:         proc,
+		bcast:        bcast,
+		startView:    first.View,
+		phase:        1,
+	}
+
+	// the seed of the secret polynomial of this node has to be private
+	seed := make([]byte, crypto.SeedMinLenDKG)
+	_, err = rand.Read(seed)
+	if err != nil {
+		log.Error().Err(err).Msg("could not generate dkg seed")
+		return
+	}
+
+	// the DKG is marked as started before any of its messages are sent
+	err = e.results.MarkStarted(counter)
+	if err != nil {
+		log.Error().Err(err).Msg("could not mark dkg as started")
+		return
+	}
+
+	err = e.start(inst, seed)
+	if err != nil {
+		log.Error().Err(err).Msg("could not start dkg")
+		return
+	}
+
+	log.Info().
+		Int("index", index).
+		Int("participants", len(participants)).
+		Uint64("start_view", first.View).
+		Msg("dkg started")
+}
+
+// start starts the DKG of the given instance and hands the pending messages over to it.
+func (e *Engine) start(inst *instance, seed []byte) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.current != nil && e.current.counter == inst.counter {
+		return fmt.Errorf("dkg for epoch %d was started already", inst.counter)
+	}
+
+	err := inst.state.Start(seed)
+	if err != nil {
+		return fmt.Errorf("could not start dkg instance: %w", err)
+	}
+	e.current = inst
+
+	if e.pendingCounter == inst.counter {
+		for _, msg := range e.pending {
+			err := inst.handle(msg.originID, msg.event)
+			if err != nil {
+				engine.LogError(e.log, err)
+			}
+		}
+	}
+	e.pending = nil
+
+	return nil
+}
+
+// onBlockFinalized ends the phases of the running DKG up to the given finalized view. The shares
+// are distributed in the first phase, complaints are sent in the second phase and answered in the
+// third phase, after which the keys are derived and the result is stored.
+func (e *Engine) onBlockFinalized(view uint64) {
+	result, err := e.advance(view)
+	if err != nil {
+		e.log.Error().Err(err).Uint64("view", view).Msg("dkg failed")
+		return
+	}
+	if result == nil {
+		return
+	}
+
+	log := e.log.With().Uint64("epoch", result.EpochCounter).Logger()
+	err = e.results.Store(result)
+	if err != nil {
+		log.Error().Err(err).Msg("could not store dkg result")
+		return
+	}
+
+	log.Info().Msg("dkg completed")
+}
+
+// advance ends the phases of the running DKG which end at or before the given view, and returns
+// the result of the DKG if it ended.
+func (e *Engine) advance(view uint64) (*dkgmodel.Result, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	inst := e.current
+	if inst == nil {
+		return nil, nil
+	}
+
+	for ; inst.phase < 3 && view >= inst.startView+inst.phase*e.phaseViews; inst.phase++ {
+		err := inst.state.NextTimeout()
+		if err != nil {
+			phase := inst.phase
+			inst.phase = 4
+			return nil, fmt.Errorf("could not end phase %d of dkg for epoch %d: %w", phase, inst.counter, err)
+		}
+		e.log.Info().Uint64("epoch", inst.counter).Uint64("phase", inst.phase).Msg("dkg phase ended")
+	}
+	if inst.phase != 3 || view < inst.startView+3*e.phaseViews {
+		return nil, nil
+	}
+	inst.phase = 4
+
+	privKeyShare, groupKey, keyShares, err := inst.state.End()
+	if err != nil {
+		return nil, fmt.Errorf("could not end dkg for epoch %d: %w", inst.counter, err)
+	}
+
+	result := &dkgmodel.Result{
+		EpochCounter: inst.counter,
+		Participants: inst.participants.NodeIDs(),
+		Index:        uint(inst.index),
+		PrivKeyShare: privKeyShare,
+		GroupKey:     groupKey,
+		KeyShares:    keyShares,
+	}
+	return result, nil
+}
+
+// checkCommit checks that our result of the DKG of the next epoch matches the DKG committed
+// to by its EpochCommit, which is required for our key share to be used by the random beacon.
+func (e *Engine) checkCommit(first *flow.Header) {
+	epoch := e.state.AtBlockID(first.ID()).Epochs().Next()
+	counter, err := epoch.Counter()
+	if err != nil {
+		e.log.Error().Err(err).Msg("could not get next epoch counter")
+		return
+	}
+	log := e.log.With().Uint64("epoch", counter).Logger()
+
+	participants, err := participantsOf(epoch)
+	if err != nil {
+		log.Error().Err(err).Msg("could not get dkg participants")
+		return
+	}
+	_, ok := indexOf(participants, e.me.NodeID())
+	if !ok {
+		return
+	}
+
+	result, err := e.results.ByEpochCounter(counter)
+	if errors.Is(err, storage.ErrNotFound) {
+		log.Error().Msg("no dkg result for committed epoch, no random beacon key share for next epoch")
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("could not get dkg result")
+		return
+	}
+	committed, err := epoch.DKG()
+	if err != nil {
+		log.Error().Err(err).Msg("could not get committed dkg")
+		return
+	}
+	err = signature.CheckDKGResult(result, e.me.NodeID(), committed)
+	if err != nil {
+		log.Error().Err(err).Msg("dkg result does not match epoch commit, no random beacon key share for next epoch")
+		return
+	}
+
+	log.Info().Msg("dkg result matches epoch commit")
+}
+
+// setupPhaseStart returns the first finalized block of the current epoch setup phase.
+func (e *Engine) setupPhaseStart() (*flow.Header, error) {
+	first, err := e.state.Final().Head()
+	if err != nil {
+		return nil, fmt.Errorf("could not get finalized block: %w", err)
+	}
+	for {
+		parent := e.state.AtBlockID(first.ParentID)
+		phase, err := parent.Phase()
+		if err != nil {
+			return nil, fmt.Errorf("could not get phase of block %x: %w", first.ParentID, err)
+		}
+		if phase != flow.EpochPhaseSetup {
+			return first, nil
+		}
+		first, err = parent.Head()
+		if err != nil {
+			return nil, fmt.Errorf("could not get block %x: %w", first.ParentID, err)
+		}
+	}
+}
+
+// handle hands a message from the given participant over to the DKG of the instance. Broadcast
+// messages are echoed, and are only handed over once they were echoed by a quorum.
+func (inst *instance) handle(originID flow.Identifier, event interface{}) error {
+	orig, ok := indexOf(inst.participants, originID)
+	if !ok {
+		return engine.NewInvalidInputErrorf("dkg message from non-participant (%x)", originID)
+	}
+	if orig == inst.index {
+		return engine.NewInvalidInputErrorf("dkg message from self")
+	}
+
+	switch msg := event.(type) {
+	case *messages.DKGMessage:
+		// any message other than the share of a dealer has to be broadcast consistently
+		if len(msg.Data) == 0 || msg.Data[0] != shareMsgTag {
+			return engine.NewInvalidInputErrorf("private dkg message from %x is not a share", originID)
+		}
+		return inst.handleMsg(orig, msg.Data)
+
+	case *messages.DKGBroadcast:
+		dataHash, echo, err := inst.bcast.receive(orig, msg.Sequence, msg.Data)
+		if err != nil {
+			return fmt.Errorf("could not receive dkg broadcast: %w", err)
+		}
+		if echo {
+			inst.proc.Echo(originID, msg.Sequence, dataHash)
+		}
+		return inst.deliver(orig, msg.Sequence)
+
+	case *messages.DKGEcho:
+		dealer, ok := indexOf(inst.participants, msg.DealerID)
+		if !ok {
+			return engine.NewInvalidInputErrorf("dkg echo for non-participant (%x)", msg.DealerID)
+		}
+		err := inst.bcast.echo(orig, dealer, msg.Sequence, msg.DataHash)
+		if err != nil {
+			return fmt.Errorf("could not receive dkg echo: %w", err)
+		}
+		return inst.deliver(dealer, msg.Sequence)
+
+	default:
+		return fmt.Errorf("invalid dkg message type (%T)", event)
+	}
+}
+
+// deliver hands the broadcast message of the dealer with the given sequence number over to the
+// DKG, once it was echoed by a quorum.
+func (inst *instance) deliver(dealer int, sequence uint64) error {
+	data, ok := inst.bcast.deliver(dealer, sequence)
+	if !ok {
+		return nil
+	}
+	return inst.handleMsg(dealer, data)
+}
+
+func (inst *instance) handleMsg(orig int, data []byte) error {
+	err := inst.state.HandleMsg(orig, data)
+	if err != nil {
+		return fmt.Errorf("could not handle dkg message: %w", err)
+	}
+	return nil
+}
+
+// participantsOf returns the participants of the DKG for the given epoch, in the order of their indices.
+func participantsOf(epoch protocol.Epoch) (flow.IdentityList, error) {
+	identities, err := epoch.InitialIdentities()
+	if err != nil {
+		return nil, fmt.Errorf("could not get initial identities: %w", err)
+	}
+	return identities.Filter(filter.HasRole(flow.RoleConsensus)), nil
+}
+
+// indexOf returns the index of the given node among the participants.
+func indexOf(participants flow.IdentityList, nodeID flow.Identifier) (int, bool) {
+	for i, participant := range participants {
+		if participant.NodeID == nodeID {
+			return i, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,301 @@
+package dkg
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/crypto/hash"
+	"github.com/onflow/flow-go/engine"
+	dkgmodel "github.com/onflow/flow-go/model/dkg"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/messages"
+	module "github.com/onflow/flow-go/module/mock"
+	network "github.com/onflow/flow-go/network/mock"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/storage"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+const (
+	testCounter    = uint64(2)
+	testPhaseViews = uint64(100)
+	testStartView  = uint64(1000)
+)
+
+func TestEngine_RunDKG(t *testing.T) {
+	participants := unittest.IdentityListFixture(4, unittest.WithRole(flow.RoleConsensus))
+	identities := append(participants, unittest.IdentityFixture(unittest.WithRole(flow.RoleCollection)))
+
+	var wg sync.WaitGroup
+	wg.Add(len(participants))
+	results := make([]*dkgmodel.Result, len(participants))
+	engines := newTestEngines(t, identities, participants, func(i int, store *storagemock.DKGResults) {
+		store.On("ByEpochCounter", testCounter).Return(nil, storage.ErrNotFound)
+		store.On("Started", testCounter).Return(false, nil).Once()
+		store.On("MarkStarted", testCounter).Return(nil).Once()
+		store.On("Store", mock.Anything).Run(func(args mock.Arguments) {
+			results[i] = args[0].(*dkgmodel.Result)
+			wg.Done()
+		}).Return(nil).Once()
+	})
+
+	// the first participant starts the DKG before the others, whose
+	// messages are kept by the others until they start it themselves
+	first := setupPhaseFirstBlock()
+	engines[0].EpochSetupPhaseStarted(testCounter, first)
+	time.Sleep(100 * time.Millisecond)
+	for _, eng := range engines[1:] {
+		eng.EpochSetupPhaseStarted(testCounter, first)
+	}
+
+	// the phases end with the finalization of the same views on all participants
+	for phase := uint64(1); phase <= 3; phase++ {
+		time.Sleep(500 * time.Millisecond)
+		final := unittest.BlockHeaderFixture()
+		final.View = testStartView + phase*testPhaseViews
+		for _, eng := range engines {
+			eng.BlockFinalized(&final)
+		}
+	}
+
+	unittest.AssertReturnsBefore(t, wg.Wait, 10*time.Second)
+
+	for i, result := range results {
+		require.NotNil(t, result)
+		assert.Equal(t, testCounter, result.EpochCounter)
+		assert.Equal(t, participants.NodeIDs(), result.Participants)
+		assert.Equal(t, uint(i), result.Index)
+		assert.True(t, result.GroupKey.Equals(results[0].GroupKey))
+		require.Len(t, result.KeyShares, len(participants))
+		for j, share := range result.KeyShares {
+			assert.True(t, share.Equals(results[0].KeyShares[j]))
+		}
+		assert.True(t, result.PrivKeyShare.PublicKey().Equals(result.KeyShares[i]))
+	}
+}
+
+func TestEngine_CompletedDKG(t *testing.T) {
+	participants := unittest.IdentityListFixture(4, unittest.WithRole(flow.RoleConsensus))
+	engines := newTestEngines(t, participants, participants[:1], func(_ int, store *storagemock.DKGResults) {
+		store.On("ByEpochCounter", testCounter).Return(&dkgmodel.Result{EpochCounter: testCounter}, nil)
+	})
+
+	// the DKG is not run again after a restart
+	engines[0].runDKG(setupPhaseFirstBlock())
+	engines[0].results.(*storagemock.DKGResults).AssertNotCalled(t, "Store", mock.Anything)
+	engines[0].con.(*network.Conduit).AssertNotCalled(t, "Unicast", mock.Anything, mock.Anything)
+	engines[0].con.(*network.Conduit).AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+func TestEngine_InterruptedDKG(t *testing.T) {
+	participants := unittest.IdentityListFixture(4, unittest.WithRole(flow.RoleConsensus))
+	engines := newTestEngines(t, participants, participants[:1], func(_ int, store *storagemock.DKGResults) {
+		store.On("ByEpochCounter", testCounter).Return(nil, storage.ErrNotFound)
+		store.On("Started", testCounter).Return(true, nil)
+	})
+
+	// a DKG which was interrupted by a restart is not started again with a new seed
+	engines[0].runDKG(setupPhaseFirstBlock())
+	engines[0].results.(*storagemock.DKGResults).AssertNotCalled(t, "MarkStarted", mock.Anything)
+	engines[0].con.(*network.Conduit).AssertNotCalled(t, "Unicast", mock.Anything, mock.Anything)
+	engines[0].con.(*network.Conduit).AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+	require.Nil(t, engines[0].current)
+}
+
+func TestEngine_LateDKG(t *testing.T) {
+	participants := unittest.IdentityListFixture(4, unittest.WithRole(flow.RoleConsensus))
+	engines := newTestEngines(t, participants, participants[:1], func(_ int, store *storagemock.DKGResults) {
+		store.On("ByEpochCounter", testCounter).Return(nil, storage.ErrNotFound)
+		store.On("Started", testCounter).Return(false, nil)
+	})
+
+	// the first phase of the DKG ended before the node observed the setup phase
+	first := setupPhaseFirstBlock()
+	first.View = testStartView - testPhaseViews
+	engines[0].runDKG(first)
+	engines[0].results.(*storagemock.DKGResults).AssertNotCalled(t, "MarkStarted", mock.Anything)
+	engines[0].con.(*network.Conduit).AssertNotCalled(t, "Unicast", mock.Anything, mock.Anything)
+	require.Nil(t, engines[0].current)
+}
+
+func TestEngine_onDKGMessage(t *testing.T) {
+	participants := unittest.IdentityListFixture(4, unittest.WithRole(flow.RoleConsensus))
+	collector := unittest.IdentityFixture(unittest.WithRole(flow.RoleCollection))
+	identities := append(participants, collector)
+	engines := newTestEngines(t, identities, participants[:1], func(int, *storagemock.DKGResults) {})
+	eng := engines[0]
+
+	t.Run("non-participant", func(t *testing.T) {
+		err := eng.process(collector.NodeID, &messages.DKGMessage{EpochCounter: testCounter})
+		require.True(t, engine.IsInvalidInputError(err))
+	})
+
+	t.Run("unexpected epoch", func(t *testing.T) {
+		err := eng.process(participants[1].NodeID, &messages.DKGMessage{EpochCounter: testCounter + 1})
+		require.NoError(t, err)
+		require.Empty(t, eng.pending)
+	})
+
+	t.Run("before start", func(t *testing.T) {
+		err := eng.process(participants[1].NodeID, &messages.DKGMessage{EpochCounter: testCounter})
+		require.NoError(t, err)
+		err = eng.process(participants[2].NodeID, &messages.DKGEcho{EpochCounter: testCounter})
+		require.NoError(t, err)
+		require.Len(t, eng.pending, 2)
+	})
+}
+
+func TestInstance_handle(t *testing.T) {
+	participants := unittest.IdentityListFixture(4, unittest.WithRole(flow.RoleConsensus))
+	con := new(network.Conduit)
+	con.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	state := &fakeDKGState{}
+	bcast := newBroadcast(len(participants), 0)
+	inst := &instance{
+		counter:      testCounter,
+		participants: participants,
+		index:        0,
+		state:        state,
+		proc: &processor{
+			log:          zerolog.Nop(),
+			con:          con,
+			counter:      testCounter,
+			participants: participants,
+			index:        0,
+			bcast:        bcast,
+		},
+		bcast: bcast,
+	}
+
+	t.Run("private message other than share", func(t *testing.T) {
+		err := inst.handle(participants[1].NodeID, &messages.DKGMessage{EpochCounter: testCounter, Data: []byte{1, 2, 3}})
+		require.True(t, engine.IsInvalidInputError(err))
+		require.Empty(t, state.handled)
+	})
+
+	t.Run("private share", func(t *testing.T) {
+		err := inst.handle(participants[1].NodeID, &messages.DKGMessage{EpochCounter: testCounter, Data: []byte{shareMsgTag, 1}})
+		require.NoError(t, err)
+		require.Equal(t, []int{1}, state.origins)
+	})
+
+	t.Run("broadcast", func(t *testing.T) {
+		state.origins = nil
+		data := []byte{1, 2, 3}
+		dealer := participants[1]
+
+		// the dealer and we echo the broadcast, which is echoed to the participants other than the dealer
+		err := inst.handle(dealer.NodeID, &messages.DKGBroadcast{EpochCounter: testCounter, Sequence: 0, Data: data})
+		require.NoError(t, err)
+		require.Empty(t, state.origins)
+		con.AssertCalled(t, "Publish", mock.AnythingOfType("*messages.DKGEcho"), participants[2].NodeID, participants[3].NodeID)
+
+		// the broadcast is delivered once it was echoed by a quorum
+		dataHash := flow.HashToID(hash.NewSHA3_256().ComputeHash(data))
+		err = inst.handle(participants[2].NodeID, &messages.DKGEcho{EpochCounter: testCounter, DealerID: dealer.NodeID, Sequence: 0, DataHash: dataHash})
+		require.NoError(t, err)
+		require.Equal(t, []int{1}, state.origins)
+
+		// and only once
+		err = inst.handle(participants[3].NodeID, &messages.DKGEcho{EpochCounter: testCounter, DealerID: dealer.NodeID, Sequence: 0, DataHash: dataHash})
+		require.NoError(t, err)
+		require.Equal(t, []int{1}, state.origins)
+	})
+}
+
+// fakeDKGState records the messages handed over to the DKG.
+type fakeDKGState struct {
+	crypto.DKGState
+	origins []int
+	handled [][]byte
+}
+
+func (s *fakeDKGState) HandleMsg(orig int, msg []byte) error {
+	s.origins = append(s.origins, orig)
+	s.handled = append(s.handled, msg)
+	return nil
+}
+
+func (s *fakeDKGState) Running() bool {
+	return true
+}
+
+// setupPhaseFirstBlock returns the first block of the setup phase of the test epoch.
+func setupPhaseFirstBlock() *flow.Header {
+	first := unittest.BlockHeaderFixture()
+	first.View = testStartView
+	return &first
+}
+
+// newTestEngines creates DKG engines for the given participants of the next epoch, whose
+// conduits are connected with each other.
+func newTestEngines(
+	t *testing.T,
+	identities flow.IdentityList,
+	participants flow.IdentityList,
+	setup func(int, *storagemock.DKGResults),
+) []*Engine {
+
+	epoch := new(protocol.Epoch)
+	epoch.On("Counter").Return(testCounter, nil)
+	epoch.On("InitialIdentities").Return(identities, nil)
+	epochs := new(protocol.EpochQuery)
+	epochs.On("Next").Return(epoch)
+	final := new(protocol.Snapshot)
+	final.On("Epochs").Return(epochs)
+	final.On("Phase").Return(flow.EpochPhaseStaking, nil)
+	final.On("Head").Return(setupPhaseFirstBlock(), nil)
+	state := new(protocol.State)
+	state.On("Final").Return(final)
+
+	engines := make([]*Engine, len(participants))
+	deliver := func(originID flow.Identifier, event interface{}, targetIDs ...flow.Identifier) {
+		for _, targetID := range targetIDs {
+			for i, participant := range participants {
+				if participant.NodeID == targetID {
+					engines[i].Submit(originID, event)
+				}
+			}
+		}
+	}
+
+	for i, participant := range participants {
+		me := new(module.Local)
+		me.On("NodeID").Return(participant.NodeID)
+
+		originID := participant.NodeID
+		con := new(network.Conduit)
+		con.On("Unicast", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			deliver(originID, args[0], args[1].(flow.Identifier))
+		}).Return(nil)
+		con.On("Publish", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			targetIDs := make([]flow.Identifier, 0, len(args)-1)
+			for _, arg := range args[1:] {
+				targetIDs = append(targetIDs, arg.(flow.Identifier))
+			}
+			deliver(originID, args[0], targetIDs...)
+		}).Return(nil)
+		net := new(module.Network)
+		net.On("Register", engine.DKGCommittee, mock.Anything).Return(con, nil)
+
+		results := new(storagemock.DKGResults)
+		setup(i, results)
+
+		eng, err := New(zerolog.Nop(), net, me, state, results, testPhaseViews)
+		require.NoError(t, err)
+		<-eng.Ready()
+		t.Cleanup(func() { <-eng.Done() })
+
+		engines[i] = eng
+	}
+
+	return engines
+}
@@ -0,0 +1,114 @@
+package dkg
+
+import (
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/messages"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/utils/logging"
+)
+
+// processor implements the crypto.DKGProcessor of a DKG instance, which delivers the
+// messages of the instance to the other participants over the network.
+type processor struct {
+	log          zerolog.Logger
+	con          network.Conduit
+	counter      uint64
+	participants flow.IdentityList
+	index        int
+	bcast        *broadcast
+}
+
+// PrivateSend sends a message to a single participant. The message is confidential, which
+// is ensured by sending it over the encrypted 1-1 connection to the participant.
+func (proc *processor) PrivateSend(dest int, data []byte) {
+	if dest < 0 || dest >= len(proc.participants) {
+		proc.log.Error().Int("dest", dest).Msg("invalid dkg message recipient")
+		return
+	}
+	targetID := proc.participants[dest].NodeID
+	msg := &messages.DKGMessage{
+		EpochCounter: proc.counter,
+		Data:         data,
+	}
+	err := proc.con.Unicast(msg, targetID)
+	if err != nil {
+		proc.log.Error().Err(err).
+			Hex("target_id", logging.ID(targetID)).
+			Msg("could not send private dkg message")
+	}
+}
+
+// Broadcast sends a message to all other participants. The participants only hand the
+// message over to their DKG once a quorum of them echoed it, so that a dealer can not
+// send different messages to different participants.
+func (proc *processor) Broadcast(data []byte) {
+	msg := &messages.DKGBroadcast{
+		EpochCounter: proc.counter,
+		Sequence:     proc.bcast.next(),
+		Data:         data,
+	}
+	err := proc.con.Publish(msg, proc.others()...)
+	if err != nil {
+		proc.log.Error().Err(err).Msg("could not broadcast dkg message")
+	}
+}
+
+// Echo sends the echo of a message broadcast by the dealer to all participants other
+// than the dealer.
+func (proc *processor) Echo(dealerID flow.Identifier, sequence uint64, dataHash flow.Identifier) {
+	msg := &messages.DKGEcho{
+		EpochCounter: proc.counter,
+		DealerID:     dealerID,
+		Sequence:     sequence,
+		DataHash:     dataHash,
+	}
+	targetIDs := make([]flow.Identifier, 0, len(proc.participants))
+	for _, targetID := range proc.others() {
+		if targetID != dealerID {
+			targetIDs = append(targetIDs, targetID)
+		}
+	}
+	err := proc.con.Publish(msg, targetIDs...)
+	if err != nil {
+		proc.log.Error().Err(err).
+			Hex("dealer_id", logging.ID(dealerID)).
+			Msg("could not echo dkg message")
+	}
+}
+
+// Blacklist logs that a participant was disqualified from the DKG.
+func (proc *processor) Blacklist(node int) {
+	proc.log.Warn().
+		Hex("node_id", proc.nodeID(node)).
+		Int("node_index", node).
+		Msg("participant disqualified from dkg")
+}
+
+// FlagMisbehavior logs misbehavior of a participant which did not lead to its disqualification.
+func (proc *processor) FlagMisbehavior(node int, logData string) {
+	proc.log.Warn().
+		Hex("node_id", proc.nodeID(node)).
+		Int("node_index", node).
+		Str("reason", logData).
+		Msg("participant misbehaved in dkg")
+}
+
+// others returns the node IDs of all other participants.
+func (proc *processor) others() []flow.Identifier {
+	targetIDs := make([]flow.Identifier, 0, len(proc.participants)-1)
+	for i, participant := range proc.participants {
+		if i != proc.index {
+			targetIDs = append(targetIDs, participant.NodeID)
+		}
+	}
+	return targetIDs
+}
+
+func (proc *processor) nodeID(node int) []byte {
+	if node < 0 || node >= len(proc.participants) {
+		return nil
+	}
+	return logging.ID(proc.participants[node].NodeID)
+}
@@ -0,0 +1,529 @@
+package checkpoints
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/model/messages"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/ledger"
+	"github.com/onflow/flow-go/storage/ledger/mtrie/node"
+	"github.com/onflow/flow-go/storage/ledger/mtrie/statesync"
+	"github.com/onflow/flow-go/storage/ledger/mtrie/trie"
+	"github.com/onflow/flow-go/utils/logging"
+)
+
+// DefaultRequestTimeout is the default time to wait for the response to a request, before requesting from another peer
+const DefaultRequestTimeout = time.Minute
+
+// maxRounds is the number of times each peer is asked for the manifest or a piece before giving up
+const maxRounds = 3
+
+// maxInFlight is the maximum number of pieces requested at the same time
+const maxInFlight = 16
+
+// Ledger is the ledger from which checkpoints are provided to other nodes, and into which checkpoints
+// obtained from other nodes are imported.
+type Ledger interface {
+	Trie(stateCommitment flow.StateCommitment) (*trie.MTrie, error)
+	ImportTrie(t *trie.MTrie) (flow.StateCommitment, error)
+}
+
+// Engine provides checkpoints of the execution state to other execution nodes, and synchronizes the execution
+// state of the latest sealed block from other execution nodes. A checkpoint is transferred as a manifest, which
+// is verified against the sealed state commitment, and pieces of the trie, which are verified against the
+// manifest, so that no trust in the providing nodes is required.
+type Engine struct {
+	unit      *engine.Unit
+	log       zerolog.Logger
+	me        module.Local
+	state     protocol.ReadOnlyState
+	execState state.ExecutionState
+	ledger    Ledger
+	con       network.Conduit
+	depth     int
+	timeout   time.Duration
+
+	// the manifest which was provided last, along with the roots of its pieces
+	providedLock   sync.Mutex
+	providedCommit flow.StateCommitment
+	provided       *statesync.Manifest
+	providedRoots  []*node.Node
+
+	// responses to the requests of a running synchronization
+	manifests chan *manifestResponse
+	pieces    chan *pieceResponse
+}
+
+type manifestResponse struct {
+	originID flow.Identifier
+	*messages.CheckpointManifestResponse
+}
+
+type pieceResponse struct {
+	originID flow.Identifier
+	*messages.CheckpointPieceResponse
+}
+
+// New creates a new checkpoint engine, which splits checkpoints into pieces at the given depth.
+func New(
+	logger zerolog.Logger,
+	net module.Network,
+	me module.Local,
+	state protocol.ReadOnlyState,
+	execState state.ExecutionState,
+	ledger Ledger,
+	depth int,
+	timeout time.Duration,
+) (*Engine, error) {
+
+	if depth < 0 || depth > statesync.MaxDepth {
+		return nil, fmt.Errorf("invalid depth %d for splitting checkpoints", depth)
+	}
+
+	e := &Engine{
+		unit:      engine.NewUnit(),
+		log:       logger.With().Str("engine", "checkpoints").Logger(),
+		me:        me,
+		state:     state,
+		execState: execState,
+		ledger:    ledger,
+		depth:     depth,
+		timeout:   timeout,
+		manifests: make(chan *manifestResponse, maxInFlight),
+		pieces:    make(chan *pieceResponse, maxInFlight),
+	}
+
+	con, err := net.Register(engine.SyncCheckpoints, e)
+	if err != nil {
+		return nil, fmt.Errorf("could not register checkpoint engine: %w", err)
+	}
+	e.con = con
+
+	return e, nil
+}
+
+// Ready returns a channel that will close when the engine has
+// successfully started.
+func (e *Engine) Ready() <-chan struct{} {
+	return e.unit.Ready()
+}
+
+// Done returns a channel that will close when the engine has
+// successfully stopped.
+func (e *Engine) Done() <-chan struct{} {
+	return e.unit.Done()
+}
+
+// SubmitLocal submits an event originating on the local node.
+func (e *Engine) SubmitLocal(event interface{}) {
+	e.Submit(e.me.NodeID(), event)
+}
+
+// Submit submits the given event from the node with the given origin ID
+// for processing in a non-blocking manner.
+func (e *Engine) Submit(originID flow.Identifier, event interface{}) {
+	e.unit.Launch(func() {
+		err := e.Process(originID, event)
+		if err != nil {
+			engine.LogError(e.log, err)
+		}
+	})
+}
+
+// ProcessLocal processes an event originating on the local node.
+func (e *Engine) ProcessLocal(event interface{}) error {
+	return e.Process(e.me.NodeID(), event)
+}
+
+// Process processes the given event from the node with the given origin ID
+// in a blocking manner.
+func (e *Engine) Process(originID flow.Identifier, event interface{}) error {
+	return e.unit.Do(func() error {
+		return e.process(originID, event)
+	})
+}
+
+func (e *Engine) process(originID flow.Identifier, event interface{}) error {
+	switch v := event.(type) {
+	case *messages.CheckpointManifestRequest:
+		return e.onManifestRequest(originID, v)
+	case *messages.CheckpointPieceRequest:
+		return e.onPieceRequest(originID, v)
+	case *messages.CheckpointManifestResponse:
+		// responses are handed over to a running synchronization, or dropped if there is none
+		select {
+		case e.manifests <- &manifestResponse{originID: originID, CheckpointManifestResponse: v}:
+		default:
+			e.log.Debug().Hex("origin_id", logging.ID(originID)).Msg("dropped unexpected checkpoint manifest")
+		}
+		return nil
+	case *messages.CheckpointPieceResponse:
+		select {
+		case e.pieces <- &pieceResponse{originID: originID, CheckpointPieceResponse: v}:
+		default:
+			e.log.Debug().Hex("origin_id", logging.ID(originID)).Msg("dropped unexpected checkpoint piece")
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid event type (%T)", event)
+	}
+}
+
+// onManifestRequest sends the manifest of the requested state to the requester, if the state is available.
+func (e *Engine) onManifestRequest(originID flow.Identifier, req *messages.CheckpointManifestRequest) error {
+	err := e.checkOrigin(originID)
+	if err != nil {
+		return err
+	}
+
+	manifest, _, err := e.manifest(req.Commit, int(req.Depth))
+	if errors.Is(err, storage.ErrNotFound) {
+		// we might not have the requested state (anymore)
+		e.log.Debug().Hex("commit", req.Commit).Msg("requested checkpoint not available")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not get manifest: %w", err)
+	}
+
+	res := &messages.CheckpointManifestResponse{
+		Commit:      req.Commit,
+		Depth:       req.Depth,
+		PieceHashes: manifest.PieceHashes,
+		Nonce:       rand.Uint64(),
+	}
+	err = e.con.Unicast(res, originID)
+	if err != nil {
+		return fmt.Errorf("could not send checkpoint manifest to (%x): %w", originID, err)
+	}
+	return nil
+}
+
+// onPieceRequest sends the requested piece of the checkpoint of a state to the requester, if the state is available.
+func (e *Engine) onPieceRequest(originID flow.Identifier, req *messages.CheckpointPieceRequest) error {
+	err := e.checkOrigin(originID)
+	if err != nil {
+		return err
+	}
+
+	manifest, roots, err := e.manifest(req.Commit, int(req.Depth))
+	if errors.Is(err, storage.ErrNotFound) {
+		e.log.Debug().Hex("commit", req.Commit).Msg("requested checkpoint not available")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not get manifest: %w", err)
+	}
+
+	index := int(req.Index)
+	if index >= len(roots) || manifest.IsEmpty(index) {
+		return engine.NewInvalidInputErrorf("invalid checkpoint piece %d", index)
+	}
+
+	res := &messages.CheckpointPieceResponse{
+		Commit: req.Commit,
+		Depth:  req.Depth,
+		Index:  req.Index,
+		Data:   statesync.EncodePiece(roots[index]),
+		Nonce:  rand.Uint64(),
+	}
+	err = e.con.Unicast(res, originID)
+	if err != nil {
+		return fmt.Errorf("could not send checkpoint piece to (%x): %w", originID, err)
+	}
+	return nil
+}
+
+// checkOrigin checks that checkpoints are only requested by execution nodes
+func (e *Engine) checkOrigin(originID flow.Identifier) error {
+	origin, err := e.state.Final().Identity(originID)
+	if err != nil {
+		return engine.NewInvalidInputErrorf("invalid origin id (%x): %w", originID, err)
+	}
+	if origin.Role != flow.RoleExecution {
+		return engine.NewInvalidInputErrorf("invalid role for requesting checkpoints: %s", origin.Role)
+	}
+	return nil
+}
+
+// manifest returns the manifest of the given state split at the given depth, along with the roots of its pieces.
+// As all pieces of a checkpoint are requested after each other, the last manifest is kept.
+func (e *Engine) manifest(commit flow.StateCommitment, depth int) (*statesync.Manifest, []*node.Node, error) {
+	if depth < 0 || depth > statesync.MaxDepth {
+		return nil, nil, engine.NewInvalidInputErrorf("invalid checkpoint depth %d", depth)
+	}
+
+	e.providedLock.Lock()
+	defer e.providedLock.Unlock()
+
+	if e.provided != nil && bytes.Equal(e.providedCommit, commit) && e.provided.Depth == depth {
+		return e.provided, e.providedRoots, nil
+	}
+
+	t, err := e.ledger.Trie(commit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not get trie (%x): %v: %w", commit, err, storage.ErrNotFound)
+	}
+	manifest, roots, err := statesync.NewManifest(t, depth)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not split trie: %w", err)
+	}
+
+	e.providedCommit = commit
+	e.provided = manifest
+	e.providedRoots = roots
+
+	return manifest, roots, nil
+}
+
+// SyncSealedState synchronizes the execution state of the latest sealed block from other execution nodes,
+// imports it into the ledger and records the block as executed, so that the execution of its descendants
+// can resume from there. If the sealed block was executed already, nothing is synchronized. It returns the
+// header of the sealed block.
+func (e *Engine) SyncSealedState(ctx context.Context) (*flow.Header, error) {
+	sealed := e.state.Sealed()
+	header, err := sealed.Head()
+	if err != nil {
+		return nil, fmt.Errorf("could not get sealed block: %w", err)
+	}
+	commit, err := sealed.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("could not get sealed state commitment: %w", err)
+	}
+
+	log := e.log.With().
+		Hex("block_id", logging.Entity(header)).
+		Uint64("height", header.Height).
+		Hex("commit", commit).
+		Logger()
+
+	_, err = e.execState.StateCommitmentByBlockID(ctx, header.ID())
+	if err == nil {
+		log.Info().Msg("sealed block executed already, no state to synchronize")
+		return header, nil
+	}
+	if !errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("could not get state commitment of sealed block: %w", err)
+	}
+
+	log.Info().Msg("synchronizing sealed execution state")
+
+	t, err := e.fetch(ctx, commit)
+	if err != nil {
+		return nil, fmt.Errorf("could not synchronize execution state: %w", err)
+	}
+
+	_, err = e.ledger.ImportTrie(t)
+	if err != nil {
+		return nil, fmt.Errorf("could not import execution state: %w", err)
+	}
+	err = e.execState.PersistStateCommitment(ctx, header.ID(), commit)
+	if err != nil {
+		return nil, fmt.Errorf("could not persist state commitment: %w", err)
+	}
+	err = e.execState.UpdateHighestExecutedBlockIfHigher(ctx, header)
+	if err != nil {
+		return nil, fmt.Errorf("could not update highest executed block: %w", err)
+	}
+
+	log.Info().Uint64("reg_count", t.AllocatedRegCount()).Msg("sealed execution state synchronized")
+
+	return header, nil
+}
+
+// fetch obtains the trie of the given state from other execution nodes
+func (e *Engine) fetch(ctx context.Context, commit flow.StateCommitment) (*trie.MTrie, error) {
+	peers, err := e.state.Final().Identities(filter.And(
+		filter.HasRole(flow.RoleExecution),
+		filter.Not(filter.HasNodeID(e.me.NodeID())),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("could not get execution nodes: %w", err)
+	}
+	if len(peers) == 0 {
+		return nil, fmt.Errorf("no execution nodes to synchronize from")
+	}
+	peerIDs := peers.NodeIDs()
+	rand.Shuffle(len(peerIDs), func(i, j int) {
+		peerIDs[i], peerIDs[j] = peerIDs[j], peerIDs[i]
+	})
+
+	manifest, err := e.fetchManifest(ctx, commit, peerIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	pieces, err := e.fetchPieces(ctx, commit, manifest, peerIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := statesync.Assemble(manifest, pieces)
+	if err != nil {
+		return nil, fmt.Errorf("could not assemble trie: %w", err)
+	}
+	return t, nil
+}
+
+// fetchManifest requests the manifest of the state from the peers in turn, until a valid manifest is received.
+func (e *Engine) fetchManifest(ctx context.Context, commit flow.StateCommitment, peerIDs []flow.Identifier) (*statesync.Manifest, error) {
+	for attempt := 0; attempt < maxRounds*len(peerIDs); attempt++ {
+		peerID := peerIDs[attempt%len(peerIDs)]
+		log := e.log.With().Hex("peer_id", logging.ID(peerID)).Logger()
+
+		req := &messages.CheckpointManifestRequest{
+			Commit: commit,
+			Depth:  uint8(e.depth),
+			Nonce:  rand.Uint64(),
+		}
+		err := e.con.Unicast(req, peerID)
+		if err != nil {
+			log.Warn().Err(err).Msg("could not request checkpoint manifest")
+			continue
+		}
+
+		manifest, err := e.awaitManifest(ctx, commit, peerID)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if err != nil {
+			log.Warn().Err(err).Msg("could not obtain checkpoint manifest")
+			continue
+		}
+		return manifest, nil
+	}
+	return nil, fmt.Errorf("could not obtain checkpoint manifest from %d execution nodes", len(peerIDs))
+}
+
+// awaitManifest waits for the manifest of the state from the given peer, and verifies it
+func (e *Engine) awaitManifest(ctx context.Context, commit flow.StateCommitment, peerID flow.Identifier) (*statesync.Manifest, error) {
+	timer := time.NewTimer(e.timeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+			return nil, fmt.Errorf("request timed out")
+		case res := <-e.manifests:
+			if res.originID != peerID || !bytes.Equal(res.Commit, commit) || int(res.Depth) != e.depth {
+				continue
+			}
+			manifest := &statesync.Manifest{
+				RootHash:    commit,
+				Height:      ledger.RegisterKeySize * 8,
+				Depth:       e.depth,
+				PieceHashes: res.PieceHashes,
+			}
+			err := manifest.Verify(commit)
+			if err != nil {
+				return nil, fmt.Errorf("invalid manifest: %w", err)
+			}
+			return manifest, nil
+		}
+	}
+}
+
+// fetchPieces requests the pieces of the manifest from the peers in turn, and verifies them as they arrive.
+// Pieces which are not received in time or are invalid are requested from the next peer.
+func (e *Engine) fetchPieces(
+	ctx context.Context,
+	commit flow.StateCommitment,
+	manifest *statesync.Manifest,
+	peerIDs []flow.Identifier,
+) (map[int]*node.Node, error) {
+
+	type request struct {
+		peerID   flow.Identifier
+		deadline time.Time
+	}
+
+	missing := manifest.Pieces()
+	total := len(missing)
+	pieces := make(map[int]*node.Node, total)
+	inFlight := make(map[int]request)
+	attempts := make(map[int]int)
+	next := 0
+
+	ticker := time.NewTicker(e.timeout / 4)
+	defer ticker.Stop()
+
+	for len(pieces) < total {
+		// request missing pieces from the peers in turn
+		for len(missing) > 0 && len(inFlight) < maxInFlight {
+			index := missing[0]
+			missing = missing[1:]
+
+			attempts[index]++
+			if attempts[index] > maxRounds*len(peerIDs) {
+				return nil, fmt.Errorf("could not obtain checkpoint piece %d from %d execution nodes", index, len(peerIDs))
+			}
+			peerID := peerIDs[next%len(peerIDs)]
+			next++
+
+			req := &messages.CheckpointPieceRequest{
+				Commit: commit,
+				Depth:  uint8(e.depth),
+				Index:  uint32(index),
+				Nonce:  rand.Uint64(),
+			}
+			err := e.con.Unicast(req, peerID)
+			if err != nil {
+				e.log.Warn().Err(err).Hex("peer_id", logging.ID(peerID)).Int("piece", index).Msg("could not request checkpoint piece")
+				missing = append(missing, index)
+				continue
+			}
+			inFlight[index] = request{peerID: peerID, deadline: time.Now().Add(e.timeout)}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+
+		case now := <-ticker.C:
+			for index, req := range inFlight {
+				if now.After(req.deadline) {
+					e.log.Warn().Hex("peer_id", logging.ID(req.peerID)).Int("piece", index).Msg("checkpoint piece request timed out")
+					delete(inFlight, index)
+					missing = append(missing, index)
+				}
+			}
+
+		case res := <-e.pieces:
+			index := int(res.Index)
+			req, ok := inFlight[index]
+			if !ok || req.peerID != res.originID || !bytes.Equal(res.Commit, commit) || int(res.Depth) != e.depth {
+				continue
+			}
+			delete(inFlight, index)
+
+			piece, err := statesync.DecodePiece(manifest, index, res.Data)
+			if err != nil {
+				e.log.Warn().Err(err).Hex("peer_id", logging.ID(res.originID)).Int("piece", index).Msg("received invalid checkpoint piece")
+				missing = append(missing, index)
+				continue
+			}
+			pieces[index] = piece
+
+			e.log.Debug().Int("piece", index).Int("received", len(pieces)).Int("total", total).Msg("checkpoint piece received")
+		}
+	}
+
+	return pieces, nil
+}
@@ -0,0 +1,182 @@
+package checkpoints
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine"
+	state "github.com/onflow/flow-go/engine/execution/state/mock"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/messages"
+	"github.com/onflow/flow-go/module/metrics"
+	module "github.com/onflow/flow-go/module/mock"
+	network "github.com/onflow/flow-go/network/mock"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/ledger"
+	"github.com/onflow/flow-go/storage/ledger/mtrie/common"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// testNode is an execution node with a ledger and a checkpoint engine
+type testNode struct {
+	identity  *flow.Identity
+	ledger    *ledger.MTrieStorage
+	execState *state.ExecutionState
+	engine    *Engine
+}
+
+// tamper modifies the events sent by a node
+type tamper func(event interface{}) interface{}
+
+func TestEngine_SyncSealedState(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		ids := common.GetRandomKeysFixedN(500, ledger.RegisterKeySize)
+		values := common.GetRandomValues(len(ids), 64)
+
+		var sealedCommit flow.StateCommitment
+		nodes := newTestNodes(t, dir, 3, func(led *ledger.MTrieStorage) {
+			var err error
+			sealedCommit, err = led.UpdateRegisters(ids, values, led.EmptyStateCommitment())
+			require.NoError(t, err)
+		}, map[int]tamper{
+			// the second provider sends invalid pieces
+			1: func(event interface{}) interface{} {
+				res, ok := event.(*messages.CheckpointPieceResponse)
+				if !ok {
+					return event
+				}
+				tampered := *res
+				tampered.Data = res.Data[:len(res.Data)-1]
+				return &tampered
+			},
+		})
+		requester := nodes[2]
+
+		sealed := unittest.BlockHeaderFixture()
+		sealedSnapshot := new(protocol.Snapshot)
+		sealedSnapshot.On("Head").Return(&sealed, nil)
+		sealedSnapshot.On("Commit").Return(sealedCommit, nil)
+		requester.engine.state.(*protocol.State).On("Sealed").Return(sealedSnapshot)
+
+		requester.execState.On("StateCommitmentByBlockID", mock.Anything, sealed.ID()).Return(nil, storage.ErrNotFound)
+		requester.execState.On("PersistStateCommitment", mock.Anything, sealed.ID(), sealedCommit).Return(nil).Once()
+		requester.execState.On("UpdateHighestExecutedBlockIfHigher", mock.Anything, &sealed).Return(nil).Once()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		header, err := requester.engine.SyncSealedState(ctx)
+		require.NoError(t, err)
+		require.Equal(t, sealed.ID(), header.ID())
+		requester.execState.AssertExpectations(t)
+
+		// the synchronized state is available in the ledger of the requester
+		retValues, err := requester.ledger.GetRegisters(ids, sealedCommit)
+		require.NoError(t, err)
+		require.Equal(t, values, retValues)
+	})
+}
+
+func TestEngine_SyncSealedState_Executed(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		nodes := newTestNodes(t, dir, 2, func(*ledger.MTrieStorage) {}, nil)
+		requester := nodes[1]
+
+		sealed := unittest.BlockHeaderFixture()
+		commit := unittest.StateCommitmentFixture()
+		sealedSnapshot := new(protocol.Snapshot)
+		sealedSnapshot.On("Head").Return(&sealed, nil)
+		sealedSnapshot.On("Commit").Return(commit, nil)
+		requester.engine.state.(*protocol.State).On("Sealed").Return(sealedSnapshot)
+
+		// the sealed block was executed already, so nothing is requested
+		requester.execState.On("StateCommitmentByBlockID", mock.Anything, sealed.ID()).Return(commit, nil)
+
+		header, err := requester.engine.SyncSealedState(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, sealed.ID(), header.ID())
+		requester.execState.AssertExpectations(t)
+	})
+}
+
+func TestEngine_onManifestRequest(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		nodes := newTestNodes(t, dir, 1, func(*ledger.MTrieStorage) {}, nil)
+		provider := nodes[0]
+
+		// only execution nodes are allowed to request checkpoints
+		verifier := unittest.IdentityFixture(unittest.WithRole(flow.RoleVerification))
+		provider.engine.state.Final().(*protocol.Snapshot).On("Identity", verifier.NodeID).Return(verifier, nil)
+		req := &messages.CheckpointManifestRequest{Commit: provider.ledger.EmptyStateCommitment(), Depth: 4}
+		err := provider.engine.onManifestRequest(verifier.NodeID, req)
+		require.True(t, engine.IsInvalidInputError(err))
+	})
+}
+
+// newTestNodes creates execution nodes whose engines are connected with each other. The ledgers of all
+// nodes except the last one are initialized with the given function, and the events sent by the nodes
+// are modified by the given tampers.
+func newTestNodes(t *testing.T, dir string, n int, initialize func(*ledger.MTrieStorage), tampers map[int]tamper) []*testNode {
+	identities := unittest.IdentityListFixture(n, unittest.WithRole(flow.RoleExecution))
+	nodes := make([]*testNode, n)
+
+	final := new(protocol.Snapshot)
+	final.On("Identities", mock.Anything).Return(
+		func(selector flow.IdentityFilter) flow.IdentityList {
+			return identities.Filter(selector)
+		},
+		nil,
+	)
+	for _, identity := range identities {
+		final.On("Identity", identity.NodeID).Return(identity, nil)
+	}
+
+	for i, identity := range identities {
+		led, err := ledger.NewMTrieStorage(dir+"/"+identity.NodeID.String(), 100, &metrics.NoopCollector{}, nil)
+		require.NoError(t, err)
+		t.Cleanup(led.CloseStorage)
+		if i < n-1 {
+			initialize(led)
+		}
+
+		me := new(module.Local)
+		me.On("NodeID").Return(identity.NodeID)
+		protoState := new(protocol.State)
+		protoState.On("Final").Return(final)
+
+		originID := identity.NodeID
+		modify := tampers[i]
+		con := new(network.Conduit)
+		con.On("Unicast", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			event := args[0]
+			if modify != nil {
+				event = modify(event)
+			}
+			for _, node := range nodes {
+				if node.identity.NodeID == args[1].(flow.Identifier) {
+					node.engine.Submit(originID, event)
+				}
+			}
+		}).Return(nil)
+		net := new(module.Network)
+		net.On("Register", engine.SyncCheckpoints, mock.Anything).Return(con, nil)
+
+		execState := new(state.ExecutionState)
+		eng, err := New(zerolog.Nop(), net, me, protoState, execState, led, 4, time.Second)
+		require.NoError(t, err)
+
+		nodes[i] = &testNode{
+			identity:  identity,
+			ledger:    led,
+			execState: execState,
+			engine:    eng,
+		}
+	}
+
+	return nodes
+}
@@ -37,15 +37,21 @@ type blockComputer struct {
 	tracer         module.Tracer
 	log            zerolog.Logger
 	systemChunkCtx fvm.Context
+	parallel       bool // execute the transactions of a collection optimistically in parallel
 }
 
 // NewBlockComputer creates a new block executor.
+//
+// If parallel is set, the transactions of each collection are executed optimistically in parallel,
+// and transactions that conflict with preceding transactions of the collection are re-executed in
+// order. The results are identical to sequential execution.
 func NewBlockComputer(
 	vm VirtualMachine,
 	vmCtx fvm.Context,
 	metrics module.ExecutionMetrics,
 	tracer module.Tracer,
 	logger zerolog.Logger,
+	parallel bool,
 ) (BlockComputer, error) {
 	systemChunkASTCache, err := fvm.NewLRUASTCache(SystemChunkASTCacheSize)
 	if err != nil {
@@ -67,6 +73,7 @@ func NewBlockComputer(
 		tracer:         tracer,
 		log:            logger,
 		systemChunkCtx: systemChunkCtx,
+		parallel:       parallel,
 	}, nil
 }
 
@@ -186,6 +193,10 @@ func (e *blockComputer) executeCollection(
 		defer colSpan.Finish()
 	}
 
+	if e.parallel {
+		return e.executeCollectionParallel(colSpan, txIndex, blockCtx, collectionView, collection)
+	}
+
 	var (
 		events    []flow.Event
 		txResults []flow.TransactionResult
@@ -220,6 +231,25 @@ func (e *blockComputer) executeTransaction(
 	ctx fvm.Context,
 	txIndex uint32,
 ) ([]flow.Event, flow.TransactionResult, uint64, error) {
+
+	txView := collectionView.NewChild()
+
+	tx, err := e.runTransaction(txBody, colSpan, txMetrics, txView, ctx)
+	if err != nil {
+		return nil, flow.TransactionResult{}, 0, err
+	}
+
+	return e.commitTransaction(txBody, tx, txView, collectionView, txIndex)
+}
+
+// runTransaction executes the transaction on the given transaction view.
+func (e *blockComputer) runTransaction(
+	txBody *flow.TransactionBody,
+	colSpan opentracing.Span,
+	txMetrics *fvm.MetricsCollector,
+	txView *delta.View,
+	ctx fvm.Context,
+) (*fvm.TransactionProcedure, error) {
 	if e.tracer != nil {
 		txSpan := e.tracer.StartSpanFromParent(colSpan, trace.EXEComputeTransaction)
 
@@ -239,8 +269,6 @@ func (e *blockComputer) executeTransaction(
 		}()
 	}
 
-	txView := collectionView.NewChild()
-
 	tx := fvm.Transaction(txBody)
 
 	err := e.vm.Run(ctx, tx, txView)
@@ -252,9 +280,22 @@ func (e *blockComputer) executeTransaction(
 	}
 
 	if err != nil {
-		return nil, flow.TransactionResult{}, 0, fmt.Errorf("failed to execute transaction: %w", err)
+		return nil, fmt.Errorf("failed to execute transaction: %w", err)
 	}
 
+	return tx, nil
+}
+
+// commitTransaction converts the outcome of the executed transaction into its events and result, and
+// merges the transaction view into the collection view if the transaction succeeded.
+func (e *blockComputer) commitTransaction(
+	txBody *flow.TransactionBody,
+	tx *fvm.TransactionProcedure,
+	txView *delta.View,
+	collectionView *delta.View,
+	txIndex uint32,
+) ([]flow.Event, flow.TransactionResult, uint64, error) {
+
 	txEvents, err := tx.ConvertEvents(txIndex)
 
 	if err != nil {
@@ -262,7 +303,8 @@ func (e *blockComputer) executeTransaction(
 	}
 
 	txResult := flow.TransactionResult{
-		TransactionID: tx.ID,
+		TransactionID:   tx.ID,
+		ComputationUsed: tx.GasUsed,
 	}
 
 	if tx.Err != nil {
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/onflow/cadence"
@@ -12,10 +14,12 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/onflow/flow-go/engine/execution"
 	"github.com/onflow/flow-go/engine/execution/computation/computer"
 	computermock "github.com/onflow/flow-go/engine/execution/computation/computer/mock"
 	"github.com/onflow/flow-go/engine/execution/state/delta"
 	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/state"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module/mempool/entity"
 )
@@ -28,7 +32,7 @@ func TestBlockExecutor_ExecuteBlock(t *testing.T) {
 
 		vm := new(computermock.VirtualMachine)
 
-		exe, err := computer.NewBlockComputer(vm, execCtx, nil, nil, zerolog.Nop())
+		exe, err := computer.NewBlockComputer(vm, execCtx, nil, nil, zerolog.Nop(), false)
 		require.NoError(t, err)
 
 		// create a block with 1 collection with 2 transactions
@@ -55,7 +59,7 @@ func TestBlockExecutor_ExecuteBlock(t *testing.T) {
 
 		vm := new(computermock.VirtualMachine)
 
-		exe, err := computer.NewBlockComputer(vm, execCtx, nil, nil, zerolog.Nop())
+		exe, err := computer.NewBlockComputer(vm, execCtx, nil, nil, zerolog.Nop(), false)
 		require.NoError(t, err)
 
 		// create an empty block
@@ -82,7 +86,7 @@ func TestBlockExecutor_ExecuteBlock(t *testing.T) {
 
 		vm := new(computermock.VirtualMachine)
 
-		exe, err := computer.NewBlockComputer(vm, execCtx, nil, nil, zerolog.Nop())
+		exe, err := computer.NewBlockComputer(vm, execCtx, nil, nil, zerolog.Nop(), false)
 		require.NoError(t, err)
 
 		collectionCount := 2
@@ -147,6 +151,131 @@ func TestBlockExecutor_ExecuteBlock(t *testing.T) {
 	})
 }
 
+// registerVM is a virtual machine that interprets each line of a transaction script as an operation on
+// a register: "inc <key>" increments the register, "read <key>" emits an event with its value, and
+// "fail" fails the transaction, which discards its changes. All other lines are ignored.
+type registerVM struct{}
+
+func (registerVM) Run(_ fvm.Context, proc fvm.Procedure, ledger state.Ledger) error {
+	tx := proc.(*fvm.TransactionProcedure)
+
+	for _, op := range strings.Split(string(tx.Transaction.Script), "\n") {
+		fields := strings.Fields(op)
+		if len(fields) == 1 && fields[0] == "fail" {
+			tx.Err = &fvm.MissingPayerError{}
+			continue
+		}
+		if len(fields) != 2 || (fields[0] != "inc" && fields[0] != "read") {
+			continue
+		}
+
+		value, err := ledger.Get("owner", "controller", fields[1])
+		if err != nil {
+			return err
+		}
+
+		counter := 0
+		if len(value) > 0 {
+			counter, err = strconv.Atoi(string(value))
+			if err != nil {
+				return err
+			}
+		}
+
+		switch fields[0] {
+		case "inc":
+			ledger.Set("owner", "controller", fields[1], []byte(strconv.Itoa(counter+1)))
+		case "read":
+			tx.Events = append(tx.Events, cadence.Event{EventType: &cadence.EventType{
+				TypeID: fmt.Sprintf("%s=%d", fields[1], counter),
+			}})
+		}
+	}
+
+	return nil
+}
+
+func TestBlockExecutor_ExecuteBlockParallel(t *testing.T) {
+
+	scripts := [][]string{
+		{
+			"inc a\nread a",
+			"read a",        // conflicts with the first transaction
+			"inc b\nfail",   // changes are discarded
+			"read b\ninc c", // does not conflict with the failed transaction
+			"read x",
+		},
+		{
+			"read c\ninc c",
+			"inc d",
+			"inc d\nread d",
+			"read e",
+		},
+	}
+
+	guarantees := make([]*flow.CollectionGuarantee, 0, len(scripts))
+	completeCollections := make(map[flow.Identifier]*entity.CompleteCollection)
+	for _, collectionScripts := range scripts {
+		transactions := make([]*flow.TransactionBody, 0, len(collectionScripts))
+		for _, script := range collectionScripts {
+			transactions = append(transactions, &flow.TransactionBody{Script: []byte(script)})
+		}
+
+		collection := flow.Collection{Transactions: transactions}
+		guarantee := &flow.CollectionGuarantee{CollectionID: collection.ID()}
+
+		guarantees = append(guarantees, guarantee)
+		completeCollections[guarantee.ID()] = &entity.CompleteCollection{
+			Guarantee:    guarantee,
+			Transactions: transactions,
+		}
+	}
+
+	block := &entity.ExecutableBlock{
+		Block: &flow.Block{
+			Header:  &flow.Header{View: 42},
+			Payload: &flow.Payload{Guarantees: guarantees},
+		},
+		CompleteCollections: completeCollections,
+	}
+
+	execute := func(parallel bool) *execution.ComputationResult {
+		exe, err := computer.NewBlockComputer(registerVM{}, fvm.NewContext(), nil, nil, zerolog.Nop(), parallel)
+		require.NoError(t, err)
+
+		view := delta.NewView(func(owner, controller, key string) (flow.RegisterValue, error) {
+			if key == "x" {
+				return []byte("7"), nil
+			}
+			return nil, nil
+		})
+
+		result, err := exe.ExecuteBlock(context.Background(), block, view)
+		require.NoError(t, err)
+
+		return result
+	}
+
+	sequential := execute(false)
+	parallel := execute(true)
+
+	assert.Equal(t, sequential.Events, parallel.Events)
+	assert.Equal(t, sequential.TransactionResult, parallel.TransactionResult)
+	assert.Equal(t, sequential.GasUsed, parallel.GasUsed)
+	assert.Equal(t, sequential.StateReads, parallel.StateReads)
+
+	require.Len(t, parallel.StateSnapshots, len(sequential.StateSnapshots))
+	for i, snapshot := range sequential.StateSnapshots {
+		assert.Equal(t, snapshot.Delta, parallel.StateSnapshots[i].Delta)
+		assert.Equal(t, snapshot.SpockSecret, parallel.StateSnapshots[i].SpockSecret)
+		assert.ElementsMatch(t, snapshot.Reads, parallel.StateSnapshots[i].Reads)
+	}
+
+	// the second transaction must have observed the write of the first one
+	require.Len(t, parallel.Events, 7)
+	assert.Equal(t, "a=1", string(parallel.Events[1].Type))
+}
+
 func generateBlock(collectionCount, transactionCount int) *entity.ExecutableBlock {
 	collections := make([]*entity.CompleteCollection, collectionCount)
 	guarantees := make([]*flow.CollectionGuarantee, collectionCount)
@@ -0,0 +1,178 @@
+package computer
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/mempool/entity"
+)
+
+// speculativeTransaction is the outcome of executing a transaction optimistically against the state of
+// the collection view before any transaction of the collection was applied.
+type speculativeTransaction struct {
+	tx    *fvm.TransactionProcedure
+	view  *delta.View
+	reads [][3]string // registers read from the collection view, in order
+	err   error
+}
+
+// conflicts returns true if the transaction read a register written by a preceding transaction, or if
+// it could not be executed speculatively at all.
+func (s *speculativeTransaction) conflicts(written map[string]struct{}) bool {
+	if s.err != nil {
+		return true
+	}
+
+	// the interactions of the view hold all registers touched by the transaction, which includes
+	// every register it read from the collection view
+	for _, id := range s.view.Interactions().Reads {
+		if _, ok := written[string(id)]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// executeCollectionParallel executes the transactions of a collection optimistically in parallel.
+//
+// Every transaction is first executed on an isolated view on top of the collection view. The results
+// are then committed in order: a transaction that did not read any register written by a preceding
+// transaction of the collection observed the same state as it would have during sequential execution,
+// so its reads are replayed on the collection view and its view is merged as is. All other
+// transactions are re-executed on the collection view. This yields exactly the same events, results
+// and state interactions as sequential execution.
+func (e *blockComputer) executeCollectionParallel(
+	colSpan opentracing.Span,
+	txIndex uint32,
+	blockCtx fvm.Context,
+	collectionView *delta.View,
+	collection *entity.CompleteCollection,
+) ([]flow.Event, []flow.TransactionResult, uint32, uint64, error) {
+
+	speculative := e.speculate(colSpan, blockCtx, collectionView, collection.Transactions)
+
+	var (
+		events    []flow.Event
+		txResults []flow.TransactionResult
+		gasUsed   uint64
+		conflicts int
+	)
+
+	written := make(map[string]struct{})
+
+	for i, txBody := range collection.Transactions {
+		spec := speculative[i]
+
+		tx, txView := spec.tx, spec.view
+
+		if spec.conflicts(written) {
+			conflicts++
+
+			txMetrics := fvm.NewMetricsCollector()
+			txCtx := fvm.NewContextFromParent(blockCtx, fvm.WithMetricsCollector(txMetrics))
+
+			txView = collectionView.NewChild()
+
+			var err error
+			tx, err = e.runTransaction(txBody, colSpan, txMetrics, txView, txCtx)
+			if err != nil {
+				return nil, nil, txIndex, 0, err
+			}
+		} else {
+			// replay the reads, so the collection view records them just like during sequential execution
+			for _, read := range spec.reads {
+				_, err := collectionView.Get(read[0], read[1], read[2])
+				if err != nil {
+					return nil, nil, txIndex, 0, fmt.Errorf("failed to read register: %w", err)
+				}
+			}
+		}
+
+		txEvents, txResult, txGasUsed, err := e.commitTransaction(txBody, tx, txView, collectionView, txIndex)
+
+		txIndex++
+		events = append(events, txEvents...)
+		txResults = append(txResults, txResult)
+		gasUsed += txGasUsed
+
+		if err != nil {
+			return nil, nil, txIndex, 0, err
+		}
+
+		// only the changes of successful transactions are applied to the collection view
+		if tx.Err == nil {
+			for _, id := range txView.Delta().RegisterIDs() {
+				written[string(id)] = struct{}{}
+			}
+		}
+	}
+
+	e.log.Debug().
+		Int("transactions", len(collection.Transactions)).
+		Int("conflicts", conflicts).
+		Msg("collection executed in parallel")
+
+	return events, txResults, txIndex, gasUsed, nil
+}
+
+// speculate executes all given transactions concurrently, each on its own view reading from the
+// current state of the collection view, which must not be modified until all of them finished.
+func (e *blockComputer) speculate(
+	colSpan opentracing.Span,
+	blockCtx fvm.Context,
+	collectionView *delta.View,
+	txBodies []*flow.TransactionBody,
+) []*speculativeTransaction {
+
+	speculative := make([]*speculativeTransaction, len(txBodies))
+
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, runtime.NumCPU())
+
+	for i, txBody := range txBodies {
+		spec := &speculativeTransaction{}
+		speculative[i] = spec
+
+		wg.Add(1)
+		workers <- struct{}{}
+
+		go func(txBody *flow.TransactionBody) {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+
+			var readErr error
+			spec.view = delta.NewView(func(owner, controller, key string) (flow.RegisterValue, error) {
+				value, err := collectionView.Peek(owner, controller, key)
+				if err != nil {
+					readErr = err
+					return nil, err
+				}
+				spec.reads = append(spec.reads, [3]string{owner, controller, key})
+				return value, nil
+			})
+
+			// the metrics collector is not safe for concurrent use
+			txMetrics := fvm.NewMetricsCollector()
+			txCtx := fvm.NewContextFromParent(blockCtx, fvm.WithMetricsCollector(txMetrics))
+
+			spec.tx, spec.err = e.runTransaction(txBody, colSpan, txMetrics, spec.view, txCtx)
+			if spec.err == nil && readErr != nil {
+				// the transaction might have failed because of the failed read
+				spec.err = readErr
+			}
+		}(txBody)
+	}
+
+	wg.Wait()
+
+	return speculative
+}
@@ -1,8 +1,13 @@
 package computation
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
 
 	jsoncdc "github.com/onflow/cadence/encoding/json"
 	"github.com/rs/zerolog"
@@ -19,6 +24,9 @@ import (
 	"github.com/onflow/flow-go/utils/logging"
 )
 
+// ScriptsASTCacheSize is the size of the AST cache shared by a batch of scripts.
+const ScriptsASTCacheSize = 256
+
 type VirtualMachine interface {
 	Run(fvm.Context, fvm.Procedure, state.Ledger) error
 	GetAccount(fvm.Context, flow.Address, state.Ledger) (*flow.Account, error)
@@ -26,6 +34,8 @@ type VirtualMachine interface {
 
 type ComputationManager interface {
 	ExecuteScript([]byte, [][]byte, *flow.Header, *delta.View) ([]byte, error)
+	ExecuteScripts(context.Context, []execution.Script, *flow.Header, *delta.View, time.Duration) []execution.ScriptResult
+	SimulateTransaction(*flow.TransactionBody, *flow.Header, *delta.View) (*execution.SimulationResult, error)
 	ComputeBlock(
 		ctx context.Context,
 		block *entity.ExecutableBlock,
@@ -52,6 +62,7 @@ func New(
 	protoState protocol.State,
 	vm VirtualMachine,
 	vmCtx fvm.Context,
+	parallelExecution bool,
 ) (*Manager, error) {
 	log := logger.With().Str("engine", "computation").Logger()
 
@@ -61,6 +72,7 @@ func New(
 		metrics,
 		tracer,
 		log.With().Str("component", "block_computer").Logger(),
+		parallelExecution,
 	)
 
 	if err != nil {
@@ -82,6 +94,115 @@ func New(
 func (e *Manager) ExecuteScript(code []byte, arguments [][]byte, blockHeader *flow.Header, view *delta.View) ([]byte, error) {
 	blockCtx := fvm.NewContextFromParent(e.vmCtx, fvm.WithBlockHeader(blockHeader))
 
+	return e.executeScript(blockCtx, code, arguments, blockHeader, view)
+}
+
+// ExecuteScripts executes a batch of scripts concurrently and returns the result of each of them.
+//
+// All scripts read from the given view, and share a single AST cache, so registers and programs used
+// by several scripts of the batch are only read and parsed once. A script which does not finish within
+// the given timeout, or before the context is done, is aborted with an error.
+func (e *Manager) ExecuteScripts(
+	ctx context.Context,
+	scripts []execution.Script,
+	blockHeader *flow.Header,
+	view *delta.View,
+	timeout time.Duration,
+) []execution.ScriptResult {
+
+	results := make([]execution.ScriptResult, len(scripts))
+
+	astCache, err := fvm.NewLRUASTCache(ScriptsASTCacheSize)
+	if err != nil {
+		for i := range results {
+			results[i].Err = fmt.Errorf("cannot create AST cache: %w", err)
+		}
+		return results
+	}
+
+	blockCtx := fvm.NewContextFromParent(
+		e.vmCtx,
+		fvm.WithBlockHeader(blockHeader),
+		fvm.WithASTCache(astCache),
+	)
+
+	reads := newSharedReads(view)
+
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, runtime.NumCPU())
+
+	for i, script := range scripts {
+		select {
+		case workers <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Err = fmt.Errorf("script execution aborted: %w", ctx.Err())
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, script execution.Script) {
+			defer wg.Done()
+
+			// the worker is released once the script finished or was aborted, so that scripts which never
+			// finish cannot block the rest of the batch
+			var once sync.Once
+			release := func() { once.Do(func() { <-workers }) }
+
+			results[i] = e.executeScriptWithTimeout(ctx, blockCtx, script, blockHeader, reads, timeout, release)
+		}(i, script)
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// executeScriptWithTimeout executes the script on its own view reading from the shared reads, and aborts
+// it if it does not finish within the timeout. An aborted script keeps running in the background. done
+// is called once the script finished or was aborted, and may be called twice.
+func (e *Manager) executeScriptWithTimeout(
+	ctx context.Context,
+	blockCtx fvm.Context,
+	script execution.Script,
+	blockHeader *flow.Header,
+	reads *sharedReads,
+	timeout time.Duration,
+	done func(),
+) execution.ScriptResult {
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	resultCh := make(chan execution.ScriptResult, 1)
+
+	go func() {
+		defer done()
+
+		view := delta.NewView(reads.get)
+		value, err := e.executeScript(blockCtx, script.Code, script.Arguments, blockHeader, view)
+
+		resultCh <- execution.ScriptResult{Value: value, Err: err}
+	}()
+
+	select {
+	case result := <-resultCh:
+		return result
+	case <-ctx.Done():
+		done()
+		return execution.ScriptResult{
+			Err: fmt.Errorf("script execution aborted: %w", ctx.Err()),
+		}
+	}
+}
+
+func (e *Manager) executeScript(
+	blockCtx fvm.Context,
+	code []byte,
+	arguments [][]byte,
+	blockHeader *flow.Header,
+	view *delta.View,
+) ([]byte, error) {
+
 	script := fvm.Script(code).WithArguments(arguments...)
 
 	err := e.vm.Run(blockCtx, script, view)
@@ -101,6 +222,56 @@ func (e *Manager) ExecuteScript(code []byte, arguments [][]byte, blockHeader *fl
 	return encodedValue, nil
 }
 
+// SimulateTransaction executes a transaction without verifying its signatures and returns its outcome.
+// The changes of the transaction are only applied to the given view, which should be discarded afterwards.
+func (e *Manager) SimulateTransaction(txBody *flow.TransactionBody, blockHeader *flow.Header, view *delta.View) (*execution.SimulationResult, error) {
+	blockCtx := fvm.NewContextFromParent(
+		e.vmCtx,
+		fvm.WithBlockHeader(blockHeader),
+		fvm.WithTransactionProcessors(
+			fvm.NewTransactionSequenceNumberChecker(),
+			fvm.NewTransactionFeeDeductor(),
+			fvm.NewTransactionInvocator(),
+		),
+	)
+
+	tx := fvm.Transaction(txBody)
+
+	err := e.vm.Run(blockCtx, tx, view)
+	if err != nil {
+		return nil, fmt.Errorf("failed to simulate transaction (internal error): %w", err)
+	}
+
+	events, err := tx.ConvertEvents(0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert events: %w", err)
+	}
+
+	touches := view.Interactions().RegisterTouches()
+	sort.Slice(touches, func(i, j int) bool {
+		return bytes.Compare(touches[i], touches[j]) < 0
+	})
+
+	updates, values := view.Delta().RegisterUpdates()
+
+	result := &execution.SimulationResult{
+		TransactionID:   tx.ID,
+		Events:          events,
+		Logs:            tx.Logs,
+		RegisterTouches: touches,
+		RegisterUpdates: updates,
+		RegisterValues:  values,
+		GasUsed:         tx.GasUsed,
+	}
+
+	if tx.Err != nil {
+		result.ErrorCode = tx.Err.Code()
+		result.ErrorMessage = tx.Err.Error()
+	}
+
+	return result, nil
+}
+
 func (e *Manager) ComputeBlock(
 	ctx context.Context,
 	block *entity.ExecutableBlock,
@@ -2,17 +2,24 @@ package computation
 
 import (
 	"context"
+	"errors"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
 	"github.com/onflow/cadence/runtime"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/onflow/flow-go/engine/execution"
 	"github.com/onflow/flow-go/engine/execution/computation/computer"
 	"github.com/onflow/flow-go/engine/execution/state/delta"
 	"github.com/onflow/flow-go/engine/execution/testutil"
 	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/state"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module/mempool/entity"
 	module "github.com/onflow/flow-go/module/mock"
@@ -85,7 +92,7 @@ func TestComputeBlockWithStorage(t *testing.T) {
 	me := new(module.Local)
 	me.On("NodeID").Return(flow.ZeroID)
 
-	blockComputer, err := computer.NewBlockComputer(vm, execCtx, nil, nil, zerolog.Nop())
+	blockComputer, err := computer.NewBlockComputer(vm, execCtx, nil, nil, zerolog.Nop(), false)
 	require.NoError(t, err)
 
 	engine := &Manager{
@@ -103,3 +110,127 @@ func TestComputeBlockWithStorage(t *testing.T) {
 	require.Len(t, returnedComputationResult.StateSnapshots, 1+1) // 1 coll + 1 system chunk
 	assert.NotEmpty(t, returnedComputationResult.StateSnapshots[0].Delta)
 }
+
+func TestSimulateTransaction(t *testing.T) {
+	rt := runtime.NewInterpreterRuntime()
+
+	chain := flow.Mainnet.Chain()
+
+	vm := fvm.New(rt)
+	execCtx := fvm.NewContext(fvm.WithChain(chain))
+
+	privateKeys, err := testutil.GenerateAccountPrivateKeys(1)
+	require.NoError(t, err)
+
+	ledger := testutil.RootBootstrappedLedger(vm, execCtx)
+	accounts, err := testutil.CreateAccounts(vm, ledger, privateKeys, chain)
+	require.NoError(t, err)
+
+	engine := &Manager{
+		vm:    vm,
+		vmCtx: execCtx,
+	}
+
+	header := &flow.Header{View: 42}
+
+	t.Run("unsigned transaction", func(t *testing.T) {
+		tx := testutil.DeployCounterContractTransaction(accounts[0], chain)
+		tx.SetProposalKey(chain.ServiceAddress(), 0, 0).
+			SetPayer(chain.ServiceAddress())
+
+		view := delta.NewView(ledger.Get)
+
+		result, err := engine.SimulateTransaction(tx, header, view.NewChild())
+		require.NoError(t, err)
+
+		assert.Equal(t, tx.ID(), result.TransactionID)
+		assert.Zero(t, result.ErrorCode)
+		assert.Empty(t, result.ErrorMessage)
+		assert.NotEmpty(t, result.RegisterUpdates)
+		assert.Len(t, result.RegisterValues, len(result.RegisterUpdates))
+		assert.NotEmpty(t, result.RegisterTouches)
+
+		// nothing is committed
+		assert.Empty(t, view.Delta().Data)
+	})
+
+	t.Run("failing transaction", func(t *testing.T) {
+		// the counter contract was never deployed
+		tx := testutil.CreateCounterTransaction(accounts[0], accounts[0])
+		tx.SetProposalKey(chain.ServiceAddress(), 0, 0).
+			SetPayer(chain.ServiceAddress())
+
+		result, err := engine.SimulateTransaction(tx, header, delta.NewView(ledger.Get))
+		require.NoError(t, err)
+
+		assert.NotZero(t, result.ErrorCode)
+		assert.NotEmpty(t, result.ErrorMessage)
+	})
+}
+
+func TestExecuteScripts(t *testing.T) {
+
+	// unblocks the scripts waiting on it
+	unblock := make(chan struct{})
+	defer close(unblock)
+
+	var reads uint64
+	view := delta.NewView(func(owner, controller, key string) (flow.RegisterValue, error) {
+		atomic.AddUint64(&reads, 1)
+		return flow.RegisterValue(key), nil
+	})
+
+	engine := &Manager{
+		vm:    &scriptVM{unblock: unblock},
+		vmCtx: fvm.NewContext(),
+	}
+
+	scripts := []execution.Script{
+		{Code: []byte("a")},
+		{Code: []byte("block")},
+		{Code: []byte("a")},
+		{Code: []byte("b")},
+	}
+
+	results := engine.ExecuteScripts(context.Background(), scripts, &flow.Header{View: 42}, view, 100*time.Millisecond)
+	require.Len(t, results, len(scripts))
+
+	for _, i := range []int{0, 2, 3} {
+		require.NoError(t, results[i].Err)
+
+		value, err := jsoncdc.Decode(results[i].Value)
+		require.NoError(t, err)
+		assert.Equal(t, cadence.NewString(string(scripts[i].Code)), value)
+	}
+
+	assert.True(t, errors.Is(results[1].Err, context.DeadlineExceeded))
+
+	// registers read by several scripts are only read once from the view
+	assert.Equal(t, uint64(3), atomic.LoadUint64(&reads))
+}
+
+// scriptVM is a virtual machine returning the value of the register named by the script.
+type scriptVM struct {
+	unblock chan struct{}
+}
+
+func (vm *scriptVM) Run(ctx fvm.Context, proc fvm.Procedure, ledger state.Ledger) error {
+	script := proc.(*fvm.ScriptProcedure)
+
+	value, err := ledger.Get("", "", string(script.Script))
+	if err != nil {
+		return err
+	}
+
+	if string(value) == "block" {
+		<-vm.unblock
+	}
+
+	script.Value = cadence.NewString(string(value))
+
+	return nil
+}
+
+func (vm *scriptVM) GetAccount(_ fvm.Context, _ flow.Address, _ state.Ledger) (*flow.Account, error) {
+	return nil, nil
+}
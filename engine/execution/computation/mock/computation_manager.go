@@ -13,6 +13,8 @@ import (
 	flow "github.com/onflow/flow-go/model/flow"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // ComputationManager is an autogenerated mock type for the ComputationManager type
@@ -66,6 +68,22 @@ func (_m *ComputationManager) ExecuteScript(_a0 []byte, _a1 [][]byte, _a2 *flow.
 	return r0, r1
 }
 
+// ExecuteScripts provides a mock function with given fields: _a0, _a1, _a2, _a3, _a4
+func (_m *ComputationManager) ExecuteScripts(_a0 context.Context, _a1 []execution.Script, _a2 *flow.Header, _a3 *delta.View, _a4 time.Duration) []execution.ScriptResult {
+	ret := _m.Called(_a0, _a1, _a2, _a3, _a4)
+
+	var r0 []execution.ScriptResult
+	if rf, ok := ret.Get(0).(func(context.Context, []execution.Script, *flow.Header, *delta.View, time.Duration) []execution.ScriptResult); ok {
+		r0 = rf(_a0, _a1, _a2, _a3, _a4)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]execution.ScriptResult)
+		}
+	}
+
+	return r0
+}
+
 // GetAccount provides a mock function with given fields: addr, header, view
 func (_m *ComputationManager) GetAccount(addr flow.Address, header *flow.Header, view *delta.View) (*flow.Account, error) {
 	ret := _m.Called(addr, header, view)
@@ -88,3 +106,26 @@ func (_m *ComputationManager) GetAccount(addr flow.Address, header *flow.Header,
 
 	return r0, r1
 }
+
+// SimulateTransaction provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ComputationManager) SimulateTransaction(_a0 *flow.TransactionBody, _a1 *flow.Header, _a2 *delta.View) (*execution.SimulationResult, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 *execution.SimulationResult
+	if rf, ok := ret.Get(0).(func(*flow.TransactionBody, *flow.Header, *delta.View) *execution.SimulationResult); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*execution.SimulationResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*flow.TransactionBody, *flow.Header, *delta.View) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
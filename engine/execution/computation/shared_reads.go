@@ -0,0 +1,46 @@
+package computation
+
+import (
+	"sync"
+
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/fvm/state"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// sharedReads allows concurrently executed scripts to read from a single view. The reads are
+// serialized, and the values read are cached, so every register is only read once from the view.
+type sharedReads struct {
+	sync.Mutex
+	view   *delta.View
+	values map[string]flow.RegisterValue
+}
+
+func newSharedReads(view *delta.View) *sharedReads {
+	return &sharedReads{
+		view:   view,
+		values: make(map[string]flow.RegisterValue),
+	}
+}
+
+// get returns the value of the register, it can be used as the read function of a view.
+func (s *sharedReads) get(owner, controller, key string) (flow.RegisterValue, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	id := string(state.RegisterID(owner, controller, key))
+
+	value, ok := s.values[id]
+	if ok {
+		return value, nil
+	}
+
+	value, err := s.view.Get(owner, controller, key)
+	if err != nil {
+		return nil, err
+	}
+
+	s.values[id] = value
+
+	return value, nil
+}
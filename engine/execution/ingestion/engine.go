@@ -358,6 +358,9 @@ func (e *Engine) executeBlock(ctx context.Context, executableBlock *entity.Execu
 
 	e.metrics.FinishBlockReceivedToExecuted(executableBlock.ID())
 	e.metrics.ExecutionGasUsedPerBlock(computationResult.GasUsed)
+	for _, txResult := range computationResult.TransactionResult {
+		e.metrics.ExecutionComputationUsedPerTransaction(txResult.ComputationUsed)
+	}
 	e.metrics.ExecutionStateReadsPerBlock(computationResult.StateReads)
 
 	finalState, err := e.handleComputationResult(ctx, computationResult, executableBlock.StartState)
@@ -633,6 +636,29 @@ func (e *Engine) ExecuteScriptAtBlockID(ctx context.Context, script []byte, argu
 	return e.computationManager.ExecuteScript(script, arguments, block, blockView)
 }
 
+func (e *Engine) ExecuteScriptsAtBlockID(
+	ctx context.Context,
+	scripts []execution.Script,
+	blockID flow.Identifier,
+	timeout time.Duration,
+) ([]execution.ScriptResult, error) {
+
+	stateCommit, err := e.execState.StateCommitmentByBlockID(ctx, blockID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get state commitment for block (%s): %w", blockID, err)
+	}
+
+	block, err := e.state.AtBlockID(blockID).Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block (%s): %w", blockID, err)
+	}
+
+	// all scripts of the batch read from the same view
+	blockView := e.execState.NewView(stateCommit)
+
+	return e.computationManager.ExecuteScripts(ctx, scripts, block, blockView, timeout), nil
+}
+
 func (e *Engine) GetAccount(ctx context.Context, addr flow.Address, blockID flow.Identifier) (*flow.Account, error) {
 	stateCommit, err := e.execState.StateCommitmentByBlockID(ctx, blockID)
 	if err != nil {
@@ -649,6 +675,53 @@ func (e *Engine) GetAccount(ctx context.Context, addr flow.Address, blockID flow
 	return e.computationManager.GetAccount(addr, block, blockView)
 }
 
+func (e *Engine) GetRegistersWithProofs(
+	ctx context.Context,
+	commit flow.StateCommitment,
+	registerIDs []flow.RegisterID,
+) ([]flow.RegisterValue, []flow.StorageProof, error) {
+	values, proofs, err := e.execState.GetRegistersWithProofs(ctx, commit, registerIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get registers at state commitment (%x): %w", commit, err)
+	}
+
+	return values, proofs, nil
+}
+
+func (e *Engine) SimulateTransaction(ctx context.Context, tx *flow.TransactionBody, blockID flow.Identifier) (*execution.SimulationResult, error) {
+	stateCommit, err := e.execState.StateCommitmentByBlockID(ctx, blockID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get state commitment for block (%s): %w", blockID, err)
+	}
+
+	block, err := e.state.AtBlockID(blockID).Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block (%s): %w", blockID, err)
+	}
+
+	// the view is discarded afterwards, so the changes of the transaction are never committed
+	blockView := e.execState.NewView(stateCommit)
+
+	type simulation struct {
+		result *execution.SimulationResult
+		err    error
+	}
+	simulationCh := make(chan simulation, 1)
+
+	// an aborted simulation keeps running in the background, but its result is dropped
+	go func() {
+		result, err := e.computationManager.SimulateTransaction(tx, block, blockView)
+		simulationCh <- simulation{result: result, err: err}
+	}()
+
+	select {
+	case s := <-simulationCh:
+		return s.result, s.err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("transaction simulation aborted: %w", ctx.Err())
+	}
+}
+
 func (e *Engine) handleComputationResult(
 	ctx context.Context,
 	result *execution.ComputationResult,
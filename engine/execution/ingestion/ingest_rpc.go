@@ -2,7 +2,9 @@ package ingestion
 
 import (
 	"context"
+	"time"
 
+	"github.com/onflow/flow-go/engine/execution"
 	"github.com/onflow/flow-go/model/flow"
 )
 
@@ -12,6 +14,18 @@ type IngestRPC interface {
 	// ExecuteScriptAtBlockID executes a script at the given Block id
 	ExecuteScriptAtBlockID(ctx context.Context, script []byte, arguments [][]byte, blockID flow.Identifier) ([]byte, error)
 
+	// ExecuteScriptsAtBlockID executes a batch of scripts at the given Block id, aborting each script that
+	// does not finish within the given timeout
+	ExecuteScriptsAtBlockID(ctx context.Context, scripts []execution.Script, blockID flow.Identifier, timeout time.Duration) ([]execution.ScriptResult, error)
+
 	// GetAccount returns the Account details at the given Block id
 	GetAccount(ctx context.Context, address flow.Address, blockID flow.Identifier) (*flow.Account, error)
+
+	// GetRegistersWithProofs returns the values of the registers at the given state commitment, along with
+	// the proofs of these values
+	GetRegistersWithProofs(ctx context.Context, commit flow.StateCommitment, registerIDs []flow.RegisterID) ([]flow.RegisterValue, []flow.StorageProof, error)
+
+	// SimulateTransaction executes a transaction at the given Block id without verifying its signatures
+	// and without committing its changes
+	SimulateTransaction(ctx context.Context, tx *flow.TransactionBody, blockID flow.Identifier) (*execution.SimulationResult, error)
 }
@@ -5,9 +5,13 @@ package mock
 import (
 	context "context"
 
+	execution "github.com/onflow/flow-go/engine/execution"
+
 	flow "github.com/onflow/flow-go/model/flow"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // IngestRPC is an autogenerated mock type for the IngestRPC type
@@ -38,6 +42,29 @@ func (_m *IngestRPC) ExecuteScriptAtBlockID(ctx context.Context, script []byte,
 	return r0, r1
 }
 
+// ExecuteScriptsAtBlockID provides a mock function with given fields: ctx, scripts, blockID, timeout
+func (_m *IngestRPC) ExecuteScriptsAtBlockID(ctx context.Context, scripts []execution.Script, blockID flow.Identifier, timeout time.Duration) ([]execution.ScriptResult, error) {
+	ret := _m.Called(ctx, scripts, blockID, timeout)
+
+	var r0 []execution.ScriptResult
+	if rf, ok := ret.Get(0).(func(context.Context, []execution.Script, flow.Identifier, time.Duration) []execution.ScriptResult); ok {
+		r0 = rf(ctx, scripts, blockID, timeout)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]execution.ScriptResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []execution.Script, flow.Identifier, time.Duration) error); ok {
+		r1 = rf(ctx, scripts, blockID, timeout)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAccount provides a mock function with given fields: ctx, address, blockID
 func (_m *IngestRPC) GetAccount(ctx context.Context, address flow.Address, blockID flow.Identifier) (*flow.Account, error) {
 	ret := _m.Called(ctx, address, blockID)
@@ -60,3 +87,58 @@ func (_m *IngestRPC) GetAccount(ctx context.Context, address flow.Address, block
 
 	return r0, r1
 }
+
+// GetRegistersWithProofs provides a mock function with given fields: ctx, commit, registerIDs
+func (_m *IngestRPC) GetRegistersWithProofs(ctx context.Context, commit []byte, registerIDs [][]byte) ([][]byte, [][]byte, error) {
+	ret := _m.Called(ctx, commit, registerIDs)
+
+	var r0 [][]byte
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, [][]byte) [][]byte); ok {
+		r0 = rf(ctx, commit, registerIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]byte)
+		}
+	}
+
+	var r1 [][]byte
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, [][]byte) [][]byte); ok {
+		r1 = rf(ctx, commit, registerIDs)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([][]byte)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, []byte, [][]byte) error); ok {
+		r2 = rf(ctx, commit, registerIDs)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SimulateTransaction provides a mock function with given fields: ctx, tx, blockID
+func (_m *IngestRPC) SimulateTransaction(ctx context.Context, tx *flow.TransactionBody, blockID flow.Identifier) (*execution.SimulationResult, error) {
+	ret := _m.Called(ctx, tx, blockID)
+
+	var r0 *execution.SimulationResult
+	if rf, ok := ret.Get(0).(func(context.Context, *flow.TransactionBody, flow.Identifier) *execution.SimulationResult); ok {
+		r0 = rf(ctx, tx, blockID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*execution.SimulationResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *flow.TransactionBody, flow.Identifier) error); ok {
+		r1 = rf(ctx, tx, blockID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
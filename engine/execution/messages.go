@@ -23,3 +23,28 @@ type ComputationResult struct {
 	GasUsed           uint64
 	StateReads        uint64
 }
+
+// SimulationResult is the outcome of a transaction that was executed without committing its changes.
+type SimulationResult struct {
+	TransactionID   flow.Identifier
+	Events          []flow.Event
+	Logs            []string
+	RegisterTouches []flow.RegisterID // all registers read or written, in ascending order
+	RegisterUpdates []flow.RegisterID // the registers written, in ascending order
+	RegisterValues  []flow.RegisterValue
+	GasUsed         uint64
+	ErrorCode       uint32 // the fvm error code, 0 if the transaction succeeded
+	ErrorMessage    string
+}
+
+// Script is a script to execute, together with its arguments.
+type Script struct {
+	Code      []byte
+	Arguments [][]byte
+}
+
+// ScriptResult is the outcome of executing a script, either its JSON-CDC encoded value or an error.
+type ScriptResult struct {
+	Value []byte
+	Err   error
+}
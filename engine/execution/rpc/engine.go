@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"net"
+	"os"
+	"time"
 
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
@@ -14,16 +16,30 @@ import (
 
 	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	exeModel "github.com/onflow/flow-go/engine/execution"
 	"github.com/onflow/flow-go/engine/execution/ingestion"
+	extended "github.com/onflow/flow-go/engine/execution/rpc/protobuf"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/ledger"
 	grpcutils "github.com/onflow/flow-go/utils/grpc"
 )
 
+// DefaultScriptTimeout is the default time a script of a batch, or a simulated transaction, may run before
+// it is aborted.
+const DefaultScriptTimeout = 5 * time.Second
+
+// MaxScriptsPerBatch is the maximum number of scripts that can be executed in a single batch.
+const MaxScriptsPerBatch = 1000
+
+// MaxRegistersPerRequest is the maximum number of registers that can be read with proofs in a single request.
+const MaxRegistersPerRequest = 1000
+
 // Config defines the configurable options for the gRPC server.
 type Config struct {
-	ListenAddr string
-	MaxMsgSize int // In bytes
+	ListenAddr    string
+	MaxMsgSize    int           // In bytes
+	ScriptTimeout time.Duration // the time a script of a batch, or a simulated transaction, may run before it is aborted
 }
 
 // Engine implements a gRPC server with a simplified version of the Observation API.
@@ -51,6 +67,10 @@ func New(
 		config.MaxMsgSize = grpcutils.DefaultMaxMsgSize
 	}
 
+	if config.ScriptTimeout == 0 {
+		config.ScriptTimeout = DefaultScriptTimeout
+	}
+
 	eng := &Engine{
 		log:  log,
 		unit: engine.NewUnit(),
@@ -61,6 +81,7 @@ func New(
 			events:             events,
 			exeResults:         exeResults,
 			transactionResults: txResults,
+			scriptTimeout:      config.ScriptTimeout,
 		},
 		server: grpc.NewServer(
 			grpc.MaxRecvMsgSize(config.MaxMsgSize),
@@ -70,6 +91,7 @@ func New(
 	}
 
 	execution.RegisterExecutionAPIServer(eng.server, eng.handler)
+	extended.RegisterExtendedExecutionAPIServer(eng.server, eng.handler)
 
 	return eng
 }
@@ -114,9 +136,11 @@ type handler struct {
 	events             storage.Events
 	exeResults         storage.ExecutionResults
 	transactionResults storage.TransactionResults
+	scriptTimeout      time.Duration
 }
 
 var _ execution.ExecutionAPIServer = &handler{}
+var _ extended.ExtendedExecutionAPIServer = &handler{}
 
 // Ping responds to requests when the server is up.
 func (h *handler) Ping(ctx context.Context, req *execution.PingRequest) (*execution.PingResponse, error) {
@@ -145,6 +169,120 @@ func (h *handler) ExecuteScriptAtBlockID(
 	return res, nil
 }
 
+// ExecuteScriptsAtBlockID executes a batch of scripts at the given block. Each script that fails or does
+// not finish in time results in an error for that script only.
+func (h *handler) ExecuteScriptsAtBlockID(
+	ctx context.Context,
+	req *extended.ExecuteScriptsAtBlockIDRequest,
+) (*extended.ExecuteScriptsAtBlockIDResponse, error) {
+
+	blockID, err := convert.BlockID(req.GetBlockId())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.GetScripts()) > MaxScriptsPerBatch {
+		return nil, status.Errorf(codes.InvalidArgument, "too many scripts in batch: %d > %d", len(req.GetScripts()), MaxScriptsPerBatch)
+	}
+
+	scripts := make([]exeModel.Script, 0, len(req.GetScripts()))
+	for _, script := range req.GetScripts() {
+		scripts = append(scripts, exeModel.Script{
+			Code:      script.GetScript(),
+			Arguments: script.GetArguments(),
+		})
+	}
+
+	results, err := h.engine.ExecuteScriptsAtBlockID(ctx, scripts, blockID, h.scriptTimeout)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to execute scripts: %v", err)
+	}
+
+	res := &extended.ExecuteScriptsAtBlockIDResponse{
+		Results: make([]*extended.ScriptResult, 0, len(results)),
+	}
+
+	for _, result := range results {
+		if result.Err != nil {
+			res.Results = append(res.Results, &extended.ScriptResult{
+				ErrorCode:    uint32(scriptErrorCode(result.Err)),
+				ErrorMessage: result.Err.Error(),
+			})
+			continue
+		}
+
+		res.Results = append(res.Results, &extended.ScriptResult{
+			Value: result.Value,
+		})
+	}
+
+	return res, nil
+}
+
+// GetRegistersWithProof returns the values of the registers at the given state commitment, along with the
+// proofs of these values. The values can be verified against the state commitment with the proofs, using
+// the ledger/verifier package.
+func (h *handler) GetRegistersWithProof(
+	ctx context.Context,
+	req *extended.GetRegistersWithProofRequest,
+) (*extended.GetRegistersWithProofResponse, error) {
+
+	commit := flow.StateCommitment(req.GetStateCommitment())
+	if len(commit) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "state commitment is required")
+	}
+
+	registerIDs := make([]flow.RegisterID, 0, len(req.GetRegisterIds()))
+	for _, registerID := range req.GetRegisterIds() {
+		if len(registerID) != ledger.RegisterKeySize {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid size of register ID (%x): %d != %d", registerID, len(registerID), ledger.RegisterKeySize)
+		}
+		registerIDs = append(registerIDs, registerID)
+	}
+
+	if len(registerIDs) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no register IDs provided")
+	}
+
+	if len(registerIDs) > MaxRegistersPerRequest {
+		return nil, status.Errorf(codes.InvalidArgument, "too many register IDs: %d > %d", len(registerIDs), MaxRegistersPerRequest)
+	}
+
+	values, proofs, err := h.engine.GetRegistersWithProofs(ctx, commit, registerIDs)
+	if errors.Is(err, os.ErrNotExist) {
+		// the trie of the state commitment is neither in memory, nor on disk
+		return nil, status.Errorf(codes.NotFound, "state commitment not found: %x", commit)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get registers: %v", err)
+	}
+
+	res := &extended.GetRegistersWithProofResponse{
+		Values: make([][]byte, 0, len(values)),
+		Proofs: make([][]byte, 0, len(proofs)),
+	}
+
+	for i := range values {
+		res.Values = append(res.Values, values[i])
+		res.Proofs = append(res.Proofs, proofs[i])
+	}
+
+	return res, nil
+}
+
+// scriptErrorCode returns the gRPC status code for the error a script of a batch, or a simulated transaction,
+// failed with.
+func scriptErrorCode(err error) codes.Code {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	default:
+		return codes.Internal
+	}
+}
+
 func (h *handler) GetEventsForBlockIDs(_ context.Context,
 	req *execution.GetEventsForBlockIDsRequest) (*execution.GetEventsForBlockIDsResponse, error) {
 
@@ -160,10 +298,32 @@ func (h *handler) GetEventsForBlockIDs(_ context.Context,
 		return nil, err
 	}
 
+	return h.blockEvents(flowBlockIDs, func(blockID flow.Identifier) ([]flow.Event, error) {
+		return h.events.ByBlockIDEventType(blockID, flow.EventType(eType))
+	})
+}
+
+// GetAllEventsForBlockIDs returns the events of all types emitted by the given blocks.
+func (h *handler) GetAllEventsForBlockIDs(_ context.Context,
+	req *extended.GetAllEventsForBlockIDsRequest) (*execution.GetEventsForBlockIDsResponse, error) {
+
+	// validate request
+	flowBlockIDs, err := convert.BlockIDs(req.GetBlockIds())
+	if err != nil {
+		return nil, err
+	}
+
+	return h.blockEvents(flowBlockIDs, h.events.ByBlockID)
+}
+
+// blockEvents creates an EventsResponse_Result for each executed block with the events returned by lookup
+func (h *handler) blockEvents(blockIDs []flow.Identifier,
+	lookup func(blockID flow.Identifier) ([]flow.Event, error)) (*execution.GetEventsForBlockIDsResponse, error) {
+
 	results := make([]*execution.GetEventsForBlockIDsResponse_Result, len(blockIDs))
 
 	// collect all the events and create a EventsResponse_Result for each block
-	for i, bID := range flowBlockIDs {
+	for i, bID := range blockIDs {
 		// Check if block has been executed
 		if _, err := h.exeResults.ByBlockID(bID); err != nil {
 			if errors.Is(err, storage.ErrNotFound) {
@@ -173,7 +333,7 @@ func (h *handler) GetEventsForBlockIDs(_ context.Context,
 		}
 
 		// lookup events
-		blockEvents, err := h.events.ByBlockIDEventType(bID, flow.EventType(eType))
+		blockEvents, err := lookup(bID)
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "failed to get events for block: %v", err)
 		}
@@ -192,7 +352,7 @@ func (h *handler) GetEventsForBlockIDs(_ context.Context,
 }
 
 func (h *handler) GetTransactionResult(
-	_ context.Context,
+	ctx context.Context,
 	req *execution.GetTransactionResultRequest,
 ) (*execution.GetTransactionResultResponse, error) {
 
@@ -233,6 +393,14 @@ func (h *handler) GetTransactionResult(
 		errMsg = txResult.ErrorMessage
 	}
 
+	// the header can only be set when called by the gRPC server
+	if grpc.ServerTransportStreamFromContext(ctx) != nil {
+		err = grpc.SetHeader(ctx, convert.ComputationUsedToHeader(txResult.ComputationUsed))
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to set response header: %v", err)
+		}
+	}
+
 	// compose a response with the events and the transaction error
 	return &execution.GetTransactionResultResponse{
 		StatusCode:   statusCode,
@@ -241,6 +409,61 @@ func (h *handler) GetTransactionResult(
 	}, nil
 }
 
+// SimulateTransaction executes a transaction without verifying its signatures and without committing
+// its changes, and returns its outcome.
+func (h *handler) SimulateTransaction(
+	ctx context.Context,
+	req *extended.SimulateTransactionRequest,
+) (*extended.SimulateTransactionResponse, error) {
+
+	blockID, err := convert.BlockID(req.GetBlockId())
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetTransaction() == nil {
+		return nil, status.Error(codes.InvalidArgument, "transaction is required")
+	}
+
+	tx, err := convert.MessageToTransaction(req.GetTransaction(), h.chain.Chain())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid transaction: %v", err)
+	}
+
+	// like the scripts of a batch, a simulation is aborted if it does not finish in time
+	ctx, cancel := context.WithTimeout(ctx, h.scriptTimeout)
+	defer cancel()
+
+	result, err := h.engine.SimulateTransaction(ctx, &tx, blockID)
+	if err != nil {
+		return nil, status.Errorf(scriptErrorCode(err), "failed to simulate transaction: %v", err)
+	}
+
+	touches := make([][]byte, 0, len(result.RegisterTouches))
+	for _, id := range result.RegisterTouches {
+		touches = append(touches, id)
+	}
+
+	updates := make([]*extended.Register, 0, len(result.RegisterUpdates))
+	for i, id := range result.RegisterUpdates {
+		updates = append(updates, &extended.Register{
+			Id:    id,
+			Value: result.RegisterValues[i],
+		})
+	}
+
+	return &extended.SimulateTransactionResponse{
+		TransactionId:   convert.IdentifierToMessage(result.TransactionID),
+		Events:          convert.EventsToMessages(result.Events),
+		Logs:            result.Logs,
+		RegisterTouches: touches,
+		RegisterUpdates: updates,
+		GasUsed:         result.GasUsed,
+		ErrorCode:       result.ErrorCode,
+		ErrorMessage:    result.ErrorMessage,
+	}, nil
+}
+
 // eventResult creates EventsResponse_Result from flow.Event for the given blockID
 func (h *handler) eventResult(blockID flow.Identifier,
 	flowEvents []flow.Event) (*execution.GetEventsForBlockIDsResponse_Result, error) {
@@ -3,19 +3,25 @@ package rpc
 import (
 	"context"
 	"errors"
+	"fmt"
+	"os"
 	"testing"
 
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	"github.com/onflow/flow/protobuf/go/flow/entities"
 	"github.com/onflow/flow/protobuf/go/flow/execution"
 
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	exeModel "github.com/onflow/flow-go/engine/execution"
 	ingestion "github.com/onflow/flow-go/engine/execution/ingestion/mock"
+	extended "github.com/onflow/flow-go/engine/execution/rpc/protobuf"
 	"github.com/onflow/flow-go/model/flow"
 	realstorage "github.com/onflow/flow-go/storage"
 	storage "github.com/onflow/flow-go/storage/mock"
@@ -117,6 +123,35 @@ func (suite *Suite) TestGetEventsForBlockIDs() {
 		suite.events.AssertExpectations(suite.T())
 	})
 
+	// happy path - the events of all types are returned for a request of all events
+	suite.Run("request for events of all types", func() {
+
+		block := unittest.BlockFixture()
+		id := block.ID()
+		blockEvents := []flow.Event{
+			unittest.EventFixture(flow.EventAccountCreated, 0, 0, unittest.IdentifierFixture()),
+			unittest.EventFixture(flow.EventAccountUpdated, 1, 0, unittest.IdentifierFixture()),
+		}
+		suite.exeResults.On("ByBlockID", id).Return(nil, nil).Once()
+		suite.events.On("ByBlockID", id).Return(blockEvents, nil).Once()
+		suite.blocks.On("ByID", id).Return(&block, nil).Once()
+
+		req := &extended.GetAllEventsForBlockIDsRequest{BlockIds: [][]byte{id[:]}}
+
+		resp, err := handler.GetAllEventsForBlockIDs(context.Background(), req)
+		suite.Require().NoError(err)
+
+		expected := []*execution.GetEventsForBlockIDsResponse_Result{{
+			BlockId:     id[:],
+			BlockHeight: block.Header.Height,
+			Events:      convert.EventsToMessages(blockEvents),
+		}}
+		suite.Require().Equal(expected, resp.GetResults())
+
+		// check that no events were looked up by type
+		suite.events.AssertExpectations(suite.T())
+	})
+
 	// failure path - empty even type in the request results in an error
 	suite.Run("request with empty event type", func() {
 
@@ -318,6 +353,28 @@ func (suite *Suite) TestGetTransactionResult() {
 		txResults.AssertExpectations(suite.T())
 	})
 
+	// happy path - the computation used by the transaction is returned in the response header
+	suite.Run("happy path with computation used", func() {
+
+		txResults := new(storage.TransactionResults)
+		txResult := flow.TransactionResult{
+			TransactionID:   txID,
+			ComputationUsed: 42,
+		}
+		txResults.On("ByBlockIDTransactionID", bID, txID).Return(&txResult, nil).Once()
+
+		handler := createHandler(txResults)
+
+		stream := &headerStream{}
+		ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+
+		_, err := handler.GetTransactionResult(ctx, concoctReq(bID[:], txID[:]))
+		suite.Require().NoError(err)
+
+		suite.Require().Equal([]string{"42"}, stream.header.Get(convert.ComputationUsedHeader))
+		txResults.AssertExpectations(suite.T())
+	})
+
 	// happy path - valid requests receives all events and an error for the given transaction
 	suite.Run("happy path with valid events and a transaction error", func() {
 
@@ -414,3 +471,265 @@ func (suite *Suite) TestGetTransactionResult() {
 		suite.events.AssertExpectations(suite.T())
 	})
 }
+
+// TestSimulateTransaction tests the SimulateTransaction API call
+func (suite *Suite) TestSimulateTransaction() {
+
+	blockID := unittest.IdentifierFixture()
+	tx := unittest.TransactionBodyFixture()
+
+	mockEngine := new(ingestion.IngestRPC)
+
+	handler := &handler{
+		engine:        mockEngine,
+		chain:         flow.Testnet,
+		scriptTimeout: DefaultScriptTimeout,
+	}
+
+	// the simulation is given the script timeout
+	hasDeadline := mock.MatchedBy(func(ctx context.Context) bool {
+		_, ok := ctx.Deadline()
+		return ok
+	})
+
+	suite.Run("happy path with valid request", func() {
+
+		event := unittest.EventFixture(flow.EventAccountCreated, 0, 0, tx.ID())
+		result := &exeModel.SimulationResult{
+			TransactionID:   tx.ID(),
+			Events:          []flow.Event{event},
+			Logs:            []string{"log"},
+			RegisterTouches: []flow.RegisterID{[]byte("a"), []byte("b")},
+			RegisterUpdates: []flow.RegisterID{[]byte("b")},
+			RegisterValues:  []flow.RegisterValue{[]byte("value")},
+			GasUsed:         10,
+			ErrorCode:       1,
+			ErrorMessage:    "failed",
+		}
+
+		mockEngine.On("SimulateTransaction", hasDeadline, &tx, blockID).Return(result, nil).Once()
+
+		resp, err := handler.SimulateTransaction(context.Background(), &extended.SimulateTransactionRequest{
+			BlockId:     blockID[:],
+			Transaction: convert.TransactionToMessage(tx),
+		})
+		suite.Require().NoError(err)
+
+		suite.Require().Equal(tx.ID(), convert.MessageToIdentifier(resp.TransactionId))
+		suite.Require().Equal([]*entities.Event{convert.EventToMessage(event)}, resp.Events)
+		suite.Require().Equal([]string{"log"}, resp.Logs)
+		suite.Require().Equal([][]byte{[]byte("a"), []byte("b")}, resp.RegisterTouches)
+		suite.Require().Equal([]*extended.Register{{Id: []byte("b"), Value: []byte("value")}}, resp.RegisterUpdates)
+		suite.Require().Equal(uint64(10), resp.GasUsed)
+		suite.Require().Equal(uint32(1), resp.ErrorCode)
+		suite.Require().Equal("failed", resp.ErrorMessage)
+		mockEngine.AssertExpectations(suite.T())
+	})
+
+	suite.Run("invalid request without transaction", func() {
+
+		_, err := handler.SimulateTransaction(context.Background(), &extended.SimulateTransactionRequest{
+			BlockId: blockID[:],
+		})
+
+		suite.Require().Error(err)
+		suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+	})
+
+	suite.Run("engine failure", func() {
+
+		mockEngine.On("SimulateTransaction", mock.Anything, &tx, blockID).Return(nil, errors.New("failed")).Once()
+
+		_, err := handler.SimulateTransaction(context.Background(), &extended.SimulateTransactionRequest{
+			BlockId:     blockID[:],
+			Transaction: convert.TransactionToMessage(tx),
+		})
+
+		suite.Require().Error(err)
+		suite.Require().Equal(codes.Internal, status.Code(err))
+	})
+
+	suite.Run("simulation timeout", func() {
+
+		mockEngine.On("SimulateTransaction", hasDeadline, &tx, blockID).
+			Return(nil, fmt.Errorf("transaction simulation aborted: %w", context.DeadlineExceeded)).Once()
+
+		_, err := handler.SimulateTransaction(context.Background(), &extended.SimulateTransactionRequest{
+			BlockId:     blockID[:],
+			Transaction: convert.TransactionToMessage(tx),
+		})
+
+		suite.Require().Error(err)
+		suite.Require().Equal(codes.DeadlineExceeded, status.Code(err))
+		mockEngine.AssertExpectations(suite.T())
+	})
+}
+
+// TestExecuteScriptsAtBlockID tests the ExecuteScriptsAtBlockID API call
+func (suite *Suite) TestExecuteScriptsAtBlockID() {
+
+	blockID := unittest.IdentifierFixture()
+
+	mockEngine := new(ingestion.IngestRPC)
+
+	handler := &handler{
+		engine:        mockEngine,
+		chain:         flow.Mainnet,
+		scriptTimeout: DefaultScriptTimeout,
+	}
+
+	scripts := []exeModel.Script{
+		{Code: []byte("script 1"), Arguments: [][]byte{[]byte("arg")}},
+		{Code: []byte("script 2")},
+		{Code: []byte("script 3")},
+	}
+
+	req := &extended.ExecuteScriptsAtBlockIDRequest{
+		BlockId: blockID[:],
+		Scripts: []*extended.Script{
+			{Script: []byte("script 1"), Arguments: [][]byte{[]byte("arg")}},
+			{Script: []byte("script 2")},
+			{Script: []byte("script 3")},
+		},
+	}
+
+	suite.Run("happy path with valid request", func() {
+
+		results := []exeModel.ScriptResult{
+			{Value: []byte("value")},
+			{Err: fmt.Errorf("script execution aborted: %w", context.DeadlineExceeded)},
+			{Err: errors.New("failed")},
+		}
+
+		mockEngine.On("ExecuteScriptsAtBlockID", mock.Anything, scripts, blockID, DefaultScriptTimeout).
+			Return(results, nil).
+			Once()
+
+		resp, err := handler.ExecuteScriptsAtBlockID(context.Background(), req)
+		suite.Require().NoError(err)
+
+		suite.Require().Len(resp.Results, 3)
+		suite.Require().Equal(&extended.ScriptResult{Value: []byte("value")}, resp.Results[0])
+		suite.Require().Equal(uint32(codes.DeadlineExceeded), resp.Results[1].ErrorCode)
+		suite.Require().Equal(uint32(codes.Internal), resp.Results[2].ErrorCode)
+		suite.Require().Equal("failed", resp.Results[2].ErrorMessage)
+		mockEngine.AssertExpectations(suite.T())
+	})
+
+	suite.Run("too many scripts", func() {
+
+		_, err := handler.ExecuteScriptsAtBlockID(context.Background(), &extended.ExecuteScriptsAtBlockIDRequest{
+			BlockId: blockID[:],
+			Scripts: make([]*extended.Script, MaxScriptsPerBatch+1),
+		})
+
+		suite.Require().Error(err)
+		suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+	})
+
+	suite.Run("engine failure", func() {
+
+		mockEngine.On("ExecuteScriptsAtBlockID", mock.Anything, scripts, blockID, DefaultScriptTimeout).
+			Return(nil, errors.New("failed")).
+			Once()
+
+		_, err := handler.ExecuteScriptsAtBlockID(context.Background(), req)
+
+		suite.Require().Error(err)
+		suite.Require().Equal(codes.Internal, status.Code(err))
+	})
+}
+
+// TestGetRegistersWithProof tests the GetRegistersWithProof API call
+func (suite *Suite) TestGetRegistersWithProof() {
+
+	commit := unittest.StateCommitmentFixture()
+	registerIDs := []flow.RegisterID{make([]byte, 32), make([]byte, 32)}
+	registerIDs[1][0] = 1
+
+	mockEngine := new(ingestion.IngestRPC)
+
+	handler := &handler{
+		engine: mockEngine,
+		chain:  flow.Mainnet,
+	}
+
+	req := &extended.GetRegistersWithProofRequest{
+		StateCommitment: commit,
+		RegisterIds:     registerIDs,
+	}
+
+	suite.Run("happy path with valid request", func() {
+
+		values := []flow.RegisterValue{[]byte("a"), []byte("b")}
+		proofs := []flow.StorageProof{[]byte("proof a"), []byte("proof b")}
+
+		mockEngine.On("GetRegistersWithProofs", mock.Anything, commit, registerIDs).Return(values, proofs, nil).Once()
+
+		resp, err := handler.GetRegistersWithProof(context.Background(), req)
+		suite.Require().NoError(err)
+
+		suite.Require().Equal([][]byte{[]byte("a"), []byte("b")}, resp.Values)
+		suite.Require().Equal([][]byte{[]byte("proof a"), []byte("proof b")}, resp.Proofs)
+		mockEngine.AssertExpectations(suite.T())
+	})
+
+	suite.Run("invalid requests", func() {
+
+		_, err := handler.GetRegistersWithProof(context.Background(), &extended.GetRegistersWithProofRequest{
+			RegisterIds: registerIDs,
+		})
+		suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+
+		_, err = handler.GetRegistersWithProof(context.Background(), &extended.GetRegistersWithProofRequest{
+			StateCommitment: commit,
+		})
+		suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+
+		_, err = handler.GetRegistersWithProof(context.Background(), &extended.GetRegistersWithProofRequest{
+			StateCommitment: commit,
+			RegisterIds:     [][]byte{[]byte("short")},
+		})
+		suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+	})
+
+	suite.Run("unknown state commitment", func() {
+
+		mockEngine.On("GetRegistersWithProofs", mock.Anything, commit, registerIDs).
+			Return(nil, nil, fmt.Errorf("could not load trie: %w", os.ErrNotExist)).
+			Once()
+
+		_, err := handler.GetRegistersWithProof(context.Background(), req)
+
+		suite.Require().Error(err)
+		suite.Require().Equal(codes.NotFound, status.Code(err))
+	})
+
+	suite.Run("engine failure", func() {
+
+		mockEngine.On("GetRegistersWithProofs", mock.Anything, commit, registerIDs).
+			Return(nil, nil, errors.New("failed")).
+			Once()
+
+		_, err := handler.GetRegistersWithProof(context.Background(), req)
+
+		suite.Require().Error(err)
+		suite.Require().Equal(codes.Internal, status.Code(err))
+	})
+}
+
+// headerStream is a server transport stream that records the response header.
+type headerStream struct {
+	header metadata.MD
+}
+
+func (s *headerStream) Method() string { return "" }
+
+func (s *headerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *headerStream) SendHeader(md metadata.MD) error { return s.SetHeader(md) }
+
+func (s *headerStream) SetTrailer(metadata.MD) error { return nil }
@@ -8,6 +8,7 @@ import (
 	"sort"
 
 	"github.com/onflow/flow-go/fvm/state"
+	"github.com/onflow/flow-go/model/encoding/cbor"
 	"github.com/onflow/flow-go/model/flow"
 )
 
@@ -189,3 +190,35 @@ func (d Delta) UnmarshalJSON(data []byte) error {
 
 	return nil
 }
+
+// encodableDelta holds the register updates of a delta, as CBOR text strings must be valid UTF-8 and
+// therefore can't hold register IDs.
+type encodableDelta struct {
+	IDs    []flow.RegisterID
+	Values []flow.RegisterValue
+}
+
+func (d Delta) MarshalCBOR() ([]byte, error) {
+	ids, values := d.RegisterUpdates()
+	return cbor.EncMode.Marshal(encodableDelta{IDs: ids, Values: values})
+}
+
+func (d *Delta) UnmarshalCBOR(data []byte) error {
+
+	var enc encodableDelta
+	err := cbor.DecMode.Unmarshal(data, &enc)
+	if err != nil {
+		return fmt.Errorf("cannot unmarshal Delta: %w", err)
+	}
+
+	if len(enc.IDs) != len(enc.Values) {
+		return fmt.Errorf("cannot unmarshal Delta: %d register IDs but %d values", len(enc.IDs), len(enc.Values))
+	}
+
+	*d = NewDelta()
+	for i, id := range enc.IDs {
+		d.Data[toString(id)] = enc.Values[i]
+	}
+
+	return nil
+}
@@ -24,6 +24,7 @@ type View struct {
 	// for views other than collection views to improve performance
 	spockSecretHasher hash.Hasher
 	readFunc          GetRegisterFunc
+	parent            *View // the view this view was created from, nil for root views
 }
 
 // Snapshot is set of interactions with the register
@@ -97,7 +98,9 @@ func (r *Snapshot) AllRegisters() []flow.RegisterID {
 
 // NewChild generates a new child view, with the current view as the base, sharing the Get function
 func (v *View) NewChild() *View {
-	return NewView(v.Get)
+	child := NewView(v.Get)
+	child.parent = v
+	return child
 }
 
 // Get gets a register value from this view.
@@ -128,6 +131,24 @@ func (v *View) Get(owner, controller, key string) (flow.RegisterValue, error) {
 	return value, err
 }
 
+// Peek gets a register value from this view or its ancestors without recording
+// the read in any of them.
+//
+// As long as none of the views is modified and the read function of the root
+// view is safe for concurrent use, Peek can be called concurrently.
+func (v *View) Peek(owner, controller, key string) (flow.RegisterValue, error) {
+	value, exists := v.delta.Data[toString(state.RegisterID(owner, controller, key))]
+	if exists {
+		return value, nil
+	}
+
+	if v.parent != nil {
+		return v.parent.Peek(owner, controller, key)
+	}
+
+	return v.readFunc(owner, controller, key)
+}
+
 // Set sets a register value in this view.
 func (v *View) Set(owner, controller, key string, value flow.RegisterValue) {
 	// every time we write something to delta (order preserving) we update spock
@@ -198,6 +198,59 @@ func TestView_Delete(t *testing.T) {
 	})
 }
 
+func TestView_Peek(t *testing.T) {
+	registerID := "fruit"
+
+	t.Run("ValueFromAncestors", func(t *testing.T) {
+		reads := 0
+		root := delta.NewView(func(owner, controller, key string) (flow.RegisterValue, error) {
+			reads++
+			if owner == registerID {
+				return flow.RegisterValue("orange"), nil
+			}
+
+			return nil, nil
+		})
+		parent := root.NewChild()
+		child := parent.NewChild()
+
+		b, err := child.Peek(registerID, "", "")
+		assert.NoError(t, err)
+		assert.Equal(t, flow.RegisterValue("orange"), b)
+		assert.Equal(t, 1, reads)
+
+		parent.Set(registerID, "", "", flow.RegisterValue("apple"))
+
+		b, err = child.Peek(registerID, "", "")
+		assert.NoError(t, err)
+		assert.Equal(t, flow.RegisterValue("apple"), b)
+		assert.Equal(t, 1, reads)
+
+		parent.Delete(registerID, "", "")
+
+		b, err = child.Peek(registerID, "", "")
+		assert.NoError(t, err)
+		assert.Nil(t, b)
+	})
+
+	t.Run("ReadNotRecorded", func(t *testing.T) {
+		root := delta.NewView(func(owner, controller, key string) (flow.RegisterValue, error) {
+			return flow.RegisterValue("orange"), nil
+		})
+		child := root.NewChild()
+		spockSecret := child.SpockSecret()
+
+		_, err := child.Peek(registerID, "", "")
+		assert.NoError(t, err)
+
+		assert.Empty(t, child.Interactions().Reads)
+		assert.Empty(t, root.Interactions().Reads)
+		assert.Empty(t, child.Delta().ReadMappings)
+		assert.Equal(t, uint64(0), root.ReadsCount())
+		assert.Equal(t, spockSecret, child.SpockSecret())
+	})
+}
+
 func TestView_MergeView(t *testing.T) {
 	registerID1 := "fruit"
 
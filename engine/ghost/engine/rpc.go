@@ -84,9 +84,11 @@ func registerConduits(net module.Network, eng network.Engine) (map[string]networ
 	allEngineIDs := []string{
 		engine.ConsensusCommittee,
 		engine.ConsensusCluster,
+		engine.DKGCommittee,
 		engine.SyncCommittee,
 		engine.SyncCluster,
 		engine.SyncExecution,
+		engine.SyncCheckpoints,
 		engine.PushTransactions,
 		engine.PushGuarantees,
 		engine.PushBlocks,
@@ -306,6 +306,7 @@ func ExecutionNode(t *testing.T, hub *stub.Hub, identity *flow.Identity, identit
 		node.State,
 		vm,
 		vmCtx,
+		false,
 	)
 	require.NoError(t, err)
 
@@ -28,8 +28,9 @@ type hostEnv struct {
 
 	runtime.Metrics
 
-	events []cadence.Event
-	logs   []string
+	events          []cadence.Event
+	logs            []string
+	computationUsed uint64
 
 	transactionEnv *transactionEnv
 	rng            *rand.Rand
@@ -86,6 +87,10 @@ func (e *hostEnv) getLogs() []string {
 	return e.logs
 }
 
+func (e *hostEnv) getComputationUsed() uint64 {
+	return e.computationUsed
+}
+
 func (e *hostEnv) GetValue(owner, key []byte) ([]byte, error) {
 	v, _ := e.ledger.Get(
 
@@ -191,6 +196,14 @@ func (e *hostEnv) GetComputationLimit() uint64 {
 	return e.ctx.GasLimit
 }
 
+// SetComputationUsed records the computation used by the transaction, as reported by the runtime
+// once the execution finished. Runtimes that don't report it leave the computation used at zero,
+// unless the transaction exceeds its computation limit.
+func (e *hostEnv) SetComputationUsed(used uint64) error {
+	e.computationUsed = used
+	return nil
+}
+
 func (e *hostEnv) DecodeArgument(b []byte, t cadence.Type) (cadence.Value, error) {
 	return jsoncdc.Decode(b)
 }
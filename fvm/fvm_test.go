@@ -392,6 +392,8 @@ func TestBlockContext_ExecuteTransaction_GasLimit(t *testing.T) {
 			gasLimit: 5,
 			check: func(t *testing.T, tx *fvm.TransactionProcedure) {
 				assert.Error(t, tx.Err)
+				// the transaction used up all of its computation
+				assert.Equal(t, uint64(5), tx.GasUsed)
 			},
 		},
 		{
@@ -401,6 +403,7 @@ func TestBlockContext_ExecuteTransaction_GasLimit(t *testing.T) {
 			check: func(t *testing.T, tx *fvm.TransactionProcedure) {
 				require.NoError(t, tx.Err)
 				require.Len(t, tx.Logs, 100)
+				assert.LessOrEqual(t, tx.GasUsed, uint64(1000))
 			},
 		},
 	}
@@ -1,6 +1,7 @@
 package fvm
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/onflow/cadence"
@@ -23,7 +24,7 @@ type TransactionProcedure struct {
 	Transaction *flow.TransactionBody
 	Logs        []string
 	Events      []cadence.Event
-	// TODO: report gas consumption: https://github.com/dapperlabs/flow-go/issues/4139
+	// GasUsed is the computation used by the transaction, as metered by the runtime
 	GasUsed uint64
 	Err     Error
 }
@@ -88,6 +89,15 @@ func (i *TransactionInvocator) Process(
 	location := runtime.TransactionLocation(proc.ID[:])
 
 	err := vm.Runtime.ExecuteTransaction(proc.Transaction.Script, proc.Transaction.Arguments, env, location)
+
+	proc.GasUsed = env.getComputationUsed()
+
+	var limitErr runtime.ComputationLimitExceededError
+	if errors.As(err, &limitErr) {
+		// the transaction used up all of its computation
+		proc.GasUsed = limitErr.Limit
+	}
+
 	if err != nil {
 		return err
 	}
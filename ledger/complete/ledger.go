@@ -11,6 +11,7 @@ import (
 	"github.com/onflow/flow-go/ledger/common/encoding"
 	"github.com/onflow/flow-go/ledger/common/pathfinder"
 	"github.com/onflow/flow-go/ledger/complete/mtrie"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/storage"
 	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
 	"github.com/onflow/flow-go/ledger/complete/wal"
 	"github.com/onflow/flow-go/module"
@@ -28,10 +29,43 @@ import (
 // tries and purge the old ones (LRU-based); in other words, Ledger is not designed to be used
 // for archival usage but make it possible for other software components to reconstruct very old tries using write-ahead logs.
 type Ledger struct {
-	forest  *mtrie.Forest
-	wal     *wal.LedgerWAL
-	metrics module.LedgerMetrics
-	logger  zerolog.Logger
+	forest      *mtrie.Forest
+	wal         *wal.LedgerWAL
+	nodeStorage *storage.DiskStorage
+	metrics     module.LedgerMetrics
+	logger      zerolog.Logger
+}
+
+// config holds the optional parameters of the ledger
+type config struct {
+	nodeStorageDir   string
+	nodeCacheSize    int
+	diskBackedForest bool
+
+	parallelUpdateThreshold int
+}
+
+// Option configures optional parameters of the ledger
+type Option func(*config)
+
+// WithDiskNodeStorage makes the ledger store the nodes of its tries in the given directory
+// and hold at most cacheSize nodes in memory, which bounds the memory used by the forest.
+// By default, the forest is held in memory entirely.
+func WithDiskNodeStorage(dir string, cacheSize int) Option {
+	return func(cfg *config) {
+		cfg.diskBackedForest = true
+		cfg.nodeStorageDir = dir
+		cfg.nodeCacheSize = cacheSize
+	}
+}
+
+// WithParallelUpdateThreshold makes the ledger update the sub-tries of a trie node concurrently
+// if at least threshold registers are updated below the node. A non-positive threshold makes
+// the ledger update its tries serially. By default, trie.DefaultParallelUpdateThreshold is used.
+func WithParallelUpdateThreshold(threshold int) Option {
+	return func(cfg *config) {
+		cfg.parallelUpdateThreshold = threshold
+	}
 }
 
 // NewLedger creates a new in-memory trie-backed ledger storage with persistence.
@@ -39,16 +73,35 @@ func NewLedger(dbDir string,
 	capacity int,
 	metrics module.LedgerMetrics,
 	log zerolog.Logger,
-	reg prometheus.Registerer) (*Ledger, error) {
+	reg prometheus.Registerer,
+	opts ...Option) (*Ledger, error) {
+
+	cfg := config{
+		parallelUpdateThreshold: trie.DefaultParallelUpdateThreshold,
+	}
+	for _, apply := range opts {
+		apply(&cfg)
+	}
 
 	w, err := wal.NewWAL(nil, reg, dbDir, capacity, pathfinder.PathByteSize, wal.SegmentSize)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create LedgerWAL: %w", err)
 	}
 
+	forestOpts := []mtrie.Option{mtrie.WithParallelUpdateThreshold(cfg.parallelUpdateThreshold)}
+	var nodeStorage *storage.DiskStorage
+	if cfg.diskBackedForest {
+		nodeStorage, err = storage.NewDiskStorage(cfg.nodeStorageDir, cfg.nodeCacheSize)
+		if err != nil {
+			_ = w.Close()
+			return nil, fmt.Errorf("cannot create node storage: %w", err)
+		}
+		forestOpts = append(forestOpts, mtrie.WithNodeStorage(nodeStorage))
+	}
+
 	forest, err := mtrie.NewForest(pathfinder.PathByteSize, dbDir, capacity, metrics, func(evictedTrie *trie.MTrie) error {
 		return w.RecordDelete(evictedTrie.RootHash())
-	})
+	}, forestOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create forest: %w", err)
 	}
@@ -56,10 +109,11 @@ func NewLedger(dbDir string,
 	logger := log.With().Str("ledger", "complete").Logger()
 
 	storage := &Ledger{
-		forest:  forest,
-		wal:     w,
-		metrics: metrics,
-		logger:  logger,
+		forest:      forest,
+		wal:         w,
+		nodeStorage: nodeStorage,
+		metrics:     metrics,
+		logger:      logger,
 	}
 
 	err = w.ReplayOnForest(forest)
@@ -84,7 +138,7 @@ func (l *Ledger) Ready() <-chan struct{} {
 // Done implements interface module.ReadyDoneAware
 // it closes all the open write-ahead log files.
 func (l *Ledger) Done() <-chan struct{} {
-	_ = l.wal.Close()
+	l.CloseStorage()
 	done := make(chan struct{})
 	close(done)
 	return done
@@ -195,9 +249,36 @@ func (l *Ledger) Prove(query *ledger.Query) (proof ledger.Proof, err error) {
 	return ledger.Proof(proofToGo), err
 }
 
+// Payloads returns at most limit payloads at the given state in the order of their paths, beginning with
+// the given path, or with the first path if start is nil. It also returns the path to continue with,
+// which is nil if there are no more payloads.
+func (l *Ledger) Payloads(state ledger.State, start ledger.Path, limit int) ([]*ledger.Payload, ledger.Path, error) {
+	t, err := l.forest.GetTrie(ledger.RootHash(state))
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot get trie: %w", err)
+	}
+	return t.Payloads(start, limit)
+}
+
+// Diff returns the changes of the payloads from one state to the other, in the order of their paths
+func (l *Ledger) Diff(from, to ledger.State) ([]ledger.PayloadChange, error) {
+	fromTrie, err := l.forest.GetTrie(ledger.RootHash(from))
+	if err != nil {
+		return nil, fmt.Errorf("cannot get trie of state %x: %w", from, err)
+	}
+	toTrie, err := l.forest.GetTrie(ledger.RootHash(to))
+	if err != nil {
+		return nil, fmt.Errorf("cannot get trie of state %x: %w", to, err)
+	}
+	return trie.Diff(fromTrie, toTrie)
+}
+
 // CloseStorage closes the DB
 func (l *Ledger) CloseStorage() {
 	_ = l.wal.Close()
+	if l.nodeStorage != nil {
+		_ = l.nodeStorage.Close()
+	}
 }
 
 // MemSize return the amount of memory used by ledger
@@ -208,7 +289,15 @@ func (l *Ledger) MemSize() (int64, error) {
 
 // DiskSize returns the amount of disk space used by the storage (in bytes)
 func (l *Ledger) DiskSize() (int64, error) {
-	return l.forest.DiskSize()
+	size, err := l.forest.DiskSize()
+	if err != nil || l.nodeStorage == nil {
+		return size, err
+	}
+	nodesSize, err := l.nodeStorage.DiskSize()
+	if err != nil {
+		return 0, err
+	}
+	return size + nodesSize, nil
 }
 
 // ForestSize returns the number of tries stored in the forest
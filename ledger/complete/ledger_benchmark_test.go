@@ -1,6 +1,7 @@
 package complete_test
 
 import (
+	"fmt"
 	"io/ioutil"
 	"math/rand"
 	"os"
@@ -13,6 +14,7 @@ import (
 	"github.com/onflow/flow-go/ledger/common/encoding"
 	"github.com/onflow/flow-go/ledger/common/utils"
 	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
 	"github.com/onflow/flow-go/ledger/partial/ptrie"
 	"github.com/onflow/flow-go/module/metrics"
 )
@@ -125,3 +127,51 @@ func benchmarkStorage(steps int, b *testing.B) {
 	b.ReportMetric(float64(totalPTrieConstTimeMS/steps), "ptrie_const_time_(ms)")
 
 }
+
+// BenchmarkTrieUpdate benchmarks the performance of trie updates, serially and in parallel
+func BenchmarkTrieUpdate(b *testing.B) {
+	for _, numInsPerStep := range []int{100, 1000, 5000} {
+		for _, threshold := range []int{0, 16, trie.DefaultParallelUpdateThreshold, 1024} {
+			b.Run(fmt.Sprintf("updates=%d/threshold=%d", numInsPerStep, threshold), func(b *testing.B) {
+				benchmarkTrieUpdate(numInsPerStep, threshold, b)
+			})
+		}
+	}
+}
+
+// benchmarkTrieUpdate benchmarks updates of the given number of registers on a trie with as many existing registers,
+// where sub-tries are updated in parallel above the given threshold and a threshold of 0 updates the trie serially
+func benchmarkTrieUpdate(numInsPerStep int, threshold int, b *testing.B) {
+	pathByteSize := 32
+	numRegisters := numInsPerStep
+	payloadMinByteSize := 2
+	payloadMaxByteSize := 64
+	rand.Seed(1)
+
+	emptyTrie, err := trie.NewEmptyMTrie(pathByteSize)
+	if err != nil {
+		b.Fatal(err)
+	}
+	paths := utils.RandomPaths(numRegisters+numInsPerStep, pathByteSize)
+	payloads := make([]ledger.Payload, 0, len(paths))
+	for _, payload := range utils.RandomPayloads(len(paths), payloadMinByteSize, payloadMaxByteSize) {
+		payloads = append(payloads, *payload)
+	}
+	parentTrie, err := trie.NewTrieWithParallelUpdate(emptyTrie, paths[:numRegisters], payloads[:numRegisters], threshold)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	// half of the updated registers exist already
+	updatedPaths := paths[numRegisters-numInsPerStep/2 : numRegisters+numInsPerStep/2]
+	updatedPayloads := payloads[numRegisters+numInsPerStep/2-numInsPerStep : numRegisters+numInsPerStep/2]
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := trie.NewTrieWithParallelUpdate(parentTrie, updatedPaths, updatedPayloads, threshold)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.StopTimer()
+}
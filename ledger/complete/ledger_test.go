@@ -256,6 +256,144 @@ func Test_WAL(t *testing.T) {
 	})
 }
 
+// TestLedger_DiskNodeStorage evaluates that a ledger holding its tries in a disk node storage, with a
+// cache far smaller than the tries, results in the same states, values and proofs as a ledger in memory,
+// and that its tries are recovered from the write-ahead log on restart.
+func TestLedger_DiskNodeStorage(t *testing.T) {
+	numInsPerStep := 50
+	keyNumberOfParts := 3
+	keyPartMinByteSize := 1
+	keyPartMaxByteSize := 100
+	valueMaxByteSize := 2 << 8
+	steps := 5
+	metricsCollector := &metrics.NoopCollector{}
+	logger := zerolog.Logger{}
+
+	unittest.RunWithTempDir(t, func(memDir string) {
+		unittest.RunWithTempDir(t, func(diskDir string) {
+			unittest.RunWithTempDir(t, func(nodeDir string) {
+
+				memLed, err := complete.NewLedger(memDir, 100, metricsCollector, logger, nil)
+				require.NoError(t, err)
+				defer memLed.Done()
+
+				diskLed, err := complete.NewLedger(diskDir, 100, metricsCollector, logger, nil, complete.WithDiskNodeStorage(nodeDir, 10))
+				require.NoError(t, err)
+
+				require.Equal(t, memLed.InitState(), diskLed.InitState())
+
+				state := memLed.InitState()
+				queries := make([]*ledger.Query, 0, steps)
+				for i := 0; i < steps; i++ {
+					keys := utils.RandomUniqueKeys(numInsPerStep, keyNumberOfParts, keyPartMinByteSize, keyPartMaxByteSize)
+					values := utils.RandomValues(numInsPerStep, 1, valueMaxByteSize)
+					update, err := ledger.NewUpdate(state, keys, values)
+					require.NoError(t, err)
+
+					memState, err := memLed.Set(update)
+					require.NoError(t, err)
+					diskState, err := diskLed.Set(update)
+					require.NoError(t, err)
+					require.Equal(t, memState, diskState)
+
+					// query the updated keys together with keys which are not allocated
+					queryKeys := append(keys, utils.RandomUniqueKeys(5, keyNumberOfParts, keyPartMinByteSize, keyPartMaxByteSize)...)
+					query, err := ledger.NewQuery(memState, queryKeys)
+					require.NoError(t, err)
+					queries = append(queries, query)
+
+					state = memState
+				}
+
+				requireEqualLedgers := func(expected, actual ledger.Ledger) {
+					for _, query := range queries {
+						expectedValues, err := expected.Get(query)
+						require.NoError(t, err)
+						actualValues, err := actual.Get(query)
+						require.NoError(t, err)
+						require.Equal(t, expectedValues, actualValues)
+
+						expectedProof, err := expected.Prove(query)
+						require.NoError(t, err)
+						actualProof, err := actual.Prove(query)
+						require.NoError(t, err)
+						require.True(t, expectedProof.Equals(actualProof))
+
+						proof, err := encoding.DecodeTrieBatchProof(actualProof)
+						require.NoError(t, err)
+						assert.True(t, common.VerifyTrieBatchProof(proof, query.State()))
+					}
+				}
+
+				requireEqualLedgers(memLed, diskLed)
+
+				<-diskLed.Done()
+
+				diskLed, err = complete.NewLedger(diskDir, 100, metricsCollector, logger, nil, complete.WithDiskNodeStorage(nodeDir, 10))
+				require.NoError(t, err)
+				defer diskLed.Done()
+
+				requireEqualLedgers(memLed, diskLed)
+			})
+		})
+	})
+}
+
+func TestLedger_PayloadsAndDiff(t *testing.T) {
+	numInsPerStep := 50
+	keyNumberOfParts := 3
+	keyPartMinByteSize := 1
+	keyPartMaxByteSize := 100
+	valueMaxByteSize := 2 << 8
+
+	unittest.RunWithTempDir(t, func(dir string) {
+		led, err := complete.NewLedger(dir, 100, &metrics.NoopCollector{}, zerolog.Logger{}, nil)
+		require.NoError(t, err)
+		defer led.Done()
+
+		keys := utils.RandomUniqueKeys(numInsPerStep, keyNumberOfParts, keyPartMinByteSize, keyPartMaxByteSize)
+		values := utils.RandomValues(numInsPerStep, 1, valueMaxByteSize)
+		update, err := ledger.NewUpdate(led.InitState(), keys, values)
+		require.NoError(t, err)
+		state, err := led.Set(update)
+		require.NoError(t, err)
+
+		// all payloads are listed over multiple pages
+		var payloads []*ledger.Payload
+		var start ledger.Path
+		for {
+			page, next, err := led.Payloads(state, start, 7)
+			require.NoError(t, err)
+			payloads = append(payloads, page...)
+			if next == nil {
+				break
+			}
+			start = next
+		}
+		require.Len(t, payloads, numInsPerStep)
+
+		_, _, err = led.Payloads(unittest.StateCommitmentFixture(), nil, 7)
+		require.Error(t, err)
+
+		// only the updated payload is reported as changed
+		newValue := utils.RandomValues(1, 1, valueMaxByteSize)
+		update, err = ledger.NewUpdate(state, keys[:1], newValue)
+		require.NoError(t, err)
+		newState, err := led.Set(update)
+		require.NoError(t, err)
+
+		changes, err := led.Diff(state, newState)
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+		require.True(t, changes[0].Old.Equals(ledger.NewPayload(keys[0], values[0])))
+		require.True(t, changes[0].New.Equals(ledger.NewPayload(keys[0], newValue[0])))
+
+		changes, err = led.Diff(led.InitState(), state)
+		require.NoError(t, err)
+		require.Len(t, changes, numInsPerStep)
+	})
+}
+
 func TestLedgerFunctionality(t *testing.T) {
 	rand.Seed(time.Now().UnixNano())
 	// You can manually increase this for more coverage
@@ -10,6 +10,7 @@ import (
 	lru "github.com/hashicorp/golang-lru"
 
 	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
 	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/utils/io"
@@ -36,6 +37,37 @@ type Forest struct {
 	onTreeEvicted  func(tree *trie.MTrie) error
 	pathByteSize   int // length [bytes] of register path
 	metrics        module.LedgerMetrics
+	nodeStorage    NodeStorage
+	// parallelUpdateThreshold is the minimum number of registers updated below a trie node
+	// for the sub-tries of the node to be updated concurrently
+	parallelUpdateThreshold int
+}
+
+// NodeStorage holds the nodes of the tries in the forest outside of memory.
+type NodeStorage interface {
+	// Persist stores the nodes of the trie with the given root, and returns
+	// the root as a stored node, which loads its children from the storage.
+	Persist(root *node.Node) (*node.Node, error)
+}
+
+// Option configures optional parameters of the forest
+type Option func(*Forest)
+
+// WithNodeStorage makes the forest hold its tries in the given node storage rather than in memory,
+// so that only the recently used nodes of the tries remain in memory.
+func WithNodeStorage(storage NodeStorage) Option {
+	return func(f *Forest) {
+		f.nodeStorage = storage
+	}
+}
+
+// WithParallelUpdateThreshold makes the forest update the left and right sub-tries of a trie node
+// concurrently if at least threshold registers are updated below the node. A non-positive
+// threshold makes the forest update tries serially.
+func WithParallelUpdateThreshold(threshold int) Option {
+	return func(f *Forest) {
+		f.parallelUpdateThreshold = threshold
+	}
 }
 
 // NewForest returns a new instance of memory forest.
@@ -45,7 +77,7 @@ type Forest struct {
 // THIS IS A ROUGH HEURISTIC as it might evict tries that are still needed.
 // Make sure you chose a sufficiently large forestCapacity, such that, when reaching the capacity, the
 // Least Recently Used trie will never be needed again.
-func NewForest(pathByteSize int, trieStorageDir string, forestCapacity int, metrics module.LedgerMetrics, onTreeEvicted func(tree *trie.MTrie) error) (*Forest, error) {
+func NewForest(pathByteSize int, trieStorageDir string, forestCapacity int, metrics module.LedgerMetrics, onTreeEvicted func(tree *trie.MTrie) error, opts ...Option) (*Forest, error) {
 	// init LRU cache as a SHORTCUT for a usage-related storage eviction policy
 	var cache *lru.Cache
 	var err error
@@ -75,6 +107,11 @@ func NewForest(pathByteSize int, trieStorageDir string, forestCapacity int, metr
 		onTreeEvicted:  onTreeEvicted,
 		pathByteSize:   pathByteSize,
 		metrics:        metrics,
+
+		parallelUpdateThreshold: trie.DefaultParallelUpdateThreshold,
+	}
+	for _, apply := range opts {
+		apply(forest)
 	}
 
 	// add empty roothash
@@ -193,7 +230,7 @@ func (f *Forest) Update(u *ledger.TrieUpdate) (ledger.RootHash, error) {
 		sortedPayloads = append(sortedPayloads, payloadMap[string(path)])
 	}
 
-	newTrie, err := trie.NewTrieWithUpdatedRegisters(parentTrie, sortedPaths, sortedPayloads)
+	newTrie, err := trie.NewTrieWithParallelUpdate(parentTrie, sortedPaths, sortedPayloads, f.parallelUpdateThreshold)
 	if err != nil {
 		return nil, fmt.Errorf("constructing updated trie failed: %w", err)
 	}
@@ -263,7 +300,7 @@ func (f *Forest) Proofs(r *ledger.TrieRead) (*ledger.TrieBatchProof, error) {
 			return bytes.Compare(notFoundPaths[i], notFoundPaths[j]) < 0
 		})
 
-		newTrie, err := trie.NewTrieWithUpdatedRegisters(stateTrie, notFoundPaths, notFoundPayloads)
+		newTrie, err := trie.NewTrieWithParallelUpdate(stateTrie, notFoundPaths, notFoundPayloads, f.parallelUpdateThreshold)
 		if err != nil {
 			return nil, err
 		}
@@ -364,6 +401,19 @@ func (f *Forest) AddTrie(newTrie *trie.MTrie) error {
 		}
 		return fmt.Errorf("forest already contains a tree with same root hash but other properties")
 	}
+
+	// tries are added to the forest as stored tries, whose nodes are loaded from the storage on demand
+	if f.nodeStorage != nil && !newTrie.RootNode().IsStored() {
+		storedRoot, err := f.nodeStorage.Persist(newTrie.RootNode())
+		if err != nil {
+			return fmt.Errorf("cannot persist trie nodes: %w", err)
+		}
+		newTrie, err = trie.NewMTrie(storedRoot)
+		if err != nil {
+			return fmt.Errorf("cannot construct stored trie: %w", err)
+		}
+	}
+
 	f.tries.Add(hashString, newTrie)
 	f.metrics.ForestNumberOfTrees(uint64(f.tries.Len()))
 
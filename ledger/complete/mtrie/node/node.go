@@ -26,6 +26,10 @@ import (
 //
 // Nodes are supposed to be used in READ-ONLY fashion. However,
 // for performance reasons, we not not copy read.
+//
+// A node is either held in memory together with its children, or STORED: a stored
+// node references its children by hash and loads them from a Storage on demand,
+// so that only the recently used parts of a trie are held in memory.
 // TODO: optimized data structures might be able to reduce memory consumption
 type Node struct {
 	lChild    *Node           // Left Child
@@ -36,6 +40,15 @@ type Node struct {
 	hashValue []byte          // hash value of node (cached)
 	maxDepth  uint16          // captures the longest path from this node to compacted leafs in the subtree
 	regCount  uint64          // number of registers allocated in the subtree
+	lHash     []byte          // hash of the left child (stored nodes only)
+	rHash     []byte          // hash of the right child (stored nodes only)
+	storage   Storage         // storage the children are loaded from (stored nodes only)
+}
+
+// Storage holds trie nodes outside of memory, addressed by their hash.
+type Storage interface {
+	// Load returns the stored node with the given hash.
+	Load(hash []byte) (*Node, error)
 }
 
 // NewNode creates a new Node.
@@ -71,6 +84,33 @@ func NewNode(height int,
 	return n
 }
 
+// NewStoredNode creates a stored Node, which loads its children with the given hashes from the storage.
+// Nil hashes denote empty sub-tries.
+// UNCHECKED requirement: combination of values must conform to
+// a valid node type (see documentation of `Node` for details)
+func NewStoredNode(height int,
+	lHash,
+	rHash []byte,
+	path ledger.Path,
+	payload *ledger.Payload,
+	hashValue []byte,
+	maxDepth uint16,
+	regCount uint64,
+	storage Storage) *Node {
+
+	return &Node{
+		height:    height,
+		path:      path,
+		payload:   payload,
+		hashValue: hashValue,
+		maxDepth:  maxDepth,
+		regCount:  regCount,
+		lHash:     lHash,
+		rHash:     rHash,
+		storage:   storage,
+	}
+}
+
 // NewEmptyTreeRoot creates a compact leaf Node
 // UNCHECKED requirement: height must be non-negative
 func NewEmptyTreeRoot(height int) *Node {
@@ -191,12 +231,39 @@ func (n *Node) Payload() *ledger.Payload { return n.payload }
 // LeftChild returns the the Node's left child.
 // Only INTERIOR nodes have children.
 // Do NOT MODIFY returned Node!
-func (n *Node) LeftChild() *Node { return n.lChild }
+func (n *Node) LeftChild() *Node {
+	if n.storage == nil {
+		return n.lChild
+	}
+	return n.load(n.lHash)
+}
 
 // RigthChild returns the the Node's right child.
 // Only INTERIOR nodes have children.
 // Do NOT MODIFY returned Node!
-func (n *Node) RigthChild() *Node { return n.rChild }
+func (n *Node) RigthChild() *Node {
+	if n.storage == nil {
+		return n.rChild
+	}
+	return n.load(n.rHash)
+}
+
+// IsStored returns true if and only if the Node loads its children from a Storage.
+func (n *Node) IsStored() bool { return n.storage != nil }
+
+// load returns the child with the given hash from the storage of a stored Node.
+// As a stored node can only reference nodes that were stored before, failing to load
+// a child means the storage is corrupted or unavailable, which is not recoverable.
+func (n *Node) load(hash []byte) *Node {
+	if hash == nil {
+		return nil
+	}
+	child, err := n.storage.Load(hash)
+	if err != nil {
+		panic(fmt.Sprintf("could not load node %x from storage: %v", hash, err))
+	}
+	return child
+}
 
 // IsLeaf returns true if and only if Node is a LEAF.
 func (n *Node) IsLeaf() bool {
@@ -207,12 +274,12 @@ func (n *Node) IsLeaf() bool {
 // FmtStr provides formatted string representation of the Node and sub tree
 func (n *Node) FmtStr(prefix string, subpath string) string {
 	right := ""
-	if n.rChild != nil {
-		right = fmt.Sprintf("\n%v", n.rChild.FmtStr(prefix+"\t", subpath+"1"))
+	if rChild := n.RigthChild(); rChild != nil {
+		right = fmt.Sprintf("\n%v", rChild.FmtStr(prefix+"\t", subpath+"1"))
 	}
 	left := ""
-	if n.lChild != nil {
-		left = fmt.Sprintf("\n%v", n.lChild.FmtStr(prefix+"\t", subpath+"0"))
+	if lChild := n.LeftChild(); lChild != nil {
+		left = fmt.Sprintf("\n%v", lChild.FmtStr(prefix+"\t", subpath+"0"))
 	}
 	payloadSize := 0
 	if n.payload != nil {
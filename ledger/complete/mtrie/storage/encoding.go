@@ -0,0 +1,139 @@
+package storage
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/encoding"
+	"github.com/onflow/flow-go/ledger/common/utils"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+)
+
+const encodingDecodingVersion = uint16(0)
+
+// encodeNode encodes a node with the given children for the storage. The hash of the node is not
+// encoded, as it is the key of the node in the storage.
+func encodeNode(n *node.Node, lChild *node.Node, rChild *node.Node) []byte {
+
+	var lHash, rHash []byte
+	if lChild != nil {
+		lHash = lChild.Hash()
+	}
+	if rChild != nil {
+		rHash = rChild.Hash()
+	}
+
+	encPayload := encoding.EncodePayload(n.Payload())
+
+	length := 2 + 2 + 2 + 8 + 2 + len(lHash) + 2 + len(rHash) + 2 + len(n.Path()) + 4 + len(encPayload)
+	buf := make([]byte, 0, length)
+
+	// 2-bytes encoding version
+	buf = utils.AppendUint16(buf, encodingDecodingVersion)
+
+	// 2-bytes Big Endian uint16 height
+	buf = utils.AppendUint16(buf, uint16(n.Height()))
+
+	// 2-bytes Big Endian maxDepth
+	buf = utils.AppendUint16(buf, n.MaxDepth())
+
+	// 8-bytes Big Endian regCount
+	buf = utils.AppendUint64(buf, n.RegCount())
+
+	// 2-bytes Big Endian uint16 hash length and n-bytes hash of the left child, empty for no child
+	buf = utils.AppendShortData(buf, lHash)
+
+	// 2-bytes Big Endian uint16 hash length and n-bytes hash of the right child, empty for no child
+	buf = utils.AppendShortData(buf, rHash)
+
+	// 2-bytes Big Endian uint16 path length and n-bytes path
+	buf = utils.AppendShortData(buf, n.Path())
+
+	// 4-bytes Big Endian uint32 encoded payload length and n-bytes encoded payload
+	buf = utils.AppendLongData(buf, encPayload)
+
+	return buf
+}
+
+// decodeNode decodes a node with the given hash as a stored node loading its children from the storage
+func decodeNode(encoded []byte, hashValue []byte, storage node.Storage) (*node.Node, error) {
+
+	version, rest, err := utils.ReadUint16(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read version: %w", err)
+	}
+	if version > encodingDecodingVersion {
+		return nil, fmt.Errorf("unsuported version %d > %d", version, encodingDecodingVersion)
+	}
+
+	height, rest, err := utils.ReadUint16(rest)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read height: %w", err)
+	}
+
+	maxDepth, rest, err := utils.ReadUint16(rest)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read max depth: %w", err)
+	}
+
+	regCount, rest, err := utils.ReadUint64(rest)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read register count: %w", err)
+	}
+
+	lHash, rest, err := readShortData(rest)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read left child hash: %w", err)
+	}
+
+	rHash, rest, err := readShortData(rest)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read right child hash: %w", err)
+	}
+
+	path, rest, err := readShortData(rest)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read path: %w", err)
+	}
+
+	encPayload, err := readLongData(rest)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read payload: %w", err)
+	}
+
+	payload, err := encoding.DecodePayload(encPayload)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode payload: %w", err)
+	}
+
+	return node.NewStoredNode(int(height), nonEmpty(lHash), nonEmpty(rHash), nonEmpty(ledger.Path(path)), payload, hashValue, maxDepth, regCount, storage), nil
+}
+
+// nonEmpty returns nil for empty slices, which the node uses to denote absent children and paths
+func nonEmpty(b []byte) []byte {
+	if len(b) == 0 {
+		return nil
+	}
+	return b
+}
+
+// readShortData reads data prefixed with its uint16 length and returns the rest of bytes
+func readShortData(input []byte) ([]byte, []byte, error) {
+	size, rest, err := utils.ReadUint16(input)
+	if err != nil {
+		return nil, nil, err
+	}
+	return utils.ReadSlice(rest, int(size))
+}
+
+// readLongData reads data prefixed with its uint32 length, which must be the remaining bytes
+func readLongData(input []byte) ([]byte, error) {
+	size, rest, err := utils.ReadUint32(input)
+	if err != nil {
+		return nil, err
+	}
+	if int(size) != len(rest) {
+		return nil, fmt.Errorf("data size %d does not match the remaining %d bytes", size, len(rest))
+	}
+	return rest, nil
+}
@@ -0,0 +1,162 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/utils/io"
+)
+
+// DefaultCacheSize is the default number of nodes held in memory by the disk storage
+const DefaultCacheSize = 1_000_000
+
+// DiskStorage stores trie nodes in an on-disk key-value store, addressed by their hash, and
+// holds the most recently used nodes in memory.
+//
+// Nodes loaded from the storage are stored nodes (see `node.Node`), which load their children
+// from the storage on demand. Hence, a trie whose root is a stored node is only held in memory
+// as far as its nodes are cached or in use.
+//
+// The storage is a cache of the forest rather than a persistence layer: the forest is
+// recovered from the write-ahead log and checkpoints on startup, therefore all nodes of
+// a previous run are dropped when the storage is opened. Nodes of tries evicted from the
+// forest remain on disk until then, as they may be shared with other tries.
+type DiskStorage struct {
+	dir   string
+	db    *badger.DB
+	cache *lru.Cache
+}
+
+// NewDiskStorage opens a node storage in the given directory, which holds at most cacheSize nodes in memory.
+func NewDiskStorage(dir string, cacheSize int) (*DiskStorage, error) {
+	if cacheSize <= 0 {
+		cacheSize = DefaultCacheSize
+	}
+
+	cache, err := lru.New(cacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create node cache: %w", err)
+	}
+
+	opts := badger.
+		DefaultOptions(dir).
+		WithKeepL0InMemory(true).
+		WithLogger(nil)
+
+	db, err := badger.Open(opts)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open node storage: %w", err)
+	}
+
+	// drop the nodes of a previous run, which are rebuilt from the write-ahead log
+	err = db.DropAll()
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("cannot reset node storage: %w", err)
+	}
+
+	return &DiskStorage{
+		dir:   dir,
+		db:    db,
+		cache: cache,
+	}, nil
+}
+
+// Load returns the stored node with the given hash.
+func (s *DiskStorage) Load(hash []byte) (*node.Node, error) {
+	if cached, ok := s.cache.Get(string(hash)); ok {
+		return cached.(*node.Node), nil
+	}
+
+	var encoded []byte
+	err := s.db.View(func(tx *badger.Txn) error {
+		item, err := tx.Get(hash)
+		if err != nil {
+			return err
+		}
+		encoded, err = item.ValueCopy(nil)
+		return err
+	})
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		return nil, fmt.Errorf("node %x not found", hash)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read node %x: %w", hash, err)
+	}
+
+	n, err := decodeNode(encoded, hash, s)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode node %x: %w", hash, err)
+	}
+
+	s.cache.Add(string(hash), n)
+
+	return n, nil
+}
+
+// Persist writes all nodes of the trie with the given root that are held in memory to the storage, and
+// returns the root as a stored node. Sub-tries with a stored root are not traversed, as they were
+// persisted before.
+func (s *DiskStorage) Persist(root *node.Node) (*node.Node, error) {
+	if root.IsStored() {
+		return root, nil
+	}
+
+	batch := s.db.NewWriteBatch()
+	defer batch.Cancel()
+
+	err := s.write(batch, root)
+	if err != nil {
+		return nil, err
+	}
+
+	err = batch.Flush()
+	if err != nil {
+		return nil, fmt.Errorf("cannot write nodes: %w", err)
+	}
+
+	return s.Load(root.Hash())
+}
+
+// write adds the in-memory nodes of the sub-trie to the batch, children first
+func (s *DiskStorage) write(batch *badger.WriteBatch, n *node.Node) error {
+	if n == nil || n.IsStored() {
+		return nil
+	}
+
+	lChild := n.LeftChild()
+	rChild := n.RigthChild()
+
+	err := s.write(batch, lChild)
+	if err != nil {
+		return err
+	}
+	err = s.write(batch, rChild)
+	if err != nil {
+		return err
+	}
+
+	// Set expects the key and value to remain unmodified until the batch is flushed,
+	// which holds since node hashes are never modified
+	err = batch.Set(n.Hash(), encodeNode(n, lChild, rChild))
+	if err != nil {
+		return fmt.Errorf("cannot write node %x: %w", n.Hash(), err)
+	}
+
+	return nil
+}
+
+// DiskSize returns the disk size of the directory used by the storage (in bytes)
+func (s *DiskStorage) DiskSize() (int64, error) {
+	return io.DirSize(s.dir)
+}
+
+// Close closes the storage, nodes of tries held by the forest can not be loaded afterwards.
+func (s *DiskStorage) Close() error {
+	s.cache.Purge()
+	return s.db.Close()
+}
@@ -0,0 +1,147 @@
+package storage_test
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/utils"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/storage"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+const pathByteSize = 32
+
+// TestDiskStorage_PersistLoad evaluates that a stored trie, whose nodes mostly do not fit into the cache,
+// returns the same payloads and proofs as the trie held in memory.
+func TestDiskStorage_PersistLoad(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		s, err := storage.NewDiskStorage(dir, 10)
+		require.NoError(t, err)
+		defer s.Close()
+
+		emptyTrie, err := trie.NewEmptyMTrie(pathByteSize)
+		require.NoError(t, err)
+
+		paths, payloads := randomUpdate(100)
+		memTrie, err := trie.NewTrieWithUpdatedRegisters(emptyTrie, paths, payloads)
+		require.NoError(t, err)
+
+		root, err := s.Persist(memTrie.RootNode())
+		require.NoError(t, err)
+		assert.True(t, root.IsStored())
+		assert.Equal(t, memTrie.RootHash(), root.Hash())
+		assert.Equal(t, memTrie.AllocatedRegCount(), root.RegCount())
+		assert.Equal(t, memTrie.MaxDepth(), root.MaxDepth())
+
+		storedTrie, err := trie.NewMTrie(root)
+		require.NoError(t, err)
+		requireEqualTries(t, memTrie, storedTrie, paths)
+
+		// updating a stored trie only persists the updated nodes, and results in the same trie as in memory
+		newPaths, newPayloads := randomUpdate(10)
+		updatedPaths := append([]ledger.Path{paths[0]}, newPaths...)
+		updatedPayloads := append([]ledger.Payload{*utils.RandomPayload(1, 100)}, newPayloads...)
+		sortUpdate(updatedPaths, updatedPayloads)
+
+		updatedMemTrie, err := trie.NewTrieWithUpdatedRegisters(memTrie, updatedPaths, updatedPayloads)
+		require.NoError(t, err)
+		updatedStoredTrie, err := trie.NewTrieWithUpdatedRegisters(storedTrie, updatedPaths, updatedPayloads)
+		require.NoError(t, err)
+		require.Equal(t, updatedMemTrie.RootHash(), updatedStoredTrie.RootHash())
+
+		updatedRoot, err := s.Persist(updatedStoredTrie.RootNode())
+		require.NoError(t, err)
+		updatedStoredTrie, err = trie.NewMTrie(updatedRoot)
+		require.NoError(t, err)
+		requireEqualTries(t, updatedMemTrie, updatedStoredTrie, append(newPaths, paths...))
+
+		// the parent trie remains readable from the storage
+		requireEqualTries(t, memTrie, storedTrie, paths)
+	})
+}
+
+// TestDiskStorage_Reopen evaluates that nodes of a previous run are dropped when the storage is opened.
+func TestDiskStorage_Reopen(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		s, err := storage.NewDiskStorage(dir, 10)
+		require.NoError(t, err)
+
+		emptyTrie, err := trie.NewEmptyMTrie(pathByteSize)
+		require.NoError(t, err)
+		paths, payloads := randomUpdate(10)
+		memTrie, err := trie.NewTrieWithUpdatedRegisters(emptyTrie, paths, payloads)
+		require.NoError(t, err)
+
+		_, err = s.Persist(memTrie.RootNode())
+		require.NoError(t, err)
+		require.NoError(t, s.Close())
+
+		s, err = storage.NewDiskStorage(dir, 10)
+		require.NoError(t, err)
+		defer s.Close()
+
+		_, err = s.Load(memTrie.RootHash())
+		assert.Error(t, err)
+	})
+}
+
+// requireEqualTries requires both tries to return the same payloads and proofs for the given paths
+func requireEqualTries(t *testing.T, expected *trie.MTrie, actual *trie.MTrie, paths []ledger.Path) {
+	sorted := make([]ledger.Path, len(paths))
+	copy(sorted, paths)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i], sorted[j]) < 0
+	})
+
+	expectedPayloads, err := expected.UnsafeRead(sorted)
+	require.NoError(t, err)
+	actualPayloads, err := actual.UnsafeRead(sorted)
+	require.NoError(t, err)
+	require.Len(t, actualPayloads, len(expectedPayloads))
+	for i := range expectedPayloads {
+		require.True(t, expectedPayloads[i].Equals(actualPayloads[i]))
+	}
+
+	expectedProofs := ledger.NewTrieBatchProofWithEmptyProofs(len(sorted))
+	actualProofs := ledger.NewTrieBatchProofWithEmptyProofs(len(sorted))
+	for i := range sorted {
+		expectedProofs.Proofs[i].Flags = make([]byte, pathByteSize)
+		actualProofs.Proofs[i].Flags = make([]byte, pathByteSize)
+	}
+	require.NoError(t, expected.UnsafeProofs(sorted, expectedProofs.Proofs))
+	require.NoError(t, actual.UnsafeProofs(sorted, actualProofs.Proofs))
+	require.True(t, expectedProofs.Equals(actualProofs))
+}
+
+// randomUpdate returns n random sorted paths and payloads
+func randomUpdate(n int) ([]ledger.Path, []ledger.Payload) {
+	paths := utils.RandomPaths(n, pathByteSize)
+	payloads := make([]ledger.Payload, 0, n)
+	for _, p := range utils.RandomPayloads(n, 1, 100) {
+		payloads = append(payloads, *p)
+	}
+	sortUpdate(paths, payloads)
+	return paths, payloads
+}
+
+func sortUpdate(paths []ledger.Path, payloads []ledger.Payload) {
+	sort.Sort(update{paths, payloads})
+}
+
+type update struct {
+	paths    []ledger.Path
+	payloads []ledger.Payload
+}
+
+func (u update) Len() int           { return len(u.paths) }
+func (u update) Less(i, j int) bool { return bytes.Compare(u.paths[i], u.paths[j]) < 0 }
+func (u update) Swap(i, j int) {
+	u.paths[i], u.paths[j] = u.paths[j], u.paths[i]
+	u.payloads[i], u.payloads[j] = u.payloads[j], u.payloads[i]
+}
@@ -0,0 +1,159 @@
+package trie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/utils"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+)
+
+// Payloads returns at most limit allocated payloads of the trie in the order of their paths, beginning with
+// the first path greater than or equal to the given start path, or with the first path if start is nil.
+// It also returns the path to continue with, which is nil if there are no more payloads.
+// Sub-tries on the left of the start path are not traversed.
+func (mt *MTrie) Payloads(start ledger.Path, limit int) ([]*ledger.Payload, ledger.Path, error) {
+	if start != nil && len(start) != mt.pathByteSize {
+		return nil, nil, fmt.Errorf("start path has length %d, but trie has path length %d", len(start), mt.pathByteSize)
+	}
+	if limit < 1 {
+		return nil, nil, errors.New("limit must be positive")
+	}
+
+	// one more leaf than requested determines where to continue
+	leaves := make([]*node.Node, 0, limit+1)
+	err := mt.collectLeaves(mt.root, start, start != nil, limit+1, &leaves)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var next ledger.Path
+	if len(leaves) > limit {
+		next = leaves[limit].Path()
+		leaves = leaves[:limit]
+	}
+	payloads := make([]*ledger.Payload, 0, len(leaves))
+	for _, leaf := range leaves {
+		payloads = append(payloads, leaf.Payload())
+	}
+	return payloads, next, nil
+}
+
+// collectLeaves appends the allocated leaves of the sub-trie in the order of their paths, until there are limit leaves.
+// If bounded, the sub-trie contains the start path, and only leaves with paths greater than or equal to it are appended.
+func (mt *MTrie) collectLeaves(n *node.Node, start ledger.Path, bounded bool, limit int, leaves *[]*node.Node) error {
+	if n == nil || len(*leaves) >= limit {
+		return nil
+	}
+	if n.IsLeaf() {
+		if !isAllocated(n) {
+			return nil
+		}
+		if bounded && bytes.Compare(n.Path(), start) < 0 {
+			return nil
+		}
+		*leaves = append(*leaves, n)
+		return nil
+	}
+
+	if bounded {
+		right, err := utils.IsBitSet(start, mt.height-n.Height())
+		if err != nil {
+			return fmt.Errorf("cannot read bit of start path: %w", err)
+		}
+		if right {
+			// the left sub-trie only contains smaller paths
+			return mt.collectLeaves(n.RigthChild(), start, true, limit, leaves)
+		}
+	}
+
+	err := mt.collectLeaves(n.LeftChild(), start, bounded, limit, leaves)
+	if err != nil {
+		return err
+	}
+	return mt.collectLeaves(n.RigthChild(), start, false, limit, leaves)
+}
+
+// Diff returns the changes of the allocated payloads from one trie to the other, in the order of their paths.
+// Sub-tries which are shared by both tries, or have the same hash, are not traversed, so that the work is
+// proportional to the number of changes rather than the size of the tries.
+func Diff(from, to *MTrie) ([]ledger.PayloadChange, error) {
+	if from.height != to.height {
+		return nil, fmt.Errorf("tries have different heights %d and %d", from.height, to.height)
+	}
+	var changes []ledger.PayloadChange
+	diff(from.root, to.root, &changes)
+	return changes, nil
+}
+
+// diff appends the changes from one sub-trie to the other sub-trie at the same height
+func diff(from, to *node.Node, changes *[]ledger.PayloadChange) {
+	if from == to {
+		return
+	}
+	if from != nil && to != nil && bytes.Equal(from.Hash(), to.Hash()) {
+		return
+	}
+
+	// below a compact leaf, the sub-tries cannot be compared node by node
+	if from == nil || to == nil || from.IsLeaf() || to.IsLeaf() {
+		mergeLeaves(allocatedLeaves(from, nil), allocatedLeaves(to, nil), changes)
+		return
+	}
+
+	diff(from.LeftChild(), to.LeftChild(), changes)
+	diff(from.RigthChild(), to.RigthChild(), changes)
+}
+
+// mergeLeaves appends the changes between two lists of leaves, which are sorted by their paths
+func mergeLeaves(from, to []*node.Node, changes *[]ledger.PayloadChange) {
+	i, j := 0, 0
+	for i < len(from) || j < len(to) {
+		var cmp int
+		switch {
+		case i == len(from):
+			cmp = 1
+		case j == len(to):
+			cmp = -1
+		default:
+			cmp = bytes.Compare(from[i].Path(), to[j].Path())
+		}
+
+		switch {
+		case cmp < 0:
+			*changes = append(*changes, ledger.PayloadChange{Path: from[i].Path(), Old: from[i].Payload()})
+			i++
+		case cmp > 0:
+			*changes = append(*changes, ledger.PayloadChange{Path: to[j].Path(), New: to[j].Payload()})
+			j++
+		default:
+			if !from[i].Payload().Equals(to[j].Payload()) {
+				*changes = append(*changes, ledger.PayloadChange{Path: from[i].Path(), Old: from[i].Payload(), New: to[j].Payload()})
+			}
+			i++
+			j++
+		}
+	}
+}
+
+// allocatedLeaves appends the allocated leaves of the sub-trie in the order of their paths
+func allocatedLeaves(n *node.Node, leaves []*node.Node) []*node.Node {
+	if n == nil {
+		return leaves
+	}
+	if n.IsLeaf() {
+		if isAllocated(n) {
+			leaves = append(leaves, n)
+		}
+		return leaves
+	}
+	leaves = allocatedLeaves(n.LeftChild(), leaves)
+	return allocatedLeaves(n.RigthChild(), leaves)
+}
+
+// isAllocated returns true if the leaf holds a register with a non-empty value
+func isAllocated(leaf *node.Node) bool {
+	return leaf.Payload() != nil && len(leaf.Payload().Value) > 0
+}
@@ -0,0 +1,189 @@
+package trie_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/utils"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+)
+
+func Test_Payloads(t *testing.T) {
+	emptyTrie, err := trie.NewEmptyMTrie(ReferenceImplPathByteSize)
+	require.NoError(t, err)
+
+	t.Run("empty trie", func(t *testing.T) {
+		payloads, next, err := emptyTrie.Payloads(nil, 10)
+		require.NoError(t, err)
+		require.Empty(t, payloads)
+		require.Nil(t, next)
+	})
+
+	rng := &LinearCongruentialGenerator{seed: 0}
+	paths, payloads := deduplicateWrites(sampleRandomRegisterWrites(rng, 500))
+	mTrie, err := trie.NewTrieWithUpdatedRegisters(emptyTrie, paths, payloads)
+	require.NoError(t, err)
+
+	// unallocated registers are not returned
+	unallocated := paths[:50]
+	mTrie, err = trie.NewTrieWithUpdatedRegisters(mTrie, unallocated, make([]ledger.Payload, len(unallocated)))
+	require.NoError(t, err)
+	expected := sortedPayloads(paths[50:], payloads[50:])
+
+	for _, limit := range []int{1, 7, 100, len(expected), len(expected) + 1} {
+		var all []*ledger.Payload
+		var start ledger.Path
+		for {
+			page, next, err := mTrie.Payloads(start, limit)
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(page), limit)
+			all = append(all, page...)
+			if next == nil {
+				break
+			}
+			require.Len(t, page, limit)
+			start = next
+		}
+		require.Len(t, all, len(expected))
+		for i := range expected {
+			require.True(t, expected[i].Equals(all[i]))
+		}
+	}
+
+	t.Run("start between paths", func(t *testing.T) {
+		sortedPaths := sortedPaths(paths[50:])
+		start := utils.TwoBytesPath(binary.BigEndian.Uint16(sortedPaths[10]) + 1)
+		first := sort.Search(len(sortedPaths), func(i int) bool {
+			return bytes.Compare(sortedPaths[i], start) >= 0
+		})
+
+		page, next, err := mTrie.Payloads(start, 5)
+		require.NoError(t, err)
+		require.Len(t, page, 5)
+		for i, payload := range page {
+			require.True(t, expected[first+i].Equals(payload))
+		}
+		require.Equal(t, sortedPaths[first+5], next)
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		_, _, err := mTrie.Payloads(ledger.Path{1}, 10)
+		require.Error(t, err)
+		_, _, err = mTrie.Payloads(nil, 0)
+		require.Error(t, err)
+	})
+}
+
+func Test_Diff(t *testing.T) {
+	emptyTrie, err := trie.NewEmptyMTrie(ReferenceImplPathByteSize)
+	require.NoError(t, err)
+
+	rng := &LinearCongruentialGenerator{seed: 0}
+	paths, payloads := deduplicateWrites(sampleRandomRegisterWrites(rng, 500))
+	from, err := trie.NewTrieWithUpdatedRegisters(emptyTrie, paths, payloads)
+	require.NoError(t, err)
+
+	// update some registers, unallocate others, and allocate new ones
+	updatedPaths, updatedPayloads := deduplicateWrites(sampleRandomRegisterWrites(rng, 100))
+	for i := 0; i < 20; i++ {
+		updatedPaths = append(updatedPaths, paths[i])
+		updatedPayloads = append(updatedPayloads, *ledger.EmptyPayload())
+	}
+	updatedPaths, updatedPayloads = deduplicateWrites(updatedPaths, updatedPayloads)
+	to, err := trie.NewTrieWithUpdatedRegisters(from, updatedPaths, updatedPayloads)
+	require.NoError(t, err)
+
+	t.Run("no changes", func(t *testing.T) {
+		changes, err := trie.Diff(from, from)
+		require.NoError(t, err)
+		require.Empty(t, changes)
+	})
+
+	t.Run("changes", func(t *testing.T) {
+		changes, err := trie.Diff(from, to)
+		require.NoError(t, err)
+		require.Equal(t, expectedChanges(t, from, to, append(paths, updatedPaths...)), changes)
+
+		reverse, err := trie.Diff(to, from)
+		require.NoError(t, err)
+		require.Len(t, reverse, len(changes))
+		for i, change := range changes {
+			require.Equal(t, change.Path, reverse[i].Path)
+			require.Equal(t, change.Old, reverse[i].New)
+			require.Equal(t, change.New, reverse[i].Old)
+		}
+	})
+
+	t.Run("from empty trie", func(t *testing.T) {
+		changes, err := trie.Diff(emptyTrie, from)
+		require.NoError(t, err)
+		require.Len(t, changes, int(from.AllocatedRegCount()))
+	})
+}
+
+// expectedChanges computes the changes between the tries by reading all given paths from both of them
+func expectedChanges(t *testing.T, from, to *trie.MTrie, paths []ledger.Path) []ledger.PayloadChange {
+	unique := make(map[string]ledger.Path)
+	for _, path := range paths {
+		unique[string(path)] = path
+	}
+	all := make([]ledger.Path, 0, len(unique))
+	for _, path := range unique {
+		all = append(all, path)
+	}
+	sorted := sortedPaths(all)
+
+	fromPayloads, err := from.UnsafeRead(sorted)
+	require.NoError(t, err)
+	toPayloads, err := to.UnsafeRead(sorted)
+	require.NoError(t, err)
+
+	var changes []ledger.PayloadChange
+	for i, path := range sorted {
+		if fromPayloads[i].Equals(toPayloads[i]) {
+			continue
+		}
+		change := ledger.PayloadChange{Path: path}
+		if len(fromPayloads[i].Value) > 0 {
+			change.Old = fromPayloads[i]
+		}
+		if len(toPayloads[i].Value) > 0 {
+			change.New = toPayloads[i]
+		}
+		if change.Old == nil && change.New == nil {
+			continue
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// sortedPayloads returns the payloads in the order of their paths
+func sortedPayloads(paths []ledger.Path, payloads []ledger.Payload) []*ledger.Payload {
+	indices := make([]int, len(paths))
+	for i := range indices {
+		indices[i] = i
+	}
+	sort.Slice(indices, func(i, j int) bool {
+		return bytes.Compare(paths[indices[i]], paths[indices[j]]) < 0
+	})
+	sorted := make([]*ledger.Payload, 0, len(paths))
+	for _, i := range indices {
+		sorted = append(sorted, &payloads[i])
+	}
+	return sorted
+}
+
+// sortedPaths returns a sorted copy of the paths
+func sortedPaths(paths []ledger.Path) []ledger.Path {
+	sorted := append([]ledger.Path{}, paths...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i], sorted[j]) < 0
+	})
+	return sorted
+}
@@ -40,6 +40,11 @@ type MTrie struct {
 	pathByteSize int
 }
 
+// DefaultParallelUpdateThreshold is the default minimum number of registers updated below a node
+// for the left and right sub-tries of the node to be updated concurrently. Below the threshold,
+// the overhead of a goroutine outweighs the gain of updating the sub-tries concurrently.
+const DefaultParallelUpdateThreshold = 64
+
 // NewEmptyMTrie returns an empty Mtrie (root is an empty node)
 func NewEmptyMTrie(pathByteSize int) (*MTrie, error) {
 	if pathByteSize < 1 {
@@ -162,8 +167,18 @@ func (mt *MTrie) read(head *node.Node, paths []ledger.Path) ([]*ledger.Payload,
 //   * keys are NOT duplicated
 // TODO: move consistency checks from MForest to here, to make API is safe and self-contained
 func NewTrieWithUpdatedRegisters(parentTrie *MTrie, updatedPaths []ledger.Path, updatedPayloads []ledger.Payload) (*MTrie, error) {
+	return NewTrieWithParallelUpdate(parentTrie, updatedPaths, updatedPayloads, DefaultParallelUpdateThreshold)
+}
+
+// NewTrieWithParallelUpdate constructs a new trie containing all registers from the parent trie,
+// like NewTrieWithUpdatedRegisters. The left and right sub-tries of a node are updated concurrently
+// if at least parallelThreshold registers are updated below the node. A non-positive threshold
+// disables concurrency, i.e. the trie is updated serially.
+// UNSAFE: method requires the following conditions to be satisfied:
+//   * keys are NOT duplicated
+func NewTrieWithParallelUpdate(parentTrie *MTrie, updatedPaths []ledger.Path, updatedPayloads []ledger.Payload, parallelThreshold int) (*MTrie, error) {
 	parentRoot := parentTrie.root
-	updatedRoot, err := update(parentTrie.height, parentRoot.Height(), parentRoot, updatedPaths, updatedPayloads)
+	updatedRoot, err := update(parentTrie.height, parentRoot.Height(), parentRoot, updatedPaths, updatedPayloads, parallelThreshold)
 	if err != nil {
 		return nil, fmt.Errorf("constructing updated trie failed: %w", err)
 	}
@@ -181,9 +196,9 @@ func NewTrieWithUpdatedRegisters(parentTrie *MTrie, updatedPaths []ledger.Path,
 //     (excluding the bit at index headHeight)
 //   * keys are NOT duplicated
 // TODO: remove error return
-func update(treeHeight int, nodeHeight int, parentNode *node.Node, paths []ledger.Path, payloads []ledger.Payload) (*node.Node, error) {
+func update(treeHeight int, nodeHeight int, parentNode *node.Node, paths []ledger.Path, payloads []ledger.Payload, parallelThreshold int) (*node.Node, error) {
 	if parentNode == nil { // parent Trie has no sub-trie for the set of paths => construct entire subtree
-		return constructSubtrie(treeHeight, nodeHeight, paths, payloads)
+		return constructSubtrie(treeHeight, nodeHeight, paths, payloads, parallelThreshold)
 	}
 
 	if len(paths) == 0 { // We are not changing any values in this sub-trie => return parent trie
@@ -205,7 +220,7 @@ func update(treeHeight int, nodeHeight int, parentNode *node.Node, paths []ledge
 			paths = append(paths, parentNode.Path())
 			payloads = append(payloads, *parentNode.Payload())
 		}
-		return constructSubtrie(treeHeight, nodeHeight, paths, payloads)
+		return constructSubtrie(treeHeight, nodeHeight, paths, payloads, parallelThreshold)
 	}
 
 	// Split payloads so we can update the trie in parallel
@@ -214,17 +229,21 @@ func update(treeHeight int, nodeHeight int, parentNode *node.Node, paths []ledge
 		return nil, fmt.Errorf("error spliting payloads by path: %w", err)
 	}
 
-	// TODO [runtime optimization]: do not branch if either lpayload or rpayload is empty
 	var lChild, rChild *node.Node
 	var lErr, rErr error
-	wg := sync.WaitGroup{}
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		lChild, lErr = update(treeHeight, nodeHeight-1, parentNode.LeftChild(), lpaths, lpayloads)
-	}()
-	rChild, rErr = update(treeHeight, nodeHeight-1, parentNode.RigthChild(), rpaths, rpayloads)
-	wg.Wait()
+	if isParallel(lpaths, rpaths, parallelThreshold) {
+		wg := sync.WaitGroup{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			lChild, lErr = update(treeHeight, nodeHeight-1, parentNode.LeftChild(), lpaths, lpayloads, parallelThreshold)
+		}()
+		rChild, rErr = update(treeHeight, nodeHeight-1, parentNode.RigthChild(), rpaths, rpayloads, parallelThreshold)
+		wg.Wait()
+	} else {
+		lChild, lErr = update(treeHeight, nodeHeight-1, parentNode.LeftChild(), lpaths, lpayloads, parallelThreshold)
+		rChild, rErr = update(treeHeight, nodeHeight-1, parentNode.RigthChild(), rpaths, rpayloads, parallelThreshold)
+	}
 	if lErr != nil || rErr != nil {
 		var merr *multierror.Error
 		if lErr != nil {
@@ -249,7 +268,7 @@ func update(treeHeight int, nodeHeight int, parentNode *node.Node, paths []ledge
 //   * paths contains at least one element
 //   * paths are NOT duplicated
 // TODO: remove error return
-func constructSubtrie(treeHeight int, nodeHeight int, paths []ledger.Path, payloads []ledger.Payload) (*node.Node, error) {
+func constructSubtrie(treeHeight int, nodeHeight int, paths []ledger.Path, payloads []ledger.Payload, parallelThreshold int) (*node.Node, error) {
 	// no inserts => default value, represented by nil node
 	if len(paths) == 0 {
 		return nil, nil
@@ -270,17 +289,21 @@ func constructSubtrie(treeHeight int, nodeHeight int, paths []ledger.Path, paylo
 		return nil, fmt.Errorf("error spliting paths: %w", err)
 	}
 
-	// TODO [runtime optimization]: do not branch if either lpaths or rpaths is empty
 	var lChild, rChild *node.Node
 	var lErr, rErr error
-	wg := sync.WaitGroup{}
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		lChild, lErr = constructSubtrie(treeHeight, nodeHeight-1, lpaths, lpayloads)
-	}()
-	rChild, rErr = constructSubtrie(treeHeight, nodeHeight-1, rpaths, rpayloads)
-	wg.Wait()
+	if isParallel(lpaths, rpaths, parallelThreshold) {
+		wg := sync.WaitGroup{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			lChild, lErr = constructSubtrie(treeHeight, nodeHeight-1, lpaths, lpayloads, parallelThreshold)
+		}()
+		rChild, rErr = constructSubtrie(treeHeight, nodeHeight-1, rpaths, rpayloads, parallelThreshold)
+		wg.Wait()
+	} else {
+		lChild, lErr = constructSubtrie(treeHeight, nodeHeight-1, lpaths, lpayloads, parallelThreshold)
+		rChild, rErr = constructSubtrie(treeHeight, nodeHeight-1, rpaths, rpayloads, parallelThreshold)
+	}
 	if lErr != nil || rErr != nil {
 		var merr *multierror.Error
 		if lErr != nil {
@@ -297,6 +320,15 @@ func constructSubtrie(treeHeight int, nodeHeight int, paths []ledger.Path, paylo
 	return node.NewInterimNode(nodeHeight, lChild, rChild), nil
 }
 
+// isParallel returns true if the sub-tries with the given paths are worth updating concurrently,
+// i.e. if both of them are updated and there are at least parallelThreshold paths in total
+func isParallel(lpaths, rpaths []ledger.Path, parallelThreshold int) bool {
+	if parallelThreshold <= 0 || len(lpaths) == 0 || len(rpaths) == 0 {
+		return false
+	}
+	return len(lpaths)+len(rpaths) >= parallelThreshold
+}
+
 // UnsafeProofs provides proofs for the given paths, this is called unsafe as
 // it requires the input paths to be sorted in advance.
 func (mt *MTrie) UnsafeProofs(paths []ledger.Path, proofs []*ledger.TrieProof) error {
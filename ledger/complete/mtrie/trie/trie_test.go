@@ -197,6 +197,32 @@ func Test_UnallocateRegisters(t *testing.T) {
 	require.Equal(t, expectedRootHashHex, hex.EncodeToString(updatedTrie.RootHash()))
 }
 
+// Test_ParallelUpdate tests that updating the sub-tries concurrently results in the same
+// root hashes as updating them serially, for any threshold.
+func Test_ParallelUpdate(t *testing.T) {
+	emptyTrie, err := trie.NewEmptyMTrie(ReferenceImplPathByteSize)
+	require.NoError(t, err)
+
+	for _, threshold := range []int{1, 2, 16, trie.DefaultParallelUpdateThreshold, 1000} {
+		rng := &LinearCongruentialGenerator{seed: 0}
+		serialTrie, parallelTrie := emptyTrie, emptyTrie
+		for r := 0; r < 20; r++ {
+			paths, payloads := deduplicateWrites(sampleRandomRegisterWrites(rng, r*100))
+			// unallocate some of the registers
+			for i := 0; i < len(payloads)/10; i++ {
+				payloads[i] = *ledger.EmptyPayload()
+			}
+
+			serialTrie, err = trie.NewTrieWithParallelUpdate(serialTrie, paths, payloads, 0)
+			require.NoError(t, err)
+			parallelTrie, err = trie.NewTrieWithParallelUpdate(parallelTrie, paths, payloads, threshold)
+			require.NoError(t, err)
+			require.Equal(t, serialTrie.RootHash(), parallelTrie.RootHash(), "threshold %d, round %d", threshold, r)
+			require.Equal(t, serialTrie.AllocatedRegCount(), parallelTrie.AllocatedRegCount())
+		}
+	}
+}
+
 type LinearCongruentialGenerator struct {
 	seed uint64
 }
@@ -177,6 +177,14 @@ func EmptyPayload() *Payload {
 	return &Payload{}
 }
 
+// PayloadChange is the change of the payload at a path from one trie to another.
+// A nil payload means that no payload is allocated at the path.
+type PayloadChange struct {
+	Path Path
+	Old  *Payload
+	New  *Payload
+}
+
 // TrieProof includes all the information needed to walk
 // through a trie branch from an specific leaf node (key)
 // up to the root of the trie.
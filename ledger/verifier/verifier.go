@@ -0,0 +1,115 @@
+// Package verifier verifies register values read from the execution state against a state commitment.
+//
+// The verification only relies on the proofs provided along with the values, so values can be read from
+// an untrusted execution node, for instance by a light client verifying account storage against the
+// state commitment of a sealed block.
+package verifier
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+// hashSize is the size of the intermediate node hashes included in the proofs (SHA3-256).
+const hashSize = 32
+
+// Verifier verifies register values and their proofs against a state commitment.
+type Verifier struct {
+	keyByteSize int
+}
+
+var _ storage.LedgerVerifier = (*Verifier)(nil)
+
+// New creates a new verifier for registers whose IDs have the given size in bytes.
+func New(keyByteSize int) *Verifier {
+	return &Verifier{
+		keyByteSize: keyByteSize,
+	}
+}
+
+// VerifyRegistersProof verifies that the registers hold the given values at the given state commitment.
+// It returns false if any of the proofs is invalid, and an error if the input is malformed.
+//
+// A proof is only valid if it hashes up to the state commitment. The value of a register which has
+// never been set is empty, and can be verified if its proof ends in an empty sub-trie. Non-inclusion
+// proofs ending in the leaf of another register can't be verified, as they don't include that leaf.
+func (v *Verifier) VerifyRegistersProof(
+	registerIDs []flow.RegisterID,
+	stateCommitment flow.StateCommitment,
+	values []flow.RegisterValue,
+	proofs []flow.StorageProof,
+) (bool, error) {
+
+	if len(registerIDs) != len(values) || len(registerIDs) != len(proofs) {
+		return false, fmt.Errorf("number of registers (%d), values (%d) and proofs (%d) do not match",
+			len(registerIDs), len(values), len(proofs))
+	}
+
+	for i, registerID := range registerIDs {
+		if len(registerID) != v.keyByteSize {
+			return false, fmt.Errorf("invalid size of register ID (%x): %d != %d", registerID, len(registerID), v.keyByteSize)
+		}
+
+		proof, err := DecodeProof(registerID, values[i], proofs[i])
+		if err != nil {
+			return false, fmt.Errorf("could not decode proof of register (%x): %w", registerID, err)
+		}
+
+		if !common.VerifyTrieProof(proof, ledger.State(stateCommitment)) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// DecodeProof decodes the proof of a register, as encoded by the execution node, into a trie proof
+// of the register value.
+//
+// The inclusion flag of the encoded proof is not trusted: the returned proof always requires the
+// register value to hash up to the state commitment.
+func DecodeProof(registerID flow.RegisterID, value flow.RegisterValue, encoded flow.StorageProof) (*ledger.TrieProof, error) {
+
+	// the encoded proof is made of the inclusion flag, the number of steps, the size of the
+	// flags, the flags and finally the hashes of the non-default intermediate nodes
+	if len(encoded) < 3 {
+		return nil, fmt.Errorf("proof is too short: %d bytes", len(encoded))
+	}
+
+	steps := encoded[1]
+
+	flagSize := int(encoded[2])
+	if flagSize < 1 {
+		return nil, fmt.Errorf("invalid flag size: %d", flagSize)
+	}
+	if len(encoded) < 3+flagSize {
+		return nil, fmt.Errorf("proof is too short for its flags: %d < %d bytes", len(encoded), 3+flagSize)
+	}
+
+	flags := encoded[3 : 3+flagSize]
+
+	rest := encoded[3+flagSize:]
+	if len(rest)%hashSize != 0 {
+		return nil, fmt.Errorf("invalid size of intermediate node hashes: %d bytes", len(rest))
+	}
+
+	interims := make([][]byte, 0, len(rest)/hashSize)
+	for i := 0; i < len(rest); i += hashSize {
+		interims = append(interims, rest[i:i+hashSize])
+	}
+
+	proof := &ledger.TrieProof{
+		Path:      ledger.Path(registerID),
+		Payload:   ledger.NewPayload(ledger.Key{}, ledger.Value(value)),
+		Interims:  interims,
+		Inclusion: true,
+		Flags:     flags,
+		Steps:     steps,
+	}
+
+	return proof, nil
+}
@@ -0,0 +1,126 @@
+package verifier_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/ledger/verifier"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/storage/ledger"
+	"github.com/onflow/flow-go/storage/ledger/utils"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestVerifyRegistersProof(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dbDir string) {
+		storage, err := ledger.NewMTrieStorage(dbDir, 100, &metrics.NoopCollector{}, nil)
+		require.NoError(t, err)
+		defer storage.CloseStorage()
+
+		ids, values := registers()
+
+		commit, err := storage.UpdateRegisters(ids, values, storage.EmptyStateCommitment())
+		require.NoError(t, err)
+
+		v := verifier.New(ledger.RegisterKeySize)
+
+		t.Run("valid proofs", func(t *testing.T) {
+			readValues, proofs, err := storage.GetRegistersWithProof(ids, commit)
+			require.NoError(t, err)
+			require.Equal(t, values, readValues)
+
+			verified, err := v.VerifyRegistersProof(ids, commit, readValues, proofs)
+			require.NoError(t, err)
+			assert.True(t, verified)
+		})
+
+		t.Run("register never set", func(t *testing.T) {
+			// the register is in an empty sub-trie
+			id := make([]byte, ledger.RegisterKeySize)
+			utils.SetBit(id, 1)
+
+			readValues, proofs, err := storage.GetRegistersWithProof([]flow.RegisterID{id}, commit)
+			require.NoError(t, err)
+			require.Empty(t, readValues[0])
+
+			verified, err := v.VerifyRegistersProof([]flow.RegisterID{id}, commit, readValues, proofs)
+			require.NoError(t, err)
+			assert.True(t, verified)
+
+			// a value can't be proven with a non-inclusion proof
+			verified, err = v.VerifyRegistersProof([]flow.RegisterID{id}, commit, []flow.RegisterValue{{'x'}}, proofs)
+			require.NoError(t, err)
+			assert.False(t, verified)
+		})
+
+		t.Run("tampered value", func(t *testing.T) {
+			_, proofs, err := storage.GetRegistersWithProof(ids, commit)
+			require.NoError(t, err)
+
+			tampered := make([]flow.RegisterValue, len(values))
+			copy(tampered, values)
+			tampered[2] = []byte{'x'}
+
+			verified, err := v.VerifyRegistersProof(ids, commit, tampered, proofs)
+			require.NoError(t, err)
+			assert.False(t, verified)
+		})
+
+		t.Run("other state commitment", func(t *testing.T) {
+			readValues, proofs, err := storage.GetRegistersWithProof(ids, commit)
+			require.NoError(t, err)
+
+			verified, err := v.VerifyRegistersProof(ids, storage.EmptyStateCommitment(), readValues, proofs)
+			require.NoError(t, err)
+			assert.False(t, verified)
+		})
+
+		t.Run("malformed proof", func(t *testing.T) {
+			readValues, proofs, err := storage.GetRegistersWithProof(ids, commit)
+			require.NoError(t, err)
+
+			proofs[0] = proofs[0][:len(proofs[0])-1]
+
+			_, err = v.VerifyRegistersProof(ids, commit, readValues, proofs)
+			assert.Error(t, err)
+
+			proofs[0] = []byte{1, 0, 200}
+
+			_, err = v.VerifyRegistersProof(ids, commit, readValues, proofs)
+			assert.Error(t, err)
+		})
+
+		t.Run("mismatching input", func(t *testing.T) {
+			readValues, proofs, err := storage.GetRegistersWithProof(ids, commit)
+			require.NoError(t, err)
+
+			_, err = v.VerifyRegistersProof(ids, commit, readValues[1:], proofs)
+			assert.Error(t, err)
+
+			_, err = v.VerifyRegistersProof([]flow.RegisterID{{1}}, commit, readValues[:1], proofs[:1])
+			assert.Error(t, err)
+		})
+	})
+}
+
+func registers() ([]flow.RegisterID, []flow.RegisterValue) {
+	id1 := make([]byte, ledger.RegisterKeySize)
+
+	id2 := make([]byte, ledger.RegisterKeySize)
+	utils.SetBit(id2, 5)
+
+	id3 := make([]byte, ledger.RegisterKeySize)
+	utils.SetBit(id3, 0)
+
+	id4 := make([]byte, ledger.RegisterKeySize)
+	utils.SetBit(id4, 0)
+	utils.SetBit(id4, 5)
+
+	ids := []flow.RegisterID{id1, id2, id3, id4}
+	values := []flow.RegisterValue{{'a'}, {'b'}, {'c'}, {'d'}}
+
+	return ids, values
+}
@@ -0,0 +1,94 @@
+package dkg
+
+import (
+	"fmt"
+
+	"github.com/vmihailenco/msgpack/v4"
+
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// Result is the outcome of the distributed key generation for the random beacon
+// of an epoch, as seen by one of its participants. Besides the private key share
+// of the participant, it contains the public data required for the EpochCommit
+// service event of the epoch.
+type Result struct {
+	EpochCounter uint64
+	Participants []flow.Identifier // participants of the DKG, in the order of their indices
+	Index        uint              // index of this node among the participants
+	PrivKeyShare crypto.PrivateKey
+	GroupKey     crypto.PublicKey
+	KeyShares    []crypto.PublicKey // public key shares of all participants, in the order of their indices
+}
+
+// Lookup returns the public key share and index of every participant, in the
+// form used by the EpochCommit service event.
+func (r *Result) Lookup() map[flow.Identifier]flow.DKGParticipant {
+	lookup := make(map[flow.Identifier]flow.DKGParticipant, len(r.Participants))
+	for i, nodeID := range r.Participants {
+		lookup[nodeID] = flow.DKGParticipant{
+			Index:    uint(i),
+			KeyShare: r.KeyShares[i],
+		}
+	}
+	return lookup
+}
+
+// encodableResult is the msgpack encoding of a result. Private keys are not
+// serializable by themselves, to prevent accidental secret sharing, so the
+// private key share is explicitly encoded here for persisting it locally.
+type encodableResult struct {
+	EpochCounter uint64
+	Participants []flow.Identifier
+	Index        uint
+	PrivKeyShare []byte
+	GroupKey     []byte
+	KeyShares    [][]byte
+}
+
+func (r Result) MarshalMsgpack() ([]byte, error) {
+	if r.PrivKeyShare == nil || r.GroupKey == nil {
+		return nil, fmt.Errorf("incomplete dkg result")
+	}
+	enc := encodableResult{
+		EpochCounter: r.EpochCounter,
+		Participants: r.Participants,
+		Index:        r.Index,
+		PrivKeyShare: r.PrivKeyShare.Encode(),
+		GroupKey:     r.GroupKey.Encode(),
+		KeyShares:    make([][]byte, 0, len(r.KeyShares)),
+	}
+	for _, share := range r.KeyShares {
+		enc.KeyShares = append(enc.KeyShares, share.Encode())
+	}
+	return msgpack.Marshal(enc)
+}
+
+func (r *Result) UnmarshalMsgpack(b []byte) error {
+	var enc encodableResult
+	err := msgpack.Unmarshal(b, &enc)
+	if err != nil {
+		return err
+	}
+	r.EpochCounter = enc.EpochCounter
+	r.Participants = enc.Participants
+	r.Index = enc.Index
+	r.PrivKeyShare, err = crypto.DecodePrivateKey(crypto.BLSBLS12381, enc.PrivKeyShare)
+	if err != nil {
+		return fmt.Errorf("could not decode private key share: %w", err)
+	}
+	r.GroupKey, err = crypto.DecodePublicKey(crypto.BLSBLS12381, enc.GroupKey)
+	if err != nil {
+		return fmt.Errorf("could not decode group key: %w", err)
+	}
+	r.KeyShares = make([]crypto.PublicKey, 0, len(enc.KeyShares))
+	for i, share := range enc.KeyShares {
+		key, err := crypto.DecodePublicKey(crypto.BLSBLS12381, share)
+		if err != nil {
+			return fmt.Errorf("could not decode key share %d: %w", i, err)
+		}
+		r.KeyShares = append(r.KeyShares, key)
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/vmihailenco/msgpack"
 
 	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/encoding/cbor"
 )
 
 // NetworkPubKey wraps a public key and allows it to be JSON encoded and decoded. It is not defined in the
@@ -162,6 +163,23 @@ func (pub *RandomBeaconPubKey) UnmarshalMsgpack(b []byte) error {
 	return err
 }
 
+func (pub RandomBeaconPubKey) MarshalCBOR() ([]byte, error) {
+	if pub.PublicKey == nil {
+		return nil, fmt.Errorf("empty public key")
+	}
+	return cbor.EncMode.Marshal(pub.PublicKey.Encode())
+}
+
+func (pub *RandomBeaconPubKey) UnmarshalCBOR(b []byte) error {
+	var bz []byte
+	if err := cbor.DecMode.Unmarshal(b, &bz); err != nil {
+		return err
+	}
+	var err error
+	pub.PublicKey, err = crypto.DecodePublicKey(crypto.BLSBLS12381, bz)
+	return err
+}
+
 func (pub *RandomBeaconPubKey) EncodeRLP(w io.Writer) error {
 	return rlp.Encode(w, pub.PublicKey.Encode())
 }
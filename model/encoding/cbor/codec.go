@@ -0,0 +1,56 @@
+package cbor
+
+import (
+	"github.com/fxamacker/cbor/v2"
+)
+
+// EncMode is the CBOR encoding mode used by Flow. Timestamps are encoded with nanosecond precision, so
+// that entities have the same ID after encoding and decoding.
+var EncMode = func() cbor.EncMode {
+	options := cbor.CanonicalEncOptions()
+	options.Time = cbor.TimeRFC3339Nano
+	encMode, err := options.EncMode()
+	if err != nil {
+		panic(err)
+	}
+	return encMode
+}()
+
+// DecMode is the CBOR decoding mode used by Flow.
+var DecMode = func() cbor.DecMode {
+	decMode, err := cbor.DecOptions{}.DecMode()
+	if err != nil {
+		panic(err)
+	}
+	return decMode
+}()
+
+type Encoder struct{}
+
+func NewEncoder() *Encoder {
+	return &Encoder{}
+}
+
+func (e *Encoder) Encode(val interface{}) ([]byte, error) {
+	return EncMode.Marshal(val)
+}
+
+func (e *Encoder) Decode(b []byte, val interface{}) error {
+	return DecMode.Unmarshal(b, val)
+}
+
+func (e *Encoder) MustEncode(val interface{}) []byte {
+	b, err := e.Encode(val)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+func (e *Encoder) MustDecode(b []byte, val interface{}) {
+	err := e.Decode(b, val)
+	if err != nil {
+		panic(err)
+	}
+}
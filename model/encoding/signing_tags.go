@@ -29,4 +29,6 @@ var (
 	SPOCKTag = tag("SPoCK")
 	// POPTag is used to generate proofs of possessions of the private key
 	POPTag = tag("Proof of Possession")
+	// NetworkMessageTag is used for the origin signatures of network messages
+	NetworkMessageTag = tag("Network Message")
 )
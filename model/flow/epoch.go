@@ -10,6 +10,7 @@ import (
 
 	"github.com/onflow/flow-go/crypto"
 	"github.com/onflow/flow-go/model/encodable"
+	"github.com/onflow/flow-go/model/encoding/cbor"
 )
 
 // EpochPhase represents a phase of the Epoch Preparation Protocol. The phase
@@ -131,6 +132,20 @@ func (commit *EpochCommit) UnmarshalMsgpack(b []byte) error {
 	return nil
 }
 
+func (commit *EpochCommit) MarshalCBOR() ([]byte, error) {
+	return cbor.EncMode.Marshal(encodableFromCommit(commit))
+}
+
+func (commit *EpochCommit) UnmarshalCBOR(b []byte) error {
+	var enc encodableCommit
+	err := cbor.DecMode.Unmarshal(b, &enc)
+	if err != nil {
+		return err
+	}
+	*commit = commitFromEncodable(enc)
+	return nil
+}
+
 // EncodeRLP encodes the commit as RLP. The RLP encoding needs to be handled
 // differently from JSON/msgpack, because it does not handle custom encoders
 // within map types.
@@ -230,6 +245,20 @@ func (part *DKGParticipant) UnmarshalMsgpack(b []byte) error {
 	return nil
 }
 
+func (part DKGParticipant) MarshalCBOR() ([]byte, error) {
+	return cbor.EncMode.Marshal(encodableFromDKGParticipant(part))
+}
+
+func (part *DKGParticipant) UnmarshalCBOR(b []byte) error {
+	var enc encodableDKGParticipant
+	err := cbor.DecMode.Unmarshal(b, &enc)
+	if err != nil {
+		return err
+	}
+	*part = dkgParticipantFromEncodable(enc)
+	return nil
+}
+
 func (part DKGParticipant) EncodeRLP(w io.Writer) error {
 	return rlp.Encode(w, encodableFromDKGParticipant(part))
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/vmihailenco/msgpack"
 
 	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/encoding/cbor"
 )
 
 // rxid is the regex for parsing node identity entries.
@@ -117,6 +118,18 @@ func (iy Identity) MarshalMsgpack() ([]byte, error) {
 	return data, nil
 }
 
+func (iy Identity) MarshalCBOR() ([]byte, error) {
+	encodable, err := encodableFromIdentity(iy)
+	if err != nil {
+		return nil, fmt.Errorf("could not convert to encodable: %w", err)
+	}
+	data, err := cbor.EncMode.Marshal(encodable)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode cbor: %w", err)
+	}
+	return data, nil
+}
+
 func identityFromEncodable(ie encodableIdentity, identity *Identity) error {
 	identity.NodeID = ie.NodeID
 	identity.Address = ie.Address
@@ -162,6 +175,19 @@ func (iy *Identity) UnmarshalMsgpack(b []byte) error {
 	return nil
 }
 
+func (iy *Identity) UnmarshalCBOR(b []byte) error {
+	var encodable encodableIdentity
+	err := cbor.DecMode.Unmarshal(b, &encodable)
+	if err != nil {
+		return fmt.Errorf("could not decode cbor: %w", err)
+	}
+	err = identityFromEncodable(encodable, iy)
+	if err != nil {
+		return fmt.Errorf("could not convert from encodable: %w", err)
+	}
+	return nil
+}
+
 // IdentityFilter is a filter on identities.
 type IdentityFilter func(*Identity) bool
 
@@ -3,6 +3,10 @@ package flow
 import (
 	"encoding/json"
 	"fmt"
+
+	cborlib "github.com/fxamacker/cbor/v2"
+
+	"github.com/onflow/flow-go/model/encoding/cbor"
 )
 
 const (
@@ -71,3 +75,42 @@ func (se *ServiceEvent) UnmarshalJSON(b []byte) error {
 	}
 	return nil
 }
+
+func (se *ServiceEvent) UnmarshalCBOR(b []byte) error {
+
+	var enc struct {
+		Type  string
+		Event cborlib.RawMessage
+	}
+	err := cbor.DecMode.Unmarshal(b, &enc)
+	if err != nil {
+		return err
+	}
+
+	// unmarshal the raw event into the appropriate type
+	var event interface{}
+	switch enc.Type {
+	case ServiceEventSetup:
+		setup := new(EpochSetup)
+		err = cbor.DecMode.Unmarshal(enc.Event, setup)
+		if err != nil {
+			return err
+		}
+		event = setup
+	case ServiceEventCommit:
+		commit := new(EpochCommit)
+		err = cbor.DecMode.Unmarshal(enc.Event, commit)
+		if err != nil {
+			return err
+		}
+		event = commit
+	default:
+		return fmt.Errorf("invalid type: %s", enc.Type)
+	}
+
+	*se = ServiceEvent{
+		Type:  enc.Type,
+		Event: event,
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package flow
+
+// Offence is a kind of slashable misbehavior of a consensus participant.
+type Offence uint8
+
+const (
+	OffenceUndefined Offence = iota
+	OffenceDoubleVote
+	OffenceDoubleProposal
+)
+
+func (o Offence) String() string {
+	return [...]string{
+		"OffenceUndefined",
+		"OffenceDoubleVote",
+		"OffenceDoubleProposal",
+	}[o]
+}
+
+// SignedVote is a HotStuff vote as sent by the voter. The signature covers the
+// view and the block ID, so that it can be verified without the voted block.
+type SignedVote struct {
+	View     uint64
+	BlockID  Identifier
+	SignerID Identifier
+	SigData  []byte
+}
+
+// SlashingEvidence is a self-contained record of a slashable offence committed
+// by a consensus participant at a view. It contains the votes or proposals
+// that constitute the offence, including the signatures of the offender, so
+// that anyone with the identity table of the view can verify it:
+//   * for a double vote, the two votes by the offender for different blocks,
+//   * for a double proposal, the two block headers proposed by the offender.
+type SlashingEvidence struct {
+	Offence    Offence
+	OffenderID Identifier
+	View       uint64
+	Votes      []*SignedVote
+	Proposals  []*Header
+}
+
+// ID returns the hash of the evidence.
+func (e *SlashingEvidence) ID() Identifier {
+	return MakeID(e)
+}
+
+// Checksum returns the checksum of the evidence.
+func (e *SlashingEvidence) Checksum() Identifier {
+	return MakeID(e)
+}
@@ -39,3 +39,11 @@ type ClusterBlockVote struct {
 	View    uint64
 	SigData []byte
 }
+
+// ClusterBlockTimeout is a timeout of a round in collection node cluster
+// consensus, including the newest QC known to the collection node.
+type ClusterBlockTimeout struct {
+	View      uint64
+	HighestQC *flow.QuorumCertificate
+	SigData   []byte
+}
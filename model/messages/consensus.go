@@ -18,3 +18,12 @@ type BlockVote struct {
 	View    uint64
 	SigData []byte
 }
+
+// BlockTimeout is part of the consensus protocol and represents a consensus node
+// timing out in a given round. It includes the newest QC known to the node, so
+// that the timeouts of a round can be aggregated into a timeout certificate.
+type BlockTimeout struct {
+	View      uint64
+	HighestQC *flow.QuorumCertificate
+	SigData   []byte
+}
@@ -0,0 +1,36 @@
+package messages
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// DKGMessage is part of the distributed key generation (DKG) for the random
+// beacon of an epoch, run among the consensus nodes of that epoch. The data
+// is opaque to the network layer and is interpreted by the DKG instance of
+// the recipient. Private messages are only ever sent to their recipient over
+// the encrypted 1-1 connection between the two nodes.
+type DKGMessage struct {
+	EpochCounter uint64
+	Data         []byte
+}
+
+// DKGBroadcast is a message of the DKG which its dealer broadcasts to all
+// participants. It is only handed over to the DKG instance of a recipient
+// once enough participants echoed it, so that all participants receive the
+// same message from a dealer. The sequence number identifies the message
+// among the broadcasts of its dealer for the epoch.
+type DKGBroadcast struct {
+	EpochCounter uint64
+	Sequence     uint64
+	Data         []byte
+}
+
+// DKGEcho is sent by every participant of the DKG to all other participants
+// when it receives a broadcast message, and contains the hash of the data
+// it received from the dealer for the sequence number.
+type DKGEcho struct {
+	EpochCounter uint64
+	DealerID     flow.Identifier
+	Sequence     uint64
+	DataHash     flow.Identifier
+}
@@ -26,6 +26,41 @@ type ExecutionStateSyncRequest struct {
 	TargetBlockID  flow.Identifier
 }
 
+// CheckpointManifestRequest requests the manifest of a checkpoint of the execution state
+// with the given state commitment, split into pieces at the given depth.
+type CheckpointManifestRequest struct {
+	Commit flow.StateCommitment
+	Depth  uint8
+	Nonce  uint64 // so that we aren't deduplicated by the network layer
+}
+
+// CheckpointManifestResponse is the response to a checkpoint manifest request. It contains
+// the root hashes of the pieces of the checkpoint, which hash up to the state commitment.
+type CheckpointManifestResponse struct {
+	Commit      flow.StateCommitment
+	Depth       uint8
+	PieceHashes [][]byte
+	Nonce       uint64 // so that we aren't deduplicated by the network layer
+}
+
+// CheckpointPieceRequest requests a piece of a checkpoint of the execution state.
+type CheckpointPieceRequest struct {
+	Commit flow.StateCommitment
+	Depth  uint8
+	Index  uint32
+	Nonce  uint64 // so that we aren't deduplicated by the network layer
+}
+
+// CheckpointPieceResponse is the response to a checkpoint piece request. It contains the
+// encoded sub-trie of the piece, which is verified against the manifest of the checkpoint.
+type CheckpointPieceResponse struct {
+	Commit flow.StateCommitment
+	Depth  uint8
+	Index  uint32
+	Data   []byte
+	Nonce  uint64 // so that we aren't deduplicated by the network layer
+}
+
 type ExecutionStateDelta struct {
 	entity.ExecutableBlock
 	StateInteractions  []*delta.Snapshot
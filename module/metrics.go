@@ -19,6 +19,9 @@ type NetworkMetrics interface {
 	// NetworkDuplicateMessagesDropped counts number of messages dropped due to duplicate detection
 	NetworkDuplicateMessagesDropped(topic string)
 
+	// NetworkMessageRejected counts number of inbound messages rejected due to the given reason
+	NetworkMessageRejected(topic string, reason string)
+
 	// Message receive queue metrics
 	// MessageAdded increments the metric tracking the number of messages in the queue with the given priority
 	MessageAdded(priority int)
@@ -262,6 +265,9 @@ type ExecutionMetrics interface {
 	// ExecutionGasUsedPerBlock reports gas used per block
 	ExecutionGasUsedPerBlock(gas uint64)
 
+	// ExecutionComputationUsedPerTransaction reports computation used per transaction
+	ExecutionComputationUsedPerTransaction(computation uint64)
+
 	// ExecutionStateReadsPerBlock reports number of state access/read operations per block
 	ExecutionStateReadsPerBlock(reads uint64)
 
@@ -303,6 +309,16 @@ type TransactionMetrics interface {
 	TransactionSubmissionFailed()
 }
 
+// AccessMetrics tracks the verification of the data the access node retrieves from execution nodes.
+type AccessMetrics interface {
+	// ExecutionResponseMismatch is called whenever an execution node returned a response that
+	// disagrees with the response agreed upon by the quorum of execution nodes.
+	ExecutionResponseMismatch(method string)
+
+	// ExecutionQuorumFailed is called whenever not enough execution nodes agreed on a response.
+	ExecutionQuorumFailed(method string)
+}
+
 type PingMetrics interface {
 	NodeReachable(node *flow.Identity, reachable bool)
 }
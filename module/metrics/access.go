@@ -0,0 +1,37 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+type AccessCollector struct {
+	executionResponseMismatch *prometheus.CounterVec
+	executionQuorumFailed     *prometheus.CounterVec
+}
+
+func NewAccessCollector() *AccessCollector {
+	ac := &AccessCollector{
+		executionResponseMismatch: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name:      "execution_response_mismatch_total",
+			Namespace: namespaceAccess,
+			Subsystem: subsystemExecutionVerification,
+			Help:      "the number of execution node responses that disagreed with the quorum of execution nodes",
+		}, []string{LabelMethod}),
+		executionQuorumFailed: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name:      "execution_quorum_failed_total",
+			Namespace: namespaceAccess,
+			Subsystem: subsystemExecutionVerification,
+			Help:      "the number of requests for which no quorum of execution nodes agreed on a response",
+		}, []string{LabelMethod}),
+	}
+	return ac
+}
+
+func (ac *AccessCollector) ExecutionResponseMismatch(method string) {
+	ac.executionResponseMismatch.With(prometheus.Labels{LabelMethod: method}).Inc()
+}
+
+func (ac *AccessCollector) ExecutionQuorumFailed(method string) {
+	ac.executionQuorumFailed.With(prometheus.Labels{LabelMethod: method}).Inc()
+}
@@ -39,6 +39,7 @@ func main() {
 			time.Sleep(time.Duration(rand.Int31n(2000)) * time.Millisecond)
 
 			collector.ExecutionGasUsedPerBlock(uint64(rand.Int63n(1e6)))
+			collector.ExecutionComputationUsedPerTransaction(uint64(rand.Int63n(1e4)))
 			collector.ExecutionStateReadsPerBlock(uint64(rand.Int63n(1e6)))
 
 			diskIncrease := rand.Int63n(1024 ^ 2)
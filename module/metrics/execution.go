@@ -20,6 +20,7 @@ const (
 type ExecutionCollector struct {
 	tracer                           *trace.OpenTracer
 	gasUsedPerBlock                  prometheus.Histogram
+	computationUsedPerTransaction    prometheus.Histogram
 	stateReadsPerBlock               prometheus.Histogram
 	totalExecutedTransactionsCounter prometheus.Counter
 	lastExecutedBlockHeightGauge     prometheus.Gauge
@@ -267,6 +268,14 @@ func NewExecutionCollector(tracer *trace.OpenTracer, registerer prometheus.Regis
 			Help:      "the gas used per block",
 		}),
 
+		computationUsedPerTransaction: promauto.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemRuntime,
+			Buckets:   prometheus.ExponentialBuckets(10, 4, 8),
+			Name:      "computation_used_per_transaction",
+			Help:      "the computation used per transaction",
+		}),
+
 		stateReadsPerBlock: promauto.NewHistogram(prometheus.HistogramOpts{
 			Namespace: namespaceExecution,
 			Subsystem: subsystemRuntime,
@@ -331,6 +340,11 @@ func (ec *ExecutionCollector) ExecutionGasUsedPerBlock(gas uint64) {
 	ec.gasUsedPerBlock.Observe(float64(gas))
 }
 
+// ExecutionComputationUsedPerTransaction reports computation used per transaction
+func (ec *ExecutionCollector) ExecutionComputationUsedPerTransaction(computation uint64) {
+	ec.computationUsedPerTransaction.Observe(float64(computation))
+}
+
 // ExecutionStateReadsPerBlock reports number of state access/read operations per block
 func (ec *ExecutionCollector) ExecutionStateReadsPerBlock(reads uint64) {
 	ec.stateReadsPerBlock.Observe(float64(reads))
@@ -9,6 +9,8 @@ const (
 	LabelNodeID   = "nodeid"
 	LabelNodeRole = "noderole"
 	LabelPriority = "priority"
+	LabelMethod   = "method"
+	LabelReason   = "reason"
 )
 
 const (
@@ -67,6 +69,7 @@ const (
 	MessageCollectionGuarantee  = "guarantee"
 	MessageBlockProposal        = "proposal"
 	MessageBlockVote            = "vote"
+	MessageBlockTimeout         = "timeout"
 	MessageExecutionReceipt     = "receipt"
 	MessageResultApproval       = "approval"
 	MessageSyncRequest          = "ping"
@@ -77,6 +80,7 @@ const (
 	MessageSyncedBlock          = "synced_block"
 	MessageClusterBlockProposal = "cluster_proposal"
 	MessageClusterBlockVote     = "cluster_vote"
+	MessageClusterBlockTimeout  = "cluster_timeout"
 	MessageClusterBlockResponse = "cluster_block_response"
 	MessageSyncedClusterBlock   = "synced_cluster_block"
 	MessageTransaction          = "transaction"
@@ -31,6 +31,7 @@ const (
 const (
 	subsystemTransactionTiming     = "transaction_timing"
 	subsystemTransactionSubmission = "transaction_submission"
+	subsystemExecutionVerification = "execution_verification"
 )
 
 // Collection subsystem
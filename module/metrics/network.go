@@ -19,6 +19,7 @@ type NetworkCollector struct {
 	outboundMessageSize      *prometheus.HistogramVec
 	inboundMessageSize       *prometheus.HistogramVec
 	duplicateMessagesDropped *prometheus.CounterVec
+	messagesRejected         *prometheus.CounterVec
 	queueSize                *prometheus.GaugeVec
 	queueDuration            *prometheus.HistogramVec
 }
@@ -50,6 +51,13 @@ func NewNetworkCollector() *NetworkCollector {
 			Help:      "number of duplicate messages dropped",
 		}, []string{LabelChannel}),
 
+		messagesRejected: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespaceNetwork,
+			Subsystem: subsystemGossip,
+			Name:      "messages_rejected",
+			Help:      "number of inbound messages rejected by message validation",
+		}, []string{LabelChannel, LabelReason}),
+
 		queueSize: promauto.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: namespaceNetwork,
 			Subsystem: subsystemQueue,
@@ -86,6 +94,11 @@ func (nc *NetworkCollector) NetworkDuplicateMessagesDropped(topic string) {
 	nc.duplicateMessagesDropped.WithLabelValues(topic).Add(1)
 }
 
+// NetworkMessageRejected tracks the number of inbound messages rejected by the network layer for the given reason
+func (nc *NetworkCollector) NetworkMessageRejected(topic string, reason string) {
+	nc.messagesRejected.WithLabelValues(topic, reason).Add(1)
+}
+
 func (nc *NetworkCollector) MessageAdded(priority int) {
 	nc.queueSize.WithLabelValues(strconv.Itoa(priority)).Inc()
 }
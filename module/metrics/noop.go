@@ -17,6 +17,7 @@ func NewNoopCollector() *NoopCollector {
 func (nc *NoopCollector) NetworkMessageSent(sizeBytes int, topic string)            {}
 func (nc *NoopCollector) NetworkMessageReceived(sizeBytes int, topic string)        {}
 func (nc *NoopCollector) NetworkDuplicateMessagesDropped(topic string)              {}
+func (nc *NoopCollector) NetworkMessageRejected(topic string, reason string)        {}
 func (nc *NoopCollector) MessageAdded(priority int)                                 {}
 func (nc *NoopCollector) MessageRemoved(priority int)                               {}
 func (nc *NoopCollector) QueueDuration(duration time.Duration, priority int)        {}
@@ -75,6 +76,7 @@ func (nc *NoopCollector) LogVerifiableChunkSize(size float64)
 func (nc *NoopCollector) StartBlockReceivedToExecuted(blockID flow.Identifier)      {}
 func (nc *NoopCollector) FinishBlockReceivedToExecuted(blockID flow.Identifier)     {}
 func (nc *NoopCollector) ExecutionGasUsedPerBlock(gas uint64)                       {}
+func (nc *NoopCollector) ExecutionComputationUsedPerTransaction(computation uint64) {}
 func (nc *NoopCollector) ExecutionStateReadsPerBlock(reads uint64)                  {}
 func (nc *NoopCollector) ExecutionStateStorageDiskTotal(bytes int64)                {}
 func (nc *NoopCollector) ExecutionStorageStateCommitment(bytes int64)               {}
@@ -106,5 +108,7 @@ func (nc *NoopCollector) TransactionFinalized(txID flow.Identifier, when time.Ti
 func (nc *NoopCollector) TransactionExecuted(txID flow.Identifier, when time.Time)  {}
 func (nc *NoopCollector) TransactionExpired(txID flow.Identifier)                   {}
 func (nc *NoopCollector) TransactionSubmissionFailed()                              {}
+func (nc *NoopCollector) ExecutionResponseMismatch(method string)                   {}
+func (nc *NoopCollector) ExecutionQuorumFailed(method string)                       {}
 func (nc *NoopCollector) ChunkDataPackRequested()                                   {}
 func (ec *NoopCollector) ExecutionSync(syncing bool)                                {}
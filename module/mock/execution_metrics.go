@@ -29,6 +29,11 @@ func (_m *ExecutionMetrics) ExecutionCollectionRequestSent() {
 	_m.Called()
 }
 
+// ExecutionComputationUsedPerTransaction provides a mock function with given fields: computation
+func (_m *ExecutionMetrics) ExecutionComputationUsedPerTransaction(computation uint64) {
+	_m.Called(computation)
+}
+
 // ExecutionGasUsedPerBlock provides a mock function with given fields: gas
 func (_m *ExecutionMetrics) ExecutionGasUsedPerBlock(gas uint64) {
 	_m.Called(gas)
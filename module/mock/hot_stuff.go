@@ -49,6 +49,11 @@ func (_m *HotStuff) SubmitProposal(proposal *flow.Header, parentView uint64) {
 	_m.Called(proposal, parentView)
 }
 
+// SubmitTimeout provides a mock function with given fields: originID, view, highestQC, sigData
+func (_m *HotStuff) SubmitTimeout(originID flow.Identifier, view uint64, highestQC *flow.QuorumCertificate, sigData []byte) {
+	_m.Called(originID, view, highestQC, sigData)
+}
+
 // SubmitVote provides a mock function with given fields: originID, blockID, view, sigData
 func (_m *HotStuff) SubmitVote(originID flow.Identifier, blockID flow.Identifier, view uint64, sigData []byte) {
 	_m.Called(originID, blockID, view, sigData)
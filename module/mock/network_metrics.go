@@ -33,6 +33,11 @@ func (_m *NetworkMetrics) NetworkMessageReceived(sizeBytes int, topic string) {
 	_m.Called(sizeBytes, topic)
 }
 
+// NetworkMessageRejected provides a mock function with given fields: topic, reason
+func (_m *NetworkMetrics) NetworkMessageRejected(topic string, reason string) {
+	_m.Called(topic, reason)
+}
+
 // NetworkMessageSent provides a mock function with given fields: sizeBytes, topic
 func (_m *NetworkMetrics) NetworkMessageSent(sizeBytes int, topic string) {
 	_m.Called(sizeBytes, topic)
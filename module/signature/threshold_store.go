@@ -0,0 +1,147 @@
+// +build relic
+
+package signature
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/dkg"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+// StaticThresholdSignerStore provides the same threshold signer for every view,
+// e.g. for the root epoch when bootstrapping.
+type StaticThresholdSignerStore struct {
+	signer module.ThresholdSigner
+}
+
+// NewStaticThresholdSignerStore creates a new store which provides the given
+// threshold signer for every view.
+func NewStaticThresholdSignerStore(signer module.ThresholdSigner) *StaticThresholdSignerStore {
+	return &StaticThresholdSignerStore{
+		signer: signer,
+	}
+}
+
+// GetThresholdSigner returns the threshold signer of the store.
+func (s *StaticThresholdSignerStore) GetThresholdSigner(_ uint64) (module.ThresholdSigner, error) {
+	return s.signer, nil
+}
+
+// EpochAwareThresholdSignerStore provides the threshold signer using the random
+// beacon key share of the local node for the epoch of a view. The key share of
+// the root epoch is the one generated when bootstrapping, the key shares of later
+// epochs are the results of the DKGs the node participated in. A DKG result is
+// only used if it matches the DKG committed to by the EpochCommit of its epoch.
+// *Important*: the threshold signers can only create and verify signatures in
+// the context of the provided KMAC tag.
+type EpochAwareThresholdSignerStore struct {
+	tag         string
+	nodeID      flow.Identifier
+	state       protocol.State
+	results     storage.DKGResults
+	rootCounter uint64
+	rootKey     crypto.PrivateKey
+
+	mu      sync.Mutex
+	signers map[uint64]module.ThresholdSigner // threshold signers by epoch counter
+}
+
+// NewEpochAwareThresholdSignerStore creates a new store of the threshold signers
+// of the given node, using the given key share for the root epoch.
+func NewEpochAwareThresholdSignerStore(
+	tag string,
+	nodeID flow.Identifier,
+	state protocol.State,
+	results storage.DKGResults,
+	rootCounter uint64,
+	rootKey crypto.PrivateKey,
+) *EpochAwareThresholdSignerStore {
+	return &EpochAwareThresholdSignerStore{
+		tag:         tag,
+		nodeID:      nodeID,
+		state:       state,
+		results:     results,
+		rootCounter: rootCounter,
+		rootKey:     rootKey,
+		signers:     make(map[uint64]module.ThresholdSigner),
+	}
+}
+
+// GetThresholdSigner returns the threshold signer for the epoch the given view
+// belongs to. As views up to the finalized view are never signed again, the view
+// belongs either to the current or to the next epoch as of the finalized state.
+func (s *EpochAwareThresholdSignerStore) GetThresholdSigner(view uint64) (module.ThresholdSigner, error) {
+
+	epochs := s.state.Final().Epochs()
+	epoch := epochs.Current()
+	finalView, err := epoch.FinalView()
+	if err != nil {
+		return nil, fmt.Errorf("could not get final view of current epoch: %w", err)
+	}
+	if view > finalView {
+		epoch = epochs.Next()
+	}
+	counter, err := epoch.Counter()
+	if err != nil {
+		return nil, fmt.Errorf("could not get epoch counter for view %d: %w", view, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	signer, ok := s.signers[counter]
+	if ok {
+		return signer, nil
+	}
+
+	key := s.rootKey
+	if counter != s.rootCounter {
+		result, err := s.results.ByEpochCounter(counter)
+		if err != nil {
+			return nil, fmt.Errorf("could not get random beacon key share for epoch %d: %w", counter, err)
+		}
+		committed, err := epoch.DKG()
+		if err != nil {
+			return nil, fmt.Errorf("could not get committed dkg for epoch %d: %w", counter, err)
+		}
+		err = CheckDKGResult(result, s.nodeID, committed)
+		if err != nil {
+			return nil, fmt.Errorf("invalid random beacon key share for epoch %d: %w", counter, err)
+		}
+		key = result.PrivKeyShare
+	}
+
+	signer = NewThresholdProvider(s.tag, key)
+	s.signers[counter] = signer
+	return signer, nil
+}
+
+// CheckDKGResult checks that the result of the DKG the given node participated in
+// matches the DKG committed to by the EpochCommit of the epoch, so that the key
+// share of the node can be used for the random beacon of the epoch.
+func CheckDKGResult(result *dkg.Result, nodeID flow.Identifier, committed protocol.DKG) error {
+	if !result.GroupKey.Equals(committed.GroupKey()) {
+		return fmt.Errorf("group key does not match the committed group key")
+	}
+	index, err := committed.Index(nodeID)
+	if err != nil {
+		return fmt.Errorf("node is not a committed dkg participant: %w", err)
+	}
+	if index != result.Index {
+		return fmt.Errorf("index %d does not match the committed index %d", result.Index, index)
+	}
+	keyShare, err := committed.KeyShare(nodeID)
+	if err != nil {
+		return fmt.Errorf("could not get committed key share: %w", err)
+	}
+	if !keyShare.Equals(result.PrivKeyShare.PublicKey()) {
+		return fmt.Errorf("key share does not match the committed key share")
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+// +build relic
+
+package signature
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/dkg"
+	"github.com/onflow/flow-go/model/flow"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	storage "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestEpochAwareThresholdSignerStore(t *testing.T) {
+	nodeID := unittest.IdentifierFixture()
+	rootKey := unittest.KeyFixture(crypto.BLSBLS12381)
+	nextKey := unittest.KeyFixture(crypto.BLSBLS12381)
+	groupKey := unittest.KeyFixture(crypto.BLSBLS12381).PublicKey()
+
+	committed := new(protocol.DKG)
+	committed.On("GroupKey").Return(groupKey)
+	committed.On("Index", nodeID).Return(uint(1), nil)
+	committed.On("KeyShare", nodeID).Return(nextKey.PublicKey(), nil)
+
+	current := new(protocol.Epoch)
+	current.On("Counter").Return(uint64(1), nil)
+	current.On("FinalView").Return(uint64(100), nil)
+	next := new(protocol.Epoch)
+	next.On("Counter").Return(uint64(2), nil)
+	next.On("DKG").Return(committed, nil)
+	epochs := new(protocol.EpochQuery)
+	epochs.On("Current").Return(current)
+	epochs.On("Next").Return(next)
+	final := new(protocol.Snapshot)
+	final.On("Epochs").Return(epochs)
+	state := new(protocol.State)
+	state.On("Final").Return(final)
+
+	msg := []byte("message")
+
+	t.Run("root epoch", func(t *testing.T) {
+		results := new(storage.DKGResults)
+		store := NewEpochAwareThresholdSignerStore("test_beacon", nodeID, state, results, 1, rootKey)
+
+		signer, err := store.GetThresholdSigner(100)
+		require.NoError(t, err)
+		sig, err := signer.Sign(msg)
+		require.NoError(t, err)
+		valid, err := signer.Verify(msg, sig, rootKey.PublicKey())
+		require.NoError(t, err)
+		assert.True(t, valid)
+		results.AssertNotCalled(t, "ByEpochCounter", uint64(1))
+	})
+
+	t.Run("next epoch", func(t *testing.T) {
+		results := new(storage.DKGResults)
+		results.On("ByEpochCounter", uint64(2)).Return(&dkg.Result{
+			EpochCounter: 2,
+			Participants: []flow.Identifier{unittest.IdentifierFixture(), nodeID},
+			Index:        1,
+			PrivKeyShare: nextKey,
+			GroupKey:     groupKey,
+		}, nil).Once()
+		store := NewEpochAwareThresholdSignerStore("test_beacon", nodeID, state, results, 1, rootKey)
+
+		signer, err := store.GetThresholdSigner(101)
+		require.NoError(t, err)
+		sig, err := signer.Sign(msg)
+		require.NoError(t, err)
+		valid, err := signer.Verify(msg, sig, nextKey.PublicKey())
+		require.NoError(t, err)
+		assert.True(t, valid)
+
+		// the signer of the epoch is kept
+		_, err = store.GetThresholdSigner(102)
+		require.NoError(t, err)
+		results.AssertExpectations(t)
+	})
+
+	t.Run("result not matching the commit", func(t *testing.T) {
+		results := new(storage.DKGResults)
+		results.On("ByEpochCounter", uint64(2)).Return(&dkg.Result{
+			EpochCounter: 2,
+			Index:        1,
+			PrivKeyShare: unittest.KeyFixture(crypto.BLSBLS12381),
+			GroupKey:     groupKey,
+		}, nil)
+		store := NewEpochAwareThresholdSignerStore("test_beacon", nodeID, state, results, 1, rootKey)
+
+		_, err := store.GetThresholdSigner(101)
+		require.Error(t, err)
+	})
+}
@@ -26,3 +26,9 @@ type ThresholdSigner interface {
 	Sign(msg []byte) (crypto.Signature, error)
 	Combine(size uint, shares []crypto.Signature, indices []uint) (crypto.Signature, error)
 }
+
+// ThresholdSignerStore provides the threshold signer using the random beacon key
+// share of the local node for the epoch the given view belongs to.
+type ThresholdSignerStore interface {
+	GetThresholdSigner(view uint64) (ThresholdSigner, error)
+}
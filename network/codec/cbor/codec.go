@@ -0,0 +1,104 @@
+// (c) 2020 Dapper Labs - ALL RIGHTS RESERVED
+
+package cbor
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/onflow/flow-go/model/encoding/cbor"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/network/codec/compression"
+)
+
+// Codec represents a CBOR codec for our network, which optionally compresses the encoded messages.
+type Codec struct {
+	compressor compression.Compressor
+}
+
+// Option is an option for the CBOR codec.
+type Option func(*Codec)
+
+// WithCompressor makes the codec compress the encoded messages with the given compressor.
+func WithCompressor(compressor compression.Compressor) Option {
+	return func(c *Codec) {
+		c.compressor = compressor
+	}
+}
+
+// NewCodec creates a new CBOR codec.
+func NewCodec(opts ...Option) *Codec {
+	c := &Codec{}
+	for _, apply := range opts {
+		apply(c)
+	}
+	return c
+}
+
+// NewEncoder creates a new CBOR encoder with the given underlying writer.
+func (c *Codec) NewEncoder(w io.Writer) network.Encoder {
+	enc := cbor.EncMode.NewEncoder(w)
+	return &Encoder{enc: enc, codec: c}
+}
+
+// NewDecoder creates a new CBOR decoder with the given underlying reader.
+func (c *Codec) NewDecoder(r io.Reader) network.Decoder {
+	dec := cbor.DecMode.NewDecoder(r)
+	return &Decoder{dec: dec, codec: c}
+}
+
+// Encode will encode the given entity and return the bytes.
+func (c *Codec) Encode(v interface{}) ([]byte, error) {
+
+	// encode the value
+	env, err := encode(v)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode envelope: %w", err)
+	}
+
+	// encode the envelope
+	data, err := cbor.EncMode.Marshal(env)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode value: %w", err)
+	}
+
+	if c.compressor == nil {
+		return data, nil
+	}
+
+	// compress the envelope
+	data, err = c.compressor.Compress(data)
+	if err != nil {
+		return nil, fmt.Errorf("could not compress envelope: %w", err)
+	}
+
+	return data, nil
+}
+
+// Decode will attempt to decode the given entity from bytes.
+func (c *Codec) Decode(data []byte) (interface{}, error) {
+
+	// decompress the envelope
+	if c.compressor != nil {
+		var err error
+		data, err = c.compressor.Decompress(data)
+		if err != nil {
+			return nil, fmt.Errorf("could not decompress envelope: %w", err)
+		}
+	}
+
+	// decode the envelope
+	var env Envelope
+	err := cbor.DecMode.Unmarshal(data, &env)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode envelope: %w", err)
+	}
+
+	// decode the value
+	v, err := decode(env)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode value: %w", err)
+	}
+
+	return v, nil
+}
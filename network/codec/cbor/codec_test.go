@@ -0,0 +1,228 @@
+// (c) 2020 Dapper Labs - ALL RIGHTS RESERVED
+
+package cbor_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/libp2p/message"
+	"github.com/onflow/flow-go/model/messages"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/network/codec/cbor"
+	"github.com/onflow/flow-go/network/codec/compression"
+	"github.com/onflow/flow-go/network/codec/json"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// maximum size of the messages compressed by the tested codecs
+const testMaxSize = 1 << 20
+
+func codecs(t testing.TB) map[string]network.Codec {
+	codecs := map[string]network.Codec{
+		"json": json.NewCodec(),
+	}
+	for _, name := range []string{compression.None, compression.Snappy, compression.Zstd} {
+		compressor, err := compression.New(name, testMaxSize)
+		require.NoError(t, err)
+		codecs["cbor+"+name] = cbor.NewCodec(cbor.WithCompressor(compressor))
+	}
+	return codecs
+}
+
+func fixtures() map[string]interface{} {
+
+	block := unittest.BlockFixture()
+	transaction := unittest.TransactionFixture()
+	collection := unittest.CollectionFixture(3)
+	chunkDataPack := unittest.ChunkDataPackFixture(unittest.IdentifierFixture())
+
+	stateDelta := unittest.StateDeltaFixture()
+	snapshot := &delta.Snapshot{
+		Delta:       delta.NewDelta(),
+		Reads:       []flow.RegisterID{{0xff, 0x00}},
+		SpockSecret: []byte("secret"),
+	}
+	// register IDs are not valid UTF-8
+	snapshot.Delta.Data[string([]byte{0xff, 0x00})] = []byte("value")
+	stateDelta.StateInteractions = []*delta.Snapshot{snapshot}
+	stateDelta.EndState = unittest.StateCommitmentFixture()
+
+	return map[string]interface{}{
+		"block proposal": unittest.ProposalFromBlock(&block),
+		"block vote": &messages.BlockVote{
+			BlockID: unittest.IdentifierFixture(),
+			View:    42,
+			SigData: unittest.SignatureFixture(),
+		},
+		"block timeout": &messages.BlockTimeout{
+			View:      42,
+			HighestQC: unittest.QuorumCertificateFixture(),
+			SigData:   unittest.SignatureFixture(),
+		},
+		"sync response":         &messages.SyncResponse{Nonce: 1, Height: 42},
+		"block response":        &messages.BlockResponse{Nonce: 1, Blocks: []*flow.Block{&block}},
+		"transaction":           &transaction,
+		"collection guarantee":  unittest.CollectionGuaranteeFixture(),
+		"transaction body":      collection.Transactions[0],
+		"execution receipt":     unittest.ExecutionReceiptFixture(),
+		"result approval":       unittest.ResultApprovalFixture(),
+		"execution state delta": stateDelta,
+		"chunk data response": &messages.ChunkDataResponse{
+			ChunkDataPack: *chunkDataPack,
+			Collection:    collection,
+			Nonce:         1,
+		},
+		"entity response": &messages.EntityResponse{
+			Nonce:     1,
+			EntityIDs: []flow.Identifier{unittest.IdentifierFixture()},
+			Blobs:     [][]byte{[]byte("blob")},
+		},
+		"echo": &message.TestMessage{Text: "echo"},
+	}
+}
+
+func TestCodec_RoundTrip(t *testing.T) {
+	for codecName, codec := range codecs(t) {
+		if codecName == "json" {
+			continue
+		}
+		for name, v := range fixtures() {
+			t.Run(fmt.Sprintf("%s/%s", codecName, name), func(t *testing.T) {
+				data, err := codec.Encode(v)
+				require.NoError(t, err)
+
+				decoded, err := codec.Decode(data)
+				require.NoError(t, err)
+				assert.Equal(t, v, decoded)
+
+				entity, ok := v.(flow.Entity)
+				if ok {
+					assert.Equal(t, entity.ID(), decoded.(flow.Entity).ID())
+				}
+			})
+		}
+	}
+}
+
+func TestCodec_Stream(t *testing.T) {
+	for codecName, codec := range codecs(t) {
+		if codecName == "json" {
+			continue
+		}
+		t.Run(codecName, func(t *testing.T) {
+			var buf bytes.Buffer
+			encoder := codec.NewEncoder(&buf)
+
+			values := make([]interface{}, 0)
+			for _, v := range fixtures() {
+				err := encoder.Encode(v)
+				require.NoError(t, err)
+				values = append(values, v)
+			}
+
+			decoder := codec.NewDecoder(&buf)
+			for _, v := range values {
+				decoded, err := decoder.Decode()
+				require.NoError(t, err)
+				assert.Equal(t, v, decoded)
+			}
+
+			_, err := decoder.Decode()
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCodec_InvalidData(t *testing.T) {
+	compressor, err := compression.New(compression.Snappy, testMaxSize)
+	require.NoError(t, err)
+
+	codec := cbor.NewCodec(cbor.WithCompressor(compressor))
+
+	_, err = codec.Encode(struct{}{})
+	assert.Error(t, err)
+
+	_, err = codec.Decode([]byte("invalid"))
+	assert.Error(t, err)
+
+	// uncompressed data can't be decoded by a codec expecting compression
+	data, err := cbor.NewCodec().Encode(&message.TestMessage{Text: "echo"})
+	require.NoError(t, err)
+
+	_, err = codec.Decode(data)
+	assert.Error(t, err)
+}
+
+func TestCodec_MaxSize(t *testing.T) {
+	for _, name := range []string{compression.Snappy, compression.Zstd} {
+		t.Run(name, func(t *testing.T) {
+			large, err := compression.New(name, 2*testMaxSize)
+			require.NoError(t, err)
+			small, err := compression.New(name, testMaxSize)
+			require.NoError(t, err)
+
+			// the message compresses well, but exceeds the maximum size once decompressed
+			v := &message.TestMessage{Text: strings.Repeat("a", testMaxSize)}
+			data, err := cbor.NewCodec(cbor.WithCompressor(large)).Encode(v)
+			require.NoError(t, err)
+			require.Less(t, len(data), testMaxSize)
+
+			_, err = cbor.NewCodec(cbor.WithCompressor(small)).Decode(data)
+			assert.Error(t, err)
+
+			_, err = cbor.NewCodec(cbor.WithCompressor(small)).Encode(v)
+			assert.Error(t, err)
+
+			decoded, err := cbor.NewCodec(cbor.WithCompressor(large)).Decode(data)
+			require.NoError(t, err)
+			assert.Equal(t, v, decoded)
+		})
+	}
+}
+
+// BenchmarkCodec_Encode compares the encoding time and size of the messages with the available codecs.
+func BenchmarkCodec_Encode(b *testing.B) {
+	for name, v := range fixtures() {
+		for codecName, codec := range codecs(b) {
+			b.Run(fmt.Sprintf("%s/%s", name, codecName), func(b *testing.B) {
+				var size int
+				for i := 0; i < b.N; i++ {
+					data, err := codec.Encode(v)
+					if err != nil {
+						b.Fatal(err)
+					}
+					size = len(data)
+				}
+				b.ReportMetric(float64(size), "bytes/msg")
+			})
+		}
+	}
+}
+
+// BenchmarkCodec_Decode compares the decoding time of the messages with the available codecs.
+func BenchmarkCodec_Decode(b *testing.B) {
+	for name, v := range fixtures() {
+		for codecName, codec := range codecs(b) {
+			data, err := codec.Encode(v)
+			require.NoError(b, err)
+
+			b.Run(fmt.Sprintf("%s/%s", name, codecName), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					_, err := codec.Decode(data)
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+				b.ReportMetric(float64(len(data)), "bytes/msg")
+			})
+		}
+	}
+}
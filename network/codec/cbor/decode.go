@@ -0,0 +1,116 @@
+// (c) 2020 Dapper Labs - ALL RIGHTS RESERVED
+
+package cbor
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/onflow/flow-go/model/encoding/cbor"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/libp2p/message"
+	"github.com/onflow/flow-go/model/messages"
+)
+
+// decode will decode the envelope into an entity.
+func decode(env Envelope) (interface{}, error) {
+
+	// create the desired message
+	var v interface{}
+	switch env.Code {
+
+	// consensus
+	case CodeBlockProposal:
+		v = &messages.BlockProposal{}
+	case CodeBlockVote:
+		v = &messages.BlockVote{}
+	case CodeBlockTimeout:
+		v = &messages.BlockTimeout{}
+
+	// cluster consensus
+	case CodeClusterBlockProposal:
+		v = &messages.ClusterBlockProposal{}
+	case CodeClusterBlockVote:
+		v = &messages.ClusterBlockVote{}
+	case CodeClusterBlockTimeout:
+		v = &messages.ClusterBlockTimeout{}
+	case CodeClusterBlockResponse:
+		v = &messages.ClusterBlockResponse{}
+
+	// distributed key generation
+	case CodeDKGMessage:
+		v = &messages.DKGMessage{}
+	case CodeDKGBroadcast:
+		v = &messages.DKGBroadcast{}
+	case CodeDKGEcho:
+		v = &messages.DKGEcho{}
+
+	// protocol state sync
+	case CodeSyncRequest:
+		v = &messages.SyncRequest{}
+	case CodeSyncResponse:
+		v = &messages.SyncResponse{}
+	case CodeRangeRequest:
+		v = &messages.RangeRequest{}
+	case CodeBatchRequest:
+		v = &messages.BatchRequest{}
+	case CodeBlockResponse:
+		v = &messages.BlockResponse{}
+
+	// collections, guarantees & transactions
+	case CodeCollectionGuarantee:
+		v = &flow.CollectionGuarantee{}
+	case CodeTransactionBody:
+		v = &flow.TransactionBody{}
+	case CodeTransaction:
+		v = &flow.Transaction{}
+
+	// core messages for execution & verification
+	case CodeExecutionReceipt:
+		v = &flow.ExecutionReceipt{}
+	case CodeResultApproval:
+		v = &flow.ResultApproval{}
+
+	// execution state synchronization
+	case CodeExecutionStateSyncRequest:
+		v = &messages.ExecutionStateSyncRequest{}
+	case CodeExecutionStateDelta:
+		v = &messages.ExecutionStateDelta{}
+	case CodeCheckpointManifestRequest:
+		v = &messages.CheckpointManifestRequest{}
+	case CodeCheckpointManifestResponse:
+		v = &messages.CheckpointManifestResponse{}
+	case CodeCheckpointPieceRequest:
+		v = &messages.CheckpointPieceRequest{}
+	case CodeCheckpointPieceResponse:
+		v = &messages.CheckpointPieceResponse{}
+
+	// data exchange for execution of blocks
+	case CodeChunkDataRequest:
+		v = &messages.ChunkDataRequest{}
+	case CodeChunkDataResponse:
+		v = &messages.ChunkDataResponse{}
+
+	// generic entity exchange engines
+	case CodeEntityRequest:
+		v = &messages.EntityRequest{}
+	case CodeEntityResponse:
+		v = &messages.EntityResponse{}
+
+	// testing
+	case CodeEcho:
+		v = &message.TestMessage{}
+
+	default:
+		return nil, errors.Errorf("invalid message code (%d)", env.Code)
+	}
+
+	// unmarshal the payload
+	err := cbor.DecMode.Unmarshal(env.Data, v)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode payload: %w", err)
+	}
+
+	return v, nil
+}
@@ -0,0 +1,34 @@
+// (c) 2020 Dapper Labs - ALL RIGHTS RESERVED
+
+package cbor
+
+import (
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// Decoder implements a stream decoder for CBOR.
+type Decoder struct {
+	dec   *cbor.Decoder
+	codec *Codec
+}
+
+// Decode will decode the next CBOR value from the stream.
+func (d *Decoder) Decode() (interface{}, error) {
+
+	// read the next encoded value
+	var data []byte
+	err := d.dec.Decode(&data)
+	if err != nil {
+		return nil, fmt.Errorf("could not read value: %w", err)
+	}
+
+	// decode the value
+	v, err := d.codec.Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode value: %w", err)
+	}
+
+	return v, nil
+}
@@ -0,0 +1,120 @@
+// (c) 2020 Dapper Labs - ALL RIGHTS RESERVED
+
+package cbor
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/onflow/flow-go/model/encoding/cbor"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/libp2p/message"
+	"github.com/onflow/flow-go/model/messages"
+)
+
+func encode(v interface{}) (*Envelope, error) {
+
+	// determine the message type
+	var code uint8
+	switch v.(type) {
+
+	// consensus
+	case *messages.BlockProposal:
+		code = CodeBlockProposal
+	case *messages.BlockVote:
+		code = CodeBlockVote
+	case *messages.BlockTimeout:
+		code = CodeBlockTimeout
+
+	// protocol state sync
+	case *messages.SyncRequest:
+		code = CodeSyncRequest
+	case *messages.SyncResponse:
+		code = CodeSyncResponse
+	case *messages.RangeRequest:
+		code = CodeRangeRequest
+	case *messages.BatchRequest:
+		code = CodeBatchRequest
+	case *messages.BlockResponse:
+		code = CodeBlockResponse
+
+	// cluster consensus
+	case *messages.ClusterBlockProposal:
+		code = CodeClusterBlockProposal
+	case *messages.ClusterBlockVote:
+		code = CodeClusterBlockVote
+	case *messages.ClusterBlockTimeout:
+		code = CodeClusterBlockTimeout
+	case *messages.ClusterBlockResponse:
+		code = CodeClusterBlockResponse
+
+	// distributed key generation
+	case *messages.DKGMessage:
+		code = CodeDKGMessage
+	case *messages.DKGBroadcast:
+		code = CodeDKGBroadcast
+	case *messages.DKGEcho:
+		code = CodeDKGEcho
+
+	// collections, guarantees & transactions
+	case *flow.CollectionGuarantee:
+		code = CodeCollectionGuarantee
+	case *flow.TransactionBody:
+		code = CodeTransactionBody
+	case *flow.Transaction:
+		code = CodeTransaction
+
+	// core messages for execution & verification
+	case *flow.ExecutionReceipt:
+		code = CodeExecutionReceipt
+	case *flow.ResultApproval:
+		code = CodeResultApproval
+
+	// execution state synchronization
+	case *messages.ExecutionStateSyncRequest:
+		code = CodeExecutionStateSyncRequest
+	case *messages.ExecutionStateDelta:
+		code = CodeExecutionStateDelta
+	case *messages.CheckpointManifestRequest:
+		code = CodeCheckpointManifestRequest
+	case *messages.CheckpointManifestResponse:
+		code = CodeCheckpointManifestResponse
+	case *messages.CheckpointPieceRequest:
+		code = CodeCheckpointPieceRequest
+	case *messages.CheckpointPieceResponse:
+		code = CodeCheckpointPieceResponse
+
+	// data exchange for execution of blocks
+	case *messages.ChunkDataRequest:
+		code = CodeChunkDataRequest
+	case *messages.ChunkDataResponse:
+		code = CodeChunkDataResponse
+
+	// generic entity exchange engines
+	case *messages.EntityRequest:
+		code = CodeEntityRequest
+	case *messages.EntityResponse:
+		code = CodeEntityResponse
+
+	// testing
+	case *message.TestMessage:
+		code = CodeEcho
+
+	default:
+		return nil, errors.Errorf("invalid encode type (%T)", v)
+	}
+
+	// encode the payload
+	data, err := cbor.EncMode.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode payload: %w", err)
+	}
+
+	env := Envelope{
+		Code: code,
+		Data: data,
+	}
+
+	return &env, nil
+}
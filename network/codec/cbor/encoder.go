@@ -0,0 +1,35 @@
+// (c) 2020 Dapper Labs - ALL RIGHTS RESERVED
+
+package cbor
+
+import (
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// Encoder is an encoder to write serialized CBOR to a writer.
+type Encoder struct {
+	enc   *cbor.Encoder
+	codec *Codec
+}
+
+// Encode will convert the given message into CBOR and write it to the
+// underlying encoder. Each message is written as a CBOR byte string, so the
+// messages can be told apart on the stream even when they are compressed.
+func (e *Encoder) Encode(v interface{}) error {
+
+	// encode the value
+	data, err := e.codec.Encode(v)
+	if err != nil {
+		return fmt.Errorf("could not encode value: %w", err)
+	}
+
+	// write the encoded value to network
+	err = e.enc.Encode(data)
+	if err != nil {
+		return fmt.Errorf("could not write value: %w", err)
+	}
+
+	return nil
+}
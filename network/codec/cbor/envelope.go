@@ -0,0 +1,76 @@
+// (c) 2020 Dapper Labs - ALL RIGHTS RESERVED
+
+package cbor
+
+import (
+	"github.com/fxamacker/cbor/v2"
+)
+
+const (
+
+	// consensus
+	CodeBlockProposal = iota + 1
+	CodeBlockVote
+
+	// protocol state sync
+	CodeSyncRequest
+	CodeSyncResponse
+	CodeRangeRequest
+	CodeBatchRequest
+	CodeBlockResponse
+
+	// cluster consensus
+	CodeClusterBlockProposal
+	CodeClusterBlockVote
+	CodeClusterBlockResponse
+
+	// collections, guarantees & transactions
+	CodeCollectionGuarantee
+	CodeTransaction
+	CodeTransactionBody
+
+	// core messages for execution & verification
+	CodeExecutionReceipt
+	CodeResultApproval
+
+	// execution state synchronization
+	CodeExecutionStateSyncRequest
+	CodeExecutionStateDelta
+
+	// data exchange for execution of blocks
+	CodeChunkDataRequest
+	CodeChunkDataResponse
+
+	// generic entity exchange engines
+	CodeEntityRequest
+	CodeEntityResponse
+
+	// testing
+	CodeEcho
+
+	// codes added after the initial set are appended, so that the codes of existing messages do not change
+
+	// consensus timeouts
+	CodeBlockTimeout
+	CodeClusterBlockTimeout
+
+	// distributed key generation
+	CodeDKGMessage
+
+	// execution state checkpoints
+	CodeCheckpointManifestRequest
+	CodeCheckpointManifestResponse
+	CodeCheckpointPieceRequest
+	CodeCheckpointPieceResponse
+
+	// consistent broadcast of distributed key generation
+	CodeDKGBroadcast
+	CodeDKGEcho
+)
+
+// Envelope is a wrapper to convey type information with the CBOR encoding of a message. The payload is
+// kept as raw CBOR, so it is only decoded once the type of the message is known.
+type Envelope struct {
+	Code uint8
+	Data cbor.RawMessage
+}
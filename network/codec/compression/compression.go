@@ -0,0 +1,112 @@
+// (c) 2020 Dapper Labs - ALL RIGHTS RESERVED
+
+package compression
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/DataDog/zstd"
+	"github.com/golang/snappy"
+)
+
+const (
+	None   = "none"
+	Snappy = "snappy"
+	Zstd   = "zstd"
+)
+
+// Compressor compresses and decompresses encoded network messages.
+type Compressor interface {
+	Compress(data []byte) ([]byte, error)
+	Decompress(data []byte) ([]byte, error)
+}
+
+// New creates the compressor with the given name. It returns nil for no compression.
+// The maximum size bounds the data before compression and after decompression, which prevents a small
+// compressed message from exhausting the memory of the receiving node. It should be the maximum size
+// of the network messages.
+func New(name string, maxSize int) (Compressor, error) {
+	if maxSize <= 0 {
+		return nil, fmt.Errorf("invalid maximum size (%d)", maxSize)
+	}
+	switch name {
+	case None, "":
+		return nil, nil
+	case Snappy:
+		return &SnappyCompressor{maxSize: maxSize}, nil
+	case Zstd:
+		return &ZstdCompressor{level: zstd.DefaultCompression, maxSize: maxSize}, nil
+	default:
+		return nil, fmt.Errorf("invalid compression (%s)", name)
+	}
+}
+
+// SnappyCompressor compresses data with snappy, favoring speed over compression ratio.
+type SnappyCompressor struct {
+	maxSize int
+}
+
+// Compress compresses the given data.
+func (s *SnappyCompressor) Compress(data []byte) ([]byte, error) {
+	if len(data) > s.maxSize {
+		return nil, fmt.Errorf("data size too large (%d > %d)", len(data), s.maxSize)
+	}
+	return snappy.Encode(nil, data), nil
+}
+
+// Decompress decompresses the given data.
+func (s *SnappyCompressor) Decompress(data []byte) ([]byte, error) {
+	size, err := snappy.DecodedLen(data)
+	if err != nil {
+		return nil, fmt.Errorf("could not read decompressed size: %w", err)
+	}
+	if size > s.maxSize {
+		return nil, fmt.Errorf("decompressed size too large (%d > %d)", size, s.maxSize)
+	}
+
+	decompressed, err := snappy.Decode(nil, data)
+	if err != nil {
+		return nil, fmt.Errorf("could not decompress: %w", err)
+	}
+
+	return decompressed, nil
+}
+
+// ZstdCompressor compresses data with zstd, favoring compression ratio over speed.
+type ZstdCompressor struct {
+	level   int
+	maxSize int
+}
+
+// Compress compresses the given data.
+func (z *ZstdCompressor) Compress(data []byte) ([]byte, error) {
+	if len(data) > z.maxSize {
+		return nil, fmt.Errorf("data size too large (%d > %d)", len(data), z.maxSize)
+	}
+	compressed, err := zstd.CompressLevel(nil, data, z.level)
+	if err != nil {
+		return nil, fmt.Errorf("could not compress: %w", err)
+	}
+
+	return compressed, nil
+}
+
+// Decompress decompresses the given data.
+func (z *ZstdCompressor) Decompress(data []byte) ([]byte, error) {
+	reader := zstd.NewReader(bytes.NewReader(data))
+	defer reader.Close()
+
+	// read one byte more than the maximum, so we can tell if the data is too large
+	decompressed, err := ioutil.ReadAll(io.LimitReader(reader, int64(z.maxSize)+1))
+	if err != nil {
+		return nil, fmt.Errorf("could not decompress: %w", err)
+	}
+	if len(decompressed) > z.maxSize {
+		return nil, fmt.Errorf("decompressed size too large (> %d)", z.maxSize)
+	}
+
+	return decompressed, nil
+}
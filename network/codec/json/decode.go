@@ -25,15 +25,27 @@ func decode(env Envelope) (interface{}, error) {
 		v = &messages.BlockProposal{}
 	case CodeBlockVote:
 		v = &messages.BlockVote{}
+	case CodeBlockTimeout:
+		v = &messages.BlockTimeout{}
 
 	// cluster consensus
 	case CodeClusterBlockProposal:
 		v = &messages.ClusterBlockProposal{}
 	case CodeClusterBlockVote:
 		v = &messages.ClusterBlockVote{}
+	case CodeClusterBlockTimeout:
+		v = &messages.ClusterBlockTimeout{}
 	case CodeClusterBlockResponse:
 		v = &messages.ClusterBlockResponse{}
 
+	// distributed key generation
+	case CodeDKGMessage:
+		v = &messages.DKGMessage{}
+	case CodeDKGBroadcast:
+		v = &messages.DKGBroadcast{}
+	case CodeDKGEcho:
+		v = &messages.DKGEcho{}
+
 	// protocol state sync
 	case CodeSyncRequest:
 		v = &messages.SyncRequest{}
@@ -65,6 +77,14 @@ func decode(env Envelope) (interface{}, error) {
 		v = &messages.ExecutionStateSyncRequest{}
 	case CodeExecutionStateDelta:
 		v = &messages.ExecutionStateDelta{}
+	case CodeCheckpointManifestRequest:
+		v = &messages.CheckpointManifestRequest{}
+	case CodeCheckpointManifestResponse:
+		v = &messages.CheckpointManifestResponse{}
+	case CodeCheckpointPieceRequest:
+		v = &messages.CheckpointPieceRequest{}
+	case CodeCheckpointPieceResponse:
+		v = &messages.CheckpointPieceResponse{}
 
 	// data exchange for execution of blocks
 	case CodeChunkDataRequest:
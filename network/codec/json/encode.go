@@ -24,6 +24,8 @@ func encode(v interface{}) (*Envelope, error) {
 		code = CodeBlockProposal
 	case *messages.BlockVote:
 		code = CodeBlockVote
+	case *messages.BlockTimeout:
+		code = CodeBlockTimeout
 
 	// protocol state sync
 	case *messages.SyncRequest:
@@ -42,9 +44,19 @@ func encode(v interface{}) (*Envelope, error) {
 		code = CodeClusterBlockProposal
 	case *messages.ClusterBlockVote:
 		code = CodeClusterBlockVote
+	case *messages.ClusterBlockTimeout:
+		code = CodeClusterBlockTimeout
 	case *messages.ClusterBlockResponse:
 		code = CodeClusterBlockResponse
 
+	// distributed key generation
+	case *messages.DKGMessage:
+		code = CodeDKGMessage
+	case *messages.DKGBroadcast:
+		code = CodeDKGBroadcast
+	case *messages.DKGEcho:
+		code = CodeDKGEcho
+
 	// collections, guarantees & transactions
 	case *flow.CollectionGuarantee:
 		code = CodeCollectionGuarantee
@@ -64,6 +76,14 @@ func encode(v interface{}) (*Envelope, error) {
 		code = CodeExecutionStateSyncRequest
 	case *messages.ExecutionStateDelta:
 		code = CodeExecutionStateDelta
+	case *messages.CheckpointManifestRequest:
+		code = CodeCheckpointManifestRequest
+	case *messages.CheckpointManifestResponse:
+		code = CodeCheckpointManifestResponse
+	case *messages.CheckpointPieceRequest:
+		code = CodeCheckpointPieceRequest
+	case *messages.CheckpointPieceResponse:
+		code = CodeCheckpointPieceResponse
 
 	// data exchange for execution of blocks
 	case *messages.ChunkDataRequest:
@@ -47,6 +47,25 @@ const (
 
 	// testing
 	CodeEcho
+
+	// codes added after the initial set are appended, so that the codes of existing messages do not change
+
+	// consensus timeouts
+	CodeBlockTimeout
+	CodeClusterBlockTimeout
+
+	// distributed key generation
+	CodeDKGMessage
+
+	// execution state checkpoints
+	CodeCheckpointManifestRequest
+	CodeCheckpointManifestResponse
+	CodeCheckpointPieceRequest
+	CodeCheckpointPieceResponse
+
+	// consistent broadcast of distributed key generation
+	CodeDKGBroadcast
+	CodeDKGEcho
 )
 
 // Envelope is a wrapper to convey type information with JSON encoding without
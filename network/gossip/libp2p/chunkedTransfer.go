@@ -0,0 +1,507 @@
+package libp2p
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+
+	"github.com/onflow/flow-go/crypto/hash"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const (
+	// DefaultChunkSize is the default size of the chunks a large 1-1 message is split into
+	DefaultChunkSize = 1 << 20 // 1 mb
+
+	// DefaultChunkWindow is the default number of chunks sent ahead of the acknowledgements of the receiver
+	DefaultChunkWindow = 8
+
+	// DefaultMaxChunkedMsgSize is the default maximum size of a 1-1 message transferred in chunks
+	DefaultMaxChunkedMsgSize = 1 << 30 // 1 gb
+)
+
+const (
+	// version of the chunked transfer protocol, sent as the first byte of the transfer header
+	chunkedTransferVersion = 1
+
+	// bounds of the chunk size accepted by the receiver
+	minChunkSize = 1 << 10  // 1 kb
+	maxChunkSize = 16 << 20 // 16 mb
+
+	// maximum number of attempts to transfer a message, each attempt resumes after the last acknowledged chunk
+	maxChunkedTransferAttempts = 3
+
+	// maximum time to wait for the next frame of a chunked transfer
+	chunkedTransferTimeout = 30 * time.Second
+
+	// duration for which a partially received message is kept to resume its transfer
+	partialTransferTTL = 2 * time.Minute
+
+	// maximum number of bytes reserved for partially received messages at once
+	maxPendingTransferBytes = 2 * DefaultMaxChunkedMsgSize
+
+	// maximum number of bytes reserved for partially received messages of a single peer at once, so that
+	// a single peer can't exhaust the reservations of all peers
+	maxPeerPendingTransferBytes = DefaultMaxChunkedMsgSize
+)
+
+// response of the receiver to the header of a chunked transfer
+const (
+	transferAccepted byte = iota
+	transferRejected
+)
+
+// sizes of the frames of the chunked transfer protocol
+const (
+	transferIDLen        = len(flow.ZeroID)
+	transferHeaderSize   = 1 + transferIDLen + 8 + 4 // version, transfer ID, message size, chunk size
+	transferResponseSize = 1 + 4                     // status, index of the next chunk to send
+	chunkHeaderSize      = 4 + 4 + 4                 // chunk index, chunk length, checksum
+	chunkAckSize         = 4                         // number of chunks received
+)
+
+// errTransferRejected is returned to the sender when the receiver does not accept a chunked transfer
+var errTransferRejected = errors.New("chunked transfer rejected by receiver")
+
+// castagnoli is the CRC32 table used for the checksums of chunks
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// A chunked transfer streams a large message over a dedicated libp2p stream in the following steps:
+// 1. the sender writes the transfer header, which identifies the transfer by the hash of the message
+// 2. the receiver responds with the index of the first chunk it misses, which is non-zero if a previous
+//    transfer of the same message from the same sender failed midway
+// 3. the sender writes chunks, each with a checksum, and never more than a window of chunks ahead of
+//    the acknowledgements of the receiver
+// 4. the receiver acknowledges every chunk, and the last chunk only once the hash of the reassembled
+//    message matches the transfer ID
+// All integers are big endian.
+
+// transferHeader is the first frame of a chunked transfer
+type transferHeader struct {
+	ID        flow.Identifier // hash of the transferred message
+	Size      uint64          // size of the transferred message in bytes
+	ChunkSize uint32          // size of all chunks but the last one
+}
+
+// chunks returns the number of chunks of the transfer
+func (h transferHeader) chunks() uint32 {
+	return uint32((h.Size + uint64(h.ChunkSize) - 1) / uint64(h.ChunkSize))
+}
+
+// chunkLength returns the length of the chunk with the given index
+func (h transferHeader) chunkLength(index uint32) uint32 {
+	offset := uint64(index) * uint64(h.ChunkSize)
+	if h.Size-offset < uint64(h.ChunkSize) {
+		return uint32(h.Size - offset)
+	}
+	return h.ChunkSize
+}
+
+// transferID returns the identifier of the chunked transfer of the given message
+func transferID(payload []byte) flow.Identifier {
+	hasher := hash.NewSHA3_256()
+	return flow.HashToID(hasher.ComputeHash(payload))
+}
+
+// deadliner is implemented by streams supporting deadlines, such as libp2p streams
+type deadliner interface {
+	SetDeadline(time.Time) error
+}
+
+// extendDeadline pushes the deadline of the stream, if it supports deadlines, by the given timeout
+func extendDeadline(rw io.ReadWriter, timeout time.Duration) error {
+	d, ok := rw.(deadliner)
+	if !ok {
+		return nil
+	}
+	return d.SetDeadline(time.Now().Add(timeout))
+}
+
+// sendChunks transfers the payload over the stream in chunks of the given size, resuming at the chunk
+// requested by the receiver. It returns once the receiver acknowledged the complete payload.
+func sendChunks(rw io.ReadWriter, payload []byte, chunkSize uint32, window uint32, timeout time.Duration) error {
+
+	header := transferHeader{
+		ID:        transferID(payload),
+		Size:      uint64(len(payload)),
+		ChunkSize: chunkSize,
+	}
+	total := header.chunks()
+
+	r := bufio.NewReader(rw)
+	w := bufio.NewWriter(rw)
+
+	err := extendDeadline(rw, timeout)
+	if err != nil {
+		return fmt.Errorf("could not set deadline: %w", err)
+	}
+
+	err = writeTransferHeader(w, header)
+	if err != nil {
+		return fmt.Errorf("could not write transfer header: %w", err)
+	}
+
+	status, next, err := readTransferResponse(r)
+	if err != nil {
+		return fmt.Errorf("could not read transfer response: %w", err)
+	}
+	if status != transferAccepted {
+		return errTransferRejected
+	}
+	if next > total {
+		return fmt.Errorf("receiver requested chunk %d of %d chunks", next, total)
+	}
+
+	acked := next
+	sent := next
+	for acked < total {
+
+		// send chunks up to the window ahead of the acknowledgements
+		for sent < total && sent-acked < window {
+			offset := uint64(sent) * uint64(chunkSize)
+			chunk := payload[offset : offset+uint64(header.chunkLength(sent))]
+			err = writeChunk(w, sent, chunk)
+			if err != nil {
+				return fmt.Errorf("could not write chunk %d: %w", sent, err)
+			}
+			sent++
+		}
+
+		err = w.Flush()
+		if err != nil {
+			return fmt.Errorf("could not flush chunks: %w", err)
+		}
+
+		// wait for the acknowledgement of the next chunk
+		err = extendDeadline(rw, timeout)
+		if err != nil {
+			return fmt.Errorf("could not set deadline: %w", err)
+		}
+
+		ack, err := readUint32(r)
+		if err != nil {
+			return fmt.Errorf("could not read acknowledgement of chunk %d: %w", acked, err)
+		}
+		if ack <= acked || ack > sent {
+			return fmt.Errorf("invalid acknowledgement of %d chunks (acknowledged: %d, sent: %d)", ack, acked, sent)
+		}
+		acked = ack
+	}
+
+	return nil
+}
+
+// transferKey identifies a chunked transfer of a peer
+type transferKey struct {
+	peer peer.ID
+	id   flow.Identifier
+}
+
+// partialTransfer is the state of a message received in chunks
+type partialTransfer struct {
+	header  transferHeader
+	data    []byte
+	next    uint32    // index of the next expected chunk
+	active  bool      // whether a stream currently receives the transfer
+	updated time.Time // last time a stream released the transfer
+}
+
+// chunkedReceiver reassembles the messages received in chunks and keeps partially received messages,
+// so that a sender can resume a failed transfer on a new stream.
+type chunkedReceiver struct {
+	sync.Mutex
+	maxMsgSize       uint64
+	maxPending       uint64
+	maxPeerPending   uint64
+	timeout          time.Duration
+	pending          map[transferKey]*partialTransfer
+	pendingBytes     uint64
+	peerPendingBytes map[peer.ID]uint64
+	now              func() time.Time
+}
+
+func newChunkedReceiver(maxMsgSize uint64) *chunkedReceiver {
+	return &chunkedReceiver{
+		maxMsgSize:       maxMsgSize,
+		maxPending:       maxPendingTransferBytes,
+		maxPeerPending:   maxPeerPendingTransferBytes,
+		timeout:          chunkedTransferTimeout,
+		pending:          make(map[transferKey]*partialTransfer),
+		peerPendingBytes: make(map[peer.ID]uint64),
+		now:              time.Now,
+	}
+}
+
+// receive receives a message in chunks from the given peer over the stream. Besides the error, it returns the
+// misbehavior of the peer if the transfer failed due to unacceptable content.
+func (cr *chunkedReceiver) receive(rw io.ReadWriter, from peer.ID) ([]byte, Misbehavior, error) {
+
+	r := bufio.NewReader(rw)
+	w := bufio.NewWriter(rw)
+
+	err := extendDeadline(rw, cr.timeout)
+	if err != nil {
+		return nil, "", fmt.Errorf("could not set deadline: %w", err)
+	}
+
+	header, misbehavior, err := readTransferHeader(r)
+	if err != nil {
+		return nil, misbehavior, fmt.Errorf("could not read transfer header: %w", err)
+	}
+	if header.Size > cr.maxMsgSize {
+		_ = writeTransferResponse(w, transferRejected, 0)
+		return nil, MisbehaviorOversizedPayload, fmt.Errorf("transfer size %d exceeds max message size %d", header.Size, cr.maxMsgSize)
+	}
+	if header.Size == 0 || header.ChunkSize < minChunkSize || header.ChunkSize > maxChunkSize {
+		_ = writeTransferResponse(w, transferRejected, 0)
+		return nil, MisbehaviorInvalidChunk, fmt.Errorf("invalid transfer header (size: %d, chunk size: %d)", header.Size, header.ChunkSize)
+	}
+
+	key := transferKey{peer: from, id: header.ID}
+	pt, err := cr.acquire(key, header)
+	if err != nil {
+		_ = writeTransferResponse(w, transferRejected, 0)
+		return nil, "", err
+	}
+
+	complete := false
+	defer func() {
+		cr.release(key, pt, complete)
+	}()
+
+	err = writeTransferResponse(w, transferAccepted, pt.next)
+	if err != nil {
+		return nil, "", fmt.Errorf("could not write transfer response: %w", err)
+	}
+
+	total := header.chunks()
+	for pt.next < total {
+
+		err = extendDeadline(rw, cr.timeout)
+		if err != nil {
+			return nil, "", fmt.Errorf("could not set deadline: %w", err)
+		}
+
+		chunk, misbehavior, err := readChunk(r, pt.next, header.chunkLength(pt.next))
+		if err != nil {
+			return nil, misbehavior, fmt.Errorf("could not read chunk %d: %w", pt.next, err)
+		}
+		pt.data = append(pt.data, chunk...)
+		pt.next++
+
+		// the last chunk is only acknowledged once the complete message was verified
+		if pt.next == total {
+			break
+		}
+
+		err = writeUint32(w, pt.next)
+		if err != nil {
+			return nil, "", fmt.Errorf("could not acknowledge chunk %d: %w", pt.next-1, err)
+		}
+	}
+
+	// the message is discarded if it does not match the transfer ID, since no chunk can be
+	// identified as faulty
+	complete = true
+	if transferID(pt.data) != header.ID {
+		return nil, MisbehaviorInvalidChunk, fmt.Errorf("received message does not match transfer ID %x", header.ID)
+	}
+
+	err = writeUint32(w, total)
+	if err != nil {
+		return nil, "", fmt.Errorf("could not acknowledge transfer: %w", err)
+	}
+
+	return pt.data, "", nil
+}
+
+// acquire returns the partial transfer with the given key for a stream to receive it, which resumes a
+// previous transfer of the same message if there is one.
+func (cr *chunkedReceiver) acquire(key transferKey, header transferHeader) (*partialTransfer, error) {
+	cr.Lock()
+	defer cr.Unlock()
+
+	now := cr.now()
+
+	// drop the partial transfers that were not resumed in time
+	for k, pt := range cr.pending {
+		if !pt.active && now.Sub(pt.updated) > partialTransferTTL {
+			cr.drop(k, pt)
+		}
+	}
+
+	pt, ok := cr.pending[key]
+	if ok && pt.active {
+		return nil, fmt.Errorf("transfer %x is already being received", key.id)
+	}
+	if ok && pt.header != header {
+		// the sender changed the chunking of the message, hence the transfer restarts
+		cr.drop(key, pt)
+		ok = false
+	}
+
+	if !ok {
+		if cr.pendingBytes+header.Size > cr.maxPending {
+			return nil, fmt.Errorf("cannot reserve %d bytes for transfer %x (pending: %d)", header.Size, key.id, cr.pendingBytes)
+		}
+		if cr.peerPendingBytes[key.peer]+header.Size > cr.maxPeerPending {
+			return nil, fmt.Errorf("cannot reserve %d bytes for transfer %x of peer (pending: %d)", header.Size, key.id, cr.peerPendingBytes[key.peer])
+		}
+		pt = &partialTransfer{header: header}
+		cr.pending[key] = pt
+		cr.pendingBytes += header.Size
+		cr.peerPendingBytes[key.peer] += header.Size
+	}
+
+	pt.active = true
+	return pt, nil
+}
+
+// release returns the partial transfer after a stream stopped receiving it. Complete transfers are dropped,
+// while incomplete ones are kept for a sender to resume them.
+func (cr *chunkedReceiver) release(key transferKey, pt *partialTransfer, complete bool) {
+	cr.Lock()
+	defer cr.Unlock()
+
+	if complete {
+		cr.drop(key, pt)
+		return
+	}
+
+	pt.active = false
+	pt.updated = cr.now()
+}
+
+// drop removes the partial transfer, it must be called with the lock held
+func (cr *chunkedReceiver) drop(key transferKey, pt *partialTransfer) {
+	if cr.pending[key] != pt {
+		return
+	}
+	delete(cr.pending, key)
+	cr.pendingBytes -= pt.header.Size
+	cr.peerPendingBytes[key.peer] -= pt.header.Size
+	if cr.peerPendingBytes[key.peer] == 0 {
+		delete(cr.peerPendingBytes, key.peer)
+	}
+}
+
+func writeTransferHeader(w *bufio.Writer, header transferHeader) error {
+	buf := make([]byte, transferHeaderSize)
+	buf[0] = chunkedTransferVersion
+	copy(buf[1:], header.ID[:])
+	binary.BigEndian.PutUint64(buf[1+transferIDLen:], header.Size)
+	binary.BigEndian.PutUint32(buf[1+transferIDLen+8:], header.ChunkSize)
+	_, err := w.Write(buf)
+	if err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// readTransferHeader reads the transfer header. Besides the error, it returns the misbehavior of the sender if
+// the header is of an unknown version.
+func readTransferHeader(r *bufio.Reader) (transferHeader, Misbehavior, error) {
+	var header transferHeader
+	buf := make([]byte, transferHeaderSize)
+	_, err := io.ReadFull(r, buf)
+	if err != nil {
+		return header, "", err
+	}
+	if buf[0] != chunkedTransferVersion {
+		return header, MisbehaviorInvalidChunk, fmt.Errorf("unsupported chunked transfer version %d", buf[0])
+	}
+	copy(header.ID[:], buf[1:])
+	header.Size = binary.BigEndian.Uint64(buf[1+transferIDLen:])
+	header.ChunkSize = binary.BigEndian.Uint32(buf[1+transferIDLen+8:])
+	return header, "", nil
+}
+
+func writeTransferResponse(w *bufio.Writer, status byte, next uint32) error {
+	buf := make([]byte, transferResponseSize)
+	buf[0] = status
+	binary.BigEndian.PutUint32(buf[1:], next)
+	_, err := w.Write(buf)
+	if err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+func readTransferResponse(r *bufio.Reader) (byte, uint32, error) {
+	buf := make([]byte, transferResponseSize)
+	_, err := io.ReadFull(r, buf)
+	if err != nil {
+		return 0, 0, err
+	}
+	return buf[0], binary.BigEndian.Uint32(buf[1:]), nil
+}
+
+// writeChunk writes the chunk with its index, length and checksum, without flushing the writer
+func writeChunk(w *bufio.Writer, index uint32, chunk []byte) error {
+	buf := make([]byte, chunkHeaderSize)
+	binary.BigEndian.PutUint32(buf, index)
+	binary.BigEndian.PutUint32(buf[4:], uint32(len(chunk)))
+	binary.BigEndian.PutUint32(buf[8:], crc32.Checksum(chunk, castagnoli))
+	_, err := w.Write(buf)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(chunk)
+	return err
+}
+
+// readChunk reads the chunk with the given index and length and verifies its checksum. Besides the error,
+// it returns the misbehavior of the sender if the chunk is not the expected one or is corrupted.
+func readChunk(r *bufio.Reader, index uint32, length uint32) ([]byte, Misbehavior, error) {
+	buf := make([]byte, chunkHeaderSize)
+	_, err := io.ReadFull(r, buf)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if binary.BigEndian.Uint32(buf) != index {
+		return nil, MisbehaviorInvalidChunk, fmt.Errorf("unexpected chunk index %d", binary.BigEndian.Uint32(buf))
+	}
+	if binary.BigEndian.Uint32(buf[4:]) != length {
+		return nil, MisbehaviorInvalidChunk, fmt.Errorf("unexpected chunk length %d (expected: %d)", binary.BigEndian.Uint32(buf[4:]), length)
+	}
+	checksum := binary.BigEndian.Uint32(buf[8:])
+
+	chunk := make([]byte, length)
+	_, err = io.ReadFull(r, chunk)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if crc32.Checksum(chunk, castagnoli) != checksum {
+		return nil, MisbehaviorInvalidChunk, fmt.Errorf("checksum mismatch")
+	}
+
+	return chunk, "", nil
+}
+
+func writeUint32(w *bufio.Writer, v uint32) error {
+	buf := make([]byte, chunkAckSize)
+	binary.BigEndian.PutUint32(buf, v)
+	_, err := w.Write(buf)
+	if err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+func readUint32(r *bufio.Reader) (uint32, error) {
+	buf := make([]byte, chunkAckSize)
+	_, err := io.ReadFull(r, buf)
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint32(buf), nil
+}
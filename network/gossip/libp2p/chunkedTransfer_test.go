@@ -0,0 +1,292 @@
+package libp2p
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testChunkSize = minChunkSize
+
+// TestChunkedTransfer_RoundTrip evaluates that a payload is reassembled by the receiver, including a last
+// chunk shorter than the chunk size.
+func TestChunkedTransfer_RoundTrip(t *testing.T) {
+	payload := randomPayload(t, 100*testChunkSize+17)
+	receiver := newChunkedReceiver(DefaultMaxChunkedMsgSize)
+
+	received, misbehavior, err := transfer(t, receiver, payload, nil)
+	require.NoError(t, err)
+	assert.Empty(t, misbehavior)
+	assert.Equal(t, payload, received)
+
+	// the complete transfer is not kept by the receiver
+	assert.Empty(t, receiver.pending)
+	assert.Zero(t, receiver.pendingBytes)
+	assert.Empty(t, receiver.peerPendingBytes)
+}
+
+// TestChunkedTransfer_Resume evaluates that a transfer interrupted midway resumes after the chunks received
+// before the interruption.
+func TestChunkedTransfer_Resume(t *testing.T) {
+	payload := randomPayload(t, 50*testChunkSize)
+	receiver := newChunkedReceiver(DefaultMaxChunkedMsgSize)
+
+	// the stream of the sender fails after ten chunks
+	limit := transferHeaderSize + 10*(chunkHeaderSize+testChunkSize)
+	_, _, err := transfer(t, receiver, payload, &limit)
+	require.Error(t, err)
+
+	require.Len(t, receiver.pending, 1)
+	for _, pt := range receiver.pending {
+		assert.Equal(t, uint32(10), pt.next)
+		assert.False(t, pt.active)
+	}
+
+	// the resumed transfer only sends the remaining chunks
+	written := 0
+	received, misbehavior, err := transferCounting(t, receiver, payload, &written)
+	require.NoError(t, err)
+	assert.Empty(t, misbehavior)
+	assert.Equal(t, payload, received)
+	assert.Equal(t, transferHeaderSize+40*(chunkHeaderSize+testChunkSize), written)
+	assert.Empty(t, receiver.pending)
+}
+
+// TestChunkedTransfer_CorruptChunk evaluates that a corrupted chunk fails the transfer with a misbehavior of
+// the sender, while keeping the chunks received before it.
+func TestChunkedTransfer_CorruptChunk(t *testing.T) {
+	payload := randomPayload(t, 4*testChunkSize)
+	receiver := newChunkedReceiver(DefaultMaxChunkedMsgSize)
+
+	client, server := loopback(t)
+	defer client.Close()
+	defer server.Close()
+
+	done := make(chan Misbehavior, 1)
+	go func() {
+		_, misbehavior, err := receiver.receive(server, peer.ID("sender"))
+		assert.Error(t, err)
+		done <- misbehavior
+	}()
+
+	header := transferHeader{ID: transferID(payload), Size: uint64(len(payload)), ChunkSize: testChunkSize}
+	r := bufio.NewReader(client)
+	w := bufio.NewWriter(client)
+	require.NoError(t, writeTransferHeader(w, header))
+	status, next, err := readTransferResponse(r)
+	require.NoError(t, err)
+	require.Equal(t, transferAccepted, status)
+	require.Zero(t, next)
+
+	require.NoError(t, writeChunk(w, 0, payload[:testChunkSize]))
+	require.NoError(t, w.Flush())
+	ack, err := readUint32(r)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), ack)
+
+	// the second chunk is modified after its checksum was computed
+	var frame bytes.Buffer
+	fw := bufio.NewWriter(&frame)
+	require.NoError(t, writeChunk(fw, 1, payload[testChunkSize:2*testChunkSize]))
+	require.NoError(t, fw.Flush())
+	corrupted := frame.Bytes()
+	corrupted[len(corrupted)-1] ^= 0xff
+	_, err = client.Write(corrupted)
+	require.NoError(t, err)
+
+	select {
+	case misbehavior := <-done:
+		assert.Equal(t, MisbehaviorInvalidChunk, misbehavior)
+	case <-time.After(time.Second):
+		require.Fail(t, "receiver did not fail the transfer")
+	}
+
+	// the corrupted chunk alone is discarded, so that the transfer can be resumed at the second chunk
+	require.Len(t, receiver.pending, 1)
+	for _, pt := range receiver.pending {
+		assert.Equal(t, uint32(1), pt.next)
+	}
+}
+
+// TestChunkedTransfer_Oversized evaluates that the receiver rejects a transfer beyond its maximum message size.
+func TestChunkedTransfer_Oversized(t *testing.T) {
+	payload := randomPayload(t, 4*testChunkSize)
+	receiver := newChunkedReceiver(uint64(len(payload) - 1))
+
+	_, misbehavior, err := transfer(t, receiver, payload, nil)
+	require.Error(t, err)
+	assert.Equal(t, MisbehaviorOversizedPayload, misbehavior)
+	assert.Empty(t, receiver.pending)
+}
+
+// TestChunkedTransfer_PendingLimit evaluates that the receiver rejects transfers once the bytes reserved for
+// partially received messages reach the limit, and releases them once they expire.
+func TestChunkedTransfer_PendingLimit(t *testing.T) {
+	now := time.Now()
+	receiver := newChunkedReceiver(DefaultMaxChunkedMsgSize)
+	receiver.maxPending = 6 * testChunkSize
+	receiver.now = func() time.Time { return now }
+
+	// an interrupted transfer reserves its full size
+	first := randomPayload(t, 4*testChunkSize)
+	limit := transferHeaderSize
+	_, _, err := transfer(t, receiver, first, &limit)
+	require.Error(t, err)
+	require.Equal(t, uint64(len(first)), receiver.pendingBytes)
+
+	second := randomPayload(t, 4*testChunkSize)
+	_, misbehavior, err := transfer(t, receiver, second, nil)
+	require.Error(t, err)
+	assert.Empty(t, misbehavior)
+
+	// the interrupted transfer expires
+	now = now.Add(partialTransferTTL + time.Second)
+	received, _, err := transfer(t, receiver, second, nil)
+	require.NoError(t, err)
+	assert.Equal(t, second, received)
+	assert.Zero(t, receiver.pendingBytes)
+}
+
+// TestChunkedTransfer_PeerPendingLimit evaluates that the receiver rejects transfers of a peer once the bytes
+// reserved for its partially received messages reach the limit, while accepting the transfers of other peers.
+func TestChunkedTransfer_PeerPendingLimit(t *testing.T) {
+	receiver := newChunkedReceiver(DefaultMaxChunkedMsgSize)
+	receiver.maxPeerPending = 6 * testChunkSize
+
+	// an interrupted transfer reserves its full size for the peer
+	first := randomPayload(t, 4*testChunkSize)
+	limit := transferHeaderSize
+	_, _, err := transferFrom(t, receiver, peer.ID("sender"), first, &limit)
+	require.Error(t, err)
+	require.Equal(t, uint64(len(first)), receiver.peerPendingBytes[peer.ID("sender")])
+
+	second := randomPayload(t, 4*testChunkSize)
+	_, misbehavior, err := transferFrom(t, receiver, peer.ID("sender"), second, nil)
+	require.Error(t, err)
+	assert.Empty(t, misbehavior)
+
+	// another peer is not affected by the reservations of the first peer
+	received, _, err := transferFrom(t, receiver, peer.ID("other"), second, nil)
+	require.NoError(t, err)
+	assert.Equal(t, second, received)
+	assert.Equal(t, uint64(len(first)), receiver.pendingBytes)
+}
+
+// transfer sends the payload to the receiver over a loopback connection, which fails after the given number
+// of bytes written by the sender if a limit is given. It returns the result of the receiver, or the error
+// of the sender if only the sender failed.
+func transfer(t *testing.T, receiver *chunkedReceiver, payload []byte, limit *int) ([]byte, Misbehavior, error) {
+	return transferFrom(t, receiver, peer.ID("sender"), payload, limit)
+}
+
+// transferFrom sends the payload to the receiver like transfer, on behalf of the given peer.
+func transferFrom(t *testing.T, receiver *chunkedReceiver, from peer.ID, payload []byte, limit *int) ([]byte, Misbehavior, error) {
+	client, server := loopback(t)
+	defer server.Close()
+
+	var stream net.Conn = client
+	if limit != nil {
+		stream = &failingConn{Conn: client, remaining: *limit}
+	}
+
+	return run(t, receiver, from, stream, server, payload)
+}
+
+// transferCounting sends the payload to the receiver over a loopback connection, and counts the bytes written
+// by the sender.
+func transferCounting(t *testing.T, receiver *chunkedReceiver, payload []byte, written *int) ([]byte, Misbehavior, error) {
+	client, server := loopback(t)
+	defer server.Close()
+
+	stream := &failingConn{Conn: client, remaining: -1, written: written}
+	return run(t, receiver, peer.ID("sender"), stream, server, payload)
+}
+
+func run(t *testing.T, receiver *chunkedReceiver, from peer.ID, client net.Conn, server net.Conn, payload []byte) ([]byte, Misbehavior, error) {
+	type result struct {
+		payload     []byte
+		misbehavior Misbehavior
+		err         error
+	}
+
+	done := make(chan result, 1)
+	go func() {
+		received, misbehavior, err := receiver.receive(server, from)
+		done <- result{received, misbehavior, err}
+	}()
+
+	sendErr := sendChunks(client, payload, testChunkSize, 4, time.Second)
+	client.Close()
+
+	var res result
+	select {
+	case res = <-done:
+	case <-time.After(3 * time.Second):
+		require.Fail(t, "receiver did not return")
+	}
+
+	if res.err == nil && sendErr != nil {
+		return nil, "", sendErr
+	}
+	return res.payload, res.misbehavior, res.err
+}
+
+// loopback returns both ends of a TCP connection on the loopback interface
+func loopback(t *testing.T) (net.Conn, net.Conn) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		conn, err := listener.Accept()
+		require.NoError(t, err)
+		accepted <- conn
+	}()
+
+	client, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+
+	return client, <-accepted
+}
+
+// failingConn closes the connection once the given number of bytes was written, unless it is negative,
+// and counts the bytes written
+type failingConn struct {
+	net.Conn
+	remaining int
+	written   *int
+}
+
+func (c *failingConn) Write(b []byte) (int, error) {
+	if c.remaining >= 0 && len(b) > c.remaining {
+		n, _ := c.Conn.Write(b[:c.remaining])
+		c.remaining = 0
+		_ = c.Conn.Close()
+		return n, errors.New("connection interrupted")
+	}
+
+	n, err := c.Conn.Write(b)
+	if c.remaining >= 0 {
+		c.remaining -= n
+	}
+	if c.written != nil {
+		*c.written += n
+	}
+	return n, err
+}
+
+func randomPayload(t *testing.T, size int) []byte {
+	payload := make([]byte, size)
+	_, err := rand.Read(payload)
+	require.NoError(t, err)
+	return payload
+}
@@ -1,26 +1,35 @@
 package libp2p
 
 import (
+	"sync"
+	"time"
+
 	"github.com/libp2p/go-libp2p-core/connmgr"
 	"github.com/libp2p/go-libp2p-core/control"
 	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/multiformats/go-multiaddr"
 	"github.com/rs/zerolog"
 )
 
 var _ connmgr.ConnectionGater = (*connGater)(nil)
+var _ pubsub.Blacklist = (*pubsubBlocklist)(nil)
 
 // connGater is the implementatiion of the libp2p connmgr.ConnectionGater interface
-// It provides node allowlisting by libp2p peer.ID which is derived from the node public networking key
+// It provides node allowlisting by libp2p peer.ID which is derived from the node public networking key,
+// as well as the temporary blocklisting of misbehaving peers
 type connGater struct {
-	peerIDAllowlist map[peer.ID]struct{} // the in-memory map of approved peer IDs
+	sync.RWMutex
+	peerIDAllowlist map[peer.ID]struct{}  // the in-memory map of approved peer IDs
+	peerIDBlocklist map[peer.ID]time.Time // the in-memory map of blocked peer IDs to the end of their blocking
 	log             zerolog.Logger
 }
 
 func newConnGater(peerInfos []peer.AddrInfo, log zerolog.Logger) *connGater {
 	cg := &connGater{
-		log: log,
+		peerIDBlocklist: make(map[peer.ID]time.Time),
+		log:             log,
 	}
 	cg.update(peerInfos)
 	return cg
@@ -38,11 +47,49 @@ func (c *connGater) update(peerInfos []peer.AddrInfo) {
 	}
 
 	// cache the new map
+	c.Lock()
 	c.peerIDAllowlist = peerIDs
+	c.Unlock()
 
 	c.log.Info().Msg("approved list of peers updated")
 }
 
+// blocklist blocks all connections with the given peer until the given time, regardless of the allowlist
+func (c *connGater) blocklist(p peer.ID, until time.Time) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.peerIDBlocklist[p] = until
+
+	c.log.Warn().
+		Str("node_id", p.Pretty()).
+		Time("until", until).
+		Msg("peer blocklisted")
+}
+
+// blocklisted returns true if the given peer is currently blocklisted
+func (c *connGater) blocklisted(p peer.ID) bool {
+	c.RLock()
+	until, ok := c.peerIDBlocklist[p]
+	c.RUnlock()
+
+	if !ok {
+		return false
+	}
+	if time.Now().Before(until) {
+		return true
+	}
+
+	// the blocking expired, so the peer is removed from the blocklist
+	c.Lock()
+	if until, ok := c.peerIDBlocklist[p]; ok && !time.Now().Before(until) {
+		delete(c.peerIDBlocklist, p)
+	}
+	c.Unlock()
+
+	return false
+}
+
 // InterceptPeerDial - a callback which allows or disallows outbound connection
 func (c *connGater) InterceptPeerDial(p peer.ID) bool {
 	return c.validPeerID(p)
@@ -85,6 +132,28 @@ func (c *connGater) InterceptUpgraded(network.Conn) (allow bool, reason control.
 }
 
 func (c *connGater) validPeerID(p peer.ID) bool {
+	if c.blocklisted(p) {
+		return false
+	}
+
+	c.RLock()
+	defer c.RUnlock()
 	_, ok := c.peerIDAllowlist[p]
 	return ok
 }
+
+// pubsubBlocklist exposes the blocklist of the connection gater to libp2p pubsub, so that messages relayed
+// by blocklisted peers are dropped as well.
+type pubsubBlocklist struct {
+	cg *connGater
+}
+
+// Add is not used. Peers are only blocklisted by the middleware through the connection gater
+func (b *pubsubBlocklist) Add(peer.ID) bool {
+	return false
+}
+
+// Contains returns true if the peer is currently blocklisted by the connection gater
+func (b *pubsubBlocklist) Contains(p peer.ID) bool {
+	return b.cg.blocklisted(p)
+}
@@ -0,0 +1,156 @@
+package libp2p
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+)
+
+// ConnectivityGraph is the graph of the connections of all nodes of the network, as chosen by the topology
+// of each node in an epoch. Connections are bidirectional once established, hence the graph is undirected.
+type ConnectivityGraph struct {
+	Epoch     uint64
+	Roles     map[flow.Identifier]flow.Role
+	Neighbors map[flow.Identifier]map[flow.Identifier]struct{}
+}
+
+// NewConnectivityGraph computes the epoch topology of every node of the given identities, with a random
+// subset of the given size, and returns the resulting connectivity graph.
+func NewConnectivityGraph(ids flow.IdentityList, size int, epoch uint64) (*ConnectivityGraph, error) {
+	g := &ConnectivityGraph{
+		Epoch:     epoch,
+		Roles:     make(map[flow.Identifier]flow.Role, len(ids)),
+		Neighbors: make(map[flow.Identifier]map[flow.Identifier]struct{}, len(ids)),
+	}
+
+	for _, id := range ids {
+		g.Roles[id.NodeID] = id.Role
+		g.Neighbors[id.NodeID] = make(map[flow.Identifier]struct{})
+	}
+
+	for _, id := range ids {
+		others := ids.Filter(filter.Not(filter.HasNodeID(id.NodeID)))
+		subset, err := epochSubset(others, size, id.NodeID, id.Role, epoch)
+		if err != nil {
+			return nil, fmt.Errorf("could not compute topology of node %x: %w", id.NodeID, err)
+		}
+
+		for _, neighbor := range subset {
+			g.Neighbors[id.NodeID][neighbor.NodeID] = struct{}{}
+			g.Neighbors[neighbor.NodeID][id.NodeID] = struct{}{}
+		}
+	}
+
+	return g, nil
+}
+
+// EdgeCount returns the number of connections in the graph.
+func (g *ConnectivityGraph) EdgeCount() int {
+	count := 0
+	for _, neighbors := range g.Neighbors {
+		count += len(neighbors)
+	}
+	return count / 2
+}
+
+// Connected returns true if every node of the graph can reach every other node.
+func (g *ConnectivityGraph) Connected() bool {
+	if len(g.Roles) == 0 {
+		return true
+	}
+
+	var start flow.Identifier
+	for nodeID := range g.Roles {
+		start = nodeID
+		break
+	}
+
+	visited := map[flow.Identifier]struct{}{start: {}}
+	queue := []flow.Identifier{start}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for neighbor := range g.Neighbors[current] {
+			if _, ok := visited[neighbor]; ok {
+				continue
+			}
+			visited[neighbor] = struct{}{}
+			queue = append(queue, neighbor)
+		}
+	}
+
+	return len(visited) == len(g.Roles)
+}
+
+// MissingRoles returns, for each node lacking a neighbor of a role it exchanges messages with, the missing roles.
+// Roles without any other node in the graph are not considered missing.
+func (g *ConnectivityGraph) MissingRoles() map[flow.Identifier][]flow.Role {
+
+	// count the nodes of each role
+	counts := make(map[flow.Role]int)
+	for _, role := range g.Roles {
+		counts[role]++
+	}
+
+	missing := make(map[flow.Identifier][]flow.Role)
+	for nodeID, role := range g.Roles {
+		for _, connected := range RoleConnections(role) {
+
+			available := counts[connected]
+			if connected == role {
+				available-- // the node itself
+			}
+			if available == 0 {
+				continue
+			}
+
+			found := false
+			for neighbor := range g.Neighbors[nodeID] {
+				if g.Roles[neighbor] == connected {
+					found = true
+					break
+				}
+			}
+			if !found {
+				missing[nodeID] = append(missing[nodeID], connected)
+			}
+		}
+	}
+
+	return missing
+}
+
+// DOT returns the graph in the DOT language of graphviz, labelling nodes with their role.
+func (g *ConnectivityGraph) DOT() string {
+
+	nodeIDs := make([]flow.Identifier, 0, len(g.Roles))
+	for nodeID := range g.Roles {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	sort.Slice(nodeIDs, func(i, j int) bool {
+		return strings.Compare(nodeIDs[i].String(), nodeIDs[j].String()) < 0
+	})
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "graph epoch_%d {\n", g.Epoch)
+	for _, nodeID := range nodeIDs {
+		fmt.Fprintf(&b, "  \"%s\" [label=\"%s\\n%s\"];\n", nodeID, g.Roles[nodeID], nodeID.String()[:8])
+	}
+	for _, nodeID := range nodeIDs {
+		for _, neighbor := range nodeIDs {
+			// each edge is written once
+			if strings.Compare(nodeID.String(), neighbor.String()) >= 0 {
+				continue
+			}
+			if _, ok := g.Neighbors[nodeID][neighbor]; ok {
+				fmt.Fprintf(&b, "  \"%s\" -- \"%s\";\n", nodeID, neighbor)
+			}
+		}
+	}
+	b.WriteString("}\n")
+
+	return b.String()
+}
@@ -0,0 +1,301 @@
+package libp2p
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/crypto/hash"
+	"github.com/onflow/flow-go/crypto/random"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/network/gossip/libp2p/middleware"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/state/protocol/events"
+)
+
+var _ middleware.Topology = &EpochTopology{}
+var _ protocol.Consumer = &EpochTopology{}
+
+// roleConnections maps each role to the roles its nodes exchange messages with. The relation is symmetric.
+var roleConnections = map[flow.Role][]flow.Role{
+	flow.RoleCollection:   {flow.RoleCollection, flow.RoleConsensus, flow.RoleExecution, flow.RoleAccess},
+	flow.RoleConsensus:    {flow.RoleCollection, flow.RoleConsensus, flow.RoleExecution, flow.RoleVerification, flow.RoleAccess},
+	flow.RoleExecution:    {flow.RoleCollection, flow.RoleConsensus, flow.RoleExecution, flow.RoleVerification},
+	flow.RoleVerification: {flow.RoleConsensus, flow.RoleExecution},
+	flow.RoleAccess:       {flow.RoleCollection, flow.RoleConsensus},
+}
+
+// RoleConnections returns the roles the nodes of the given role exchange messages with.
+func RoleConnections(role flow.Role) []flow.Role {
+	return roleConnections[role]
+}
+
+// EpochTopology generates a deterministic random topology, which is recomputed on every epoch transition.
+// Similar to the RandPermTopology, the topology of a node is a union of three sets:
+// 1. a random subset of the given size
+// 2. one node of each role the node exchanges messages with, if not already part of the first set
+// 3. half of the remaining nodes of the same role as the node, if the node exchanges messages with its own role
+// The random generator is seeded with the node ID and the epoch counter, so that the topology of every node
+// changes with each epoch, and can be computed by any other node for the purpose of debugging.
+type EpochTopology struct {
+	events.Noop
+	sync.Mutex
+	log         zerolog.Logger
+	me          flow.Identifier
+	role        flow.Role
+	epoch       uint64
+	state       protocol.State
+	ids         flow.IdentityList                 // identities of the latest subset, including this node
+	fingerprint flow.Identifier                   // fingerprint of the identities the cached subset was computed for
+	size        int                               // size of the random subset of the cached subset
+	cached      map[flow.Identifier]flow.Identity // subset of the current epoch
+	notifiers   []func(flow.IdentityList)
+}
+
+// NewEpochTopology creates a topology for the given node starting at the epoch of the latest finalized block.
+func NewEpochTopology(log zerolog.Logger, me flow.Identifier, state protocol.State) (*EpochTopology, error) {
+
+	final := state.Final()
+
+	identity, err := final.Identity(me)
+	if err != nil {
+		return nil, fmt.Errorf("could not get identity of node %x: %w", me, err)
+	}
+
+	epoch, err := final.Epochs().Current().Counter()
+	if err != nil {
+		return nil, fmt.Errorf("could not get current epoch: %w", err)
+	}
+
+	t := &EpochTopology{
+		log:   log.With().Str("component", "epoch_topology").Logger(),
+		me:    me,
+		role:  identity.Role,
+		epoch: epoch,
+		state: state,
+	}
+
+	return t, nil
+}
+
+// OnEpochTransition registers a function that is called with the identities of the new epoch on every
+// epoch transition, before the topology is recomputed. It is used to update the identity list the network
+// provides to the topology.
+func (t *EpochTopology) OnEpochTransition(notifier func(ids flow.IdentityList)) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.notifiers = append(t.notifiers, notifier)
+}
+
+// EpochTransition invalidates the topology of the previous epoch, so that it is recomputed on the next
+// call of Subset, and passes the identities of the new epoch to the registered notifiers.
+func (t *EpochTopology) EpochTransition(newEpoch uint64, first *flow.Header) {
+
+	ids, err := t.state.AtBlockID(first.ID()).Identities(filter.Any)
+	if err != nil {
+		t.log.Error().Err(err).Uint64("epoch", newEpoch).Msg("could not get identities of new epoch")
+		return
+	}
+
+	t.Lock()
+	t.epoch = newEpoch
+	t.cached = nil
+	identity, ok := ids.ByNodeID(t.me)
+	if ok {
+		t.role = identity.Role
+	}
+	notifiers := t.notifiers
+	t.Unlock()
+
+	t.log.Info().
+		Uint64("epoch", newEpoch).
+		Int("identities", len(ids)).
+		Msg("epoch transition, recomputing topology")
+
+	for _, notify := range notifiers {
+		notify(ids)
+	}
+}
+
+// Subset returns the topology of this node for the current epoch. The topology is only recomputed after an
+// epoch transition, or if the identities or the size differ from the ones of the last computation.
+func (t *EpochTopology) Subset(idList flow.IdentityList, size int, _ string) (map[flow.Identifier]flow.Identity, error) {
+	t.Lock()
+	defer t.Unlock()
+
+	fingerprint := idList.Fingerprint()
+	if t.cached == nil || t.fingerprint != fingerprint || t.size != size {
+		subset, err := epochSubset(idList, size, t.me, t.role, t.epoch)
+		if err != nil {
+			return nil, fmt.Errorf("cannot sample topology: %w", err)
+		}
+
+		t.cached = make(map[flow.Identifier]flow.Identity, len(subset))
+		for _, id := range subset {
+			t.cached[id.NodeID] = *id
+		}
+		t.fingerprint = fingerprint
+		t.size = size
+		t.ids = t.withMe(idList)
+
+		t.log.Info().
+			Uint64("epoch", t.epoch).
+			Int("peers", len(t.cached)).
+			Msg("topology computed")
+
+		t.logGraph()
+	}
+
+	topMap := make(map[flow.Identifier]flow.Identity, len(t.cached))
+	for nodeID, id := range t.cached {
+		topMap[nodeID] = id
+	}
+
+	return topMap, nil
+}
+
+// ConnectivityGraph returns the connectivity graph of the network of the latest computed topology.
+func (t *EpochTopology) ConnectivityGraph() (*ConnectivityGraph, error) {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.cached == nil {
+		return nil, fmt.Errorf("topology of epoch %d not computed yet", t.epoch)
+	}
+
+	return NewConnectivityGraph(t.ids, t.size, t.epoch)
+}
+
+// withMe adds the identity of this node to the given identities, which exclude this node.
+// It must be called with the lock held.
+func (t *EpochTopology) withMe(idList flow.IdentityList) flow.IdentityList {
+	ids := make(flow.IdentityList, 0, len(idList)+1)
+	ids = append(ids, idList...)
+	if _, ok := idList.ByNodeID(t.me); !ok {
+		ids = append(ids, &flow.Identity{NodeID: t.me, Role: t.role})
+	}
+	return ids
+}
+
+// logGraph reports the connectivity graph of the network for debugging purposes.
+// It must be called with the lock held.
+func (t *EpochTopology) logGraph() {
+	graph, err := NewConnectivityGraph(t.ids, t.size, t.epoch)
+	if err != nil {
+		t.log.Error().Err(err).Msg("could not compute connectivity graph")
+		return
+	}
+
+	missing := graph.MissingRoles()
+
+	log := t.log.With().
+		Uint64("epoch", t.epoch).
+		Int("nodes", len(graph.Roles)).
+		Int("edges", graph.EdgeCount()).
+		Bool("connected", graph.Connected()).
+		Int("nodes_missing_roles", len(missing)).
+		Logger()
+
+	if !graph.Connected() || len(missing) > 0 {
+		log.Warn().Msg("network connectivity graph is incomplete")
+	} else {
+		log.Info().Msg("network connectivity graph computed")
+	}
+
+	log.Debug().Str("graph", graph.DOT()).Msg("network connectivity graph")
+}
+
+// epochSubset computes the topology of the given node for the given epoch, which is a deterministic function
+// of its inputs.
+func epochSubset(idList flow.IdentityList, size int, nodeID flow.Identifier, role flow.Role, epoch uint64) (flow.IdentityList, error) {
+
+	if len(idList) < size {
+		return nil, fmt.Errorf("cannot sample topology idList %d smaller than desired fanout %d", len(idList), size)
+	}
+
+	rng, err := epochRand(nodeID, epoch)
+	if err != nil {
+		return nil, fmt.Errorf("cannot seed the prng: %w", err)
+	}
+
+	// find a random subset of the given size from the list
+	fanoutIDs, err := randomSubset(idList, size, rng)
+	if err != nil {
+		return nil, err
+	}
+
+	remainder := idList.Filter(filter.Not(filter.In(fanoutIDs)))
+
+	// find one id for each role this node talks to from the remaining list,
+	// if it is not already part of fanoutIDs
+	oneOfEachRoleIDs := make(flow.IdentityList, 0)
+	for _, connected := range RoleConnections(role) {
+
+		if len(fanoutIDs.Filter(filter.HasRole(connected))) > 0 {
+			// we already have a node with this role
+			continue
+		}
+
+		ids := remainder.Filter(filter.HasRole(connected))
+		if len(ids) == 0 {
+			// there are no more nodes of this role to choose from
+			continue
+		}
+
+		// choose 1 out of all the remaining nodes of this role
+		selectedID := rng.UintN(uint64(len(ids)))
+
+		oneOfEachRoleIDs = append(oneOfEachRoleIDs, ids[selectedID])
+	}
+
+	finalIDs := append(fanoutIDs, oneOfEachRoleIDs...)
+
+	// find a n/2 random subset of nodes of the same role from the remaining list,
+	// if this node talks to its own role
+	if talksTo(role, role) {
+		remainder = remainder.Filter(filter.Not(filter.In(oneOfEachRoleIDs)))
+		ids := remainder.Filter(filter.HasRole(role))
+		sameRoleIDs := (len(ids) + 1) / 2 // rounded up to the closest integer
+
+		selfRoleIDs, err := randomSubset(ids, sameRoleIDs, rng)
+		if err != nil {
+			return nil, err
+		}
+
+		finalIDs = append(finalIDs, selfRoleIDs...)
+	}
+
+	return finalIDs, nil
+}
+
+// epochRand returns a random generator seeded with the node ID and the epoch counter
+func epochRand(nodeID flow.Identifier, epoch uint64) (random.Rand, error) {
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, epoch)
+
+	hasher := hash.NewSHA3_256()
+	_, err := hasher.Write(nodeID[:])
+	if err != nil {
+		return nil, err
+	}
+	_, err = hasher.Write(counter)
+	if err != nil {
+		return nil, err
+	}
+
+	return random.NewRand(hasher.SumHash())
+}
+
+// talksTo returns true if nodes of the given role exchange messages with nodes of the other role
+func talksTo(role flow.Role, other flow.Role) bool {
+	for _, connected := range RoleConnections(role) {
+		if connected == other {
+			return true
+		}
+	}
+	return false
+}
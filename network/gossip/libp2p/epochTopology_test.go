@@ -0,0 +1,187 @@
+package libp2p
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+type EpochTopologyTestSuite struct {
+	suite.Suite
+	ids      flow.IdentityList
+	me       *flow.Identity
+	state    *protocol.State
+	final    *protocol.Snapshot
+	topology *EpochTopology
+}
+
+func TestEpochTopologyTestSuite(t *testing.T) {
+	suite.Run(t, new(EpochTopologyTestSuite))
+}
+
+func (suite *EpochTopologyTestSuite) SetupTest() {
+	suite.ids = identities(map[flow.Role]int{
+		flow.RoleCollection:   20,
+		flow.RoleConsensus:    10,
+		flow.RoleExecution:    2,
+		flow.RoleVerification: 20,
+		flow.RoleAccess:       1,
+	})
+	suite.me = suite.ids.Filter(filter.HasRole(flow.RoleVerification))[0]
+
+	epoch := new(protocol.Epoch)
+	epoch.On("Counter").Return(uint64(1), nil)
+	epochs := new(protocol.EpochQuery)
+	epochs.On("Current").Return(epoch)
+
+	suite.final = new(protocol.Snapshot)
+	suite.final.On("Identity", suite.me.NodeID).Return(suite.me, nil)
+	suite.final.On("Epochs").Return(epochs)
+
+	suite.state = new(protocol.State)
+	suite.state.On("Final").Return(suite.final)
+
+	var err error
+	suite.topology, err = NewEpochTopology(zerolog.Nop(), suite.me.NodeID, suite.state)
+	require.NoError(suite.T(), err)
+}
+
+// TestRoleConnectivity evaluates that the topology of every node contains a node of each role it
+// exchanges messages with, and that the resulting connectivity graph is connected.
+func (suite *EpochTopologyTestSuite) TestRoleConnectivity() {
+	others := suite.ids.Filter(filter.Not(filter.HasNodeID(suite.me.NodeID)))
+	size := (len(others) + 1) / 2
+
+	subset, err := suite.topology.Subset(others, size, suite.me.NodeID.String())
+	require.NoError(suite.T(), err)
+
+	assert.NotContains(suite.T(), subset, suite.me.NodeID)
+	for _, role := range RoleConnections(flow.RoleVerification) {
+		found := false
+		for _, id := range subset {
+			if id.Role == role {
+				found = true
+				break
+			}
+		}
+		assert.True(suite.T(), found, "topology misses role %s", role)
+	}
+
+	graph, err := suite.topology.ConnectivityGraph()
+	require.NoError(suite.T(), err)
+	assert.Len(suite.T(), graph.Roles, len(suite.ids))
+	assert.True(suite.T(), graph.Connected())
+	assert.Empty(suite.T(), graph.MissingRoles())
+	assert.Contains(suite.T(), graph.DOT(), suite.me.NodeID.String())
+}
+
+// TestStableWithinEpoch evaluates that the topology does not change within an epoch.
+func (suite *EpochTopologyTestSuite) TestStableWithinEpoch() {
+	others := suite.ids.Filter(filter.Not(filter.HasNodeID(suite.me.NodeID)))
+	size := (len(others) + 1) / 2
+
+	first, err := suite.topology.Subset(others, size, "")
+	require.NoError(suite.T(), err)
+
+	second, err := suite.topology.Subset(others, size, "")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), first, second)
+
+	// a fresh topology for the same node and epoch computes the same subset
+	topology, err := NewEpochTopology(zerolog.Nop(), suite.me.NodeID, suite.state)
+	require.NoError(suite.T(), err)
+	third, err := topology.Subset(others, size, "")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), first, third)
+}
+
+// TestEpochTransition evaluates that the topology is recomputed on an epoch transition and that
+// the registered notifiers receive the identities of the new epoch.
+func (suite *EpochTopologyTestSuite) TestEpochTransition() {
+	others := suite.ids.Filter(filter.Not(filter.HasNodeID(suite.me.NodeID)))
+	size := (len(others) + 1) / 2
+
+	before, err := suite.topology.Subset(others, size, "")
+	require.NoError(suite.T(), err)
+
+	// the new epoch replaces some collection nodes
+	joined := identities(map[flow.Role]int{flow.RoleCollection: 5})
+	next := append(suite.ids.Filter(filter.Not(filter.In(suite.ids.Filter(filter.HasRole(flow.RoleCollection))[:5]))), joined...)
+
+	first := unittest.BlockHeaderFixture()
+	snapshot := new(protocol.Snapshot)
+	snapshot.On("Identities", mock.Anything).Return(next, nil)
+	suite.state.On("AtBlockID", first.ID()).Return(snapshot)
+
+	var notified flow.IdentityList
+	suite.topology.OnEpochTransition(func(ids flow.IdentityList) {
+		notified = ids
+	})
+
+	suite.topology.EpochTransition(2, &first)
+	assert.Equal(suite.T(), next, notified)
+
+	nextOthers := next.Filter(filter.Not(filter.HasNodeID(suite.me.NodeID)))
+	after, err := suite.topology.Subset(nextOthers, size, "")
+	require.NoError(suite.T(), err)
+	assert.NotEqual(suite.T(), before, after)
+
+	// the same identities in the new epoch result in a different topology
+	afterSame, err := suite.topology.Subset(others, size, "")
+	require.NoError(suite.T(), err)
+	assert.NotEqual(suite.T(), before, afterSame)
+
+	graph, err := suite.topology.ConnectivityGraph()
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), uint64(2), graph.Epoch)
+	assert.True(suite.T(), graph.Connected())
+}
+
+// TestMissingRoles evaluates that the connectivity graph reports nodes without a neighbor of a role
+// they exchange messages with.
+func (suite *EpochTopologyTestSuite) TestMissingRoles() {
+	ids := identities(map[flow.Role]int{
+		flow.RoleConsensus:    1,
+		flow.RoleVerification: 2,
+	})
+	consensus := ids.Filter(filter.HasRole(flow.RoleConsensus))[0]
+
+	graph := &ConnectivityGraph{
+		Roles:     make(map[flow.Identifier]flow.Role),
+		Neighbors: make(map[flow.Identifier]map[flow.Identifier]struct{}),
+	}
+	for _, id := range ids {
+		graph.Roles[id.NodeID] = id.Role
+		graph.Neighbors[id.NodeID] = make(map[flow.Identifier]struct{})
+	}
+
+	// only the first verification node is connected to the consensus node
+	verification := ids.Filter(filter.HasRole(flow.RoleVerification))
+	graph.Neighbors[consensus.NodeID][verification[0].NodeID] = struct{}{}
+	graph.Neighbors[verification[0].NodeID][consensus.NodeID] = struct{}{}
+
+	assert.False(suite.T(), graph.Connected())
+	assert.Equal(suite.T(), 1, graph.EdgeCount())
+
+	missing := graph.MissingRoles()
+	require.Len(suite.T(), missing, 1)
+	assert.Equal(suite.T(), []flow.Role{flow.RoleConsensus}, missing[verification[1].NodeID])
+}
+
+// identities returns an identity list with the given number of identities of each role
+func identities(counts map[flow.Role]int) flow.IdentityList {
+	var ids flow.IdentityList
+	for _, role := range flow.Roles() {
+		ids = append(ids, unittest.IdentityListFixture(counts[role], unittest.WithRole(role))...)
+	}
+	return ids
+}
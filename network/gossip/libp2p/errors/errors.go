@@ -1,6 +1,7 @@
 package errors
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 )
@@ -22,6 +23,31 @@ func NewInvalidEngineError(id string, senderID string) *InvalidEngineError {
 	}
 }
 
+// InvalidPayloadError indicates that the payload of a received message could not be decoded
+type InvalidPayloadError struct {
+	err error
+}
+
+func (e InvalidPayloadError) Error() string {
+	return fmt.Sprintf("could not decode payload: %v", e.err)
+}
+
+func (e InvalidPayloadError) Unwrap() error {
+	return e.err
+}
+
+func NewInvalidPayloadError(err error) *InvalidPayloadError {
+	return &InvalidPayloadError{
+		err: err,
+	}
+}
+
+// IsInvalidPayloadError returns true if the input error wraps an InvalidPayloadError
+func IsInvalidPayloadError(err error) bool {
+	var invalidPayload *InvalidPayloadError
+	return errors.As(err, &invalidPayload)
+}
+
 // IsDialFailureError returns true if the input error contains a wrapped dial failure error
 func IsDialFailureError(err error) bool {
 	return strings.Contains(err.Error(), "failed to dial")
@@ -35,6 +35,10 @@ const (
 	// All nodes communicate with each other using this protocol id suffixed with the id of the root block
 	FlowLibP2PProtocolIDPrefix = "/flow/push/"
 
+	// A unique Libp2p protocol ID prefix for the chunked transfer of large 1-1 messages between Flow nodes,
+	// suffixed with the id of the root block like the protocol ID of regular messages
+	FlowLibP2PChunkedProtocolIDPrefix = "/flow/chunked/"
+
 	// Maximum time to wait for a ping reply from a remote node
 	PingTimeoutSecs = time.Second * 4
 )
@@ -63,6 +67,7 @@ type P2PNode struct {
 	conMgr               ConnManager                     // the connection manager passed in to libp2p
 	connGater            *connGater                      // the connection gator passed in to libp2p
 	flowLibP2PProtocolID protocol.ID                     // the unique protocol ID
+	chunkedProtocolID    protocol.ID                     // the unique protocol ID of chunked transfers
 }
 
 // Start starts a libp2p node on the given address.
@@ -81,6 +86,7 @@ func (p *P2PNode) Start(ctx context.Context,
 	p.name = n.Name
 	p.logger = logger
 	p.flowLibP2PProtocolID = generateProtocolID(rootBlockID)
+	p.chunkedProtocolID = generateChunkedProtocolID(rootBlockID)
 	addr := MultiaddressStr(n)
 	sourceMultiAddr, err := multiaddr.NewMultiaddr(addr)
 	if err != nil {
@@ -124,6 +130,9 @@ func (p *P2PNode) Start(ctx context.Context,
 
 		// provide the connection gater as an option to libp2p
 		options = append(options, libp2p.ConnectionGater(p.connGater))
+
+		// drop pubsub messages relayed by peers blocklisted by the connection gater
+		psOption = append(psOption, pubsub.WithBlacklist(&pubsubBlocklist{cg: p.connGater}))
 	}
 
 	// create the libp2p host
@@ -236,17 +245,38 @@ func (p *P2PNode) CreateStream(ctx context.Context, n NodeAddress) (network.Stre
 	}
 
 	// Open libp2p Stream with the remote peer (will use an existing TCP connection underneath if it exists)
-	stream, err := p.tryCreateNewStream(ctx, n, peerID, maxConnectAttempt)
+	stream, err := p.tryCreateNewStream(ctx, n, peerID, p.flowLibP2PProtocolID, maxConnectAttempt)
 	if err != nil {
 		return nil, netwk.NewPeerUnreachableError(fmt.Errorf("could not create stream (name: %s, address: %s:%s): %w", n.Name, n.IP, n.Port, err))
 	}
 	return stream, nil
 }
 
+// CreateChunkedStream creates a new stream with node n for the chunked transfer of a large message
+func (p *P2PNode) CreateChunkedStream(ctx context.Context, n NodeAddress) (network.Stream, error) {
+
+	// Get the PeerID
+	peerID, err := peer.IDFromPublicKey(n.PubKey)
+	if err != nil {
+		return nil, fmt.Errorf("could not get peer ID: %w", err)
+	}
+
+	stream, err := p.tryCreateNewStream(ctx, n, peerID, p.chunkedProtocolID, maxConnectAttempt)
+	if err != nil {
+		return nil, netwk.NewPeerUnreachableError(fmt.Errorf("could not create chunked stream (name: %s, address: %s:%s): %w", n.Name, n.IP, n.Port, err))
+	}
+	return stream, nil
+}
+
+// SetChunkedStreamHandler sets the handler of incoming streams of chunked transfers
+func (p *P2PNode) SetChunkedStreamHandler(handler network.StreamHandler) {
+	p.libP2PHost.SetStreamHandler(p.chunkedProtocolID, handler)
+}
+
 // tryCreateNewStream makes at most maxAttempts to create a stream with the target peer
 // This was put in as a fix for #2416. PubSub and 1-1 communication compete with each other when trying to connect to
 // remote nodes and once in a while NewStream returns an error 'both yamux endpoints are clients'
-func (p *P2PNode) tryCreateNewStream(ctx context.Context, n NodeAddress, targetID peer.ID, maxAttempts int) (network.Stream, error) {
+func (p *P2PNode) tryCreateNewStream(ctx context.Context, n NodeAddress, targetID peer.ID, protocolID protocol.ID, maxAttempts int) (network.Stream, error) {
 	var errs, err error
 	var s network.Stream
 	var retries = 0
@@ -291,7 +321,7 @@ func (p *P2PNode) tryCreateNewStream(ctx context.Context, n NodeAddress, targetI
 			continue
 		}
 
-		s, err = p.libP2PHost.NewStream(ctx, targetID, p.flowLibP2PProtocolID)
+		s, err = p.libP2PHost.NewStream(ctx, targetID, protocolID)
 		if err != nil {
 			errs = multierror.Append(errs, err)
 			continue
@@ -507,6 +537,26 @@ func (p *P2PNode) UpdateAllowlist(allowListAddrs ...NodeAddress) error {
 	return nil
 }
 
+// Blocklist closes all connections with the given peer and rejects any new connection with it until the given time
+func (p *P2PNode) Blocklist(id peer.ID, until time.Time) error {
+	if p.connGater == nil {
+		return fmt.Errorf("could not blocklist peer %s: allowlisting is disabled", id.Pretty())
+	}
+
+	p.connGater.blocklist(id, until)
+
+	err := p.libP2PHost.Network().ClosePeer(id)
+	if err != nil {
+		return fmt.Errorf("could not close connections with peer %s: %w", id.Pretty(), err)
+	}
+
+	return nil
+}
+
 func generateProtocolID(rootBlockID string) protocol.ID {
 	return protocol.ID(FlowLibP2PProtocolIDPrefix + rootBlockID)
 }
+
+func generateChunkedProtocolID(rootBlockID string) protocol.ID {
+	return protocol.ID(FlowLibP2PChunkedProtocolIDPrefix + rootBlockID)
+}
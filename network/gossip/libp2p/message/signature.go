@@ -0,0 +1,28 @@
+package message
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/crypto/hash"
+	"github.com/onflow/flow-go/model/encoding"
+)
+
+// NewSignatureHasher returns the hasher used to sign and verify the origin signature of messages.
+func NewSignatureHasher() hash.Hasher {
+	return hash.NewSHA3_256()
+}
+
+// SignatureData returns the bytes covered by the origin signature of the message. They consist of
+// the domain tag of network messages followed by the encoding of the message with an empty signature,
+// so that any node relaying the message can reproduce them.
+func SignatureData(msg *Message) ([]byte, error) {
+	unsigned := *msg
+	unsigned.Signature = nil
+
+	data, err := unsigned.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("could not encode message: %w", err)
+	}
+
+	return append([]byte(encoding.NetworkMessageTag), data...), nil
+}
@@ -4,6 +4,7 @@ package libp2p
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"fmt"
 	"net"
@@ -11,8 +12,10 @@ import (
 	"time"
 
 	ggio "github.com/gogo/protobuf/io"
+	"github.com/hashicorp/go-multierror"
 	"github.com/libp2p/go-libp2p-core/helpers"
 	libp2pnetwork "github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/rs/zerolog"
 
@@ -22,6 +25,7 @@ import (
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/network/gossip/libp2p/errors"
 	"github.com/onflow/flow-go/network/gossip/libp2p/message"
 	"github.com/onflow/flow-go/network/gossip/libp2p/middleware"
 	"github.com/onflow/flow-go/network/gossip/libp2p/validators"
@@ -46,6 +50,9 @@ const (
 // the inbound message queue size for One to One and One to K messages (each)
 const InboundMessageQueueSize = 100
 
+// reason reported to the metrics for messages rejected due to the rate limits
+const rejectRateLimited = "rate_limited"
+
 // Middleware handles the input & output on the direct connections we have to
 // our neighbours on the peer-to-peer network.
 type Middleware struct {
@@ -67,13 +74,20 @@ type Middleware struct {
 	maxUnicastMsgSize int // used to define maximum message size in unicast mode
 	rootBlockID       string
 	validators        []validators.MessageValidator
+	limits            PeerLimits
+	rateLimiter       *rateLimiter
+	scores            *misbehaviorScores
+	maxChunkedMsgSize int    // used to define maximum message size of 1-1 messages transferred in chunks
+	chunkSize         uint32 // size of the chunks of 1-1 messages beyond the maximum unicast message size
+	chunkWindow       uint32 // number of chunks sent ahead of the acknowledgements of the receiver
+	chunks            *chunkedReceiver
 }
 
 // NewMiddleware creates a new middleware instance with the given config and using the
 // given codec to encode/decode messages to our peers.
 func NewMiddleware(log zerolog.Logger, codec network.Codec, address string, flowID flow.Identifier,
 	key crypto.PrivateKey, metrics module.NetworkMetrics, maxUnicastMsgSize int, maxPubSubMsgSize int,
-	rootBlockID string, validators ...validators.MessageValidator) (*Middleware, error) {
+	limits PeerLimits, rootBlockID string, validators ...validators.MessageValidator) (*Middleware, error) {
 	ip, port, err := net.SplitHostPort(address)
 	if err != nil {
 		return nil, err
@@ -113,8 +127,19 @@ func NewMiddleware(log zerolog.Logger, codec network.Codec, address string, flow
 		maxUnicastMsgSize: maxUnicastMsgSize,
 		rootBlockID:       rootBlockID,
 		validators:        validators,
+		limits:            limits,
+		rateLimiter:       newRateLimiter(limits),
+		scores:            newMisbehaviorScores(limits.MisbehaviorThreshold, limits.MisbehaviorDecay),
+		maxChunkedMsgSize: DefaultMaxChunkedMsgSize,
+		chunkSize:         DefaultChunkSize,
+		chunkWindow:       DefaultChunkWindow,
+		chunks:            newChunkedReceiver(DefaultMaxChunkedMsgSize),
 	}
 
+	// the origin of every message is authenticated after the configured validators passed, so the
+	// signature verification is only paid for messages that are not dropped otherwise
+	m.validators = append(m.validators, newSignatureValidator(log, m.identity, metrics))
+
 	return m, err
 }
 
@@ -125,6 +150,11 @@ func defaultValidators(log zerolog.Logger, flowID flow.Identifier) []validators.
 	}
 }
 
+// newSignatureValidator returns the validator authenticating the origin of messages against the identity table
+func newSignatureValidator(log zerolog.Logger, identity validators.IdentityFunc, metrics module.NetworkMetrics) validators.MessageValidator {
+	return validators.NewSignatureValidator(log, identity, metrics)
+}
+
 // Me returns the flow identifier of the this middleware
 func (m *Middleware) Me() flow.Identifier {
 	return m.me
@@ -191,6 +221,9 @@ func (m *Middleware) Start(ov middleware.Overlay) error {
 		return fmt.Errorf("failed to start libp2p node: %w", err)
 	}
 
+	// receive the 1-1 messages beyond the maximum unicast message size on streams of their own
+	m.libP2PNode.SetChunkedStreamHandler(m.handleIncomingChunkedStream)
+
 	// the ip,port may change after libp2p has been started. e.g. 0.0.0.0:0 would change to an actual IP and port
 	m.host, m.port, err = m.libP2PNode.GetIPPort()
 	if err != nil {
@@ -202,7 +235,10 @@ func (m *Middleware) Start(ov middleware.Overlay) error {
 
 // Stop will end the execution of the middleware and wait for it to end.
 func (m *Middleware) Stop() {
+	// no further stream handlers join the wait group once the stop channel is closed under the lock
+	m.Lock()
 	close(m.stop)
+	m.Unlock()
 
 	// stop libp2p
 	done, err := m.libP2PNode.Stop()
@@ -275,17 +311,30 @@ func (m *Middleware) chooseMode(_ string, _ *message.Message, targetIDs ...flow.
 //
 // Dispatch should be used whenever guaranteed delivery to a specific target is required. Otherwise, Publish is
 // a more efficient candidate.
+//
+// Messages beyond the maximum unicast message size are transparently transferred in chunks, up to the maximum
+// chunked message size.
 func (m *Middleware) SendDirect(msg *message.Message, targetID flow.Identifier) error {
 	targetAddress, err := m.nodeAddressFromID(targetID)
 	if err != nil {
 		return err
 	}
 
-	if msg.Size() > m.maxUnicastMsgSize {
-		// message size goes beyond maximum size that the serializer can handle.
-		// proceeding with this message results in closing the connection by the target side, and
+	err = m.sign(msg)
+	if err != nil {
+		return fmt.Errorf("failed to sign message: %w", err)
+	}
+
+	if msg.Size() > m.maxChunkedMsgSize {
+		// message size goes beyond maximum size that the receiver accepts even in chunks.
+		// proceeding with this message results in closing the stream by the target side, and
 		// delivery failure.
-		return fmt.Errorf("message size %d exceeds configured max message size %d", msg.Size(), m.maxUnicastMsgSize)
+		return fmt.Errorf("message size %d exceeds configured max message size %d", msg.Size(), m.maxChunkedMsgSize)
+	}
+
+	if msg.Size() > m.maxUnicastMsgSize {
+		// message size goes beyond maximum size that the serializer can handle in a single frame
+		return m.sendChunked(msg, targetAddress, targetID)
 	}
 
 	// create new stream
@@ -330,6 +379,64 @@ func (m *Middleware) SendDirect(msg *message.Message, targetID flow.Identifier)
 	return nil
 }
 
+// sendChunked transfers the message to the target in chunks over a dedicated stream. A failed transfer is
+// resumed on a new stream, after the chunks the target acknowledged.
+func (m *Middleware) sendChunked(msg *message.Message, targetAddress NodeAddress, targetID flow.Identifier) error {
+	payload, err := msg.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	log := m.log.With().
+		Hex("target_id", targetID[:]).
+		Int("size", len(payload)).
+		Logger()
+
+	var errs error
+	for attempt := 1; attempt <= maxChunkedTransferAttempts; attempt++ {
+
+		// if this is a retry attempt, wait for the target to release the failed transfer
+		if attempt > 1 {
+			select {
+			case <-m.ctx.Done():
+				return fmt.Errorf("context done before chunked transfer to %s completed: %w", targetID.String(), errs)
+			case <-time.After(time.Duration(attempt-1) * time.Second):
+			}
+		}
+
+		err = m.tryChunkedTransfer(payload, targetAddress)
+		if err == nil {
+			// OneToOne communication metrics are reported with topic OneToOne
+			m.metrics.NetworkMessageSent(len(payload), metrics.ChannelOneToOne)
+			return nil
+		}
+
+		log.Warn().Err(err).Int("attempt", attempt).Msg("chunked transfer failed")
+		errs = multierror.Append(errs, err)
+	}
+
+	return fmt.Errorf("failed to send message to %s in chunks: %w", targetID.String(), errs)
+}
+
+// tryChunkedTransfer makes a single attempt to transfer the payload in chunks over a new stream
+func (m *Middleware) tryChunkedTransfer(payload []byte, targetAddress NodeAddress) error {
+	stream, err := m.libP2PNode.CreateChunkedStream(m.ctx, targetAddress)
+	if err != nil {
+		return fmt.Errorf("failed to create chunked stream for %s: %w", targetAddress.Name, err)
+	}
+
+	err = sendChunks(stream, payload, m.chunkSize, m.chunkWindow, chunkedTransferTimeout)
+	if err != nil {
+		_ = stream.Reset()
+		return err
+	}
+
+	// close the stream immediately
+	go helpers.FullClose(stream)
+
+	return nil
+}
+
 // nodeAddressFromID returns the libp2p.NodeAddress for the given flow.id.
 func (m *Middleware) nodeAddressFromID(id flow.Identifier) (NodeAddress, error) {
 
@@ -348,6 +455,35 @@ func (m *Middleware) nodeAddressFromID(id flow.Identifier) (NodeAddress, error)
 	return nodeAddressFromIdentity(flowIdentity)
 }
 
+// identity returns the flow.Identity of the given node from the identity table of the overlay.
+func (m *Middleware) identity(nodeID flow.Identifier) (flow.Identity, bool) {
+	idsMap, err := m.ov.Identity()
+	if err != nil {
+		m.log.Error().Err(err).Msg("could not get identities")
+		return flow.Identity{}, false
+	}
+
+	identity, found := idsMap[nodeID]
+	return identity, found
+}
+
+// sign signs the message with the networking key of this node, which allows the receivers
+// to authenticate the origin of the message, no matter which node relayed it.
+func (m *Middleware) sign(msg *message.Message) error {
+	data, err := message.SignatureData(msg)
+	if err != nil {
+		return err
+	}
+
+	sig, err := m.key.Sign(data, message.NewSignatureHasher())
+	if err != nil {
+		return err
+	}
+
+	msg.Signature = sig
+	return nil
+}
+
 // nodeAddressFromIdentity returns the libp2p.NodeAddress for the given flow.identity
 func nodeAddressFromIdentity(flowIdentity flow.Identity) (NodeAddress, error) {
 
@@ -395,13 +531,65 @@ func (m *Middleware) handleIncomingStream(s libp2pnetwork.Stream) {
 	log.Info().Msg("incoming connection established")
 
 	//create a new readConnection with the context of the middleware
-	conn := newReadConnection(m.ctx, s, m.processMessage, log, m.metrics, m.maxUnicastMsgSize)
+	conn := newReadConnection(m.ctx, s, m.processUnicastMessage, m.penalize, log, m.metrics, m.maxUnicastMsgSize)
 
 	// kick off the receive loop to continuously receive messages
 	m.wg.Add(1)
 	go conn.receiveLoop(m.wg)
 }
 
+// handleIncomingChunkedStream handles an incoming stream of a chunked transfer from a remote peer
+// it is a callback that gets called for each incoming chunked stream by libp2p with a new stream object
+func (m *Middleware) handleIncomingChunkedStream(s libp2pnetwork.Stream) {
+
+	// libp2p may call the handler while the middleware is stopping, in which case the stream is dropped
+	// rather than joining the wait group concurrently with Stop waiting for it
+	m.Lock()
+	select {
+	case <-m.stop:
+		m.Unlock()
+		_ = s.Reset()
+		return
+	default:
+	}
+	m.wg.Add(1)
+	m.Unlock()
+	defer m.wg.Done()
+
+	remotePeer := s.Conn().RemotePeer()
+
+	// qualify the logger with the remote address
+	log := m.log.With().
+		Str("remote_addr", s.Conn().RemoteMultiaddr().String()).
+		Logger()
+
+	payload, misbehavior, err := m.chunks.receive(s, remotePeer)
+	if err != nil {
+		// the remote peer is penalized for sending an unacceptable transfer
+		if misbehavior != "" {
+			m.penalize(remotePeer, misbehavior)
+		}
+		log.Error().Err(err).Msg("failed to receive chunked message")
+		_ = s.Reset()
+		return
+	}
+
+	go helpers.FullClose(s)
+
+	var msg message.Message
+	err = msg.Unmarshal(payload)
+	if err != nil {
+		m.penalize(remotePeer, MisbehaviorDecodeFailure)
+		log.Error().Err(err).Msg("failed to unmarshal chunked message")
+		return
+	}
+
+	// log metrics with the channel name as OneToOne
+	m.metrics.NetworkMessageReceived(msg.Size(), metrics.ChannelOneToOne)
+
+	m.processUnicastMessage(&msg, remotePeer)
+}
+
 // Subscribe will subscribe the middleware for a topic with the fully qualified channel ID name
 func (m *Middleware) Subscribe(channelID string) error {
 
@@ -413,7 +601,7 @@ func (m *Middleware) Subscribe(channelID string) error {
 	}
 
 	// create a new readSubscription with the context of the middleware
-	rs := newReadSubscription(m.ctx, s, m.processMessage, m.log, m.metrics)
+	rs := newReadSubscription(m.ctx, s, m.processMessage, m.penalize, m.log, m.metrics)
 	m.wg.Add(1)
 
 	// kick off the receive loop to continuously receive messages
@@ -428,8 +616,31 @@ func (m *Middleware) Unsubscribe(channelID string) error {
 	return m.libP2PNode.UnSubscribe(topic)
 }
 
-// processMessage processes a message and eventually passes it to the overlay
-func (m *Middleware) processMessage(msg *message.Message) {
+// processUnicastMessage processes a message received on a 1-1 connection. As opposed to pub-sub messages,
+// which are delivered to all subscribers of the channel, a 1-1 message must target this node.
+func (m *Middleware) processUnicastMessage(msg *message.Message, from peer.ID) {
+	for _, target := range msg.TargetIDs {
+		if bytes.Equal(target, m.me[:]) {
+			m.processMessage(msg, from)
+			return
+		}
+	}
+
+	m.penalize(from, MisbehaviorInvalidTarget)
+}
+
+// processMessage processes a message delivered by the given peer and eventually passes it to the overlay
+func (m *Middleware) processMessage(msg *message.Message, from peer.ID) {
+
+	// drop the message if the peer exceeded its rate limits
+	if !m.rateLimiter.allow(from, msg.ChannelID) {
+		m.log.Debug().
+			Str("peer_id", from.Pretty()).
+			Str("channel", msg.ChannelID).
+			Msg("dropping message due to rate limiting")
+		m.metrics.NetworkMessageRejected(msg.ChannelID, rejectRateLimited)
+		return
+	}
 
 	// run through all the message validators
 	for _, v := range m.validators {
@@ -441,16 +652,51 @@ func (m *Middleware) processMessage(msg *message.Message) {
 
 	// if validation passed, send the message to the overlay
 	err := m.ov.Receive(flow.HashToID(msg.OriginID), msg)
+	if errors.IsInvalidPayloadError(err) {
+		m.penalize(from, MisbehaviorDecodeFailure)
+	}
 	if err != nil {
 		m.log.Error().Err(err).Msg("could not deliver payload")
 	}
 }
 
+// penalize increases the misbehavior score of the given peer, and disconnects and blocklists
+// the peer once its score reaches the misbehavior threshold.
+func (m *Middleware) penalize(from peer.ID, misbehavior Misbehavior) {
+	score, exceeded := m.scores.penalize(from, misbehavior)
+
+	log := m.log.With().
+		Str("peer_id", from.Pretty()).
+		Str("misbehavior", string(misbehavior)).
+		Float64("score", score).
+		Logger()
+
+	if !exceeded {
+		log.Warn().Msg("peer misbehaved")
+		return
+	}
+
+	m.rateLimiter.remove(from)
+
+	err := m.libP2PNode.Blocklist(from, time.Now().Add(m.limits.BlocklistDuration))
+	if err != nil {
+		log.Error().Err(err).Msg("could not blocklist misbehaving peer")
+		return
+	}
+
+	log.Warn().Msg("misbehaving peer disconnected and blocklisted")
+}
+
 // Publish publishes msg on the channel. It models a distributed broadcast where the message is meant for all or
 // a many nodes subscribing to the channel ID. It does not guarantee the delivery though, and operates on a best
 // effort.
 func (m *Middleware) Publish(msg *message.Message, channelID string) error {
 
+	err := m.sign(msg)
+	if err != nil {
+		return fmt.Errorf("failed to sign message: %w", err)
+	}
+
 	// convert the message to bytes to be put on the wire.
 	data, err := msg.Marshal()
 	if err != nil {
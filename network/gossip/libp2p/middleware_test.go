@@ -1,6 +1,8 @@
 package libp2p
 
 import (
+	"crypto/rand"
+	"encoding/base64"
 	"fmt"
 	"os"
 	"sync"
@@ -18,6 +20,8 @@ import (
 	"github.com/onflow/flow-go/model/flow"
 	message2 "github.com/onflow/flow-go/model/libp2p/message"
 	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/network/codec/cbor"
+	"github.com/onflow/flow-go/network/codec/compression"
 	"github.com/onflow/flow-go/network/codec/json"
 	"github.com/onflow/flow-go/network/gossip/libp2p/message"
 	"github.com/onflow/flow-go/network/gossip/libp2p/mock"
@@ -81,14 +85,14 @@ func (m *MiddlewareTestSuite) TestPingTypeReception() {
 // it does not evaluate the actual value of the sender ID
 func (m *MiddlewareTestSuite) TestPingIDType() {
 	msg := createMessage(m.ids[0], m.ids[1])
-	m.Ping(mockery.AnythingOfType("flow.Identifier"), msg)
+	m.Ping(mockery.AnythingOfType("flow.Identifier"), unsignedMessage(msg))
 }
 
 // TestPingContentReception tests the middleware against both
 // the payload and sender ID of the event upon reception at the receiver side
 func (m *MiddlewareTestSuite) TestPingContentReception() {
 	msg := createMessage(m.ids[0], m.ids[1])
-	m.Ping(m.mws[0].me, msg)
+	m.Ping(m.mws[0].me, unsignedMessage(msg))
 }
 
 // TestMultiPing tests the middleware against type of received payload
@@ -263,11 +267,14 @@ func (m *MiddlewareTestSuite) TestEcho() {
 }
 
 // TestMaxMessageSize_SendDirect evaluates that invoking SendDirect method of the middleware on a message
-// size beyond the permissible unicast message size returns an error.
+// size beyond the permissible chunked message size returns an error.
 func (m *MiddlewareTestSuite) TestMaxMessageSize_SendDirect() {
 	firstNode := 0
 	lastNode := m.size - 1
 
+	// limits chunked messages to the unicast message size to keep the test payload small
+	m.mws[firstNode].maxChunkedMsgSize = DefaultMaxUnicastMsgSize
+
 	msg := createMessage(m.ids[firstNode], m.ids[lastNode], "")
 
 	// creates a network payload beyond the maximum message size
@@ -291,6 +298,100 @@ func (m *MiddlewareTestSuite) TestMaxMessageSize_SendDirect() {
 	require.Error(m.Suite.T(), err)
 }
 
+// TestChunkedSendDirect evaluates that a message beyond the permissible unicast message size is delivered
+// by SendDirect in chunks.
+func (m *MiddlewareTestSuite) TestChunkedSendDirect() {
+	firstNode := 0
+	lastNode := m.size - 1
+
+	msg := createMessage(m.ids[firstNode], m.ids[lastNode], "")
+
+	// creates a network payload beyond the maximum unicast message size
+	payload := NetworkPayloadFixture(m.T(), uint(DefaultMaxUnicastMsgSize)+1000)
+	event := &message2.TestMessage{
+		Text: string(payload),
+	}
+
+	codec := json.NewCodec()
+	encodedEvent, err := codec.Encode(event)
+	require.NoError(m.T(), err)
+
+	msg.Payload = encodedEvent
+	require.Greater(m.T(), msg.Size(), DefaultMaxUnicastMsgSize)
+
+	// the sender signs the message in place
+	expected := *msg
+
+	ch := make(chan struct{})
+	m.ov[lastNode].On("Receive", m.mws[firstNode].me, unsignedMessage(&expected)).Return(nil).Once().
+		Run(func(args mockery.Arguments) {
+			close(ch)
+		})
+
+	// sends a direct message from first node to the last node
+	err = m.mws[firstNode].SendDirect(msg, m.ids[lastNode])
+	require.NoError(m.Suite.T(), err)
+
+	select {
+	case <-ch:
+	case <-time.After(10 * time.Second):
+		assert.Fail(m.T(), "peer 1 failed to send a chunked message to peer 2")
+	}
+
+	m.ov[lastNode].AssertExpectations(m.T())
+}
+
+// TestChunkedSendDirect_Compressed evaluates that a compressed message, which is beyond the permissible
+// unicast message size and decompresses beyond it, is delivered by SendDirect in chunks and decoded.
+func (m *MiddlewareTestSuite) TestChunkedSendDirect_Compressed() {
+	firstNode := 0
+	lastNode := m.size - 1
+
+	for _, name := range []string{compression.Snappy, compression.Zstd} {
+		m.Run(name, func() {
+			compressor, err := compression.New(name, DefaultMaxChunkedMsgSize)
+			require.NoError(m.T(), err)
+			codec := cbor.NewCodec(cbor.WithCompressor(compressor))
+
+			// base64 encoded random bytes barely compress, and decompress beyond 16 mb
+			random := make([]byte, DefaultMaxUnicastMsgSize*3/2)
+			_, err = rand.Read(random)
+			require.NoError(m.T(), err)
+			event := &message2.TestMessage{
+				Text: base64.StdEncoding.EncodeToString(random),
+			}
+
+			msg := createMessage(m.ids[firstNode], m.ids[lastNode], "")
+			msg.Payload, err = codec.Encode(event)
+			require.NoError(m.T(), err)
+			require.Greater(m.T(), msg.Size(), DefaultMaxUnicastMsgSize)
+
+			// the receiver decodes the payload of the received message
+			ch := make(chan interface{}, 1)
+			m.ov[lastNode].On("Receive", m.mws[firstNode].me, mockery.Anything).Return(nil).Once().
+				Run(func(args mockery.Arguments) {
+					received := args.Get(1).(*message.Message)
+					decoded, err := codec.Decode(received.Payload)
+					assert.NoError(m.T(), err)
+					ch <- decoded
+				})
+
+			// sends a direct message from first node to the last node
+			err = m.mws[firstNode].SendDirect(msg, m.ids[lastNode])
+			require.NoError(m.Suite.T(), err)
+
+			select {
+			case decoded := <-ch:
+				assert.Equal(m.T(), event, decoded)
+			case <-time.After(30 * time.Second):
+				assert.Fail(m.T(), "peer 1 failed to send a compressed chunked message to peer 2")
+			}
+
+			m.ov[lastNode].AssertExpectations(m.T())
+		})
+	}
+}
+
 // TestMaxMessageSize_Publish evaluates that invoking Publish method of the middleware on a message
 // size beyond the permissible publish message size returns an error.
 func (m *MiddlewareTestSuite) TestMaxMessageSize_Publish() {
@@ -366,6 +467,59 @@ func (m *MiddlewareTestSuite) TestUnsubscribe() {
 	}, 2*time.Second, time.Millisecond)
 }
 
+// TestUnknownOrigin evaluates that a message claiming an origin which is not part of the identity table of the
+// receiver is dropped by the middleware of the receiver, since the origin signature cannot be authenticated.
+func (m *MiddlewareTestSuite) TestUnknownOrigin() {
+	firstNode := 0
+	lastNode := m.size - 1
+
+	m.ov[lastNode].On("Receive", mockery.Anything, mockery.Anything).Return(nil).Maybe()
+
+	// the first node claims to relay a message of a node unknown to the last node
+	msg := createMessage(unittest.IdentifierFixture(), m.ids[lastNode])
+	err := m.mws[firstNode].SendDirect(msg, m.ids[lastNode])
+	require.NoError(m.Suite.T(), err)
+
+	// assert that the message is not delivered to the overlay of the last node
+	assert.Never(m.T(), func() bool {
+		return !m.ov[lastNode].AssertNotCalled(m.T(), "Receive", mockery.Anything, mockery.Anything)
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+// TestMisbehavingPeerBlocklisted evaluates that a peer repeatedly sending 1-1 messages that do not target the
+// receiver is disconnected and blocklisted by the receiver once its misbehavior score reaches the threshold.
+func (m *MiddlewareTestSuite) TestMisbehavingPeerBlocklisted() {
+	firstNode := 0
+	lastNode := m.size - 1
+
+	m.ov[lastNode].On("Receive", mockery.Anything, mockery.Anything).Return(nil).Maybe()
+
+	firstPeer := m.mws[firstNode].libP2PNode.libP2PHost.ID()
+	misbehaviors := int(DefaultMisbehaviorThreshold/penalties[MisbehaviorInvalidTarget]) + 1
+
+	// the first node sends messages targeting another node than the last node over a 1-1 connection
+	for i := 0; i < misbehaviors; i++ {
+		msg := createMessage(m.ids[firstNode], unittest.IdentifierFixture(), fmt.Sprintf("misdirected %d", i))
+		err := m.mws[firstNode].SendDirect(msg, m.ids[lastNode])
+		if err != nil {
+			// the connection is refused once the first node is blocklisted
+			break
+		}
+	}
+
+	require.Eventually(m.T(), func() bool {
+		return m.mws[lastNode].libP2PNode.connGater.blocklisted(firstPeer)
+	}, 3*time.Second, 10*time.Millisecond)
+
+	// a well-formed message of the blocklisted node is not delivered anymore
+	msg := createMessage(m.ids[firstNode], m.ids[lastNode])
+	_ = m.mws[firstNode].SendDirect(msg, m.ids[lastNode])
+
+	assert.Never(m.T(), func() bool {
+		return !m.ov[lastNode].AssertNotCalled(m.T(), "Receive", mockery.Anything, mockery.Anything)
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
 // createMiddelwares creates middlewares with mock overlay for each middleware
 func (m *MiddlewareTestSuite) createMiddleWares(count int) ([]flow.Identifier, []*Middleware) {
 	var mws []*Middleware
@@ -395,6 +549,7 @@ func (m *MiddlewareTestSuite) createMiddleWares(count int) ([]flow.Identifier, [
 			m.metrics,
 			DefaultMaxUnicastMsgSize,
 			DefaultMaxPubSubMsgSize,
+			DefaultPeerLimits(),
 			rootID)
 		require.NoError(m.Suite.T(), err)
 
@@ -426,6 +581,16 @@ func createMessage(originID flow.Identifier, targetID flow.Identifier, msg ...st
 	}
 }
 
+// unsignedMessage matches a received message against the expected message, disregarding the origin
+// signature the sender's middleware added to it
+func unsignedMessage(expected *message.Message) interface{} {
+	return mockery.MatchedBy(func(received *message.Message) bool {
+		unsigned := *received
+		unsigned.Signature = nil
+		return assert.ObjectsAreEqual(expected, &unsigned)
+	})
+}
+
 func (m *MiddlewareTestSuite) stopMiddlewares() {
 	// start all the middlewares
 	for i := 0; i < m.size; i++ {
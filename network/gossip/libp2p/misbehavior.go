@@ -0,0 +1,90 @@
+package libp2p
+
+import (
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+// Misbehavior is a kind of peer misbehavior that increases the misbehavior score of the peer
+type Misbehavior string
+
+const (
+	// MisbehaviorDecodeFailure is a message or message payload that could not be decoded
+	MisbehaviorDecodeFailure Misbehavior = "decode_failure"
+
+	// MisbehaviorInvalidTarget is a 1-1 message that does not target the receiving node
+	MisbehaviorInvalidTarget Misbehavior = "invalid_target"
+
+	// MisbehaviorOversizedPayload is a message beyond the maximum message size
+	MisbehaviorOversizedPayload Misbehavior = "oversized_payload"
+
+	// MisbehaviorInvalidChunk is a chunk of a chunked transfer that is out of order, corrupted or does not
+	// match the transferred message
+	MisbehaviorInvalidChunk Misbehavior = "invalid_chunk"
+)
+
+// penalties maps each misbehavior to the increase of the misbehavior score it causes
+var penalties = map[Misbehavior]float64{
+	MisbehaviorDecodeFailure:    10,
+	MisbehaviorInvalidTarget:    10,
+	MisbehaviorOversizedPayload: 25,
+	MisbehaviorInvalidChunk:     10,
+}
+
+// misbehaviorScores keeps track of the misbehavior score of each peer. Scores decay linearly over time,
+// so that occasional faults of honest peers do not add up to the threshold.
+type misbehaviorScores struct {
+	sync.Mutex
+	threshold float64
+	decay     float64
+	scores    map[peer.ID]*misbehaviorScore
+	now       func() time.Time
+}
+
+// misbehaviorScore is the score of a single peer as of its last update
+type misbehaviorScore struct {
+	value   float64
+	updated time.Time
+}
+
+func newMisbehaviorScores(threshold float64, decay float64) *misbehaviorScores {
+	return &misbehaviorScores{
+		threshold: threshold,
+		decay:     decay,
+		scores:    make(map[peer.ID]*misbehaviorScore),
+		now:       time.Now,
+	}
+}
+
+// penalize increases the score of the peer by the penalty of the misbehavior. It returns the new score of the peer
+// and whether the score reached the threshold. A peer reaching the threshold is reset to a zero score.
+func (m *misbehaviorScores) penalize(pid peer.ID, misbehavior Misbehavior) (float64, bool) {
+	m.Lock()
+	defer m.Unlock()
+
+	now := m.now()
+
+	score, ok := m.scores[pid]
+	if !ok {
+		score = &misbehaviorScore{updated: now}
+		m.scores[pid] = score
+	}
+
+	// forgive the misbehavior proportionally to the time passed since the last update
+	score.value -= now.Sub(score.updated).Seconds() * m.decay
+	if score.value < 0 {
+		score.value = 0
+	}
+	score.value += penalties[misbehavior]
+	score.updated = now
+
+	value := score.value
+	if m.threshold <= 0 || value < m.threshold {
+		return value, false
+	}
+
+	delete(m.scores, pid)
+	return value, true
+}
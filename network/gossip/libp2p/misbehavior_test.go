@@ -0,0 +1,65 @@
+package libp2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMisbehaviorScores_Threshold evaluates that a peer reaches the threshold after enough misbehaviors,
+// and that its score is reset once it did.
+func TestMisbehaviorScores_Threshold(t *testing.T) {
+	now := time.Now()
+	scores := newMisbehaviorScores(50, 1)
+	scores.now = func() time.Time { return now }
+
+	pid := peer.ID("peer")
+
+	score, exceeded := scores.penalize(pid, MisbehaviorOversizedPayload)
+	assert.Equal(t, penalties[MisbehaviorOversizedPayload], score)
+	assert.False(t, exceeded)
+
+	// another peer has its own score
+	_, exceeded = scores.penalize(peer.ID("other"), MisbehaviorDecodeFailure)
+	assert.False(t, exceeded)
+
+	_, exceeded = scores.penalize(pid, MisbehaviorOversizedPayload)
+	assert.True(t, exceeded)
+
+	score, exceeded = scores.penalize(pid, MisbehaviorDecodeFailure)
+	assert.Equal(t, penalties[MisbehaviorDecodeFailure], score)
+	assert.False(t, exceeded)
+}
+
+// TestMisbehaviorScores_Decay evaluates that the score of a peer decays over time.
+func TestMisbehaviorScores_Decay(t *testing.T) {
+	now := time.Now()
+	scores := newMisbehaviorScores(50, 1)
+	scores.now = func() time.Time { return now }
+
+	pid := peer.ID("peer")
+
+	_, _ = scores.penalize(pid, MisbehaviorOversizedPayload)
+
+	// a score of 10 is forgiven over 10 seconds
+	now = now.Add(10 * time.Second)
+	score, _ := scores.penalize(pid, MisbehaviorInvalidTarget)
+	assert.Equal(t, penalties[MisbehaviorOversizedPayload]-10+penalties[MisbehaviorInvalidTarget], score)
+
+	// the score does not decay below zero
+	now = now.Add(time.Hour)
+	score, _ = scores.penalize(pid, MisbehaviorInvalidTarget)
+	assert.Equal(t, penalties[MisbehaviorInvalidTarget], score)
+}
+
+// TestMisbehaviorScores_NoThreshold evaluates that a zero threshold disables blocklisting.
+func TestMisbehaviorScores_NoThreshold(t *testing.T) {
+	scores := newMisbehaviorScores(0, 0)
+
+	for i := 0; i < 100; i++ {
+		_, exceeded := scores.penalize(peer.ID("peer"), MisbehaviorOversizedPayload)
+		assert.False(t, exceeded)
+	}
+}
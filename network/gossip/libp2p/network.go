@@ -11,6 +11,7 @@ import (
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/network"
 	"github.com/onflow/flow-go/network/gossip/libp2p/cache"
+	"github.com/onflow/flow-go/network/gossip/libp2p/errors"
 	"github.com/onflow/flow-go/network/gossip/libp2p/message"
 	"github.com/onflow/flow-go/network/gossip/libp2p/middleware"
 	"github.com/onflow/flow-go/network/gossip/libp2p/queue"
@@ -201,7 +202,7 @@ func (n *Network) processNetworkMessage(senderID flow.Identifier, message *messa
 	// Convert message payload to a known message type
 	decodedMessage, err := n.codec.Decode(message.Payload)
 	if err != nil {
-		return fmt.Errorf("could not decode event: %w", err)
+		return fmt.Errorf("could not decode event: %w", errors.NewInvalidPayloadError(err))
 	}
 
 	// create queue message
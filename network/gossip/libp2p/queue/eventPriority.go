@@ -43,6 +43,8 @@ func getPriorityByType(message interface{}) Priority {
 		return HighPriority
 	case *messages.BlockVote:
 		return HighPriority
+	case *messages.BlockTimeout:
+		return HighPriority
 
 	// protocol state sync
 	case *messages.SyncRequest:
@@ -61,9 +63,19 @@ func getPriorityByType(message interface{}) Priority {
 		return HighPriority
 	case *messages.ClusterBlockVote:
 		return HighPriority
+	case *messages.ClusterBlockTimeout:
+		return HighPriority
 	case *messages.ClusterBlockResponse:
 		return HighPriority
 
+	// distributed key generation
+	case *messages.DKGMessage:
+		return MediumPriority
+	case *messages.DKGBroadcast:
+		return MediumPriority
+	case *messages.DKGEcho:
+		return MediumPriority
+
 	// collections, guarantees & transactions
 	case *flow.CollectionGuarantee:
 		return HighPriority
@@ -83,6 +95,14 @@ func getPriorityByType(message interface{}) Priority {
 		return MediumPriority
 	case *messages.ExecutionStateDelta:
 		return MediumPriority
+	case *messages.CheckpointManifestRequest:
+		return LowPriority
+	case *messages.CheckpointManifestResponse:
+		return LowPriority
+	case *messages.CheckpointPieceRequest:
+		return LowPriority
+	case *messages.CheckpointPieceResponse:
+		return LowPriority
 
 	// data exchange for execution of blocks
 	case *messages.ChunkDataRequest:
@@ -0,0 +1,142 @@
+package libp2p
+
+import (
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// DefaultPeerMessageRate is the default number of messages per second accepted from a single peer
+	DefaultPeerMessageRate = 1000
+
+	// DefaultPeerMessageBurst is the default number of messages accepted from a single peer at once
+	DefaultPeerMessageBurst = 1000
+
+	// DefaultChannelMessageRate is the default number of messages per second accepted from a single peer on a single channel
+	DefaultChannelMessageRate = 500
+
+	// DefaultChannelMessageBurst is the default number of messages accepted from a single peer on a single channel at once
+	DefaultChannelMessageBurst = 500
+
+	// DefaultMisbehaviorThreshold is the default misbehavior score at which a peer is disconnected and blocklisted
+	DefaultMisbehaviorThreshold = 100
+
+	// DefaultMisbehaviorDecay is the default misbehavior score forgiven per second
+	DefaultMisbehaviorDecay = 1
+
+	// DefaultBlocklistDuration is the default duration for which a misbehaving peer is blocklisted
+	DefaultBlocklistDuration = 10 * time.Minute
+)
+
+// maximum number of channels rate limited for a single peer, which bounds the number of
+// limiters a peer can make us allocate by sending messages on arbitrary channels
+const maxRateLimitedChannels = 64
+
+// PeerLimits configures the rate limits and the misbehavior scoring the middleware applies to its peers.
+// Rate limits apply to the peer delivering a message, which is the origin for 1-1 messages and the
+// relaying node for pub-sub messages.
+type PeerLimits struct {
+	PeerMessageRate      float64       // number of messages per second accepted from a peer, 0 disables the limit
+	PeerMessageBurst     int           // number of messages accepted from a peer at once
+	ChannelMessageRate   float64       // number of messages per second accepted from a peer on a channel, 0 disables the limit
+	ChannelMessageBurst  int           // number of messages accepted from a peer on a channel at once
+	MisbehaviorThreshold float64       // misbehavior score at which a peer is disconnected and blocklisted, 0 disables blocklisting
+	MisbehaviorDecay     float64       // misbehavior score forgiven per second
+	BlocklistDuration    time.Duration // duration for which a misbehaving peer is blocklisted
+}
+
+// DefaultPeerLimits returns the default limits applied by the middleware to its peers.
+func DefaultPeerLimits() PeerLimits {
+	return PeerLimits{
+		PeerMessageRate:      DefaultPeerMessageRate,
+		PeerMessageBurst:     DefaultPeerMessageBurst,
+		ChannelMessageRate:   DefaultChannelMessageRate,
+		ChannelMessageBurst:  DefaultChannelMessageBurst,
+		MisbehaviorThreshold: DefaultMisbehaviorThreshold,
+		MisbehaviorDecay:     DefaultMisbehaviorDecay,
+		BlocklistDuration:    DefaultBlocklistDuration,
+	}
+}
+
+// rateLimiter limits the rate of messages accepted from each peer, overall and per channel.
+// The number of tracked peers is bounded by the allowlist of the node.
+type rateLimiter struct {
+	sync.Mutex
+	limits PeerLimits
+	peers  map[peer.ID]*peerRateLimiter
+}
+
+// peerRateLimiter holds the token buckets of a single peer
+type peerRateLimiter struct {
+	overall  *rate.Limiter
+	channels map[string]*rate.Limiter
+}
+
+func newRateLimiter(limits PeerLimits) *rateLimiter {
+	return &rateLimiter{
+		limits: limits,
+		peers:  make(map[peer.ID]*peerRateLimiter),
+	}
+}
+
+// allow returns true if a message from the given peer on the given channel is within the rate limits
+// and consumes a token from the respective buckets.
+func (r *rateLimiter) allow(pid peer.ID, channelID string) bool {
+	r.Lock()
+	defer r.Unlock()
+
+	pl, ok := r.peers[pid]
+	if !ok {
+		pl = &peerRateLimiter{
+			overall:  newLimiter(r.limits.PeerMessageRate, r.limits.PeerMessageBurst),
+			channels: make(map[string]*rate.Limiter),
+		}
+		r.peers[pid] = pl
+	}
+
+	cl, ok := pl.channels[channelID]
+	if !ok {
+		if len(pl.channels) >= maxRateLimitedChannels {
+			return false
+		}
+		cl = newLimiter(r.limits.ChannelMessageRate, r.limits.ChannelMessageBurst)
+		pl.channels[channelID] = cl
+	}
+
+	now := time.Now()
+	reservation := pl.overall.ReserveN(now, 1)
+	if !reservation.OK() || reservation.DelayFrom(now) > 0 {
+		reservation.CancelAt(now)
+		return false
+	}
+
+	// a message rejected on its channel does not count against the overall limit of the peer
+	if !cl.AllowN(now, 1) {
+		reservation.CancelAt(now)
+		return false
+	}
+
+	return true
+}
+
+// remove drops the rate limiting state of the given peer
+func (r *rateLimiter) remove(pid peer.ID) {
+	r.Lock()
+	defer r.Unlock()
+
+	delete(r.peers, pid)
+}
+
+// newLimiter returns a token bucket with the given rate and burst, a zero rate disables limiting
+func newLimiter(limit float64, burst int) *rate.Limiter {
+	if limit <= 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return rate.NewLimiter(rate.Limit(limit), burst)
+}
@@ -0,0 +1,62 @@
+package libp2p
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRateLimiter_Burst evaluates that a peer is limited to the burst of messages overall and per channel,
+// and that the limits of a peer do not affect other peers.
+func TestRateLimiter_Burst(t *testing.T) {
+	limiter := newRateLimiter(PeerLimits{
+		PeerMessageRate:     0.001,
+		PeerMessageBurst:    5,
+		ChannelMessageRate:  0.001,
+		ChannelMessageBurst: 3,
+	})
+
+	first := peer.ID("first")
+	second := peer.ID("second")
+
+	// the channel burst is exhausted first
+	for i := 0; i < 3; i++ {
+		assert.True(t, limiter.allow(first, "channel-1"))
+	}
+	assert.False(t, limiter.allow(first, "channel-1"))
+
+	// messages rejected on a channel do not count against the overall limit of the peer
+	assert.True(t, limiter.allow(first, "channel-2"))
+	assert.True(t, limiter.allow(first, "channel-2"))
+	assert.False(t, limiter.allow(first, "channel-3"))
+
+	// other peers are not affected
+	assert.True(t, limiter.allow(second, "channel-1"))
+
+	// removing the peer resets its limits
+	limiter.remove(first)
+	assert.True(t, limiter.allow(first, "channel-1"))
+}
+
+// TestRateLimiter_Disabled evaluates that zero rates disable the rate limits.
+func TestRateLimiter_Disabled(t *testing.T) {
+	limiter := newRateLimiter(PeerLimits{})
+
+	for i := 0; i < 10000; i++ {
+		assert.True(t, limiter.allow(peer.ID("peer"), "channel"))
+	}
+}
+
+// TestRateLimiter_MaxChannels evaluates that the number of channels rate limited for a peer is bounded.
+func TestRateLimiter_MaxChannels(t *testing.T) {
+	limiter := newRateLimiter(DefaultPeerLimits())
+
+	for i := 0; i < maxRateLimitedChannels; i++ {
+		assert.True(t, limiter.allow(peer.ID("peer"), string(rune('a'+i))))
+	}
+	assert.False(t, limiter.allow(peer.ID("peer"), "one-too-many"))
+
+	// already known channels are still accepted
+	assert.True(t, limiter.allow(peer.ID("peer"), "a"))
+}
@@ -1,12 +1,14 @@
 package libp2p
 
 import (
+	"bufio"
 	"context"
+	"encoding/binary"
 	"io"
 	"sync"
 
-	ggio "github.com/gogo/protobuf/io"
 	libp2pnetwork "github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/rs/zerolog"
 
 	"github.com/onflow/flow-go/module"
@@ -22,13 +24,15 @@ type readConnection struct {
 	log        zerolog.Logger
 	metrics    module.NetworkMetrics
 	maxMsgSize int
-	callback   func(msg *message.Message)
+	callback   func(msg *message.Message, from peer.ID)
+	penalize   func(from peer.ID, misbehavior Misbehavior)
 }
 
 // newReadConnection creates a new readConnection
 func newReadConnection(ctx context.Context,
 	stream libp2pnetwork.Stream,
-	callback func(msg *message.Message),
+	callback func(msg *message.Message, from peer.ID),
+	penalize func(from peer.ID, misbehavior Misbehavior),
 	log zerolog.Logger,
 	metrics module.NetworkMetrics,
 	maxMsgSize int) *readConnection {
@@ -41,6 +45,7 @@ func newReadConnection(ctx context.Context,
 		ctx:        ctx,
 		stream:     stream,
 		callback:   callback,
+		penalize:   penalize,
 		log:        log,
 		metrics:    metrics,
 		maxMsgSize: maxMsgSize,
@@ -55,8 +60,10 @@ func (rc *readConnection) receiveLoop(wg *sync.WaitGroup) {
 	defer wg.Done()
 	defer rc.log.Debug().Msg("exiting receive routine")
 
+	remotePeer := rc.stream.Conn().RemotePeer()
+
 	// create the reader
-	r := ggio.NewDelimitedReader(rc.stream, rc.maxMsgSize)
+	r := bufio.NewReader(rc.stream)
 
 	for {
 		// check if we should stop
@@ -68,10 +75,15 @@ func (rc *readConnection) receiveLoop(wg *sync.WaitGroup) {
 
 		var msg message.Message
 		// read the nex message (blocking call)
-		err := r.ReadMsg(&msg)
+		misbehavior, err := rc.readMsg(r, &msg)
 
 		// error handling done similar to comm.go in pubsub (as suggested by libp2p folks)
 		if err != nil {
+			// the remote peer is penalized for sending an unacceptable message
+			if misbehavior != "" {
+				rc.penalize(remotePeer, misbehavior)
+			}
+
 			// if the sender closes the connection an EOF is received otherwise an actual error is received
 			if err != io.EOF {
 				rc.log.Error().Err(err)
@@ -92,6 +104,33 @@ func (rc *readConnection) receiveLoop(wg *sync.WaitGroup) {
 		rc.metrics.NetworkMessageReceived(msg.Size(), metrics.ChannelOneToOne)
 
 		// call the callback
-		rc.callback(&msg)
+		rc.callback(&msg, remotePeer)
 	}
 }
+
+// readMsg reads the next length-delimited message from the reader, following the framing of the gogo protobuf
+// delimited writer used by the sender. Besides the error, it returns the misbehavior of the remote peer if the
+// message it sent is not acceptable.
+func (rc *readConnection) readMsg(r *bufio.Reader, msg *message.Message) (Misbehavior, error) {
+	length, err := binary.ReadUvarint(r)
+	if err != nil {
+		return "", err
+	}
+
+	if length > uint64(rc.maxMsgSize) {
+		return MisbehaviorOversizedPayload, io.ErrShortBuffer
+	}
+
+	buf := make([]byte, length)
+	_, err = io.ReadFull(r, buf)
+	if err != nil {
+		return "", err
+	}
+
+	err = msg.Unmarshal(buf)
+	if err != nil {
+		return MisbehaviorDecodeFailure, err
+	}
+
+	return "", nil
+}
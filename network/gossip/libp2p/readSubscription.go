@@ -5,6 +5,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/libp2p/go-libp2p-core/peer"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/rs/zerolog"
 
@@ -19,13 +20,15 @@ type readSubscription struct {
 	log      zerolog.Logger
 	sub      *pubsub.Subscription
 	metrics  module.NetworkMetrics
-	callback func(msg *message.Message)
+	callback func(msg *message.Message, from peer.ID)
+	penalize func(from peer.ID, misbehavior Misbehavior)
 }
 
 // newReadSubscription reads the messages coming in on the subscription
 func newReadSubscription(ctx context.Context,
 	sub *pubsub.Subscription,
-	callback func(msg *message.Message),
+	callback func(msg *message.Message, from peer.ID),
+	penalize func(from peer.ID, misbehavior Misbehavior),
 	log zerolog.Logger,
 	metrics module.NetworkMetrics) *readSubscription {
 
@@ -38,6 +41,7 @@ func newReadSubscription(ctx context.Context,
 		log:      log,
 		sub:      sub,
 		callback: callback,
+		penalize: penalize,
 		metrics:  metrics,
 	}
 
@@ -81,13 +85,15 @@ func (r *readSubscription) receiveLoop(wg *sync.WaitGroup) {
 		err = msg.Unmarshal(rawMsg.Data)
 		if err != nil {
 			r.log.Err(err).Str("topic_message", msg.String()).Msg("failed to unmarshal message")
-			return
+			// the peer that relayed the message to us is penalized, and the subscription keeps on reading
+			r.penalize(rawMsg.ReceivedFrom, MisbehaviorDecodeFailure)
+			continue
 		}
 
 		// log metrics
 		r.metrics.NetworkMessageReceived(msg.Size(), msg.ChannelID)
 
 		// call the callback
-		r.callback(&msg)
+		r.callback(&msg, rawMsg.ReceivedFrom)
 	}
 }
@@ -148,6 +148,7 @@ func createMiddleware(log zerolog.Logger, identities []*flow.Identity) ([]*libp2
 			metrics,
 			libp2p.DefaultMaxUnicastMsgSize,
 			libp2p.DefaultMaxPubSubMsgSize,
+			libp2p.DefaultPeerLimits(),
 			rootBlockID)
 		if err != nil {
 			return nil, err
@@ -61,6 +61,7 @@ func (n *TopologyTestSuite) SetupTest() {
 		metrics,
 		libp2p.DefaultMaxUnicastMsgSize,
 		libp2p.DefaultMaxPubSubMsgSize,
+		libp2p.DefaultPeerLimits(),
 		unittest.IdentifierFixture().String())
 	require.NoError(n.Suite.T(), err)
 
@@ -0,0 +1,94 @@
+package validators
+
+import (
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/network/gossip/libp2p/message"
+)
+
+// reasons reported to the metrics when a message is rejected by the SignatureValidator
+const (
+	RejectUnknownOrigin    = "unknown_origin"
+	RejectMissingSignature = "missing_signature"
+	RejectInvalidSignature = "invalid_signature"
+)
+
+var _ MessageValidator = &SignatureValidator{}
+
+// IdentityFunc looks up the identity of the given node in the current identity table. It returns false
+// if the node is not part of the identity table.
+type IdentityFunc func(nodeID flow.Identifier) (flow.Identity, bool)
+
+// SignatureValidator authenticates the origin of messages by verifying the origin signature of each
+// message against the networking key of the origin in the identity table. As the signature is created
+// by the origin itself, it remains verifiable when the message is relayed by other nodes.
+type SignatureValidator struct {
+	log      zerolog.Logger
+	identity IdentityFunc
+	metrics  module.NetworkMetrics
+}
+
+// NewSignatureValidator returns a new SignatureValidator looking up origin identities with the given function
+func NewSignatureValidator(log zerolog.Logger, identity IdentityFunc, metrics module.NetworkMetrics) *SignatureValidator {
+	sv := &SignatureValidator{
+		log:      log,
+		identity: identity,
+		metrics:  metrics,
+	}
+	return sv
+}
+
+// Validate returns true if the message carries a valid signature of its origin and false otherwise.
+func (sv *SignatureValidator) Validate(msg message.Message) bool {
+	log := sv.log.With().
+		Hex("origin_id", msg.OriginID).
+		Hex("event_id", msg.EventID).
+		Str("channel", msg.ChannelID).
+		Logger()
+
+	if len(msg.OriginID) != len(flow.ZeroID) {
+		log.Warn().Msg("rejecting message with malformed origin")
+		sv.metrics.NetworkMessageRejected(msg.ChannelID, RejectUnknownOrigin)
+		return false
+	}
+
+	origin, found := sv.identity(flow.HashToID(msg.OriginID))
+	if !found || origin.NetworkPubKey == nil {
+		log.Warn().Msg("rejecting message from unknown origin")
+		sv.metrics.NetworkMessageRejected(msg.ChannelID, RejectUnknownOrigin)
+		return false
+	}
+
+	if len(msg.Signature) == 0 {
+		log.Warn().Msg("rejecting unsigned message")
+		sv.metrics.NetworkMessageRejected(msg.ChannelID, RejectMissingSignature)
+		return false
+	}
+
+	valid, err := verify(origin.NetworkPubKey, &msg)
+	if err != nil {
+		log.Warn().Err(err).Msg("rejecting message with unverifiable signature")
+		sv.metrics.NetworkMessageRejected(msg.ChannelID, RejectInvalidSignature)
+		return false
+	}
+	if !valid {
+		log.Warn().Msg("rejecting message with invalid signature")
+		sv.metrics.NetworkMessageRejected(msg.ChannelID, RejectInvalidSignature)
+		return false
+	}
+
+	return true
+}
+
+// verify checks the origin signature of the message against the given networking key
+func verify(key crypto.PublicKey, msg *message.Message) (bool, error) {
+	data, err := message.SignatureData(msg)
+	if err != nil {
+		return false, err
+	}
+
+	return key.Verify(msg.Signature, data, message.NewSignatureHasher())
+}
@@ -0,0 +1,107 @@
+package validators
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/mock"
+	"github.com/onflow/flow-go/network/gossip/libp2p/message"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestSignatureValidator(t *testing.T) {
+
+	originKey := networkingKey(t, 1)
+	origin := unittest.IdentityFixture()
+	origin.NetworkPubKey = originKey.PublicKey()
+
+	identities := map[flow.Identifier]flow.Identity{origin.NodeID: *origin}
+	identity := func(nodeID flow.Identifier) (flow.Identity, bool) {
+		id, found := identities[nodeID]
+		return id, found
+	}
+
+	// signedMessage creates a message from the origin signed with the given key
+	signedMessage := func(key crypto.PrivateKey) *message.Message {
+		target := unittest.IdentifierFixture()
+		msg := &message.Message{
+			ChannelID: "test-channel",
+			EventID:   []byte("1"),
+			OriginID:  origin.NodeID[:],
+			TargetIDs: [][]byte{target[:]},
+			Payload:   []byte("hello"),
+		}
+		data, err := message.SignatureData(msg)
+		require.NoError(t, err)
+		msg.Signature, err = key.Sign(data, message.NewSignatureHasher())
+		require.NoError(t, err)
+		return msg
+	}
+
+	// validate runs the validator on the message and checks the rejection reason reported to the metrics
+	validate := func(t *testing.T, msg *message.Message, reason string) bool {
+		metrics := &mock.NetworkMetrics{}
+		if reason != "" {
+			metrics.On("NetworkMessageRejected", msg.ChannelID, reason).Once()
+		}
+		sv := NewSignatureValidator(zerolog.Nop(), identity, metrics)
+		valid := sv.Validate(*msg)
+		metrics.AssertExpectations(t)
+		return valid
+	}
+
+	t.Run("valid signature", func(t *testing.T) {
+		msg := signedMessage(originKey)
+		assert.True(t, validate(t, msg, ""))
+	})
+
+	t.Run("unknown origin", func(t *testing.T) {
+		msg := signedMessage(originKey)
+		unknown := unittest.IdentifierFixture()
+		msg.OriginID = unknown[:]
+		assert.False(t, validate(t, msg, RejectUnknownOrigin))
+	})
+
+	t.Run("malformed origin", func(t *testing.T) {
+		msg := signedMessage(originKey)
+		msg.OriginID = msg.OriginID[:8]
+		assert.False(t, validate(t, msg, RejectUnknownOrigin))
+	})
+
+	t.Run("missing signature", func(t *testing.T) {
+		msg := signedMessage(originKey)
+		msg.Signature = nil
+		assert.False(t, validate(t, msg, RejectMissingSignature))
+	})
+
+	t.Run("signed by another key", func(t *testing.T) {
+		msg := signedMessage(networkingKey(t, 2))
+		assert.False(t, validate(t, msg, RejectInvalidSignature))
+	})
+
+	t.Run("tampered payload", func(t *testing.T) {
+		msg := signedMessage(originKey)
+		msg.Payload = []byte("tampered")
+		assert.False(t, validate(t, msg, RejectInvalidSignature))
+	})
+
+	t.Run("tampered targets", func(t *testing.T) {
+		msg := signedMessage(originKey)
+		target := unittest.IdentifierFixture()
+		msg.TargetIDs = append(msg.TargetIDs, target[:])
+		assert.False(t, validate(t, msg, RejectInvalidSignature))
+	})
+}
+
+func networkingKey(t *testing.T, seed byte) crypto.PrivateKey {
+	s := make([]byte, crypto.KeyGenSeedMinLenECDSASecp256k1)
+	s[0] = seed
+	key, err := crypto.GeneratePrivateKey(crypto.ECDSASecp256k1, s)
+	require.NoError(t, err)
+	return key
+}
@@ -73,11 +73,6 @@ func (m *Mutator) Bootstrap(genesis *cluster.Block) error {
 		if err != nil {
 			return fmt.Errorf("could not insert started view: %w", err)
 		}
-		// insert voted view for hotstuff
-		err = operation.InsertVotedView(chainID, genesis.Header.View)(tx)
-		if err != nil {
-			return fmt.Errorf("could not insert started view: %w", err)
-		}
 
 		return nil
 	})
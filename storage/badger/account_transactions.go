@@ -0,0 +1,70 @@
+package badger
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// AccountTransactions implements the access node account transaction index on top of badger.
+type AccountTransactions struct {
+	db *badger.DB
+}
+
+func NewAccountTransactions(db *badger.DB) *AccountTransactions {
+	return &AccountTransactions{
+		db: db,
+	}
+}
+
+// Index indexes the given transactions, included by the block at the given height, by their payer,
+// proposer and authorizers. Transactions that are already indexed are skipped.
+func (a *AccountTransactions) Index(height uint64, txs []*flow.TransactionBody) error {
+	return operation.RetryOnConflict(a.db.Update, func(tx *badger.Txn) error {
+		for _, body := range txs {
+			txID := body.ID()
+			for _, address := range accounts(body) {
+				err := operation.IndexAccountTransaction(address, height, txID)(tx)
+				if errors.Is(err, storage.ErrAlreadyExists) {
+					continue
+				}
+				if err != nil {
+					return fmt.Errorf("could not index transaction %x for account %s: %w", txID, address, err)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// ByAddress returns a page of the transactions involving the given account that were included by
+// blocks within the given height range (inclusive), ordered by height.
+func (a *AccountTransactions) ByAddress(address flow.Address, startHeight, endHeight uint64, offset, limit uint) ([]storage.AccountTransaction, error) {
+
+	var txs []storage.AccountTransaction
+	err := a.db.View(operation.LookupAccountTransactions(address, startHeight, endHeight, offset, limit, &txs))
+	if err != nil {
+		return nil, fmt.Errorf("could not look up account transactions: %w", err)
+	}
+
+	return txs, nil
+}
+
+// accounts returns the distinct addresses of the payer, proposer and authorizers of the transaction.
+func accounts(tx *flow.TransactionBody) []flow.Address {
+	addresses := make([]flow.Address, 0, len(tx.Authorizers)+2)
+	seen := make(map[flow.Address]struct{}, len(tx.Authorizers)+2)
+	for _, address := range append([]flow.Address{tx.Payer, tx.ProposalKey.Address}, tx.Authorizers...) {
+		if _, ok := seen[address]; ok {
+			continue
+		}
+		seen[address] = struct{}{}
+		addresses = append(addresses, address)
+	}
+	return addresses
+}
@@ -0,0 +1,76 @@
+package badger_test
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestAccountTransactions(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		index := bstorage.NewAccountTransactions(db)
+
+		payer := flow.HexToAddress("01")
+		proposer := flow.HexToAddress("02")
+		authorizer := flow.HexToAddress("03")
+
+		// the payer pays for a transaction at every height, and is its own proposer at even heights
+		txIDs := map[uint64]flow.Identifier{}
+		for height := uint64(10); height <= 14; height++ {
+			tx := unittest.TransactionBodyFixture(func(tx *flow.TransactionBody) {
+				tx.Payer = payer
+				tx.ProposalKey.Address = proposer
+				if height%2 == 0 {
+					tx.ProposalKey.Address = payer
+				}
+				tx.Authorizers = []flow.Address{authorizer, payer}
+			})
+			txIDs[height] = tx.ID()
+
+			err := index.Index(height, []*flow.TransactionBody{&tx})
+			require.NoError(t, err)
+
+			// indexing the same transaction again is a no-op
+			err = index.Index(height, []*flow.TransactionBody{&tx})
+			require.NoError(t, err)
+		}
+
+		// each transaction is returned once per account, no matter how many roles the account has
+		txs, err := index.ByAddress(payer, 11, 13, 0, 0)
+		require.NoError(t, err)
+		require.Len(t, txs, 3)
+		for i, tx := range txs {
+			height := uint64(11 + i)
+			assert.Equal(t, height, tx.Height)
+			assert.Equal(t, txIDs[height], tx.TransactionID)
+		}
+
+		txs, err = index.ByAddress(proposer, 10, 14, 0, 0)
+		require.NoError(t, err)
+		require.Len(t, txs, 2)
+		assert.Equal(t, txIDs[11], txs[0].TransactionID)
+		assert.Equal(t, txIDs[13], txs[1].TransactionID)
+
+		// pages are taken in order of height
+		txs, err = index.ByAddress(authorizer, 10, 14, 1, 2)
+		require.NoError(t, err)
+		require.Len(t, txs, 2)
+		assert.Equal(t, txIDs[11], txs[0].TransactionID)
+		assert.Equal(t, txIDs[12], txs[1].TransactionID)
+
+		txs, err = index.ByAddress(authorizer, 10, 14, 4, 2)
+		require.NoError(t, err)
+		require.Len(t, txs, 1)
+		assert.Equal(t, txIDs[14], txs[0].TransactionID)
+
+		txs, err = index.ByAddress(flow.HexToAddress("04"), 10, 14, 0, 0)
+		require.NoError(t, err)
+		assert.Empty(t, txs)
+	})
+}
@@ -0,0 +1,65 @@
+package badger
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/dkg"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// DKGResults implements persistent storage for the results of distributed key generations on top of badger.
+type DKGResults struct {
+	db *badger.DB
+}
+
+func NewDKGResults(db *badger.DB) *DKGResults {
+	return &DKGResults{
+		db: db,
+	}
+}
+
+// Store stores the result of the DKG of an epoch, unless there is a result for the epoch
+// already, in which case storage.ErrAlreadyExists is returned.
+func (d *DKGResults) Store(result *dkg.Result) error {
+	err := operation.RetryOnConflict(d.db.Update, operation.InsertDKGResult(result))
+	if err != nil {
+		return fmt.Errorf("could not insert dkg result: %w", err)
+	}
+	return nil
+}
+
+// MarkStarted records that this node started the DKG of the epoch with the given counter.
+func (d *DKGResults) MarkStarted(counter uint64) error {
+	err := operation.RetryOnConflict(d.db.Update, operation.InsertDKGStarted(counter))
+	if err != nil {
+		return fmt.Errorf("could not insert dkg started flag: %w", err)
+	}
+	return nil
+}
+
+// Started returns whether this node started the DKG of the epoch with the given counter.
+func (d *DKGResults) Started(counter uint64) (bool, error) {
+	var started bool
+	err := d.db.View(operation.RetrieveDKGStarted(counter, &started))
+	if errors.Is(err, storage.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("could not retrieve dkg started flag: %w", err)
+	}
+	return started, nil
+}
+
+// ByEpochCounter returns the result of the DKG of the epoch with the given counter.
+func (d *DKGResults) ByEpochCounter(counter uint64) (*dkg.Result, error) {
+	var result dkg.Result
+	err := d.db.View(operation.RetrieveDKGResult(counter, &result))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve dkg result: %w", err)
+	}
+	return &result, nil
+}
@@ -0,0 +1,90 @@
+// +build relic
+
+package badger_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/dkg"
+	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestDKGResults(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store := bstorage.NewDKGResults(db)
+
+		_, err := store.ByEpochCounter(1)
+		require.True(t, errors.Is(err, storage.ErrNotFound))
+
+		participants := unittest.IdentifierListFixture(3)
+		keys := make([]crypto.PrivateKey, 0, len(participants))
+		shares := make([]crypto.PublicKey, 0, len(participants))
+		for range participants {
+			key := unittest.KeyFixture(crypto.BLSBLS12381)
+			keys = append(keys, key)
+			shares = append(shares, key.PublicKey())
+		}
+		result := &dkg.Result{
+			EpochCounter: 1,
+			Participants: participants,
+			Index:        1,
+			PrivKeyShare: keys[1],
+			GroupKey:     unittest.KeyFixture(crypto.BLSBLS12381).PublicKey(),
+			KeyShares:    shares,
+		}
+
+		err = store.Store(result)
+		require.NoError(t, err)
+
+		// there is only one result per epoch
+		err = store.Store(result)
+		require.True(t, errors.Is(err, storage.ErrAlreadyExists))
+
+		stored, err := store.ByEpochCounter(1)
+		require.NoError(t, err)
+		assert.Equal(t, result.EpochCounter, stored.EpochCounter)
+		assert.Equal(t, result.Participants, stored.Participants)
+		assert.Equal(t, result.Index, stored.Index)
+		assert.True(t, result.PrivKeyShare.Equals(stored.PrivKeyShare))
+		assert.True(t, result.GroupKey.Equals(stored.GroupKey))
+		require.Len(t, stored.KeyShares, len(shares))
+		for i, share := range shares {
+			assert.True(t, share.Equals(stored.KeyShares[i]))
+		}
+
+		lookup := stored.Lookup()
+		require.Len(t, lookup, len(participants))
+		assert.Equal(t, uint(2), lookup[participants[2]].Index)
+		assert.True(t, shares[2].Equals(lookup[participants[2]].KeyShare))
+	})
+}
+
+func TestDKGResults_Started(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store := bstorage.NewDKGResults(db)
+
+		started, err := store.Started(1)
+		require.NoError(t, err)
+		assert.False(t, started)
+
+		err = store.MarkStarted(1)
+		require.NoError(t, err)
+
+		started, err = store.Started(1)
+		require.NoError(t, err)
+		assert.True(t, started)
+
+		// the flag is kept per epoch
+		started, err = store.Started(2)
+		require.NoError(t, err)
+		assert.False(t, started)
+	})
+}
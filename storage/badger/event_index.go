@@ -0,0 +1,92 @@
+package badger
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// EventIndex implements the access node event index on top of badger.
+type EventIndex struct {
+	db *badger.DB
+}
+
+func NewEventIndex(db *badger.DB) *EventIndex {
+	return &EventIndex{
+		db: db,
+	}
+}
+
+// Store indexes the events emitted by the block at the given height by event type and transaction ID,
+// and upserts the last indexed height within the same transaction.
+func (e *EventIndex) Store(height uint64, events []flow.Event) error {
+	return operation.RetryOnConflict(e.db.Update, func(tx *badger.Txn) error {
+		for _, event := range events {
+			err := operation.IndexEventByType(height, event)(tx)
+			if err != nil {
+				return fmt.Errorf("could not index event by type: %w", err)
+			}
+			err = operation.IndexEventByTransaction(event)(tx)
+			if err != nil {
+				return fmt.Errorf("could not index event by transaction: %w", err)
+			}
+		}
+
+		// try to update
+		err := operation.UpdateLastIndexedEventHeight(height)(tx)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, storage.ErrNotFound) {
+			return fmt.Errorf("could not update last indexed height: %w", err)
+		}
+
+		// if key does not exist, try insert
+		err = operation.InsertLastIndexedEventHeight(height)(tx)
+		if err != nil {
+			return fmt.Errorf("could not insert last indexed height: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// ByHeightRangeEventType returns the events of the given type emitted by blocks within the given
+// height range (inclusive), keyed by block height. Heights without matching events are omitted.
+func (e *EventIndex) ByHeightRangeEventType(startHeight, endHeight uint64, eventType flow.EventType) (map[uint64][]flow.Event, error) {
+
+	events := make(map[uint64][]flow.Event)
+	err := e.db.View(operation.LookupIndexedEventsByType(eventType, startHeight, endHeight, events))
+	if err != nil {
+		return nil, handleError(err, flow.Event{})
+	}
+
+	return events, nil
+}
+
+// ByTransactionID returns the events emitted by the given transaction
+func (e *EventIndex) ByTransactionID(txID flow.Identifier) ([]flow.Event, error) {
+
+	var events []flow.Event
+	err := e.db.View(operation.LookupIndexedEventsByTransaction(txID, &events))
+	if err != nil {
+		return nil, handleError(err, flow.Event{})
+	}
+
+	return events, nil
+}
+
+// LastIndexedHeight returns the height of the last block for which events were indexed
+func (e *EventIndex) LastIndexedHeight() (uint64, error) {
+	var height uint64
+	err := e.db.View(operation.RetrieveLastIndexedEventHeight(&height))
+	if err != nil {
+		return 0, fmt.Errorf("could not retrieve last indexed height: %w", err)
+	}
+	return height, nil
+}
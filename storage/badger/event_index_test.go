@@ -0,0 +1,73 @@
+package badger_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestEventIndex(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		index := bstorage.NewEventIndex(db)
+
+		// nothing is indexed yet
+		_, err := index.LastIndexedHeight()
+		require.True(t, errors.Is(err, storage.ErrNotFound))
+
+		// the event type is a prefix of the other type, to make sure the types are told apart
+		created := flow.EventType("A.0x1.Token.Created")
+		createdAll := flow.EventType("A.0x1.Token.CreatedAll")
+
+		txIDs := map[uint64]flow.Identifier{}
+		for height := uint64(10); height <= 14; height++ {
+			txIDs[height] = unittest.IdentifierFixture()
+			events := []flow.Event{
+				unittest.EventFixture(created, 0, 0, txIDs[height]),
+				unittest.EventFixture(createdAll, 0, 1, txIDs[height]),
+			}
+			// only even heights emit a second event of the queried type
+			if height%2 == 0 {
+				events = append(events, unittest.EventFixture(created, 0, 2, txIDs[height]))
+			}
+
+			err := index.Store(height, events)
+			require.NoError(t, err)
+
+			last, err := index.LastIndexedHeight()
+			require.NoError(t, err)
+			assert.Equal(t, height, last)
+		}
+
+		byHeight, err := index.ByHeightRangeEventType(11, 13, created)
+		require.NoError(t, err)
+		require.Len(t, byHeight, 3)
+		assert.Len(t, byHeight[11], 1)
+		assert.Len(t, byHeight[12], 2)
+		assert.Len(t, byHeight[13], 1)
+		for height, events := range byHeight {
+			for _, event := range events {
+				assert.Equal(t, created, event.Type)
+				assert.Equal(t, txIDs[height], event.TransactionID)
+			}
+		}
+
+		byHeight, err = index.ByHeightRangeEventType(20, 30, created)
+		require.NoError(t, err)
+		assert.Empty(t, byHeight)
+
+		byTx, err := index.ByTransactionID(txIDs[12])
+		require.NoError(t, err)
+		require.Len(t, byTx, 3)
+		for i, event := range byTx {
+			assert.Equal(t, uint32(i), event.EventIndex)
+		}
+	})
+}
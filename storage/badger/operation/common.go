@@ -142,9 +142,14 @@ type createFunc func() interface{}
 
 // handleFunc is a function that starts the processing of the current key-value
 // pair during a badger iteration. It should be called after the key was checked
-// and the entity was decoded.
+// and the entity was decoded. During an iterate, it can return errStopIteration
+// to end the iteration early without failing it.
 type handleFunc func() error
 
+// errStopIteration is returned by a handle function to stop the iteration after
+// the current key-value pair, once all entities of interest were processed.
+var errStopIteration = errors.New("stop iteration")
+
 // iterationFunc is a function provided to our low-level iteration function that
 // allows us to pass badger efficiencies across badger boundaries. By calling it
 // for each iteration step, we can inject a function to check the key, a
@@ -278,6 +283,9 @@ func iterate(start []byte, end []byte, iteration iterationFunc) func(*badger.Txn
 
 				return nil
 			})
+			if errors.Is(err, errStopIteration) {
+				break
+			}
 			if err != nil {
 				return fmt.Errorf("could not process value: %w", err)
 			}
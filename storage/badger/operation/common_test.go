@@ -301,6 +301,50 @@ func TestIterate(t *testing.T) {
 	})
 }
 
+func TestIterateStop(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		keys := [][]byte{{0x10}, {0x11}, {0x12}, {0x13}}
+
+		_ = db.Update(func(tx *badger.Txn) error {
+			for i, key := range keys {
+				enc, err := msgpack.Marshal(i)
+				require.NoError(t, err)
+				err = tx.Set(key, enc)
+				require.NoError(t, err)
+			}
+			return nil
+		})
+
+		// stop the iteration once the second value was handled
+		var checked []int
+		var actual []int
+		iterationFunc := func() (checkFunc, createFunc, handleFunc) {
+			check := func(key []byte) bool {
+				checked = append(checked, int(key[0]))
+				return true
+			}
+			var val int
+			create := func() interface{} {
+				return &val
+			}
+			handle := func() error {
+				actual = append(actual, val)
+				if len(actual) == 2 {
+					return errStopIteration
+				}
+				return nil
+			}
+			return check, create, handle
+		}
+
+		err := db.View(iterate(keys[0], keys[3], iterationFunc))
+		require.NoError(t, err)
+
+		assert.Equal(t, []int{0, 1}, actual)
+		assert.Equal(t, []int{0x10, 0x11}, checked)
+	})
+}
+
 func TestTraverse(t *testing.T) {
 	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
 		keys := [][]byte{{0x42, 0x00}, {0xff}, {0x42, 0x56}, {0x00}, {0x42, 0xff}}
@@ -0,0 +1,27 @@
+package operation
+
+import (
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/dkg"
+)
+
+// InsertDKGResult inserts the result of the DKG of an epoch, keyed by the epoch counter.
+func InsertDKGResult(result *dkg.Result) func(*badger.Txn) error {
+	return insert(makePrefix(codeDKGResult, result.EpochCounter), result)
+}
+
+// InsertDKGStarted inserts the flag that this node started the DKG of the epoch with the given counter.
+func InsertDKGStarted(counter uint64) func(*badger.Txn) error {
+	return insert(makePrefix(codeDKGStarted, counter), true)
+}
+
+// RetrieveDKGStarted retrieves the flag that this node started the DKG of the epoch with the given counter.
+func RetrieveDKGStarted(counter uint64, started *bool) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeDKGStarted, counter), started)
+}
+
+// RetrieveDKGResult retrieves the result of the DKG of the epoch with the given counter.
+func RetrieveDKGResult(counter uint64, result *dkg.Result) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeDKGResult, counter), result)
+}
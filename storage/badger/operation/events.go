@@ -3,6 +3,8 @@
 package operation
 
 import (
+	"encoding/binary"
+
 	"github.com/dgraph-io/badger/v2"
 
 	"github.com/onflow/flow-go/model/flow"
@@ -65,3 +67,44 @@ func eventFilterIterationFunc(events *[]flow.Event, eventType flow.EventType) fu
 		return check, create, handle
 	}
 }
+
+// IndexEventByType indexes the given event by its type and the height of the block that emitted it.
+// Event types are of variable length, so the key uses the ID of the event type instead.
+func IndexEventByType(height uint64, event flow.Event) func(*badger.Txn) error {
+	return insert(makePrefix(codeIndexedEventByType, flow.MakeID(event.Type), height, event.TransactionIndex, event.EventIndex), event)
+}
+
+// IndexEventByTransaction indexes the given event by the ID of the transaction that emitted it.
+func IndexEventByTransaction(event flow.Event) func(*badger.Txn) error {
+	return insert(makePrefix(codeIndexedEventByTransaction, event.TransactionID, event.EventIndex), event)
+}
+
+// LookupIndexedEventsByType retrieves all indexed events of the given type emitted by blocks within
+// the given height range (inclusive), keyed by block height.
+func LookupIndexedEventsByType(eventType flow.EventType, startHeight, endHeight uint64, events map[uint64][]flow.Event) func(*badger.Txn) error {
+	typeID := flow.MakeID(eventType)
+	start := makePrefix(codeIndexedEventByType, typeID, startHeight)
+	end := makePrefix(codeIndexedEventByType, typeID, endHeight)
+	return iterate(start, end, func() (checkFunc, createFunc, handleFunc) {
+		var height uint64
+		check := func(key []byte) bool {
+			// the height follows the one byte code and the event type ID
+			height = binary.BigEndian.Uint64(key[1+len(typeID):])
+			return true
+		}
+		var val flow.Event
+		create := func() interface{} {
+			return &val
+		}
+		handle := func() error {
+			events[height] = append(events[height], val)
+			return nil
+		}
+		return check, create, handle
+	})
+}
+
+// LookupIndexedEventsByTransaction retrieves all indexed events emitted by the given transaction.
+func LookupIndexedEventsByTransaction(txID flow.Identifier, events *[]flow.Event) func(*badger.Txn) error {
+	return traverse(makePrefix(codeIndexedEventByTransaction, txID), eventIterationFunc(events))
+}
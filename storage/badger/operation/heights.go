@@ -49,3 +49,15 @@ func UpdateLastCompleteBlockHeight(height uint64) func(*badger.Txn) error {
 func RetrieveLastCompleteBlockHeight(height *uint64) func(*badger.Txn) error {
 	return retrieve(makePrefix(codeLastCompleteBlockHeight), height)
 }
+
+func InsertLastIndexedEventHeight(height uint64) func(*badger.Txn) error {
+	return insert(makePrefix(codeLastIndexedEventHeight), height)
+}
+
+func UpdateLastIndexedEventHeight(height uint64) func(*badger.Txn) error {
+	return update(makePrefix(codeLastIndexedEventHeight), height)
+}
+
+func RetrieveLastIndexedEventHeight(height *uint64) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeLastIndexedEventHeight), height)
+}
@@ -16,7 +16,11 @@ const (
 
 	// codes for views with special meaning
 	codeStartedView = 10 // latest view hotstuff started
-	codeVotedView   = 11 // latest view hotstuff voted on
+
+	// codes for the hotstuff safety state
+	codeLegacyVotedView = 11 // latest view hotstuff voted on, superseded by the safety data
+	codeSafetyData      = 12 // latest hotstuff safety data
+	codeCollectedVote   = 13 // votes collected by hotstuff, keyed by view and voter
 
 	// code for heights with special meaning
 	codeFinalizedHeight         = 20 // latest finalized block height
@@ -25,6 +29,7 @@ const (
 	codeExecutedBlock           = 23 // latest executed block with max height
 	codeRootHeight              = 24 // the height of the first loaded block
 	codeLastCompleteBlockHeight = 25 // the height of the last block for which all collections were received
+	codeLastIndexedEventHeight  = 26 // the height of the last block for which events were indexed
 
 	// codes for single entity storage
 	// 31 was used for identities before epochs
@@ -41,6 +46,7 @@ const (
 	codeBlockToSeal         = 41 // index mapping a block its last payload seal
 	codeCollectionReference = 42 // index reference block ID for collection
 	codeBlockValidity       = 43 // validity of block per HotStuff
+	codeSealedBlockToSeal   = 44 // index mapping a sealed block to the seal that sealed it
 
 	// codes for indexing multiple identifiers by identifier
 	// NOTE: 51 was used for identity indexes before epochs
@@ -50,10 +56,23 @@ const (
 	codeCollectionBlock       = 54 // index mapping collection ID to block ID
 	codeBlockExecutionReceipt = 55 // index mapping block ID to execution receipt ID
 	codeBlockEpochStatus      = 56 // index mapping block ID to epoch status
+	codeAllBlockReceipts      = 57 // index mapping block ID to the execution receipts of all executors
 
 	// codes related to epoch information
 	codeEpochSetup  = 60 // EpochSetup service event, keyed by ID
 	codeEpochCommit = 61 // EpochCommit service event, keyed by ID
+	codeDKGResult   = 62 // result of the DKG this node participated in, keyed by epoch counter
+	codeDKGStarted  = 63 // flag that this node started the DKG of an epoch, keyed by epoch counter
+
+	// codes for the access node event index
+	codeIndexedEventByType        = 70 // index mapping event type and block height to events
+	codeIndexedEventByTransaction = 71 // index mapping transaction ID to events
+
+	// codes for the access node account transaction index
+	codeAccountTransaction = 72 // index mapping account address and block height to transactions
+
+	// codes for evidence of slashable offences
+	codeSlashingEvidence = 80 // evidence keyed by offender, view and kind of offence
 
 	// legacy codes (should be cleaned up)
 	codeChunkDataPack                = 100
@@ -94,6 +113,8 @@ func b(v interface{}) []byte {
 		return []byte{byte(i)}
 	case flow.Identifier:
 		return i[:]
+	case flow.Address:
+		return i[:]
 	case flow.ChainID:
 		return []byte(i)
 	default:
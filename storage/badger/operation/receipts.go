@@ -25,3 +25,13 @@ func IndexExecutionReceipt(blockID flow.Identifier, receiptID flow.Identifier) f
 func LookupExecutionReceipt(blockID flow.Identifier, receiptID *flow.Identifier) func(*badger.Txn) error {
 	return retrieve(makePrefix(codeBlockExecutionReceipt, blockID), receiptID)
 }
+
+// IndexExecutionReceipts adds an execution receipt ID to the receipts of all executors of the block.
+func IndexExecutionReceipts(blockID flow.Identifier, receiptID flow.Identifier) func(*badger.Txn) error {
+	return insert(makePrefix(codeAllBlockReceipts, blockID, receiptID), receiptID)
+}
+
+// LookupExecutionReceipts finds the execution receipt IDs of all executors of the block.
+func LookupExecutionReceipts(blockID flow.Identifier, receiptIDs *[]flow.Identifier) func(*badger.Txn) error {
+	return traverse(makePrefix(codeAllBlockReceipts, blockID), lookup(receiptIDs))
+}
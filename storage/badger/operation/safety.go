@@ -0,0 +1,90 @@
+package operation
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// InsertSafetyData inserts the hotstuff safety data into the database.
+func InsertSafetyData(chainID flow.ChainID, safetyData *model.SafetyData) func(*badger.Txn) error {
+	return insert(makePrefix(codeSafetyData, chainID), safetyData)
+}
+
+// UpdateSafetyData updates the hotstuff safety data in the database.
+func UpdateSafetyData(chainID flow.ChainID, safetyData *model.SafetyData) func(*badger.Txn) error {
+	return update(makePrefix(codeSafetyData, chainID), safetyData)
+}
+
+// RetrieveSafetyData retrieves the hotstuff safety data from the database.
+func RetrieveSafetyData(chainID flow.ChainID, safetyData *model.SafetyData) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeSafetyData, chainID), safetyData)
+}
+
+// InsertCollectedVote inserts a vote collected by hotstuff, keyed by the view and the voter.
+func InsertCollectedVote(chainID flow.ChainID, vote *model.Vote) func(*badger.Txn) error {
+	return insert(makePrefix(codeCollectedVote, chainID, vote.View, vote.SignerID), vote)
+}
+
+// LookupCollectedVotes retrieves the collected votes for the given view and above, ordered by view.
+func LookupCollectedVotes(chainID flow.ChainID, view uint64, votes *[]*model.Vote) func(*badger.Txn) error {
+	start := makePrefix(codeCollectedVote, chainID, view)
+	end := makePrefix(codeCollectedVote, chainID, uint64(math.MaxUint64))
+	return iterate(start, end, collectedVoteIterationFunc(votes))
+}
+
+// PruneCollectedVotes removes the collected votes for all views below the given view.
+func PruneCollectedVotes(chainID flow.ChainID, view uint64) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		if view == 0 {
+			return nil
+		}
+
+		// collect the keys first, as we can't delete while iterating
+		var keys [][]byte
+		start := makePrefix(codeCollectedVote, chainID, uint64(0))
+		end := makePrefix(codeCollectedVote, chainID, view-1)
+		err := iterate(start, end, func() (checkFunc, createFunc, handleFunc) {
+			check := func(key []byte) bool {
+				keys = append(keys, append([]byte(nil), key...))
+				return false
+			}
+			return check, nil, nil
+		})(tx)
+		if err != nil {
+			return fmt.Errorf("could not find votes to prune: %w", err)
+		}
+
+		for _, key := range keys {
+			err := tx.Delete(key)
+			if err != nil {
+				return fmt.Errorf("could not delete vote: %w", err)
+			}
+		}
+
+		return nil
+	}
+}
+
+// collectedVoteIterationFunc returns an iteration function which returns all votes found during traversal
+func collectedVoteIterationFunc(votes *[]*model.Vote) func() (checkFunc, createFunc, handleFunc) {
+	*votes = make([]*model.Vote, 0)
+	return func() (checkFunc, createFunc, handleFunc) {
+		check := func(key []byte) bool {
+			return true
+		}
+		var val model.Vote
+		create := func() interface{} {
+			return &val
+		}
+		handle := func() error {
+			*votes = append(*votes, &val)
+			return nil
+		}
+		return check, create, handle
+	}
+}
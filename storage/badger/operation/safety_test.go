@@ -0,0 +1,105 @@
+package operation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestSafetyDataInsertUpdateRetrieve(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		chainID := flow.ChainID("chain")
+
+		var actual model.SafetyData
+		err := db.View(RetrieveSafetyData(chainID, &actual))
+		require.True(t, errors.Is(err, storage.ErrNotFound))
+
+		expected := &model.SafetyData{
+			LockedQC:  unittest.QuorumCertificateFixture(),
+			HighestQC: unittest.QuorumCertificateFixture(),
+		}
+		err = db.Update(InsertSafetyData(chainID, expected))
+		require.NoError(t, err)
+
+		err = db.View(RetrieveSafetyData(chainID, &actual))
+		require.NoError(t, err)
+		assert.Equal(t, expected, &actual)
+
+		expected.LastVote = unittest.VoteFixture()
+		err = db.Update(UpdateSafetyData(chainID, expected))
+		require.NoError(t, err)
+
+		actual = model.SafetyData{}
+		err = db.View(RetrieveSafetyData(chainID, &actual))
+		require.NoError(t, err)
+		assert.Equal(t, expected, &actual)
+
+		// safety data is kept separately for each chain
+		err = db.View(RetrieveSafetyData(flow.ChainID("other"), &actual))
+		require.True(t, errors.Is(err, storage.ErrNotFound))
+	})
+}
+
+func TestCollectedVotesInsertLookupPrune(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		chainID := flow.ChainID("chain")
+
+		votes := make([]*model.Vote, 0, 6)
+		for view := uint64(1); view <= 3; view++ {
+			for i := 0; i < 2; i++ {
+				vote := unittest.VoteFixture()
+				vote.View = view
+				votes = append(votes, vote)
+				err := db.Update(InsertCollectedVote(chainID, vote))
+				require.NoError(t, err)
+			}
+		}
+
+		// only the first vote of a replica for a view is kept
+		err := db.Update(InsertCollectedVote(chainID, votes[0]))
+		require.True(t, errors.Is(err, storage.ErrAlreadyExists))
+
+		var actual []*model.Vote
+		err = db.View(LookupCollectedVotes(chainID, 2, &actual))
+		require.NoError(t, err)
+		assert.ElementsMatch(t, votes[2:], actual)
+
+		err = db.Update(PruneCollectedVotes(chainID, 3))
+		require.NoError(t, err)
+
+		err = db.View(LookupCollectedVotes(chainID, 0, &actual))
+		require.NoError(t, err)
+		assert.ElementsMatch(t, votes[4:], actual)
+	})
+}
+
+func TestLegacyVotedViewRetrieveRemove(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		chainID := flow.ChainID("chain")
+
+		var actual uint64
+		err := db.View(RetrieveLegacyVotedView(chainID, &actual))
+		require.True(t, errors.Is(err, storage.ErrNotFound))
+
+		err = db.Update(InsertLegacyVotedView(chainID, 1337))
+		require.NoError(t, err)
+
+		err = db.View(RetrieveLegacyVotedView(chainID, &actual))
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1337), actual)
+
+		err = db.Update(RemoveLegacyVotedView(chainID))
+		require.NoError(t, err)
+
+		err = db.View(RetrieveLegacyVotedView(chainID, &actual))
+		require.True(t, errors.Is(err, storage.ErrNotFound))
+	})
+}
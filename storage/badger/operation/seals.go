@@ -29,3 +29,13 @@ func IndexBlockSeal(blockID flow.Identifier, sealID flow.Identifier) func(*badge
 func LookupBlockSeal(blockID flow.Identifier, sealID *flow.Identifier) func(*badger.Txn) error {
 	return retrieve(makePrefix(codeBlockToSeal, blockID), &sealID)
 }
+
+// IndexSealedBlockSeal indexes the seal by the ID of the block it seals.
+func IndexSealedBlockSeal(sealedID flow.Identifier, sealID flow.Identifier) func(*badger.Txn) error {
+	return insert(makePrefix(codeSealedBlockToSeal, sealedID), sealID)
+}
+
+// LookupSealedBlockSeal finds the seal that sealed the block.
+func LookupSealedBlockSeal(sealedID flow.Identifier, sealID *flow.Identifier) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeSealedBlockToSeal, sealedID), sealID)
+}
@@ -0,0 +1,41 @@
+package operation
+
+import (
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// InsertSlashingEvidence inserts the evidence keyed by the offender, the view and the kind of offence.
+func InsertSlashingEvidence(evidence *flow.SlashingEvidence) func(*badger.Txn) error {
+	return insert(makePrefix(codeSlashingEvidence, evidence.OffenderID, evidence.View, uint8(evidence.Offence)), evidence)
+}
+
+// LookupSlashingEvidence retrieves the evidence against the given offender, ordered by view.
+func LookupSlashingEvidence(offenderID flow.Identifier, evidence *[]*flow.SlashingEvidence) func(*badger.Txn) error {
+	return traverse(makePrefix(codeSlashingEvidence, offenderID), slashingEvidenceIterationFunc(evidence))
+}
+
+// LookupAllSlashingEvidence retrieves all evidence, ordered by offender and view.
+func LookupAllSlashingEvidence(evidence *[]*flow.SlashingEvidence) func(*badger.Txn) error {
+	return traverse(makePrefix(codeSlashingEvidence), slashingEvidenceIterationFunc(evidence))
+}
+
+// slashingEvidenceIterationFunc returns an iteration function which returns all evidence found during traversal
+func slashingEvidenceIterationFunc(evidence *[]*flow.SlashingEvidence) func() (checkFunc, createFunc, handleFunc) {
+	*evidence = make([]*flow.SlashingEvidence, 0)
+	return func() (checkFunc, createFunc, handleFunc) {
+		check := func(key []byte) bool {
+			return true
+		}
+		var val flow.SlashingEvidence
+		create := func() interface{} {
+			return &val
+		}
+		handle := func() error {
+			*evidence = append(*evidence, &val)
+			return nil
+		}
+		return check, create, handle
+	}
+}
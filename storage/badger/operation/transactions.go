@@ -4,6 +4,7 @@ import (
 	"github.com/dgraph-io/badger/v2"
 
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
 )
 
 // InsertTransaction inserts a transaction keyed by transaction fingerprint.
@@ -15,3 +16,42 @@ func InsertTransaction(txID flow.Identifier, tx *flow.TransactionBody) func(*bad
 func RetrieveTransaction(txID flow.Identifier, tx *flow.TransactionBody) func(*badger.Txn) error {
 	return retrieve(makePrefix(codeTransaction, txID), tx)
 }
+
+// IndexAccountTransaction indexes the transaction by the address of an account involved in it and the
+// height of the block that included it.
+func IndexAccountTransaction(address flow.Address, height uint64, txID flow.Identifier) func(*badger.Txn) error {
+	return insert(makePrefix(codeAccountTransaction, address, height, txID), storage.AccountTransaction{
+		Height:        height,
+		TransactionID: txID,
+	})
+}
+
+// LookupAccountTransactions retrieves the transactions involving the given account that were included
+// by blocks within the given height range (inclusive), ordered by height. The first offset transactions
+// are skipped and at most limit transactions are retrieved, a limit of 0 retrieves all of them.
+func LookupAccountTransactions(address flow.Address, startHeight, endHeight uint64, offset, limit uint, txs *[]storage.AccountTransaction) func(*badger.Txn) error {
+	start := makePrefix(codeAccountTransaction, address, startHeight)
+	end := makePrefix(codeAccountTransaction, address, endHeight)
+	*txs = make([]storage.AccountTransaction, 0)
+	var seen uint
+	return iterate(start, end, func() (checkFunc, createFunc, handleFunc) {
+		check := func(key []byte) bool {
+			// skip the values of all keys before the requested page
+			seen++
+			return seen > offset
+		}
+		var val storage.AccountTransaction
+		create := func() interface{} {
+			return &val
+		}
+		handle := func() error {
+			*txs = append(*txs, val)
+			// stop once the page is complete, rather than scanning up to the end height
+			if limit != 0 && uint(len(*txs)) == limit {
+				return errStopIteration
+			}
+			return nil
+		}
+		return check, create, handle
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -24,3 +25,43 @@ func TestTransactions(t *testing.T) {
 		assert.Equal(t, expected, actual)
 	})
 }
+
+func TestLookupAccountTransactions(t *testing.T) {
+
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		address := unittest.AddressFixture()
+		other := flow.HexToAddress("02")
+
+		// index one transaction of the account per height, and one of another account in between
+		var expected []storage.AccountTransaction
+		for height := uint64(1); height <= 10; height++ {
+			txID := unittest.IdentifierFixture()
+			err := db.Update(IndexAccountTransaction(address, height, txID))
+			require.NoError(t, err)
+			err = db.Update(IndexAccountTransaction(other, height, unittest.IdentifierFixture()))
+			require.NoError(t, err)
+			expected = append(expected, storage.AccountTransaction{Height: height, TransactionID: txID})
+		}
+
+		t.Run("all transactions within the height range", func(t *testing.T) {
+			var actual []storage.AccountTransaction
+			err := db.View(LookupAccountTransactions(address, 3, 5, 0, 0, &actual))
+			require.NoError(t, err)
+			assert.Equal(t, expected[2:5], actual)
+		})
+
+		t.Run("page within the height range", func(t *testing.T) {
+			var actual []storage.AccountTransaction
+			err := db.View(LookupAccountTransactions(address, 2, 10, 3, 2, &actual))
+			require.NoError(t, err)
+			assert.Equal(t, expected[4:6], actual)
+		})
+
+		t.Run("page beyond the height range", func(t *testing.T) {
+			var actual []storage.AccountTransaction
+			err := db.View(LookupAccountTransactions(address, 8, 10, 2, 5, &actual))
+			require.NoError(t, err)
+			assert.Equal(t, expected[9:], actual)
+		})
+	})
+}
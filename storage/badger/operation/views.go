@@ -21,17 +21,20 @@ func RetrieveStartedView(chainID flow.ChainID, view *uint64) func(*badger.Txn) e
 	return retrieve(makePrefix(codeStartedView, chainID), view)
 }
 
-// InsertVotedView inserts a view into the database.
-func InsertVotedView(chainID flow.ChainID, view uint64) func(*badger.Txn) error {
-	return insert(makePrefix(codeVotedView, chainID), view)
+// InsertLegacyVotedView inserts a voted view into the database, as nodes did before the voted view became
+// part of the safety data.
+func InsertLegacyVotedView(chainID flow.ChainID, view uint64) func(*badger.Txn) error {
+	return insert(makePrefix(codeLegacyVotedView, chainID), view)
 }
 
-// UpdateVotedView updates the view in the database.
-func UpdateVotedView(chainID flow.ChainID, view uint64) func(*badger.Txn) error {
-	return update(makePrefix(codeVotedView, chainID), view)
+// RetrieveLegacyVotedView retrieves the voted view a node persisted before the voted view became part of
+// the safety data.
+func RetrieveLegacyVotedView(chainID flow.ChainID, view *uint64) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeLegacyVotedView, chainID), view)
 }
 
-// RetrieveVotedView retrieves a view from the database.
-func RetrieveVotedView(chainID flow.ChainID, view *uint64) func(*badger.Txn) error {
-	return retrieve(makePrefix(codeVotedView, chainID), view)
+// RemoveLegacyVotedView removes the voted view a node persisted before the voted view became part of
+// the safety data.
+func RemoveLegacyVotedView(chainID flow.ChainID) func(*badger.Txn) error {
+	return remove(makePrefix(codeLegacyVotedView, chainID))
 }
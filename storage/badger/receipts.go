@@ -83,3 +83,29 @@ func (r *ExecutionReceipts) ByBlockID(blockID flow.Identifier) (*flow.ExecutionR
 	defer tx.Discard()
 	return r.byBlockID(blockID)(tx)
 }
+
+func (r *ExecutionReceipts) IndexAll(blockID, receiptID flow.Identifier) error {
+	return operation.RetryOnConflict(r.db.Update, operation.IndexExecutionReceipts(blockID, receiptID))
+}
+
+func (r *ExecutionReceipts) AllByBlockID(blockID flow.Identifier) ([]*flow.ExecutionReceipt, error) {
+	tx := r.db.NewTransaction(false)
+	defer tx.Discard()
+
+	var receiptIDs []flow.Identifier
+	err := operation.LookupExecutionReceipts(blockID, &receiptIDs)(tx)
+	if err != nil {
+		return nil, fmt.Errorf("could not look up receipt IDs: %w", err)
+	}
+
+	receipts := make([]*flow.ExecutionReceipt, 0, len(receiptIDs))
+	for _, receiptID := range receiptIDs {
+		receipt, err := r.byID(receiptID)(tx)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve receipt (%x): %w", receiptID, err)
+		}
+		receipts = append(receipts, receipt)
+	}
+
+	return receipts, nil
+}
@@ -0,0 +1,64 @@
+package badger_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestExecutionReceiptsOfAllExecutors(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store := bstorage.NewExecutionReceipts(db, bstorage.NewExecutionResults(db))
+
+		blockID := unittest.IdentifierFixture()
+		result := unittest.ExecutionResultFixture()
+		result.BlockID = blockID
+
+		// two executors committed to the same result
+		receipt1 := unittest.ExecutionReceiptFixture()
+		receipt1.ExecutionResult = *result
+		receipt2 := unittest.ExecutionReceiptFixture()
+		receipt2.ExecutionResult = *result
+
+		for _, receipt := range []*flow.ExecutionReceipt{receipt1, receipt2} {
+			require.NoError(t, store.Store(receipt))
+			require.NoError(t, store.IndexAll(blockID, receipt.ID()))
+		}
+
+		receipts, err := store.AllByBlockID(blockID)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []flow.Identifier{receipt1.ID(), receipt2.ID()}, flow.GetIDs(receipts))
+
+		// a block without receipts has none
+		receipts, err = store.AllByBlockID(unittest.IdentifierFixture())
+		require.NoError(t, err)
+		assert.Empty(t, receipts)
+	})
+}
+
+func TestSealsBySealedBlockID(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store := bstorage.NewSeals(metrics.NewNoopCollector(), db)
+
+		seal := unittest.SealFixture()
+		require.NoError(t, store.Store(seal))
+		require.NoError(t, store.IndexBySealedBlockID(seal))
+
+		actual, err := store.BySealedBlockID(seal.BlockID)
+		require.NoError(t, err)
+		assert.Equal(t, seal, actual)
+
+		// a block that was not sealed has no seal
+		_, err = store.BySealedBlockID(unittest.IdentifierFixture())
+		assert.True(t, errors.Is(err, storage.ErrNotFound))
+	})
+}
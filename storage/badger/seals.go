@@ -79,3 +79,16 @@ func (s *Seals) ByBlockID(blockID flow.Identifier) (*flow.Seal, error) {
 	}
 	return s.ByID(sealID)
 }
+
+func (s *Seals) IndexBySealedBlockID(seal *flow.Seal) error {
+	return operation.RetryOnConflict(s.db.Update, operation.IndexSealedBlockSeal(seal.BlockID, seal.ID()))
+}
+
+func (s *Seals) BySealedBlockID(sealedID flow.Identifier) (*flow.Seal, error) {
+	var sealID flow.Identifier
+	err := s.db.View(operation.LookupSealedBlockSeal(sealedID, &sealID))
+	if err != nil {
+		return nil, fmt.Errorf("could not look up seal of sealed block: %w", err)
+	}
+	return s.ByID(sealID)
+}
@@ -0,0 +1,51 @@
+package badger
+
+import (
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// SlashingEvidence implements persistent storage for evidence of slashable offences on top of badger.
+type SlashingEvidence struct {
+	db *badger.DB
+}
+
+func NewSlashingEvidence(db *badger.DB) *SlashingEvidence {
+	return &SlashingEvidence{
+		db: db,
+	}
+}
+
+// Store stores the evidence, unless there is evidence of the same offence by the offender at the
+// view already, in which case storage.ErrAlreadyExists is returned.
+func (s *SlashingEvidence) Store(evidence *flow.SlashingEvidence) error {
+	err := operation.RetryOnConflict(s.db.Update, operation.InsertSlashingEvidence(evidence))
+	if err != nil {
+		return fmt.Errorf("could not insert slashing evidence: %w", err)
+	}
+	return nil
+}
+
+// ByOffender returns the evidence against the given node, ordered by view.
+func (s *SlashingEvidence) ByOffender(offenderID flow.Identifier) ([]*flow.SlashingEvidence, error) {
+	var evidence []*flow.SlashingEvidence
+	err := s.db.View(operation.LookupSlashingEvidence(offenderID, &evidence))
+	if err != nil {
+		return nil, fmt.Errorf("could not look up slashing evidence: %w", err)
+	}
+	return evidence, nil
+}
+
+// All returns all evidence, ordered by offender and view.
+func (s *SlashingEvidence) All() ([]*flow.SlashingEvidence, error) {
+	var evidence []*flow.SlashingEvidence
+	err := s.db.View(operation.LookupAllSlashingEvidence(&evidence))
+	if err != nil {
+		return nil, fmt.Errorf("could not look up slashing evidence: %w", err)
+	}
+	return evidence, nil
+}
@@ -0,0 +1,70 @@
+package badger_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestSlashingEvidence(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store := bstorage.NewSlashingEvidence(db)
+
+		offender := unittest.IdentifierFixture()
+		other := unittest.IdentifierFixture()
+
+		doubleVote := func(offenderID flow.Identifier, view uint64) *flow.SlashingEvidence {
+			return &flow.SlashingEvidence{
+				Offence:    flow.OffenceDoubleVote,
+				OffenderID: offenderID,
+				View:       view,
+				Votes: []*flow.SignedVote{
+					{View: view, BlockID: unittest.IdentifierFixture(), SignerID: offenderID, SigData: unittest.SignatureFixture()},
+					{View: view, BlockID: unittest.IdentifierFixture(), SignerID: offenderID, SigData: unittest.SignatureFixture()},
+				},
+			}
+		}
+		header1 := unittest.BlockHeaderFixture()
+		header2 := unittest.BlockHeaderFixture()
+		doublePropose := &flow.SlashingEvidence{
+			Offence:    flow.OffenceDoubleProposal,
+			OffenderID: offender,
+			View:       12,
+			Proposals:  []*flow.Header{&header1, &header2},
+		}
+
+		// evidence is stored in order of view
+		expected := []*flow.SlashingEvidence{doubleVote(offender, 12), doublePropose, doubleVote(offender, 256)}
+		for _, evidence := range []*flow.SlashingEvidence{expected[2], expected[0], expected[1], doubleVote(other, 1)} {
+			err := store.Store(evidence)
+			require.NoError(t, err)
+		}
+
+		// further evidence for the same offence at the same view is dropped
+		err := store.Store(doubleVote(offender, 12))
+		require.True(t, errors.Is(err, storage.ErrAlreadyExists))
+
+		evidence, err := store.ByOffender(offender)
+		require.NoError(t, err)
+		require.Len(t, evidence, len(expected))
+		for i := range expected {
+			assert.Equal(t, expected[i].ID(), evidence[i].ID())
+		}
+
+		all, err := store.All()
+		require.NoError(t, err)
+		assert.Len(t, all, len(expected)+1)
+
+		evidence, err = store.ByOffender(unittest.IdentifierFixture())
+		require.NoError(t, err)
+		assert.Empty(t, evidence)
+	})
+}
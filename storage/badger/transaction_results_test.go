@@ -21,8 +21,9 @@ func TestStoringTransactionResults(t *testing.T) {
 		for i := 0; i < 10; i++ {
 			txID := unittest.IdentifierFixture()
 			expected := &flow.TransactionResult{
-				TransactionID: txID,
-				ErrorMessage:  "a runtime error " + string(i),
+				TransactionID:   txID,
+				ErrorMessage:    "a runtime error " + string(i),
+				ComputationUsed: uint64(i * 100),
 			}
 			txResults = append(txResults, expected)
 		}
@@ -0,0 +1,24 @@
+package storage
+
+import (
+	"github.com/onflow/flow-go/model/dkg"
+)
+
+// DKGResults represents persistent storage for the results of the distributed key
+// generations this node participated in.
+type DKGResults interface {
+
+	// Store stores the result of the DKG of an epoch. There is at most one result per
+	// epoch, and storing another one returns ErrAlreadyExists.
+	Store(result *dkg.Result) error
+
+	// ByEpochCounter returns the result of the DKG of the epoch with the given counter.
+	ByEpochCounter(counter uint64) (*dkg.Result, error)
+
+	// MarkStarted records that this node started the DKG of the epoch with the given counter,
+	// which it must not start again, as it cannot recover the state of an interrupted DKG.
+	MarkStarted(counter uint64) error
+
+	// Started returns whether this node started the DKG of the epoch with the given counter.
+	Started(counter uint64) (bool, error)
+}
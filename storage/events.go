@@ -17,3 +17,21 @@ type Events interface {
 	// ByBlockIDEventType returns the events for the given block ID and event type
 	ByBlockIDEventType(blockID flow.Identifier, eventType flow.EventType) ([]flow.Event, error)
 }
+
+// EventIndex represents persistent storage for the events of finalized blocks, indexed by event type,
+// block height and transaction. It allows the access node to answer event queries locally.
+type EventIndex interface {
+
+	// Store indexes the events emitted by the block at the given height and marks the height as indexed
+	Store(height uint64, events []flow.Event) error
+
+	// ByHeightRangeEventType returns the events of the given type emitted by blocks within the given
+	// height range (inclusive), keyed by block height
+	ByHeightRangeEventType(startHeight, endHeight uint64, eventType flow.EventType) (map[uint64][]flow.Event, error)
+
+	// ByTransactionID returns the events emitted by the given transaction
+	ByTransactionID(txID flow.Identifier) ([]flow.Event, error)
+
+	// LastIndexedHeight returns the height of the last block for which events were indexed
+	LastIndexedHeight() (uint64, error)
+}
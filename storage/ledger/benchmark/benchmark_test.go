@@ -6,6 +6,7 @@ package benchmark
 // }
 
 import (
+	"fmt"
 	"io/ioutil"
 	"math/rand"
 	"os"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/storage/ledger"
+	"github.com/onflow/flow-go/storage/ledger/mtrie/trie"
 	"github.com/onflow/flow-go/storage/ledger/ptrie"
 	"github.com/onflow/flow-go/storage/ledger/utils"
 )
@@ -109,3 +111,47 @@ func benchmarkStorage(steps int, b *testing.B) {
 	b.ReportMetric(float64(totalPTrieConstTimeMS/steps), "ptrie_const_time_(ms)")
 
 }
+
+// BenchmarkTrieUpdate benchmarks the performance of trie updates, serially and in parallel
+func BenchmarkTrieUpdate(b *testing.B) {
+	for _, numInsPerStep := range []int{100, 1000, 5000} {
+		for _, threshold := range []int{0, 16, trie.DefaultParallelUpdateThreshold, 1024} {
+			b.Run(fmt.Sprintf("updates=%d/threshold=%d", numInsPerStep, threshold), func(b *testing.B) {
+				benchmarkTrieUpdate(numInsPerStep, threshold, b)
+			})
+		}
+	}
+}
+
+// benchmarkTrieUpdate benchmarks updates of the given number of registers on a trie with as many existing registers,
+// where sub-tries are updated in parallel above the given threshold and a threshold of 0 updates the trie serially
+func benchmarkTrieUpdate(numInsPerStep int, threshold int, b *testing.B) {
+	keyByteSize := 32
+	numRegisters := numInsPerStep
+	valueMaxByteSize := 64
+	rand.Seed(1)
+
+	emptyTrie, err := trie.NewEmptyMTrie(keyByteSize)
+	if err != nil {
+		b.Fatal(err)
+	}
+	keys := utils.GetRandomKeysFixedN(numRegisters+numInsPerStep, keyByteSize)
+	values := utils.GetRandomValues(len(keys), 1, valueMaxByteSize)
+	parentTrie, err := trie.NewTrieWithParallelUpdate(emptyTrie, keys[:numRegisters], values[:numRegisters], threshold)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	// half of the updated registers exist already
+	updatedKeys := keys[numRegisters-numInsPerStep/2 : numRegisters+numInsPerStep/2]
+	updatedValues := values[numRegisters+numInsPerStep/2-numInsPerStep : numRegisters+numInsPerStep/2]
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := trie.NewTrieWithParallelUpdate(parentTrie, updatedKeys, updatedValues, threshold)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.StopTimer()
+}
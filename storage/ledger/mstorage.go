@@ -1,6 +1,7 @@
 package ledger
 
 import (
+	"bytes"
 	"fmt"
 	"time"
 
@@ -9,7 +10,9 @@ import (
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/storage/ledger/mtrie"
+	"github.com/onflow/flow-go/storage/ledger/mtrie/flattener"
 	"github.com/onflow/flow-go/storage/ledger/mtrie/proof"
+	"github.com/onflow/flow-go/storage/ledger/mtrie/storage"
 	"github.com/onflow/flow-go/storage/ledger/mtrie/trie"
 	"github.com/onflow/flow-go/storage/ledger/wal"
 )
@@ -31,31 +34,100 @@ const (
 // tries and purge the old ones (LRU-based); in other words MTrieStorage is not designed to be used
 // for archival use but make it possible for other software components to reconstruct very old tries using write-ahead logs.
 type MTrieStorage struct {
-	mForest *mtrie.MForest
-	wal     *wal.LedgerWAL
-	metrics module.LedgerMetrics
+	mForest     *mtrie.MForest
+	wal         *wal.LedgerWAL
+	nodeStorage *storage.DiskStorage
+	metrics     module.LedgerMetrics
 }
 
 const CacheSize = 1000
 
+// importBatchSize is the approximate size of the registers which are recorded in a single update
+// of the write-ahead log when importing a trie [bytes]
+const importBatchSize = wal.SegmentSize / 4
+
+// config holds the optional parameters of the storage
+type config struct {
+	parallelUpdateThreshold int
+
+	nodeStorageDir   string
+	nodeCacheSize    int
+	diskBackedForest bool
+}
+
+// Option configures optional parameters of the storage
+type Option func(*config)
+
+// WithDiskNodeStorage makes the storage store the nodes of its tries in the given directory
+// and hold at most cacheSize nodes in memory, which bounds the memory used by the forest.
+// A non-positive cacheSize selects storage.DefaultCacheSize. By default, the forest is held
+// in memory entirely.
+func WithDiskNodeStorage(dir string, cacheSize int) Option {
+	return func(cfg *config) {
+		cfg.diskBackedForest = true
+		cfg.nodeStorageDir = dir
+		cfg.nodeCacheSize = cacheSize
+	}
+}
+
+// WithParallelUpdateThreshold makes the storage update the sub-tries of a trie node concurrently
+// if at least threshold registers are updated below the node. A non-positive threshold makes
+// the storage update its tries serially. By default, trie.DefaultParallelUpdateThreshold is used.
+func WithParallelUpdateThreshold(threshold int) Option {
+	return func(cfg *config) {
+		cfg.parallelUpdateThreshold = threshold
+	}
+}
+
 // NewMTrieStorage creates a new in-memory trie-backed ledger storage with persistence.
-func NewMTrieStorage(dbDir string, capacity int, metrics module.LedgerMetrics, reg prometheus.Registerer) (*MTrieStorage, error) {
+func NewMTrieStorage(dbDir string, capacity int, metrics module.LedgerMetrics, reg prometheus.Registerer, opts ...Option) (*MTrieStorage, error) {
 
 	w, err := wal.NewWAL(nil, reg, dbDir, capacity, RegisterKeySize, wal.SegmentSize)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create LedgerWAL: %w", err)
 	}
 
+	return newMTrieStorage(w, dbDir, capacity, metrics, opts...)
+}
+
+// NewReadOnlyMTrieStorage restores the trie-backed ledger storage in the given directory without modifying it,
+// e.g. to inspect the execution state of a node. Updates of the storage fail.
+func NewReadOnlyMTrieStorage(dbDir string, capacity int, metrics module.LedgerMetrics, opts ...Option) (*MTrieStorage, error) {
+	w := wal.NewReadOnlyWAL(dbDir, capacity, RegisterKeySize)
+	return newMTrieStorage(w, dbDir, capacity, metrics, opts...)
+}
+
+func newMTrieStorage(w *wal.LedgerWAL, dbDir string, capacity int, metrics module.LedgerMetrics, opts ...Option) (*MTrieStorage, error) {
+	cfg := config{
+		parallelUpdateThreshold: trie.DefaultParallelUpdateThreshold,
+	}
+	for _, apply := range opts {
+		apply(&cfg)
+	}
+
+	forestOpts := []mtrie.Option{mtrie.WithParallelUpdateThreshold(cfg.parallelUpdateThreshold)}
+	var nodeStorage *storage.DiskStorage
+	if cfg.diskBackedForest {
+		var err error
+		nodeStorage, err = storage.NewDiskStorage(cfg.nodeStorageDir, cfg.nodeCacheSize)
+		if err != nil {
+			_ = w.Close()
+			return nil, fmt.Errorf("cannot create node storage: %w", err)
+		}
+		forestOpts = append(forestOpts, mtrie.WithNodeStorage(nodeStorage))
+	}
+
 	mForest, err := mtrie.NewMForest(RegisterKeySize, dbDir, capacity, metrics, func(evictedTrie *trie.MTrie) error {
 		return w.RecordDelete(evictedTrie.RootHash())
-	})
+	}, forestOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create MForest: %w", err)
 	}
 	storage := &MTrieStorage{
-		mForest: mForest,
-		wal:     w,
-		metrics: metrics,
+		mForest:     mForest,
+		wal:         w,
+		nodeStorage: nodeStorage,
+		metrics:     metrics,
 	}
 
 	err = w.ReplayOnMForest(mForest)
@@ -80,7 +152,7 @@ func (f *MTrieStorage) Ready() <-chan struct{} {
 // Done implements interface module.ReadyDoneAware
 // it closes all the open write-ahead log files.
 func (f *MTrieStorage) Done() <-chan struct{} {
-	_ = f.wal.Close()
+	f.CloseStorage()
 	done := make(chan struct{})
 	close(done)
 	return done
@@ -249,11 +321,22 @@ func (f *MTrieStorage) UpdateRegistersWithProof(
 // CloseStorage closes the DB
 func (f *MTrieStorage) CloseStorage() {
 	_ = f.wal.Close()
+	if f.nodeStorage != nil {
+		_ = f.nodeStorage.Close()
+	}
 }
 
 // DiskSize returns the amount of disk space used by the storage (in bytes)
 func (f *MTrieStorage) DiskSize() (int64, error) {
-	return f.mForest.DiskSize()
+	size, err := f.mForest.DiskSize()
+	if err != nil || f.nodeStorage == nil {
+		return size, err
+	}
+	nodesSize, err := f.nodeStorage.DiskSize()
+	if err != nil {
+		return 0, err
+	}
+	return size + nodesSize, nil
 }
 
 // ForestSize returns the number of tries stored in the forest
@@ -261,6 +344,107 @@ func (f *MTrieStorage) ForestSize() int {
 	return f.mForest.Size()
 }
 
+// Trie returns the trie of the given state commitment
+func (f *MTrieStorage) Trie(stateCommitment flow.StateCommitment) (*trie.MTrie, error) {
+	return f.mForest.GetTrie(stateCommitment)
+}
+
+// Registers returns the keys and values of at most limit registers at the given state commitment in the order
+// of their keys, beginning with the given key, or with the first key if start is nil. It also returns the key
+// to continue with, which is nil if there are no more registers.
+func (f *MTrieStorage) Registers(
+	stateCommitment flow.StateCommitment,
+	start flow.RegisterID,
+	limit int,
+) (
+	registerIDs []flow.RegisterID,
+	values []flow.RegisterValue,
+	next flow.RegisterID,
+	err error,
+) {
+	t, err := f.mForest.GetTrie(stateCommitment)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot get trie: %w", err)
+	}
+	return t.Registers(start, limit)
+}
+
+// Diff returns the changes of the registers from one state commitment to the other, in the order of their keys
+func (f *MTrieStorage) Diff(from, to flow.StateCommitment) ([]trie.RegisterChange, error) {
+	fromTrie, err := f.mForest.GetTrie(from)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get trie of state %x: %w", from, err)
+	}
+	toTrie, err := f.mForest.GetTrie(to)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get trie of state %x: %w", to, err)
+	}
+	return trie.Diff(fromTrie, toTrie)
+}
+
+// ImportTrie adds a trie which was obtained from outside of the storage, e.g. synchronized from other nodes,
+// and returns its state commitment. The registers of the trie are recorded in the write-ahead log as batches
+// of updates on top of the empty state, so that the trie is restored on restarts like any other state. The
+// intermediate states of the batches are removed again.
+func (f *MTrieStorage) ImportTrie(t *trie.MTrie) (flow.StateCommitment, error) {
+	if t.KeyLength() != f.mForest.KeyLength() {
+		return nil, fmt.Errorf("trie has key length %d, but storage has key length %d", t.KeyLength(), f.mForest.KeyLength())
+	}
+
+	emptyCommitment := f.EmptyStateCommitment()
+	stateCommitment := emptyCommitment
+	var ids []flow.RegisterID
+	var values []flow.RegisterValue
+	size := 0
+
+	flush := func() error {
+		if len(ids) == 0 {
+			return nil
+		}
+		newStateCommitment, err := f.UpdateRegisters(ids, values, stateCommitment)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(stateCommitment, emptyCommitment) {
+			err = f.wal.RecordDelete(stateCommitment)
+			if err != nil {
+				return fmt.Errorf("cannot remove intermediate state, error while writing LedgerWAL: %w", err)
+			}
+			f.mForest.RemoveTrie(stateCommitment)
+		}
+		stateCommitment = newStateCommitment
+		ids, values, size = nil, nil, 0
+		return nil
+	}
+
+	for itr := flattener.NewNodeIterator(t); itr.Next(); {
+		n := itr.Value()
+		if !n.IsLeaf() || len(n.Value()) == 0 {
+			continue
+		}
+		ids = append(ids, n.Key())
+		values = append(values, n.Value())
+		size += len(n.Key()) + len(n.Value())
+		if size < importBatchSize {
+			continue
+		}
+		err := flush()
+		if err != nil {
+			return nil, fmt.Errorf("cannot import registers: %w", err)
+		}
+	}
+	err := flush()
+	if err != nil {
+		return nil, fmt.Errorf("cannot import registers: %w", err)
+	}
+
+	if !bytes.Equal(stateCommitment, t.RootHash()) {
+		return nil, fmt.Errorf("imported state %x does not match trie %x", stateCommitment, t.RootHash())
+	}
+
+	return stateCommitment, nil
+}
+
 func (f *MTrieStorage) Checkpointer() (*wal.Checkpointer, error) {
 	checkpointer, err := f.wal.NewCheckpointer()
 	if err != nil {
@@ -1,6 +1,8 @@
 package ledger_test
 
 import (
+	"errors"
+	"io/ioutil"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -9,7 +11,11 @@ import (
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/storage/ledger"
+	"github.com/onflow/flow-go/storage/ledger/mtrie/common"
+	"github.com/onflow/flow-go/storage/ledger/mtrie/node"
+	"github.com/onflow/flow-go/storage/ledger/mtrie/statesync"
 	"github.com/onflow/flow-go/storage/ledger/utils"
+	"github.com/onflow/flow-go/storage/ledger/wal"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -149,6 +155,199 @@ func TestTrieStorage_UpdateRegistersWithProof(t *testing.T) {
 	})
 }
 
+func TestTrieStorage_DiskNodeStorage(t *testing.T) {
+	steps := 5
+	metricsCollector := &metrics.NoopCollector{}
+
+	unittest.RunWithTempDir(t, func(memDir string) {
+		unittest.RunWithTempDir(t, func(diskDir string) {
+			unittest.RunWithTempDir(t, func(nodeDir string) {
+
+				memStorage, err := ledger.NewMTrieStorage(memDir, 100, metricsCollector, nil)
+				require.NoError(t, err)
+				defer memStorage.Done()
+
+				diskStorage, err := ledger.NewMTrieStorage(diskDir, 100, metricsCollector, nil, ledger.WithDiskNodeStorage(nodeDir, 10))
+				require.NoError(t, err)
+
+				require.Equal(t, memStorage.EmptyStateCommitment(), diskStorage.EmptyStateCommitment())
+
+				stateCommitment := memStorage.EmptyStateCommitment()
+				var queries []flow.StateCommitment
+				var queryIDs [][]flow.RegisterID
+				for i := 0; i < steps; i++ {
+					ids := utils.GetRandomKeysFixedN(50, ledger.RegisterKeySize)
+					values := utils.GetRandomValues(len(ids), 1, 100)
+
+					memCommitment, err := memStorage.UpdateRegisters(ids, values, stateCommitment)
+					require.NoError(t, err)
+					diskCommitment, err := diskStorage.UpdateRegisters(ids, values, stateCommitment)
+					require.NoError(t, err)
+					require.Equal(t, memCommitment, diskCommitment)
+
+					// query the updated registers together with registers which are not allocated
+					queries = append(queries, memCommitment)
+					queryIDs = append(queryIDs, append(ids, utils.GetRandomKeysFixedN(5, ledger.RegisterKeySize)...))
+
+					stateCommitment = memCommitment
+				}
+
+				requireEqualStorages := func(expected, actual *ledger.MTrieStorage) {
+					for i, stateCommitment := range queries {
+						expectedValues, expectedProofs, err := expected.GetRegistersWithProof(queryIDs[i], stateCommitment)
+						require.NoError(t, err)
+						actualValues, actualProofs, err := actual.GetRegistersWithProof(queryIDs[i], stateCommitment)
+						require.NoError(t, err)
+						require.Equal(t, expectedValues, actualValues)
+						require.Equal(t, expectedProofs, actualProofs)
+					}
+				}
+
+				requireEqualStorages(memStorage, diskStorage)
+
+				// the nodes are rebuilt from the write-ahead log on restarts
+				<-diskStorage.Done()
+
+				diskStorage, err = ledger.NewMTrieStorage(diskDir, 100, metricsCollector, nil, ledger.WithDiskNodeStorage(nodeDir, 10))
+				require.NoError(t, err)
+				defer diskStorage.Done()
+
+				requireEqualStorages(memStorage, diskStorage)
+			})
+		})
+	})
+}
+
+func TestTrieStorage_ImportTrie(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dbDir string) {
+		unittest.RunWithTempDir(t, func(syncedDir string) {
+
+			metricsCollector := &metrics.NoopCollector{}
+			f, err := ledger.NewMTrieStorage(dbDir, 100, metricsCollector, nil)
+			require.NoError(t, err)
+			defer f.CloseStorage()
+
+			// large enough values to be imported in several batches
+			ids := common.GetRandomKeysFixedN(100, ledger.RegisterKeySize)
+			values := make([]flow.RegisterValue, 0, len(ids))
+			for range ids {
+				values = append(values, common.GetRandomValues(1, 1<<18)[0])
+			}
+			commit, err := f.UpdateRegisters(ids, values, f.EmptyStateCommitment())
+			require.NoError(t, err)
+
+			// transfer the trie of the state in pieces
+			mTrie, err := f.Trie(commit)
+			require.NoError(t, err)
+			manifest, roots, err := statesync.NewManifest(mTrie, statesync.DefaultDepth)
+			require.NoError(t, err)
+			require.NoError(t, manifest.Verify(commit))
+			pieces := make(map[int]*node.Node)
+			for _, i := range manifest.Pieces() {
+				pieces[i], err = statesync.DecodePiece(manifest, i, statesync.EncodePiece(roots[i]))
+				require.NoError(t, err)
+			}
+			syncedTrie, err := statesync.Assemble(manifest, pieces)
+			require.NoError(t, err)
+
+			synced, err := ledger.NewMTrieStorage(syncedDir, 100, metricsCollector, nil)
+			require.NoError(t, err)
+			importedCommit, err := synced.ImportTrie(syncedTrie)
+			require.NoError(t, err)
+			require.Equal(t, commit, importedCommit)
+
+			requireValues := func(storage *ledger.MTrieStorage) {
+				retValues, err := storage.GetRegisters(ids, commit)
+				require.NoError(t, err)
+				require.Equal(t, values, retValues)
+			}
+			requireValues(synced)
+
+			// the imported state is restored from the write-ahead log
+			synced.CloseStorage()
+			synced, err = ledger.NewMTrieStorage(syncedDir, 100, metricsCollector, nil)
+			require.NoError(t, err)
+			defer synced.CloseStorage()
+			requireValues(synced)
+
+			// updates on top of the imported state are recorded as usual
+			newValues := common.GetRandomValues(len(ids), 100)
+			expectedCommit, err := f.UpdateRegisters(ids, newValues, commit)
+			require.NoError(t, err)
+			newCommit, err := synced.UpdateRegisters(ids, newValues, commit)
+			require.NoError(t, err)
+			require.Equal(t, expectedCommit, newCommit)
+		})
+	})
+}
+
+func TestTrieStorage_RegistersAndDiff(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dbDir string) {
+
+		metricsCollector := &metrics.NoopCollector{}
+		f, err := ledger.NewMTrieStorage(dbDir, 100, metricsCollector, nil)
+		require.NoError(t, err)
+
+		ids := common.GetRandomKeysFixedN(50, ledger.RegisterKeySize)
+		values := common.GetRandomValues(len(ids), 100)
+		commit, err := f.UpdateRegisters(ids, values, f.EmptyStateCommitment())
+		require.NoError(t, err)
+
+		newValue := common.GetRandomValues(1, 100)
+		newCommit, err := f.UpdateRegisters(ids[:1], newValue, commit)
+		require.NoError(t, err)
+		f.CloseStorage()
+
+		// the storage is restored without modifying its directory
+		listFiles := func() map[string]int64 {
+			files, err := ioutil.ReadDir(dbDir)
+			require.NoError(t, err)
+			sizes := make(map[string]int64)
+			for _, file := range files {
+				sizes[file.Name()] = file.Size()
+			}
+			return sizes
+		}
+		files := listFiles()
+		r, err := ledger.NewReadOnlyMTrieStorage(dbDir, 100, metricsCollector)
+		require.NoError(t, err)
+		defer r.CloseStorage()
+		require.Equal(t, files, listFiles())
+
+		_, err = r.UpdateRegisters(ids[:1], values[:1], newCommit)
+		require.True(t, errors.Is(err, wal.ErrReadOnly))
+
+		// all registers are listed over multiple pages
+		var retIDs []flow.RegisterID
+		var start flow.RegisterID
+		for {
+			page, _, next, err := r.Registers(commit, start, 7)
+			require.NoError(t, err)
+			retIDs = append(retIDs, page...)
+			if next == nil {
+				break
+			}
+			start = next
+		}
+		require.Len(t, retIDs, len(ids))
+
+		_, _, _, err = r.Registers(unittest.StateCommitmentFixture(), nil, 7)
+		require.Error(t, err)
+
+		// only the updated register is reported as changed
+		changes, err := r.Diff(commit, newCommit)
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+		require.Equal(t, ids[0], changes[0].Key)
+		require.Equal(t, values[0], changes[0].Old)
+		require.Equal(t, newValue[0], changes[0].New)
+
+		changes, err = r.Diff(r.EmptyStateCommitment(), commit)
+		require.NoError(t, err)
+		require.Len(t, changes, len(ids))
+	})
+}
+
 func makeTestValues() ([][]byte, [][]byte) {
 	id1 := make([]byte, 32)
 	value1 := []byte{'a'}
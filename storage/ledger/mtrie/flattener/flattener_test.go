@@ -14,6 +14,7 @@ import (
 
 	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/storage/ledger/mtrie/flattener"
+	"github.com/onflow/flow-go/storage/ledger/utils"
 )
 
 func TestForestStoreAndLoad(t *testing.T) {
@@ -85,3 +86,65 @@ func TestForestStoreAndLoad(t *testing.T) {
 		require.True(t, bytes.Equal(values[i], newRetValues[i]))
 	}
 }
+
+func TestForestIncrementalFlattening(t *testing.T) {
+	keyByteSize := 32
+	dir, err := ioutil.TempDir("", "test-mtrie-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	metricsCollector := &metrics.NoopCollector{}
+	mForest, err := mtrie.NewMForest(keyByteSize, dir, 5, metricsCollector, nil)
+	require.NoError(t, err)
+
+	update := func(rootHash []byte) []byte {
+		keys := utils.GetRandomKeysFixedN(100, keyByteSize)
+		values := utils.GetRandomValues(100, 1, 100)
+		newTrie, err := mForest.Update(rootHash, keys, values)
+		require.NoError(t, err)
+		return newTrie.RootHash()
+	}
+	rootHash := update(mForest.GetEmptyRootHash())
+
+	// a full flattening in parallel
+	index := make(flattener.NodeIndex)
+	storableNodes, storableTries, err := flattener.FlattenForestIncrementally(mForest, index, 4)
+	require.NoError(t, err)
+	nodes := append([]*flattener.StorableNode{nil}, storableNodes...)
+
+	rebuiltTries, err := flattener.RebuildTries(&flattener.FlattenedForest{Nodes: nodes, Tries: storableTries})
+	require.NoError(t, err)
+	require.Len(t, rebuiltTries, 2)
+
+	// an incremental flattening only contains the nodes of the new trie
+	rootHash = update(rootHash)
+	newNodes, storableTries, err := flattener.FlattenForestIncrementally(mForest, index, 4)
+	require.NoError(t, err)
+	require.NotEmpty(t, newNodes)
+	full, err := flattener.FlattenForest(mForest)
+	require.NoError(t, err)
+	require.Equal(t, len(full.Nodes), len(storableNodes)+len(newNodes)+1)
+	nodes = append(nodes, newNodes...)
+
+	rebuiltTries, err = flattener.RebuildTries(&flattener.FlattenedForest{Nodes: nodes, Tries: storableTries})
+	require.NoError(t, err)
+	require.Len(t, rebuiltTries, 3)
+
+	newForest, err := mtrie.NewMForest(keyByteSize, dir, 5, metricsCollector, nil)
+	require.NoError(t, err)
+	err = newForest.AddTries(rebuiltTries)
+	require.NoError(t, err)
+
+	for _, t1 := range rebuiltTries {
+		t2, err := mForest.GetTrie(t1.RootHash())
+		require.NoError(t, err)
+		require.True(t, t1.Equals(t2))
+	}
+	_, err = newForest.GetTrie(rootHash)
+	require.NoError(t, err)
+
+	// nothing is left to flatten
+	newNodes, _, err = flattener.FlattenForestIncrementally(mForest, index, 4)
+	require.NoError(t, err)
+	require.Empty(t, newNodes)
+}
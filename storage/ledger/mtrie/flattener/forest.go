@@ -26,8 +26,9 @@ type FlattenedForest struct {
 	Tries []*StorableTrie
 }
 
-// node2indexMap maps a node pointer to the node index in the serialization
-type node2indexMap map[*node.Node]uint64
+// NodeIndex maps a node pointer to the node index in the serialization.
+// The nil node has index 0.
+type NodeIndex map[*node.Node]uint64
 
 // FlattenForest returns forest FlattenedForest, which contains all nodes and tries of the MForest.
 func FlattenForest(f *mtrie.MForest) (*FlattenedForest, error) {
@@ -40,7 +41,7 @@ func FlattenForest(f *mtrie.MForest) (*FlattenedForest, error) {
 	storableNodes := []*StorableNode{nil} // 0th element is nil
 
 	// assign unique value to every node
-	allNodes := make(node2indexMap)
+	allNodes := make(NodeIndex)
 	allNodes[nil] = 0 // 0th element is nil
 
 	counter := uint64(1) // start from 1, as 0 marks nil
@@ -73,7 +74,7 @@ func FlattenForest(f *mtrie.MForest) (*FlattenedForest, error) {
 	}, nil
 }
 
-func toStorableNode(node *node.Node, indexForNode node2indexMap) (*StorableNode, error) {
+func toStorableNode(node *node.Node, indexForNode NodeIndex) (*StorableNode, error) {
 	leftIndex, found := indexForNode[node.LeftChild()]
 	if !found {
 		return nil, fmt.Errorf("internal error: missing node with hash %s", hex.EncodeToString(node.LeftChild().Hash()))
@@ -96,7 +97,7 @@ func toStorableNode(node *node.Node, indexForNode node2indexMap) (*StorableNode,
 	return storableNode, nil
 }
 
-func toStorableTrie(mtrie *trie.MTrie, indexForNode node2indexMap) (*StorableTrie, error) {
+func toStorableTrie(mtrie *trie.MTrie, indexForNode NodeIndex) (*StorableTrie, error) {
 	rootIndex, found := indexForNode[mtrie.RootNode()]
 	if !found {
 		return nil, fmt.Errorf("internal error: missing node with hash %s", hex.EncodeToString(mtrie.RootNode().Hash()))
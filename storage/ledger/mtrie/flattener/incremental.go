@@ -0,0 +1,179 @@
+package flattener
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/onflow/flow-go/storage/ledger/mtrie"
+	"github.com/onflow/flow-go/storage/ledger/mtrie/node"
+	"github.com/onflow/flow-go/storage/ledger/mtrie/trie"
+)
+
+// subtrieDepth is the depth at which tries are split into sub-tries, which are flattened in parallel
+const subtrieDepth = 4
+
+// NewNodeIndex returns the index of the given nodes, as returned by RebuildNodes.
+func NewNodeIndex(nodes []*node.Node) NodeIndex {
+	index := make(NodeIndex, len(nodes))
+	for i, n := range nodes {
+		index[n] = uint64(i)
+	}
+	index[nil] = 0
+	return index
+}
+
+// FlattenForestIncrementally returns the storable nodes of the forest's tries, which are not contained in the
+// index of previously flattened nodes, and the storable tries of the forest. The returned nodes are numbered
+// consecutively after the nodes in the index, and added to the index. Appended to the previously flattened
+// nodes, they satisfy the Descendents-First-Relationship.
+//
+// The sub-tries of each trie are traversed and serialized by the given number of workers in parallel. Sub-tries
+// whose root is contained in the index are not traversed at all, so that the work is proportional to the number
+// of new nodes rather than the size of the forest.
+func FlattenForestIncrementally(f *mtrie.MForest, index NodeIndex, workers int) ([]*StorableNode, []*StorableTrie, error) {
+	if workers < 1 {
+		workers = 1
+	}
+	if _, ok := index[nil]; !ok {
+		index[nil] = 0
+	}
+
+	tries, err := f.GetTries()
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot get cached tries root hashes: %w", err)
+	}
+
+	// the index contains the nil node, which is not counted
+	next := uint64(len(index))
+	var newNodes []*node.Node
+	storableTries := make([]*StorableTrie, 0, len(tries))
+	for _, t := range tries {
+		// the new nodes of a trie are indexed before continuing with the next trie,
+		// as tries share nodes with each other
+		nodes := newTrieNodes(t, index, workers)
+		for _, n := range nodes {
+			if _, has := index[n]; has {
+				continue
+			}
+			index[n] = next
+			next++
+			newNodes = append(newNodes, n)
+		}
+
+		storableTrie, err := toStorableTrie(t, index)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to construct storable trie: %w", err)
+		}
+		storableTries = append(storableTries, storableTrie)
+	}
+
+	storableNodes, err := toStorableNodes(newNodes, index, workers)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return storableNodes, storableTries, nil
+}
+
+// newTrieNodes returns the nodes of the trie which are not contained in the index, in an order satisfying
+// the Descendents-First-Relationship. The index is only read, concurrently by the given number of workers.
+func newTrieNodes(t *trie.MTrie, index NodeIndex, workers int) []*node.Node {
+	// nodes above the sub-tries, and roots of the sub-tries, from left to right
+	var top, roots []*node.Node
+	var split func(n *node.Node, depth int)
+	split = func(n *node.Node, depth int) {
+		if n == nil {
+			return
+		}
+		if _, has := index[n]; has {
+			return
+		}
+		if depth == subtrieDepth || n.IsLeaf() {
+			roots = append(roots, n)
+			return
+		}
+		split(n.LeftChild(), depth+1)
+		split(n.RigthChild(), depth+1)
+		top = append(top, n)
+	}
+	split(t.RootNode(), 0)
+
+	subtries := make([][]*node.Node, len(roots))
+	jobs := make(chan int, len(roots))
+	for i := range roots {
+		jobs <- i
+	}
+	close(jobs)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < len(roots); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				subtries[i] = appendNewNodes(nil, roots[i], index)
+			}
+		}()
+	}
+	wg.Wait()
+
+	var nodes []*node.Node
+	for _, subtrie := range subtries {
+		nodes = append(nodes, subtrie...)
+	}
+	// nodes above the sub-tries were collected children first, and only depend on the sub-tries
+	return append(nodes, top...)
+}
+
+// appendNewNodes appends the nodes of the sub-trie which are not contained in the index, children first
+func appendNewNodes(nodes []*node.Node, n *node.Node, index NodeIndex) []*node.Node {
+	if n == nil {
+		return nodes
+	}
+	if _, has := index[n]; has {
+		return nodes
+	}
+	nodes = appendNewNodes(nodes, n.LeftChild(), index)
+	nodes = appendNewNodes(nodes, n.RigthChild(), index)
+	return append(nodes, n)
+}
+
+// toStorableNodes converts the nodes to storable nodes, using the given number of workers in parallel
+func toStorableNodes(nodes []*node.Node, index NodeIndex, workers int) ([]*StorableNode, error) {
+	storableNodes := make([]*StorableNode, len(nodes))
+	errs := make([]error, workers)
+
+	var wg sync.WaitGroup
+	batch := (len(nodes) + workers - 1) / workers
+	for w := 0; w < workers; w++ {
+		from := w * batch
+		to := from + batch
+		if to > len(nodes) {
+			to = len(nodes)
+		}
+		if from >= to {
+			break
+		}
+
+		wg.Add(1)
+		go func(w, from, to int) {
+			defer wg.Done()
+			for i := from; i < to; i++ {
+				storableNode, err := toStorableNode(nodes[i], index)
+				if err != nil {
+					errs[w] = fmt.Errorf("failed to construct storable node: %w", err)
+					return
+				}
+				storableNodes[i] = storableNode
+			}
+		}(w, from, to)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return storableNodes, nil
+}
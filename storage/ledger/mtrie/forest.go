@@ -11,6 +11,7 @@ import (
 	lru "github.com/hashicorp/golang-lru"
 
 	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/storage/ledger/mtrie/node"
 	"github.com/onflow/flow-go/storage/ledger/mtrie/proof"
 	"github.com/onflow/flow-go/storage/ledger/mtrie/trie"
 	"github.com/onflow/flow-go/utils/io"
@@ -37,6 +38,37 @@ type MForest struct {
 	onTreeEvicted  func(tree *trie.MTrie) error
 	keyByteSize    int // length [bytes] of register keys
 	metrics        module.LedgerMetrics
+	// parallelUpdateThreshold is the minimum number of registers updated below a trie node
+	// for the sub-tries of the node to be updated concurrently
+	parallelUpdateThreshold int
+	nodeStorage             NodeStorage
+}
+
+// NodeStorage holds the nodes of the tries in the forest outside of memory.
+type NodeStorage interface {
+	// Persist stores the nodes of the trie with the given root, and returns
+	// the root as a stored node, which loads its children from the storage.
+	Persist(root *node.Node) (*node.Node, error)
+}
+
+// Option configures optional parameters of the forest
+type Option func(*MForest)
+
+// WithNodeStorage makes the forest hold its tries in the given node storage rather than in memory,
+// so that only the recently used nodes of the tries remain in memory.
+func WithNodeStorage(storage NodeStorage) Option {
+	return func(f *MForest) {
+		f.nodeStorage = storage
+	}
+}
+
+// WithParallelUpdateThreshold makes the forest update the left and right sub-tries of a trie node
+// concurrently if at least threshold registers are updated below the node. A non-positive
+// threshold makes the forest update tries serially.
+func WithParallelUpdateThreshold(threshold int) Option {
+	return func(f *MForest) {
+		f.parallelUpdateThreshold = threshold
+	}
 }
 
 // NewMForest returns a new instance of memory forest.
@@ -46,7 +78,7 @@ type MForest struct {
 // THIS IS A ROUGH HEURISTIC as it might evict tries that are still needed.
 // Make sure you chose a sufficiently large forestCapacity, such that, when reaching the capacity, the
 // Least Recently Used trie will never be needed again.
-func NewMForest(keyByteSize int, trieStorageDir string, forestCapacity int, metrics module.LedgerMetrics, onTreeEvicted func(tree *trie.MTrie) error) (*MForest, error) {
+func NewMForest(keyByteSize int, trieStorageDir string, forestCapacity int, metrics module.LedgerMetrics, onTreeEvicted func(tree *trie.MTrie) error, opts ...Option) (*MForest, error) {
 	// init LRU cache as a SHORTCUT for a usage-related storage eviction policy
 	var cache *lru.Cache
 	var err error
@@ -76,6 +108,11 @@ func NewMForest(keyByteSize int, trieStorageDir string, forestCapacity int, metr
 		onTreeEvicted:  onTreeEvicted,
 		keyByteSize:    keyByteSize,
 		metrics:        metrics,
+
+		parallelUpdateThreshold: trie.DefaultParallelUpdateThreshold,
+	}
+	for _, apply := range opts {
+		apply(forest)
 	}
 
 	// add empty roothash
@@ -158,6 +195,19 @@ func (f *MForest) AddTrie(newTrie *trie.MTrie) error {
 		}
 		return fmt.Errorf("forest already contains a tree with same root hash but other properties")
 	}
+
+	// tries are added to the forest as stored tries, whose nodes are loaded from the storage on demand
+	if f.nodeStorage != nil && !newTrie.RootNode().IsStored() {
+		storedRoot, err := f.nodeStorage.Persist(newTrie.RootNode())
+		if err != nil {
+			return fmt.Errorf("cannot persist trie nodes: %w", err)
+		}
+		newTrie, err = trie.NewMTrie(storedRoot)
+		if err != nil {
+			return fmt.Errorf("cannot construct stored trie: %w", err)
+		}
+	}
+
 	f.tries.Add(hashString, newTrie)
 	f.metrics.ForestNumberOfTrees(uint64(f.tries.Len()))
 
@@ -278,7 +328,7 @@ func (f *MForest) Update(rootHash []byte, keys [][]byte, values [][]byte) (*trie
 		sortedValues = append(sortedValues, valueMap[string(key)])
 	}
 
-	newTrie, err := trie.NewTrieWithUpdatedRegisters(parentTrie, sortedKeys, sortedValues)
+	newTrie, err := trie.NewTrieWithParallelUpdate(parentTrie, sortedKeys, sortedValues, f.parallelUpdateThreshold)
 	if err != nil {
 		return nil, fmt.Errorf("constructing updated trie failed: %w", err)
 	}
@@ -349,7 +399,7 @@ func (f *MForest) Proofs(rootHash []byte, keys [][]byte) (*proof.BatchProof, err
 			return bytes.Compare(notFoundKeys[i], notFoundKeys[j]) < 0
 		})
 
-		newTrie, err := trie.NewTrieWithUpdatedRegisters(stateTrie, notFoundKeys, notFoundValues)
+		newTrie, err := trie.NewTrieWithParallelUpdate(stateTrie, notFoundKeys, notFoundValues, f.parallelUpdateThreshold)
 		if err != nil {
 			return nil, err
 		}
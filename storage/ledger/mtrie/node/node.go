@@ -25,6 +25,10 @@ import (
 //
 // Nodes are supposed to be used in READ-ONLY fashion. However,
 // for performance reasons, we not not copy read.
+//
+// A node is either held in memory together with its children, or STORED: a stored
+// node references its children by hash and loads them from a Storage on demand,
+// so that only the recently used parts of a trie are held in memory.
 // TODO: optimized data structures might be able to reduce memory consumption
 type Node struct {
 	lChild    *Node // Left Child
@@ -35,6 +39,15 @@ type Node struct {
 	hashValue []byte
 	maxDepth  uint16 // captures the longest path from this node to compacted leafs in the subtree
 	regCount  uint64 // number of registers allocated in the subtree
+	lHash     []byte  // hash of the left child (stored nodes only)
+	rHash     []byte  // hash of the right child (stored nodes only)
+	storage   Storage // storage the children are loaded from (stored nodes only)
+}
+
+// Storage holds trie nodes outside of memory, addressed by their hash.
+type Storage interface {
+	// Load returns the stored node with the given hash.
+	Load(hash []byte) (*Node, error)
 }
 
 // NewNode creates a new Node.
@@ -54,6 +67,24 @@ func NewNode(height int, lchild, rchild *Node, key, value, hashValue []byte, max
 	return n
 }
 
+// NewStoredNode creates a stored Node, which loads its children with the given hashes from the storage.
+// Nil hashes denote empty sub-tries.
+// UNCHECKED requirement: combination of values must conform to
+// a valid node type (see documentation of `Node` for details)
+func NewStoredNode(height int, lHash, rHash, key, value, hashValue []byte, maxDepth uint16, regCount uint64, storage Storage) *Node {
+	return &Node{
+		height:    height,
+		key:       key,
+		value:     value,
+		hashValue: hashValue,
+		maxDepth:  maxDepth,
+		regCount:  regCount,
+		lHash:     lHash,
+		rHash:     rHash,
+		storage:   storage,
+	}
+}
+
 // NewLeaf creates a compact leaf Node
 // UNCHECKED requirement: height must be non-negative
 func NewEmptyTreeRoot(height int) *Node {
@@ -172,12 +203,39 @@ func (n *Node) Value() []byte { return n.value }
 // LeftChild returns the the Node's left child.
 // Only INTERIOR nodes have children.
 // Do NOT MODIFY returned Node!
-func (n *Node) LeftChild() *Node { return n.lChild }
+func (n *Node) LeftChild() *Node {
+	if n.storage == nil {
+		return n.lChild
+	}
+	return n.load(n.lHash)
+}
 
 // RigthChild returns the the Node's right child.
 // Only INTERIOR nodes have children.
 // Do NOT MODIFY returned Node!
-func (n *Node) RigthChild() *Node { return n.rChild }
+func (n *Node) RigthChild() *Node {
+	if n.storage == nil {
+		return n.rChild
+	}
+	return n.load(n.rHash)
+}
+
+// IsStored returns true if and only if the Node loads its children from a Storage.
+func (n *Node) IsStored() bool { return n.storage != nil }
+
+// load returns the child with the given hash from the storage of a stored Node.
+// As a stored node can only reference nodes that were stored before, failing to load
+// a child means the storage is corrupted or unavailable, which is not recoverable.
+func (n *Node) load(hash []byte) *Node {
+	if hash == nil {
+		return nil
+	}
+	child, err := n.storage.Load(hash)
+	if err != nil {
+		panic(fmt.Sprintf("could not load node %x from storage: %v", hash, err))
+	}
+	return child
+}
 
 // IsLeaf returns true if and only if Node is a LEAF.
 func (n *Node) IsLeaf() bool {
@@ -188,12 +246,12 @@ func (n *Node) IsLeaf() bool {
 // FmtStr provides formatted string representation of the Node and sub tree
 func (n Node) FmtStr(prefix string, path string) string {
 	right := ""
-	if n.rChild != nil {
-		right = fmt.Sprintf("\n%v", n.rChild.FmtStr(prefix+"\t", path+"1"))
+	if rChild := n.RigthChild(); rChild != nil {
+		right = fmt.Sprintf("\n%v", rChild.FmtStr(prefix+"\t", path+"1"))
 	}
 	left := ""
-	if n.lChild != nil {
-		left = fmt.Sprintf("\n%v", n.lChild.FmtStr(prefix+"\t", path+"0"))
+	if lChild := n.LeftChild(); lChild != nil {
+		left = fmt.Sprintf("\n%v", lChild.FmtStr(prefix+"\t", path+"0"))
 	}
 	return fmt.Sprintf("%v%v: (k:%v, v:%v, h:%v)[%s] %v %v ", prefix, n.height, n.key, hex.EncodeToString(n.value), hex.EncodeToString(n.hashValue), path, left, right)
 }
@@ -218,14 +276,11 @@ func (n *Node) DeepCopy() *Node {
 		copy(h, n.key)
 		newNode.key = h
 	}
-	if n.lChild != nil {
-		newNode.lChild = n.lChild.DeepCopy()
+	if lChild := n.LeftChild(); lChild != nil {
+		newNode.lChild = lChild.DeepCopy()
 	}
-	if n.rChild != nil {
-		newNode.rChild = n.rChild.DeepCopy()
-	}
-	if n.rChild != nil {
-		newNode.rChild = n.rChild.DeepCopy()
+	if rChild := n.RigthChild(); rChild != nil {
+		newNode.rChild = rChild.DeepCopy()
 	}
 	return newNode
 }
@@ -269,18 +324,20 @@ func (n *Node) Equals(o *Node) bool {
 	}
 
 	// left children don't match
-	if (n.lChild == nil) != (o.lChild == nil) {
+	nLeft, oLeft := n.LeftChild(), o.LeftChild()
+	if (nLeft == nil) != (oLeft == nil) {
 		return false
 	}
-	if n.lChild != nil && o.lChild != nil && !n.lChild.Equals(o.lChild) {
+	if nLeft != nil && oLeft != nil && !nLeft.Equals(oLeft) {
 		return false
 	}
 
 	// right children don't match
-	if (n.rChild == nil) != (o.rChild == nil) {
+	nRight, oRight := n.RigthChild(), o.RigthChild()
+	if (nRight == nil) != (oRight == nil) {
 		return false
 	}
-	if n.rChild != nil && o.rChild != nil && !n.rChild.Equals(o.rChild) {
+	if nRight != nil && oRight != nil && !nRight.Equals(oRight) {
 		return false
 	}
 
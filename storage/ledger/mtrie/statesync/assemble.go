@@ -0,0 +1,65 @@
+package statesync
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/onflow/flow-go/storage/ledger/mtrie/node"
+	"github.com/onflow/flow-go/storage/ledger/mtrie/trie"
+)
+
+// Assemble builds the trie of the manifest from the roots of its pieces, as returned by DecodePiece, indexed
+// by their position. Empty pieces may be omitted. The nodes above the pieces are created bottom-up, where
+// a single leaf in a sub-trie is compacted to the root of the sub-trie again, and the resulting trie is
+// verified against the root hash of the manifest.
+func Assemble(m *Manifest, pieces map[int]*node.Node) (*trie.MTrie, error) {
+	level := make([]*node.Node, len(m.PieceHashes))
+	for i := range level {
+		if m.IsEmpty(i) {
+			continue
+		}
+		piece, ok := pieces[i]
+		if !ok || piece == nil {
+			return nil, fmt.Errorf("missing piece %d", i)
+		}
+		level[i] = piece
+	}
+
+	for height := m.Height - m.Depth + 1; len(level) > 1; height++ {
+		parents := make([]*node.Node, len(level)/2)
+		for i := range parents {
+			parents[i] = parent(height, level[2*i], level[2*i+1])
+		}
+		level = parents
+	}
+
+	root := level[0]
+	if root == nil {
+		root = node.NewEmptyTreeRoot(m.Height)
+	}
+	if !bytes.Equal(root.Hash(), m.RootHash) {
+		return nil, fmt.Errorf("assembled trie does not match manifest (expected: %s, got: %s)",
+			hex.EncodeToString(m.RootHash), hex.EncodeToString(root.Hash()))
+	}
+
+	t, err := trie.NewMTrie(root)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create trie: %w", err)
+	}
+	return t, nil
+}
+
+// parent returns the node at the given height with the given children
+func parent(height int, left, right *node.Node) *node.Node {
+	switch {
+	case left == nil && right == nil:
+		return nil
+	case left == nil && right.IsLeaf():
+		return node.NewLeaf(right.Key(), right.Value(), height)
+	case right == nil && left.IsLeaf():
+		return node.NewLeaf(left.Key(), left.Value(), height)
+	default:
+		return node.NewInterimNode(height, left, right)
+	}
+}
@@ -0,0 +1,153 @@
+package statesync
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/onflow/flow-go/storage/ledger/mtrie/common"
+	"github.com/onflow/flow-go/storage/ledger/mtrie/node"
+	"github.com/onflow/flow-go/storage/ledger/mtrie/trie"
+)
+
+// DefaultDepth is the default depth at which a trie is split into pieces, which results in 2^DefaultDepth pieces
+const DefaultDepth = 8
+
+// MaxDepth is the maximum depth at which a trie can be split into pieces
+const MaxDepth = 16
+
+// Manifest describes how the trie of a state is split into pieces, which can be transferred and verified
+// independently of each other. The pieces are the sub-tries rooted at the given depth, where the sub-trie
+// at index i covers all keys whose first Depth bits are the binary representation of i. As the hashes
+// of the pieces determine the root hash of the trie, a manifest can be verified against a state commitment,
+// and every piece against the manifest.
+type Manifest struct {
+	RootHash    []byte
+	Height      int      // height of the trie
+	Depth       int      // depth of the roots of the pieces
+	PieceHashes [][]byte // root hashes of the pieces, from left to right
+}
+
+// NewManifest returns the manifest of the trie split at the given depth, along with the roots of the pieces.
+// The roots of empty pieces are nil. A compact leaf above the given depth is pushed down to the root of its
+// piece, which does not change its hash.
+func NewManifest(t *trie.MTrie, depth int) (*Manifest, []*node.Node, error) {
+	height := t.KeyLength() * 8
+	if depth < 0 || depth > MaxDepth || depth > height {
+		return nil, nil, fmt.Errorf("invalid depth %d for splitting a trie of height %d", depth, height)
+	}
+
+	pieceHeight := height - depth
+	roots := make([]*node.Node, 1<<depth)
+
+	var split func(n *node.Node, d int, index int) error
+	split = func(n *node.Node, d int, index int) error {
+		if n == nil {
+			return nil
+		}
+		if d == depth {
+			roots[index] = n
+			return nil
+		}
+		if n.IsLeaf() {
+			prefix, err := keyPrefix(n.Key(), depth)
+			if err != nil {
+				return fmt.Errorf("invalid key of leaf: %w", err)
+			}
+			roots[prefix] = node.NewLeaf(n.Key(), n.Value(), pieceHeight)
+			return nil
+		}
+		// the root of an empty trie has no children
+		err := split(n.LeftChild(), d+1, 2*index)
+		if err != nil {
+			return err
+		}
+		return split(n.RigthChild(), d+1, 2*index+1)
+	}
+	err := split(t.RootNode(), 0, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defaultHash := common.GetDefaultHashForHeight(pieceHeight)
+	hashes := make([][]byte, len(roots))
+	for i, root := range roots {
+		if root == nil || bytes.Equal(root.Hash(), defaultHash) {
+			// pieces without allocated registers do not have to be transferred
+			roots[i] = nil
+			hashes[i] = defaultHash
+			continue
+		}
+		hashes[i] = root.Hash()
+	}
+
+	manifest := &Manifest{
+		RootHash:    t.RootHash(),
+		Height:      height,
+		Depth:       depth,
+		PieceHashes: hashes,
+	}
+
+	return manifest, roots, nil
+}
+
+// Verify checks that the piece hashes of the manifest hash up to the given root hash.
+func (m *Manifest) Verify(rootHash []byte) error {
+	if m.Depth < 0 || m.Depth > MaxDepth || m.Depth > m.Height {
+		return fmt.Errorf("invalid depth %d for a trie of height %d", m.Depth, m.Height)
+	}
+	if len(m.PieceHashes) != 1<<m.Depth {
+		return fmt.Errorf("invalid number of piece hashes (expected: %d, got: %d)", 1<<m.Depth, len(m.PieceHashes))
+	}
+
+	level := m.PieceHashes
+	for len(level) > 1 {
+		parents := make([][]byte, len(level)/2)
+		for i := range parents {
+			parents[i] = common.HashInterNode(level[2*i], level[2*i+1])
+		}
+		level = parents
+	}
+
+	if !bytes.Equal(level[0], rootHash) {
+		return fmt.Errorf("piece hashes do not match root hash (expected: %s, got: %s)",
+			hex.EncodeToString(rootHash), hex.EncodeToString(level[0]))
+	}
+	if !bytes.Equal(m.RootHash, rootHash) {
+		return fmt.Errorf("manifest has a different root hash (expected: %s, got: %s)",
+			hex.EncodeToString(rootHash), hex.EncodeToString(m.RootHash))
+	}
+	return nil
+}
+
+// IsEmpty returns true if the piece at the given index has no allocated registers, and does not have to be transferred.
+func (m *Manifest) IsEmpty(index int) bool {
+	return bytes.Equal(m.PieceHashes[index], common.GetDefaultHashForHeight(m.Height-m.Depth))
+}
+
+// Pieces returns the indices of the pieces which have to be transferred.
+func (m *Manifest) Pieces() []int {
+	var indices []int
+	for i := range m.PieceHashes {
+		if !m.IsEmpty(i) {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// keyPrefix returns the first bits of the key as integer
+func keyPrefix(key []byte, bits int) (int, error) {
+	prefix := 0
+	for i := 0; i < bits; i++ {
+		set, err := common.IsBitSet(key, i)
+		if err != nil {
+			return 0, err
+		}
+		prefix <<= 1
+		if set {
+			prefix |= 1
+		}
+	}
+	return prefix, nil
+}
@@ -0,0 +1,264 @@
+package statesync
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/onflow/flow-go/storage/ledger/mtrie/common"
+	"github.com/onflow/flow-go/storage/ledger/mtrie/node"
+)
+
+const pieceEncodingVersion = uint16(0)
+
+// EncodePiece encodes the sub-trie with the given root as a sequence of nodes, which satisfies the
+// Descendents-First-Relationship and ends with the root. Each node is encoded as
+//   2-bytes Big Endian uint16 height
+//   8-bytes Big Endian uint64 index of the left child (0 for none)
+//   8-bytes Big Endian uint64 index of the right child (0 for none)
+//   2-bytes Big Endian uint16 key length, key bytes
+//   4-bytes Big Endian uint32 value length, value bytes
+// The hashes of the nodes are not encoded, as they are recomputed when decoding.
+func EncodePiece(root *node.Node) []byte {
+	var nodes []*node.Node
+	var collect func(n *node.Node)
+	collect = func(n *node.Node) {
+		if n == nil {
+			return
+		}
+		collect(n.LeftChild())
+		collect(n.RigthChild())
+		nodes = append(nodes, n)
+	}
+	collect(root)
+
+	buf := make([]byte, 2+8)
+	binary.BigEndian.PutUint16(buf, pieceEncodingVersion)
+	binary.BigEndian.PutUint64(buf[2:], uint64(len(nodes)))
+
+	// index 0 is reserved for nil children
+	index := make(map[*node.Node]uint64, len(nodes)+1)
+	index[nil] = 0
+	for i, n := range nodes {
+		index[n] = uint64(i + 1)
+	}
+
+	for _, n := range nodes {
+		header := make([]byte, 2+8+8)
+		binary.BigEndian.PutUint16(header, uint16(n.Height()))
+		binary.BigEndian.PutUint64(header[2:], index[n.LeftChild()])
+		binary.BigEndian.PutUint64(header[10:], index[n.RigthChild()])
+		buf = append(buf, header...)
+
+		buf = append(buf, 0, 0)
+		binary.BigEndian.PutUint16(buf[len(buf)-2:], uint16(len(n.Key())))
+		buf = append(buf, n.Key()...)
+
+		buf = append(buf, 0, 0, 0, 0)
+		binary.BigEndian.PutUint32(buf[len(buf)-4:], uint32(len(n.Value())))
+		buf = append(buf, n.Value()...)
+	}
+
+	return buf
+}
+
+// DecodePiece decodes the piece at the given index of the manifest and verifies it against the manifest.
+// The hashes of the nodes are recomputed, so that the piece is only accepted if its nodes hash up to the
+// piece hash in the manifest. Furthermore, the keys of all leaves have to be located at their position
+// in the trie.
+func DecodePiece(m *Manifest, index int, encoded []byte) (*node.Node, error) {
+	if index < 0 || index >= len(m.PieceHashes) {
+		return nil, fmt.Errorf("invalid piece index %d", index)
+	}
+	if m.IsEmpty(index) {
+		return nil, fmt.Errorf("piece %d is empty", index)
+	}
+
+	r := &reader{data: encoded}
+	version, err := r.uint16()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read piece encoding version: %w", err)
+	}
+	if version != pieceEncodingVersion {
+		return nil, fmt.Errorf("unsupported piece encoding version %d", version)
+	}
+	count, err := r.uint64()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read number of nodes: %w", err)
+	}
+	if count == 0 || count > uint64(len(r.data)) {
+		return nil, fmt.Errorf("invalid number of nodes %d", count)
+	}
+
+	pieceHeight := m.Height - m.Depth
+	nodes := make([]*node.Node, 1, count+1)
+	for i := uint64(1); i <= count; i++ {
+		n, err := readNode(r, nodes, pieceHeight, m.Height)
+		if err != nil {
+			return nil, fmt.Errorf("invalid node %d: %w", i, err)
+		}
+		nodes = append(nodes, n)
+	}
+	if len(r.data) > 0 {
+		return nil, fmt.Errorf("piece has %d trailing bytes", len(r.data))
+	}
+
+	root := nodes[count]
+	if root.Height() != pieceHeight {
+		return nil, fmt.Errorf("invalid height of piece root (expected: %d, got: %d)", pieceHeight, root.Height())
+	}
+	if !bytes.Equal(root.Hash(), m.PieceHashes[index]) {
+		return nil, fmt.Errorf("piece does not match manifest (expected: %s, got: %s)",
+			hex.EncodeToString(m.PieceHashes[index]), hex.EncodeToString(root.Hash()))
+	}
+
+	err = checkKeys(root, m.Height, m.Depth, index)
+	if err != nil {
+		return nil, err
+	}
+
+	return root, nil
+}
+
+// readNode reads the next node of a piece, given the previously read nodes. Only the structure and
+// content of the node is read, its hash is computed.
+func readNode(r *reader, nodes []*node.Node, maxHeight int, treeHeight int) (*node.Node, error) {
+	height, err := r.uint16()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read height: %w", err)
+	}
+	lIndex, err := r.uint64()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read left child: %w", err)
+	}
+	rIndex, err := r.uint64()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read right child: %w", err)
+	}
+	keyLength, err := r.uint16()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read key length: %w", err)
+	}
+	key, err := r.bytes(int(keyLength))
+	if err != nil {
+		return nil, fmt.Errorf("cannot read key: %w", err)
+	}
+	valueLength, err := r.uint32()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read value length: %w", err)
+	}
+	value, err := r.bytes(int(valueLength))
+	if err != nil {
+		return nil, fmt.Errorf("cannot read value: %w", err)
+	}
+
+	if int(height) > maxHeight {
+		return nil, fmt.Errorf("height %d exceeds height of the piece %d", height, maxHeight)
+	}
+	if lIndex >= uint64(len(nodes)) || rIndex >= uint64(len(nodes)) {
+		return nil, fmt.Errorf("sequence of nodes does not satisfy Descendents-First-Relationship")
+	}
+
+	if len(key) > 0 {
+		if lIndex != 0 || rIndex != 0 {
+			return nil, fmt.Errorf("leaf cannot have children")
+		}
+		if len(key)*8 != treeHeight {
+			return nil, fmt.Errorf("invalid key length %d", len(key))
+		}
+		return node.NewLeaf(key, value, int(height)), nil
+	}
+
+	if len(value) > 0 {
+		return nil, fmt.Errorf("interim node cannot have a value")
+	}
+	if height == 0 {
+		return nil, fmt.Errorf("interim node cannot be at height 0")
+	}
+	left := nodes[lIndex]
+	right := nodes[rIndex]
+	if left == nil && right == nil {
+		return nil, fmt.Errorf("interim node without children")
+	}
+	for _, child := range []*node.Node{left, right} {
+		if child != nil && child.Height() != int(height)-1 {
+			return nil, fmt.Errorf("invalid height of child (expected: %d, got: %d)", height-1, child.Height())
+		}
+	}
+	return node.NewInterimNode(int(height), left, right), nil
+}
+
+// checkKeys checks that the keys of all leaves of the piece begin with the bits leading to their position.
+func checkKeys(root *node.Node, treeHeight int, depth int, index int) error {
+	var check func(n *node.Node, route []bool) error
+	check = func(n *node.Node, route []bool) error {
+		if n == nil {
+			return nil
+		}
+		if n.IsLeaf() {
+			for i, right := range route {
+				set, err := common.IsBitSet(n.Key(), i)
+				if err != nil {
+					return fmt.Errorf("invalid key of leaf: %w", err)
+				}
+				if set != right {
+					return fmt.Errorf("leaf with key %s is not located at its position", hex.EncodeToString(n.Key()))
+				}
+			}
+			return nil
+		}
+		err := check(n.LeftChild(), append(route, false))
+		if err != nil {
+			return err
+		}
+		return check(n.RigthChild(), append(route, true))
+	}
+
+	route := make([]bool, depth, treeHeight)
+	for i := 0; i < depth; i++ {
+		route[i] = index&(1<<(depth-1-i)) != 0
+	}
+	return check(root, route)
+}
+
+// reader reads the fields of an encoded piece, without allocating more than the length of the piece
+type reader struct {
+	data []byte
+}
+
+func (r *reader) bytes(n int) ([]byte, error) {
+	if n > len(r.data) {
+		return nil, fmt.Errorf("expected %d bytes, but only %d left", n, len(r.data))
+	}
+	if n == 0 {
+		return nil, nil
+	}
+	b := r.data[:n:n]
+	r.data = r.data[n:]
+	return b, nil
+}
+
+func (r *reader) uint16() (uint16, error) {
+	b, err := r.bytes(2)
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint16(b), nil
+}
+
+func (r *reader) uint32() (uint32, error) {
+	b, err := r.bytes(4)
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint32(b), nil
+}
+
+func (r *reader) uint64() (uint64, error) {
+	b, err := r.bytes(8)
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(b), nil
+}
@@ -0,0 +1,145 @@
+package statesync_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/storage/ledger/mtrie/common"
+	"github.com/onflow/flow-go/storage/ledger/mtrie/node"
+	"github.com/onflow/flow-go/storage/ledger/mtrie/statesync"
+	"github.com/onflow/flow-go/storage/ledger/mtrie/trie"
+)
+
+func TestSplitAndAssemble(t *testing.T) {
+
+	t.Run("empty trie", func(t *testing.T) {
+		emptyTrie, err := trie.NewEmptyMTrie(32)
+		require.NoError(t, err)
+		for _, depth := range []int{0, 1, statesync.DefaultDepth} {
+			requireSyncedTrie(t, emptyTrie, depth)
+		}
+	})
+
+	t.Run("compact leaves above pieces", func(t *testing.T) {
+		keys := [][]byte{{1}, {2}, {130}}
+		values := [][]byte{[]byte("a"), []byte("b"), []byte("c")}
+		mTrie := newTrie(t, 1, keys, values)
+		for depth := 0; depth <= 8; depth++ {
+			requireSyncedTrie(t, mTrie, depth)
+		}
+	})
+
+	t.Run("random trie", func(t *testing.T) {
+		keys := common.GetRandomKeysFixedN(1000, 32)
+		values := common.GetRandomValues(1000, 100)
+		mTrie := newTrie(t, 32, keys, values)
+		for _, depth := range []int{0, 3, statesync.DefaultDepth, 12} {
+			requireSyncedTrie(t, mTrie, depth)
+		}
+	})
+}
+
+func TestVerification(t *testing.T) {
+	keys := common.GetRandomKeysFixedN(100, 32)
+	values := common.GetRandomValues(100, 100)
+	mTrie := newTrie(t, 32, keys, values)
+
+	manifest, roots, err := statesync.NewManifest(mTrie, 4)
+	require.NoError(t, err)
+	require.NoError(t, manifest.Verify(mTrie.RootHash()))
+
+	t.Run("tampered manifest", func(t *testing.T) {
+		tampered := *manifest
+		tampered.PieceHashes = append([][]byte{}, manifest.PieceHashes...)
+		tampered.PieceHashes[0], tampered.PieceHashes[1] = tampered.PieceHashes[1], tampered.PieceHashes[0]
+		require.Error(t, tampered.Verify(mTrie.RootHash()))
+
+		tampered = *manifest
+		tampered.PieceHashes = manifest.PieceHashes[1:]
+		require.Error(t, tampered.Verify(mTrie.RootHash()))
+	})
+
+	indices := manifest.Pieces()
+	require.NotEmpty(t, indices)
+	index := indices[0]
+	encoded := statesync.EncodePiece(roots[index])
+
+	t.Run("tampered piece", func(t *testing.T) {
+		// a well-formed piece of a trie with a different value for one register
+		key := allKeys(roots[index])[0]
+		updated, err := trie.NewTrieWithUpdatedRegisters(mTrie, [][]byte{key}, common.GetRandomValues(1, 100))
+		require.NoError(t, err)
+		_, updatedRoots, err := statesync.NewManifest(updated, 4)
+		require.NoError(t, err)
+		_, err = statesync.DecodePiece(manifest, index, statesync.EncodePiece(updatedRoots[index]))
+		require.Error(t, err)
+
+		_, err = statesync.DecodePiece(manifest, index, encoded[:len(encoded)-1])
+		require.Error(t, err)
+	})
+
+	t.Run("piece at wrong position", func(t *testing.T) {
+		other := indices[1]
+		_, err := statesync.DecodePiece(manifest, other, encoded)
+		require.Error(t, err)
+	})
+
+	t.Run("missing piece", func(t *testing.T) {
+		pieces := make(map[int]*node.Node)
+		for _, i := range indices[1:] {
+			pieces[i], err = statesync.DecodePiece(manifest, i, statesync.EncodePiece(roots[i]))
+			require.NoError(t, err)
+		}
+		_, err := statesync.Assemble(manifest, pieces)
+		require.Error(t, err)
+	})
+}
+
+// requireSyncedTrie splits the trie into pieces, transfers them through their encoding and requires
+// the assembled trie to be equivalent to the original one
+func requireSyncedTrie(t *testing.T, mTrie *trie.MTrie, depth int) {
+	manifest, roots, err := statesync.NewManifest(mTrie, depth)
+	require.NoError(t, err)
+	require.Len(t, manifest.PieceHashes, 1<<depth)
+	require.NoError(t, manifest.Verify(mTrie.RootHash()))
+
+	pieces := make(map[int]*node.Node)
+	for _, i := range manifest.Pieces() {
+		encoded := statesync.EncodePiece(roots[i])
+		pieces[i], err = statesync.DecodePiece(manifest, i, encoded)
+		require.NoError(t, err)
+	}
+
+	assembled, err := statesync.Assemble(manifest, pieces)
+	require.NoError(t, err)
+	require.Equal(t, mTrie.RootHash(), assembled.RootHash())
+	require.Equal(t, mTrie.AllocatedRegCount(), assembled.AllocatedRegCount())
+
+	keys := allKeys(mTrie.RootNode())
+	expected, err := mTrie.UnsafeRead(keys)
+	require.NoError(t, err)
+	actual, err := assembled.UnsafeRead(keys)
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+}
+
+func newTrie(t *testing.T, keyByteSize int, keys [][]byte, values [][]byte) *trie.MTrie {
+	emptyTrie, err := trie.NewEmptyMTrie(keyByteSize)
+	require.NoError(t, err)
+
+	mTrie, err := trie.NewTrieWithUpdatedRegisters(emptyTrie, keys, values)
+	require.NoError(t, err)
+	return mTrie
+}
+
+// allKeys returns the keys of all leaves in sorted order
+func allKeys(n *node.Node) [][]byte {
+	if n == nil {
+		return nil
+	}
+	if n.IsLeaf() {
+		return [][]byte{n.Key()}
+	}
+	return append(allKeys(n.LeftChild()), allKeys(n.RigthChild())...)
+}
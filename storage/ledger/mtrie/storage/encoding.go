@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/onflow/flow-go/storage/ledger/mtrie/node"
+)
+
+const encodingDecodingVersion = uint16(0)
+
+// encodeNode encodes a node with the given children for the storage as
+//   2-bytes Big Endian uint16 encoding version
+//   2-bytes Big Endian uint16 height
+//   2-bytes Big Endian uint16 max depth
+//   8-bytes Big Endian uint64 register count
+//   2-bytes Big Endian uint16 hash length, hash of the left child (empty for no child)
+//   2-bytes Big Endian uint16 hash length, hash of the right child (empty for no child)
+//   2-bytes Big Endian uint16 key length, key bytes
+//   4-bytes Big Endian uint32 value length, value bytes
+// The hash of the node is not encoded, as it is the key of the node in the storage.
+func encodeNode(n *node.Node, lChild *node.Node, rChild *node.Node) []byte {
+
+	var lHash, rHash []byte
+	if lChild != nil {
+		lHash = lChild.Hash()
+	}
+	if rChild != nil {
+		rHash = rChild.Hash()
+	}
+
+	length := 2 + 2 + 2 + 8 + 2 + len(lHash) + 2 + len(rHash) + 2 + len(n.Key()) + 4 + len(n.Value())
+	buf := make([]byte, 14, length)
+
+	binary.BigEndian.PutUint16(buf, encodingDecodingVersion)
+	binary.BigEndian.PutUint16(buf[2:], uint16(n.Height()))
+	binary.BigEndian.PutUint16(buf[4:], n.MaxDepth())
+	binary.BigEndian.PutUint64(buf[6:], n.RegCount())
+
+	buf = appendShortData(buf, lHash)
+	buf = appendShortData(buf, rHash)
+	buf = appendShortData(buf, n.Key())
+
+	buf = append(buf, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(buf[len(buf)-4:], uint32(len(n.Value())))
+	buf = append(buf, n.Value()...)
+
+	return buf
+}
+
+// decodeNode decodes a node with the given hash as a stored node loading its children from the storage
+func decodeNode(encoded []byte, hashValue []byte, storage node.Storage) (*node.Node, error) {
+	if len(encoded) < 14 {
+		return nil, fmt.Errorf("encoded node is too short (%d bytes)", len(encoded))
+	}
+
+	version := binary.BigEndian.Uint16(encoded)
+	if version > encodingDecodingVersion {
+		return nil, fmt.Errorf("unsuported version %d > %d", version, encodingDecodingVersion)
+	}
+	height := binary.BigEndian.Uint16(encoded[2:])
+	maxDepth := binary.BigEndian.Uint16(encoded[4:])
+	regCount := binary.BigEndian.Uint64(encoded[6:])
+	rest := encoded[14:]
+
+	lHash, rest, err := readShortData(rest)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read left child hash: %w", err)
+	}
+
+	rHash, rest, err := readShortData(rest)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read right child hash: %w", err)
+	}
+
+	key, rest, err := readShortData(rest)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read key: %w", err)
+	}
+
+	if len(rest) < 4 {
+		return nil, fmt.Errorf("cannot read value length")
+	}
+	size := binary.BigEndian.Uint32(rest)
+	value := rest[4:]
+	if int(size) != len(value) {
+		return nil, fmt.Errorf("value size %d does not match the remaining %d bytes", size, len(value))
+	}
+
+	return node.NewStoredNode(int(height), nonEmpty(lHash), nonEmpty(rHash), nonEmpty(key), value, hashValue, maxDepth, regCount, storage), nil
+}
+
+// nonEmpty returns nil for empty slices, which the node uses to denote absent children and keys
+func nonEmpty(b []byte) []byte {
+	if len(b) == 0 {
+		return nil
+	}
+	return b
+}
+
+// appendShortData appends the data prefixed with its uint16 length
+func appendShortData(buf []byte, data []byte) []byte {
+	buf = append(buf, 0, 0)
+	binary.BigEndian.PutUint16(buf[len(buf)-2:], uint16(len(data)))
+	return append(buf, data...)
+}
+
+// readShortData reads data prefixed with its uint16 length and returns the rest of bytes
+func readShortData(input []byte) ([]byte, []byte, error) {
+	if len(input) < 2 {
+		return nil, nil, fmt.Errorf("cannot read data length")
+	}
+	size := int(binary.BigEndian.Uint16(input))
+	if len(input) < 2+size {
+		return nil, nil, fmt.Errorf("data size %d exceeds the remaining %d bytes", size, len(input)-2)
+	}
+	return input[2 : 2+size], input[2+size:], nil
+}
@@ -0,0 +1,141 @@
+package storage_test
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/storage/ledger/mtrie/proof"
+	"github.com/onflow/flow-go/storage/ledger/mtrie/storage"
+	"github.com/onflow/flow-go/storage/ledger/mtrie/trie"
+	"github.com/onflow/flow-go/storage/ledger/utils"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+const keyByteSize = 32
+
+// TestDiskStorage_PersistLoad evaluates that a stored trie, whose nodes mostly do not fit into the cache,
+// returns the same values and proofs as the trie held in memory.
+func TestDiskStorage_PersistLoad(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		s, err := storage.NewDiskStorage(dir, 10)
+		require.NoError(t, err)
+		defer s.Close()
+
+		emptyTrie, err := trie.NewEmptyMTrie(keyByteSize)
+		require.NoError(t, err)
+
+		keys, values := randomUpdate(100)
+		memTrie, err := trie.NewTrieWithUpdatedRegisters(emptyTrie, keys, values)
+		require.NoError(t, err)
+
+		root, err := s.Persist(memTrie.RootNode())
+		require.NoError(t, err)
+		assert.True(t, root.IsStored())
+		assert.Equal(t, memTrie.RootHash(), root.Hash())
+		assert.Equal(t, memTrie.AllocatedRegCount(), root.RegCount())
+		assert.Equal(t, memTrie.MaxDepth(), root.MaxDepth())
+
+		storedTrie, err := trie.NewMTrie(root)
+		require.NoError(t, err)
+		requireEqualTries(t, memTrie, storedTrie, keys)
+
+		// updating a stored trie only persists the updated nodes, and results in the same trie as in memory
+		newKeys, newValues := randomUpdate(10)
+		updatedKeys := append([][]byte{keys[0]}, newKeys...)
+		updatedValues := append(utils.GetRandomValues(1, 1, 100), newValues...)
+		sortUpdate(updatedKeys, updatedValues)
+
+		updatedMemTrie, err := trie.NewTrieWithUpdatedRegisters(memTrie, updatedKeys, updatedValues)
+		require.NoError(t, err)
+		updatedStoredTrie, err := trie.NewTrieWithUpdatedRegisters(storedTrie, updatedKeys, updatedValues)
+		require.NoError(t, err)
+		require.Equal(t, updatedMemTrie.RootHash(), updatedStoredTrie.RootHash())
+
+		updatedRoot, err := s.Persist(updatedStoredTrie.RootNode())
+		require.NoError(t, err)
+		updatedStoredTrie, err = trie.NewMTrie(updatedRoot)
+		require.NoError(t, err)
+		requireEqualTries(t, updatedMemTrie, updatedStoredTrie, append(newKeys, keys...))
+
+		// the parent trie remains readable from the storage
+		requireEqualTries(t, memTrie, storedTrie, keys)
+	})
+}
+
+// TestDiskStorage_Reopen evaluates that nodes of a previous run are dropped when the storage is opened.
+func TestDiskStorage_Reopen(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		s, err := storage.NewDiskStorage(dir, 10)
+		require.NoError(t, err)
+
+		emptyTrie, err := trie.NewEmptyMTrie(keyByteSize)
+		require.NoError(t, err)
+		keys, values := randomUpdate(10)
+		memTrie, err := trie.NewTrieWithUpdatedRegisters(emptyTrie, keys, values)
+		require.NoError(t, err)
+
+		_, err = s.Persist(memTrie.RootNode())
+		require.NoError(t, err)
+		require.NoError(t, s.Close())
+
+		s, err = storage.NewDiskStorage(dir, 10)
+		require.NoError(t, err)
+		defer s.Close()
+
+		_, err = s.Load(memTrie.RootHash())
+		assert.Error(t, err)
+	})
+}
+
+// requireEqualTries requires both tries to return the same values and proofs for the given keys
+func requireEqualTries(t *testing.T, expected *trie.MTrie, actual *trie.MTrie, keys [][]byte) {
+	sorted := make([][]byte, len(keys))
+	copy(sorted, keys)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i], sorted[j]) < 0
+	})
+
+	expectedValues, err := expected.UnsafeRead(sorted)
+	require.NoError(t, err)
+	actualValues, err := actual.UnsafeRead(sorted)
+	require.NoError(t, err)
+	require.Equal(t, expectedValues, actualValues)
+
+	expectedProofs := proof.NewBatchProofWithEmptyProofs(len(sorted))
+	actualProofs := proof.NewBatchProofWithEmptyProofs(len(sorted))
+	for i := range sorted {
+		expectedProofs.Proofs[i].Flags = make([]byte, keyByteSize)
+		actualProofs.Proofs[i].Flags = make([]byte, keyByteSize)
+	}
+	require.NoError(t, expected.UnsafeProofs(sorted, expectedProofs.Proofs))
+	require.NoError(t, actual.UnsafeProofs(sorted, actualProofs.Proofs))
+	require.Equal(t, expectedProofs, actualProofs)
+}
+
+// randomUpdate returns n random sorted keys and values
+func randomUpdate(n int) ([][]byte, [][]byte) {
+	keys := utils.GetRandomKeysFixedN(n, keyByteSize)
+	values := utils.GetRandomValues(n, 1, 100)
+	sortUpdate(keys, values)
+	return keys, values
+}
+
+func sortUpdate(keys [][]byte, values [][]byte) {
+	sort.Sort(update{keys, values})
+}
+
+type update struct {
+	keys   [][]byte
+	values [][]byte
+}
+
+func (u update) Len() int           { return len(u.keys) }
+func (u update) Less(i, j int) bool { return bytes.Compare(u.keys[i], u.keys[j]) < 0 }
+func (u update) Swap(i, j int) {
+	u.keys[i], u.keys[j] = u.keys[j], u.keys[i]
+	u.values[i], u.values[j] = u.values[j], u.values[i]
+}
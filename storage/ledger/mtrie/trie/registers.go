@@ -0,0 +1,168 @@
+package trie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/onflow/flow-go/storage/ledger/mtrie/common"
+	"github.com/onflow/flow-go/storage/ledger/mtrie/node"
+)
+
+// RegisterChange is the change of the value of a register from one trie to another.
+// A nil value means that the register is not allocated.
+type RegisterChange struct {
+	Key []byte
+	Old []byte
+	New []byte
+}
+
+// Registers returns the keys and values of at most limit allocated registers of the trie in the order of their
+// keys, beginning with the first key greater than or equal to the given start key, or with the first key if start
+// is nil. It also returns the key to continue with, which is nil if there are no more registers.
+// Sub-tries on the left of the start key are not traversed.
+func (mt *MTrie) Registers(start []byte, limit int) ([][]byte, [][]byte, []byte, error) {
+	if start != nil && len(start) != mt.keyByteSize {
+		return nil, nil, nil, fmt.Errorf("start key has length %d, but trie has key length %d", len(start), mt.keyByteSize)
+	}
+	if limit < 1 {
+		return nil, nil, nil, errors.New("limit must be positive")
+	}
+
+	// one more leaf than requested determines where to continue
+	leaves := make([]*node.Node, 0, limit+1)
+	err := mt.collectLeaves(mt.root, start, start != nil, limit+1, &leaves)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var next []byte
+	if len(leaves) > limit {
+		next = leaves[limit].Key()
+		leaves = leaves[:limit]
+	}
+	keys := make([][]byte, 0, len(leaves))
+	values := make([][]byte, 0, len(leaves))
+	for _, leaf := range leaves {
+		keys = append(keys, leaf.Key())
+		values = append(values, leaf.Value())
+	}
+	return keys, values, next, nil
+}
+
+// collectLeaves appends the allocated leaves of the sub-trie in the order of their keys, until there are limit leaves.
+// If bounded, the sub-trie contains the start key, and only leaves with keys greater than or equal to it are appended.
+func (mt *MTrie) collectLeaves(n *node.Node, start []byte, bounded bool, limit int, leaves *[]*node.Node) error {
+	if n == nil || len(*leaves) >= limit {
+		return nil
+	}
+	if n.IsLeaf() {
+		if !isAllocated(n) {
+			return nil
+		}
+		if bounded && bytes.Compare(n.Key(), start) < 0 {
+			return nil
+		}
+		*leaves = append(*leaves, n)
+		return nil
+	}
+
+	if bounded {
+		right, err := common.IsBitSet(start, mt.height-n.Height())
+		if err != nil {
+			return fmt.Errorf("cannot read bit of start key: %w", err)
+		}
+		if right {
+			// the left sub-trie only contains smaller keys
+			return mt.collectLeaves(n.RigthChild(), start, true, limit, leaves)
+		}
+	}
+
+	err := mt.collectLeaves(n.LeftChild(), start, bounded, limit, leaves)
+	if err != nil {
+		return err
+	}
+	return mt.collectLeaves(n.RigthChild(), start, false, limit, leaves)
+}
+
+// Diff returns the changes of the allocated registers from one trie to the other, in the order of their keys.
+// Sub-tries which are shared by both tries, or have the same hash, are not traversed, so that the work is
+// proportional to the number of changes rather than the size of the tries.
+func Diff(from, to *MTrie) ([]RegisterChange, error) {
+	if from.height != to.height {
+		return nil, fmt.Errorf("tries have different heights %d and %d", from.height, to.height)
+	}
+	var changes []RegisterChange
+	diff(from.root, to.root, &changes)
+	return changes, nil
+}
+
+// diff appends the changes from one sub-trie to the other sub-trie at the same height
+func diff(from, to *node.Node, changes *[]RegisterChange) {
+	if from == to {
+		return
+	}
+	if from != nil && to != nil && bytes.Equal(from.Hash(), to.Hash()) {
+		return
+	}
+
+	// below a compact leaf, the sub-tries cannot be compared node by node
+	if from == nil || to == nil || from.IsLeaf() || to.IsLeaf() {
+		mergeLeaves(allocatedLeaves(from, nil), allocatedLeaves(to, nil), changes)
+		return
+	}
+
+	diff(from.LeftChild(), to.LeftChild(), changes)
+	diff(from.RigthChild(), to.RigthChild(), changes)
+}
+
+// mergeLeaves appends the changes between two lists of leaves, which are sorted by their keys
+func mergeLeaves(from, to []*node.Node, changes *[]RegisterChange) {
+	i, j := 0, 0
+	for i < len(from) || j < len(to) {
+		var cmp int
+		switch {
+		case i == len(from):
+			cmp = 1
+		case j == len(to):
+			cmp = -1
+		default:
+			cmp = bytes.Compare(from[i].Key(), to[j].Key())
+		}
+
+		switch {
+		case cmp < 0:
+			*changes = append(*changes, RegisterChange{Key: from[i].Key(), Old: from[i].Value()})
+			i++
+		case cmp > 0:
+			*changes = append(*changes, RegisterChange{Key: to[j].Key(), New: to[j].Value()})
+			j++
+		default:
+			if !bytes.Equal(from[i].Value(), to[j].Value()) {
+				*changes = append(*changes, RegisterChange{Key: from[i].Key(), Old: from[i].Value(), New: to[j].Value()})
+			}
+			i++
+			j++
+		}
+	}
+}
+
+// allocatedLeaves appends the allocated leaves of the sub-trie in the order of their keys
+func allocatedLeaves(n *node.Node, leaves []*node.Node) []*node.Node {
+	if n == nil {
+		return leaves
+	}
+	if n.IsLeaf() {
+		if isAllocated(n) {
+			leaves = append(leaves, n)
+		}
+		return leaves
+	}
+	leaves = allocatedLeaves(n.LeftChild(), leaves)
+	return allocatedLeaves(n.RigthChild(), leaves)
+}
+
+// isAllocated returns true if the leaf holds a register with a non-empty value
+func isAllocated(leaf *node.Node) bool {
+	return len(leaf.Value()) > 0
+}
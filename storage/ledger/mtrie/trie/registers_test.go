@@ -0,0 +1,177 @@
+package trie_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/storage/ledger/mtrie/trie"
+)
+
+func Test_Registers(t *testing.T) {
+	emptyTrie, err := trie.NewEmptyMTrie(ReferenceImplKeyByteSize)
+	require.NoError(t, err)
+
+	t.Run("empty trie", func(t *testing.T) {
+		keys, values, next, err := emptyTrie.Registers(nil, 10)
+		require.NoError(t, err)
+		require.Empty(t, keys)
+		require.Empty(t, values)
+		require.Nil(t, next)
+	})
+
+	rng := &LinearCongruentialGenerator{seed: 0}
+	keys, values := deduplicateRegisterWrites(sampleRandomRegisterWrites(rng, 500))
+	mTrie, err := trie.NewTrieWithUpdatedRegisters(emptyTrie, keys, values)
+	require.NoError(t, err)
+
+	// unallocated registers are not returned
+	unallocated := keys[:50]
+	mTrie, err = trie.NewTrieWithUpdatedRegisters(mTrie, unallocated, make([][]byte, len(unallocated)))
+	require.NoError(t, err)
+	expectedKeys, expectedValues := sortedRegisters(keys[50:], values[50:])
+
+	for _, limit := range []int{1, 7, 100, len(expectedKeys), len(expectedKeys) + 1} {
+		var allKeys, allValues [][]byte
+		var start []byte
+		for {
+			pageKeys, pageValues, next, err := mTrie.Registers(start, limit)
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(pageKeys), limit)
+			require.Len(t, pageValues, len(pageKeys))
+			allKeys = append(allKeys, pageKeys...)
+			allValues = append(allValues, pageValues...)
+			if next == nil {
+				break
+			}
+			require.Len(t, pageKeys, limit)
+			start = next
+		}
+		require.Equal(t, expectedKeys, allKeys)
+		require.Equal(t, expectedValues, allValues)
+	}
+
+	t.Run("start between keys", func(t *testing.T) {
+		start := uint2binary(binary.BigEndian.Uint16(expectedKeys[10]) + 1)
+		first := sort.Search(len(expectedKeys), func(i int) bool {
+			return bytes.Compare(expectedKeys[i], start) >= 0
+		})
+
+		pageKeys, pageValues, next, err := mTrie.Registers(start, 5)
+		require.NoError(t, err)
+		require.Equal(t, expectedKeys[first:first+5], pageKeys)
+		require.Equal(t, expectedValues[first:first+5], pageValues)
+		require.Equal(t, expectedKeys[first+5], next)
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		_, _, _, err := mTrie.Registers([]byte{1}, 10)
+		require.Error(t, err)
+		_, _, _, err = mTrie.Registers(nil, 0)
+		require.Error(t, err)
+	})
+}
+
+func Test_Diff(t *testing.T) {
+	emptyTrie, err := trie.NewEmptyMTrie(ReferenceImplKeyByteSize)
+	require.NoError(t, err)
+
+	rng := &LinearCongruentialGenerator{seed: 0}
+	keys, values := deduplicateRegisterWrites(sampleRandomRegisterWrites(rng, 500))
+	from, err := trie.NewTrieWithUpdatedRegisters(emptyTrie, keys, values)
+	require.NoError(t, err)
+
+	// update some registers, unallocate others, and allocate new ones
+	updatedKeys, updatedValues := sampleRandomRegisterWrites(rng, 100)
+	for i := 0; i < 20; i++ {
+		updatedKeys = append(updatedKeys, keys[i])
+		updatedValues = append(updatedValues, []byte{})
+	}
+	updatedKeys, updatedValues = deduplicateRegisterWrites(updatedKeys, updatedValues)
+	to, err := trie.NewTrieWithUpdatedRegisters(from, updatedKeys, updatedValues)
+	require.NoError(t, err)
+
+	t.Run("no changes", func(t *testing.T) {
+		changes, err := trie.Diff(from, from)
+		require.NoError(t, err)
+		require.Empty(t, changes)
+	})
+
+	t.Run("changes", func(t *testing.T) {
+		changes, err := trie.Diff(from, to)
+		require.NoError(t, err)
+		require.Equal(t, expectedChanges(t, from, to, append(keys, updatedKeys...)), changes)
+
+		reverse, err := trie.Diff(to, from)
+		require.NoError(t, err)
+		require.Len(t, reverse, len(changes))
+		for i, change := range changes {
+			require.Equal(t, change.Key, reverse[i].Key)
+			require.Equal(t, change.Old, reverse[i].New)
+			require.Equal(t, change.New, reverse[i].Old)
+		}
+	})
+
+	t.Run("from empty trie", func(t *testing.T) {
+		changes, err := trie.Diff(emptyTrie, from)
+		require.NoError(t, err)
+		require.Len(t, changes, int(from.AllocatedRegCount()))
+	})
+}
+
+// expectedChanges computes the changes between the tries by reading all given keys from both of them
+func expectedChanges(t *testing.T, from, to *trie.MTrie, keys [][]byte) []trie.RegisterChange {
+	unique := make(map[string][]byte)
+	for _, key := range keys {
+		unique[string(key)] = key
+	}
+	sorted := make([][]byte, 0, len(unique))
+	for _, key := range unique {
+		sorted = append(sorted, key)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i], sorted[j]) < 0
+	})
+
+	fromValues, err := from.UnsafeRead(sorted)
+	require.NoError(t, err)
+	toValues, err := to.UnsafeRead(sorted)
+	require.NoError(t, err)
+
+	var changes []trie.RegisterChange
+	for i, key := range sorted {
+		if bytes.Equal(fromValues[i], toValues[i]) {
+			continue
+		}
+		change := trie.RegisterChange{Key: key}
+		if len(fromValues[i]) > 0 {
+			change.Old = fromValues[i]
+		}
+		if len(toValues[i]) > 0 {
+			change.New = toValues[i]
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// sortedRegisters returns the keys and values of the registers in the order of their keys
+func sortedRegisters(keys [][]byte, values [][]byte) ([][]byte, [][]byte) {
+	indices := make([]int, len(keys))
+	for i := range indices {
+		indices[i] = i
+	}
+	sort.Slice(indices, func(i, j int) bool {
+		return bytes.Compare(keys[indices[i]], keys[indices[j]]) < 0
+	})
+	sortedKeys := make([][]byte, 0, len(keys))
+	sortedValues := make([][]byte, 0, len(values))
+	for _, i := range indices {
+		sortedKeys = append(sortedKeys, keys[i])
+		sortedValues = append(sortedValues, values[i])
+	}
+	return sortedKeys, sortedValues
+}
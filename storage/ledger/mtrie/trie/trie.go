@@ -48,6 +48,11 @@ type MTrie struct {
 	keyByteSize int
 }
 
+// DefaultParallelUpdateThreshold is the default minimum number of registers updated below a node
+// for the left and right sub-tries of the node to be updated concurrently. Below the threshold,
+// the overhead of a goroutine outweighs the gain of updating the sub-tries concurrently.
+const DefaultParallelUpdateThreshold = 64
+
 func NewEmptyMTrie(keyByteSize int) (*MTrie, error) {
 	if keyByteSize < 1 {
 		return nil, errors.New("trie's key size [in bytes] must be positive")
@@ -170,8 +175,18 @@ func (mt *MTrie) read(head *node.Node, keys [][]byte) ([][]byte, error) {
 //   * keys are NOT duplicated
 // TODO: move consistency checks from MForest to here, to make API is safe and self-contained
 func NewTrieWithUpdatedRegisters(parentTrie *MTrie, updatedRegisterKeys [][]byte, updatedRegisterValues [][]byte) (*MTrie, error) {
+	return NewTrieWithParallelUpdate(parentTrie, updatedRegisterKeys, updatedRegisterValues, DefaultParallelUpdateThreshold)
+}
+
+// NewTrieWithParallelUpdate constructs a new trie containing all registers from the parent trie,
+// like NewTrieWithUpdatedRegisters. The left and right sub-tries of a node are updated concurrently
+// if at least parallelThreshold registers are updated below the node. A non-positive threshold
+// disables concurrency, i.e. the trie is updated serially.
+// UNSAFE: method requires the following conditions to be satisfied:
+//   * keys are NOT duplicated
+func NewTrieWithParallelUpdate(parentTrie *MTrie, updatedRegisterKeys [][]byte, updatedRegisterValues [][]byte, parallelThreshold int) (*MTrie, error) {
 	parentRoot := parentTrie.root
-	updatedRoot, err := update(parentTrie.height, parentRoot.Height(), parentRoot, updatedRegisterKeys, updatedRegisterValues)
+	updatedRoot, err := update(parentTrie.height, parentRoot.Height(), parentRoot, updatedRegisterKeys, updatedRegisterValues, parallelThreshold)
 	if err != nil {
 		return nil, fmt.Errorf("constructing updated trie failed: %w", err)
 	}
@@ -189,9 +204,9 @@ func NewTrieWithUpdatedRegisters(parentTrie *MTrie, updatedRegisterKeys [][]byte
 //     (excluding the bit at index headHeight)
 //   * keys are NOT duplicated
 // TODO: remove error return
-func update(treeHeight int, nodeHeight int, parentNode *node.Node, keys [][]byte, values [][]byte) (*node.Node, error) {
+func update(treeHeight int, nodeHeight int, parentNode *node.Node, keys [][]byte, values [][]byte, parallelThreshold int) (*node.Node, error) {
 	if parentNode == nil { // parent Trie has no sub-trie for the set of key-value pairs => construct entire subtree
-		return constructSubtrie(treeHeight, nodeHeight, keys, values)
+		return constructSubtrie(treeHeight, nodeHeight, keys, values, parallelThreshold)
 	}
 	if len(keys) == 0 { // We are not changing any values in this sub-trie => return parent trie
 		return parentNode, nil
@@ -212,7 +227,7 @@ func update(treeHeight int, nodeHeight int, parentNode *node.Node, keys [][]byte
 			keys = append(keys, parentKey)
 			values = append(values, parentNode.Value())
 		}
-		return constructSubtrie(treeHeight, nodeHeight, keys, values)
+		return constructSubtrie(treeHeight, nodeHeight, keys, values, parallelThreshold)
 	}
 
 	// Split the keys and Values array so we can update the trie in parallel
@@ -221,17 +236,21 @@ func update(treeHeight int, nodeHeight int, parentNode *node.Node, keys [][]byte
 		return nil, fmt.Errorf("error spliting key Values: %w", err)
 	}
 
-	// TODO [runtime optimization]: do not branch if either lkeys or rkeys is empty
 	var lChild, rChild *node.Node
 	var lErr, rErr error
-	wg := sync.WaitGroup{}
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		lChild, lErr = update(treeHeight, nodeHeight-1, parentNode.LeftChild(), lkeys, lvalues)
-	}()
-	rChild, rErr = update(treeHeight, nodeHeight-1, parentNode.RigthChild(), rkeys, rvalues)
-	wg.Wait()
+	if isParallel(lkeys, rkeys, parallelThreshold) {
+		wg := sync.WaitGroup{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			lChild, lErr = update(treeHeight, nodeHeight-1, parentNode.LeftChild(), lkeys, lvalues, parallelThreshold)
+		}()
+		rChild, rErr = update(treeHeight, nodeHeight-1, parentNode.RigthChild(), rkeys, rvalues, parallelThreshold)
+		wg.Wait()
+	} else {
+		lChild, lErr = update(treeHeight, nodeHeight-1, parentNode.LeftChild(), lkeys, lvalues, parallelThreshold)
+		rChild, rErr = update(treeHeight, nodeHeight-1, parentNode.RigthChild(), rkeys, rvalues, parallelThreshold)
+	}
 	if lErr != nil || rErr != nil {
 		var merr *multierror.Error
 		if lErr != nil {
@@ -256,7 +275,7 @@ func update(treeHeight int, nodeHeight int, parentNode *node.Node, keys [][]byte
 //   * keys contains at least one element
 //   * keys are NOT duplicated
 // TODO: remove error return
-func constructSubtrie(treeHeight int, nodeHeight int, keys [][]byte, values [][]byte) (*node.Node, error) {
+func constructSubtrie(treeHeight int, nodeHeight int, keys [][]byte, values [][]byte, parallelThreshold int) (*node.Node, error) {
 	// no keys => default value, represented by nil node
 	if len(keys) == 0 {
 		return nil, nil
@@ -277,17 +296,21 @@ func constructSubtrie(treeHeight int, nodeHeight int, keys [][]byte, values [][]
 		return nil, fmt.Errorf("error spliting key Values: %w", err)
 	}
 
-	// TODO [runtime optimization]: do not branch if either lkeys or rkeys is empty
 	var lChild, rChild *node.Node
 	var lErr, rErr error
-	wg := sync.WaitGroup{}
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		lChild, lErr = constructSubtrie(treeHeight, nodeHeight-1, lkeys, lvalues)
-	}()
-	rChild, rErr = constructSubtrie(treeHeight, nodeHeight-1, rkeys, rvalues)
-	wg.Wait()
+	if isParallel(lkeys, rkeys, parallelThreshold) {
+		wg := sync.WaitGroup{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			lChild, lErr = constructSubtrie(treeHeight, nodeHeight-1, lkeys, lvalues, parallelThreshold)
+		}()
+		rChild, rErr = constructSubtrie(treeHeight, nodeHeight-1, rkeys, rvalues, parallelThreshold)
+		wg.Wait()
+	} else {
+		lChild, lErr = constructSubtrie(treeHeight, nodeHeight-1, lkeys, lvalues, parallelThreshold)
+		rChild, rErr = constructSubtrie(treeHeight, nodeHeight-1, rkeys, rvalues, parallelThreshold)
+	}
 	if lErr != nil || rErr != nil {
 		var merr *multierror.Error
 		if lErr != nil {
@@ -304,6 +327,15 @@ func constructSubtrie(treeHeight int, nodeHeight int, keys [][]byte, values [][]
 	return node.NewInterimNode(nodeHeight, lChild, rChild), nil
 }
 
+// isParallel returns true if the sub-tries with the given keys are worth updating concurrently,
+// i.e. if both of them are updated and there are at least parallelThreshold keys in total
+func isParallel(lkeys, rkeys [][]byte, parallelThreshold int) bool {
+	if parallelThreshold <= 0 || len(lkeys) == 0 || len(rkeys) == 0 {
+		return false
+	}
+	return len(lkeys)+len(rkeys) >= parallelThreshold
+}
+
 func (mt *MTrie) UnsafeProofs(keys [][]byte, proofs []*proof.Proof) error {
 	return mt.proofs(mt.root, keys, proofs)
 }
@@ -589,7 +621,7 @@ func (mt *MTrie) dumpAsJSON(n *node.Node, writer *bufio.Writer) error {
 //   * keys must have the same keyLength
 func constructTrieFromKeyValuePairs(keyByteSize int, keys [][]byte, values [][]byte) (*MTrie, error) {
 	treeHeight := 8 * keyByteSize
-	root, err := constructSubtrie(treeHeight, treeHeight, keys, values)
+	root, err := constructSubtrie(treeHeight, treeHeight, keys, values, DefaultParallelUpdateThreshold)
 	if err != nil {
 		return nil, fmt.Errorf("constructing trie from key-value pairs failed: %w", err)
 	}
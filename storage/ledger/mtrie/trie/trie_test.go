@@ -193,6 +193,32 @@ func Test_UnallocateRegisters(t *testing.T) {
 	require.Equal(t, expectedRootHashHex, hex.EncodeToString(updatedTrie.RootHash()))
 }
 
+// Test_ParallelUpdate tests that updating the sub-tries concurrently results in the same
+// root hashes as updating them serially, for any threshold.
+func Test_ParallelUpdate(t *testing.T) {
+	emptyTrie, err := trie.NewEmptyMTrie(ReferenceImplKeyByteSize)
+	require.NoError(t, err)
+
+	for _, threshold := range []int{1, 2, 16, trie.DefaultParallelUpdateThreshold, 1000} {
+		rng := &LinearCongruentialGenerator{seed: 0}
+		serialTrie, parallelTrie := emptyTrie, emptyTrie
+		for r := 0; r < 20; r++ {
+			keys, values := deduplicateRegisterWrites(sampleRandomRegisterWrites(rng, r*100))
+			// unallocate some of the registers
+			for i := 0; i < len(values)/10; i++ {
+				values[i] = []byte{}
+			}
+
+			serialTrie, err = trie.NewTrieWithParallelUpdate(serialTrie, keys, values, 0)
+			require.NoError(t, err)
+			parallelTrie, err = trie.NewTrieWithParallelUpdate(parallelTrie, keys, values, threshold)
+			require.NoError(t, err)
+			require.Equal(t, serialTrie.RootHash(), parallelTrie.RootHash(), "threshold %d, round %d", threshold, r)
+			require.Equal(t, serialTrie.AllocatedRegCount(), parallelTrie.AllocatedRegCount())
+		}
+	}
+}
+
 func uint2binary(integer uint16) []byte {
 	b := make([]byte, ReferenceImplKeyByteSize)
 	binary.BigEndian.PutUint16(b, integer)
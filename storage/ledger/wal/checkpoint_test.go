@@ -4,6 +4,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"path"
 	"testing"
@@ -194,6 +195,8 @@ func Test_Checkpointing(t *testing.T) {
 
 				checkpointer, err := wal2.NewCheckpointer()
 				require.NoError(t, err)
+				// checkpoints are copied as standalone files below, so they must not be incremental
+				checkpointer.SetMaxIncrementalCheckpoints(0)
 
 				// create checkpoint at 5 to use it as a root
 				err = checkpointer.Checkpoint(5, func() (io.WriteCloser, error) {
@@ -511,6 +514,150 @@ func TestCheckpointsVersions(t *testing.T) {
 	})
 }
 
+func Test_IncrementalCheckpointing(t *testing.T) {
+
+	unittest.RunWithTempDir(t, func(dir string) {
+
+		f, err := mtrie.NewMForest(keyByteSize, dir, size*10, metricsCollector, func(tree *trie.MTrie) error { return nil })
+		require.NoError(t, err)
+
+		var stateCommitment = f.GetEmptyRootHash()
+
+		//saved data after updates
+		savedData := make(map[string]map[string][]byte)
+
+		wal, err := realWAL.NewWAL(nil, nil, dir, size*10, keyByteSize, segmentSize)
+		require.NoError(t, err)
+
+		for i := 0; i < size; i++ {
+			keys := utils.GetRandomKeysFixedN(numInsPerStep, keyByteSize)
+			values := utils.GetRandomValues(len(keys), valueMaxByteSize, valueMaxByteSize)
+
+			err = wal.RecordUpdate(stateCommitment, keys, values)
+			require.NoError(t, err)
+
+			newTrie, err := f.Update(stateCommitment, keys, values)
+			require.NoError(t, err)
+			stateCommitment = newTrie.RootHash()
+
+			data := make(map[string][]byte, len(keys))
+			for j, key := range keys {
+				data[string(key)] = values[j]
+			}
+			savedData[string(stateCommitment)] = data
+		}
+		err = wal.Close()
+		require.NoError(t, err)
+
+		require.FileExists(t, path.Join(dir, "00000008")) //make sure we have enough segments saved
+
+		requireForestContains := func(t *testing.T, forest *mtrie.MForest) {
+			for stateCommitment, data := range savedData {
+				keys := make([][]byte, 0, len(data))
+				for keyString := range data {
+					keys = append(keys, []byte(keyString))
+				}
+				registerValues, err := forest.Read([]byte(stateCommitment), keys)
+				require.NoError(t, err)
+				for i, key := range keys {
+					require.Equal(t, data[string(key)], registerValues[i])
+				}
+			}
+		}
+
+		replay := func(t *testing.T) *mtrie.MForest {
+			forest, err := mtrie.NewMForest(keyByteSize, dir, size*10, metricsCollector, func(tree *trie.MTrie) error { return nil })
+			require.NoError(t, err)
+
+			wal, err := realWAL.NewWAL(nil, nil, dir, size*10, keyByteSize, segmentSize)
+			require.NoError(t, err)
+			defer wal.Close()
+
+			err = wal.Replay(
+				func(forestSequencing *flattener.FlattenedForest) error {
+					return loadIntoForest(forest, forestSequencing)
+				},
+				func(commitment flow.StateCommitment, keys [][]byte, values [][]byte) error {
+					_, err := forest.Update(commitment, keys, values)
+					return err
+				},
+				func(commitment flow.StateCommitment) error {
+					return fmt.Errorf("I should fail as there should be no deletions")
+				},
+			)
+			require.NoError(t, err)
+			return forest
+		}
+
+		t.Run("create full and incremental checkpoint", func(t *testing.T) {
+			wal, err := realWAL.NewWAL(nil, nil, dir, size*10, keyByteSize, segmentSize)
+			require.NoError(t, err)
+			defer wal.Close()
+
+			checkpointer, err := wal.NewCheckpointer()
+			require.NoError(t, err)
+
+			for _, to := range []int{5, 8} {
+				err = checkpointer.Checkpoint(to, func() (io.WriteCloser, error) {
+					return checkpointer.CheckpointWriter(to)
+				})
+				require.NoError(t, err)
+			}
+
+			checkpoints, err := checkpointer.Checkpoints()
+			require.NoError(t, err)
+			require.Equal(t, []int{5, 8}, checkpoints)
+
+			// the incremental checkpoint is loaded together with its base
+			base, err := checkpointer.LoadCheckpoint(5)
+			require.NoError(t, err)
+			incremental, err := checkpointer.LoadCheckpoint(8)
+			require.NoError(t, err)
+			require.Greater(t, len(incremental.Nodes), len(base.Nodes))
+			require.Equal(t, base.Nodes, incremental.Nodes[:len(base.Nodes)])
+
+			// the incremental checkpoint is smaller than a full checkpoint of the same forest
+			tries, err := flattener.RebuildTries(incremental)
+			require.NoError(t, err)
+			forest, err := mtrie.NewMForest(keyByteSize, dir, size*10, metricsCollector, func(tree *trie.MTrie) error { return nil })
+			require.NoError(t, err)
+			require.NoError(t, forest.AddTries(tries))
+			full, err := flattener.FlattenForest(forest)
+			require.NoError(t, err)
+
+			fullPath := path.Join(dir, "full")
+			writer, err := realWAL.CreateCheckpointWriterForFile(fullPath)
+			require.NoError(t, err)
+			require.NoError(t, realWAL.StoreCheckpoint(full, writer))
+			require.NoError(t, writer.Close())
+
+			fullInfo, err := os.Stat(fullPath)
+			require.NoError(t, err)
+			incrementalInfo, err := os.Stat(path.Join(dir, "checkpoint.00000008"))
+			require.NoError(t, err)
+			require.Less(t, incrementalInfo.Size(), fullInfo.Size())
+			require.NoError(t, os.Remove(fullPath))
+		})
+
+		t.Run("replay incremental checkpoint", func(t *testing.T) {
+			requireForestContains(t, replay(t))
+		})
+
+		t.Run("fall back to older checkpoint if corrupted", func(t *testing.T) {
+			filename := path.Join(dir, "checkpoint.00000008")
+			content, err := ioutil.ReadFile(filename)
+			require.NoError(t, err)
+			content[len(content)/2] ^= 0xff
+			require.NoError(t, ioutil.WriteFile(filename, content, 0644))
+
+			_, err = realWAL.LoadCheckpoint(filename)
+			require.Error(t, err)
+
+			requireForestContains(t, replay(t))
+		})
+	})
+}
+
 func loadIntoForest(forest *mtrie.MForest, forestSequencing *flattener.FlattenedForest) error {
 	tries, err := flattener.RebuildTries(forestSequencing)
 	if err != nil {
@@ -2,10 +2,15 @@ package wal
 
 import (
 	"bufio"
+	"bytes"
+	"errors"
 	"fmt"
+	"hash/crc32"
 	"io"
 	"os"
 	"path"
+	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -25,32 +30,74 @@ const MagicBytes uint16 = 0x2137
 const VersionV1 uint16 = 0x01
 const VersionV2 uint16 = 0x02
 
+// VersionV3 checkpoints can be incremental, and end with a checksum of the file
+const VersionV3 uint16 = 0x03
+
 const RootCheckpointFilename = "root.checkpoint"
 
+// DefaultMaxIncrementalCheckpoints is the default number of consecutive incremental checkpoints,
+// after which a full checkpoint is created
+const DefaultMaxIncrementalCheckpoints = 10
+
+// checkpointHeaderSize is the size of the header of V1 and V2 checkpoints: magic bytes, version, node count and trie count
+const checkpointHeaderSize = 2 + 2 + 8 + 2
+
+// checkpointHeaderSizeV3 is the size of the header of V3 checkpoints, which adds the base checkpoint and its node count
+const checkpointHeaderSizeV3 = checkpointHeaderSize + 8 + 8
+
+const checksumSize = 4
+
+// noBaseCheckpoint is the base checkpoint of full checkpoints
+const noBaseCheckpoint = -1
+
+var crc32Table = crc32.MakeTable(crc32.Castagnoli)
+
 type Checkpointer struct {
-	dir            string
-	wal            *LedgerWAL
-	keyByteSize    int
-	forestCapacity int
+	dir                       string
+	wal                       *LedgerWAL
+	keyByteSize               int
+	forestCapacity            int
+	maxIncrementalCheckpoints int
+	workers                   int
 }
 
 func NewCheckpointer(wal *LedgerWAL, keyByteSize int, forestCapacity int) *Checkpointer {
 	return &Checkpointer{
-		dir:            wal.wal.Dir(),
-		wal:            wal,
-		keyByteSize:    keyByteSize,
-		forestCapacity: forestCapacity,
+		dir:                       wal.dir,
+		wal:                       wal,
+		keyByteSize:               keyByteSize,
+		forestCapacity:            forestCapacity,
+		maxIncrementalCheckpoints: DefaultMaxIncrementalCheckpoints,
+		workers:                   runtime.NumCPU(),
 	}
 }
 
+// SetMaxIncrementalCheckpoints sets the number of consecutive incremental checkpoints after which a full
+// checkpoint is created. Zero disables incremental checkpoints.
+func (c *Checkpointer) SetMaxIncrementalCheckpoints(max int) {
+	c.maxIncrementalCheckpoints = max
+}
+
 // LatestCheckpoint returns number of latest checkpoint or -1 if there are no checkpoints
 func (c *Checkpointer) LatestCheckpoint() (int, error) {
+	checkpoints, err := c.Checkpoints()
+	if err != nil {
+		return -1, err
+	}
+	if len(checkpoints) == 0 {
+		return -1, nil
+	}
+	return checkpoints[len(checkpoints)-1], nil
+}
+
+// Checkpoints returns the numbers of all checkpoints in ascending order
+func (c *Checkpointer) Checkpoints() ([]int, error) {
 
 	files, err := fileutil.ReadDir(c.dir)
 	if err != nil {
-		return -1, err
+		return nil, err
 	}
-	last := -1
+	var checkpoints []int
 	for _, fn := range files {
 		if !strings.HasPrefix(fn, checkpointFilenamePrefix) {
 			continue
@@ -61,10 +108,11 @@ func (c *Checkpointer) LatestCheckpoint() (int, error) {
 			continue
 		}
 
-		last = k
+		checkpoints = append(checkpoints, k)
 	}
+	sort.Ints(checkpoints)
 
-	return last, nil
+	return checkpoints, nil
 }
 
 // NotCheckpointedSegments - returns numbers of segments which are not checkpointed yet,
@@ -76,7 +124,7 @@ func (c *Checkpointer) NotCheckpointedSegments() (from, to int, err error) {
 		return -1, -1, fmt.Errorf("cannot get last checkpoint: %w", err)
 	}
 
-	first, last, err := c.wal.wal.Segments()
+	first, last, err := c.wal.segments()
 	if err != nil {
 		return -1, -1, fmt.Errorf("cannot get range of segments: %w", err)
 	}
@@ -104,7 +152,12 @@ func (c *Checkpointer) NotCheckpointedSegments() (from, to int, err error) {
 	return latestCheckpoint + 1, last, nil
 }
 
-// Checkpoint creates new checkpoint stopping at given segment
+// Checkpoint creates new checkpoint stopping at given segment.
+//
+// If the forest is restored from a previous checkpoint, the new checkpoint is incremental: it only contains
+// the nodes created since, and references the previous checkpoint as its base, which must remain in the
+// directory of the checkpointer. Every maxIncrementalCheckpoints checkpoints, a full checkpoint is created instead,
+// so that nodes of tries evicted from the forest are eventually dropped.
 func (c *Checkpointer) Checkpoint(to int, targetWriter func() (io.WriteCloser, error)) error {
 
 	_, notCheckpointedTo, err := c.NotCheckpointedSegments()
@@ -132,18 +185,34 @@ func (c *Checkpointer) Checkpoint(to int, targetWriter func() (io.WriteCloser, e
 		return fmt.Errorf("cannot create mForest: %w", err)
 	}
 
+	base := noBaseCheckpoint
+	index := make(flattener.NodeIndex)
+
 	err = c.wal.replay(0, to,
-		func(forestSequencing *flattener.FlattenedForest) error {
-			tries, err := flattener.RebuildTries(forestSequencing)
+		func(checkpoint int, forestSequencing *flattener.FlattenedForest) error {
+			nodes, err := flattener.RebuildNodes(forestSequencing.Nodes)
 			if err != nil {
 				return err
 			}
-			for _, t := range tries {
-				err := mForest.AddTrie(t)
+			for _, storableTrie := range forestSequencing.Tries {
+				t, err := trie.NewMTrie(nodes[storableTrie.RootIndex])
+				if err != nil {
+					return err
+				}
+				err = mForest.AddTrie(t)
 				if err != nil {
 					return err
 				}
 			}
+
+			incremental, err := c.continuesChain(checkpoint)
+			if err != nil {
+				return err
+			}
+			if incremental {
+				base = checkpoint
+				index = flattener.NewNodeIndex(nodes)
+			}
 			return nil
 		},
 		func(commitment flow.StateCommitment, keys [][]byte, values [][]byte) error {
@@ -157,7 +226,13 @@ func (c *Checkpointer) Checkpoint(to int, targetWriter func() (io.WriteCloser, e
 		return fmt.Errorf("cannot replay WAL: %w", err)
 	}
 
-	forestSequencing, err := flattener.FlattenForest(mForest)
+	// the index contains the nodes of the base checkpoint and the nil node
+	baseNodes := uint64(len(index))
+	if baseNodes > 0 {
+		baseNodes--
+	}
+
+	storableNodes, storableTries, err := flattener.FlattenForestIncrementally(mForest, index, c.workers)
 	if err != nil {
 		return fmt.Errorf("cannot get storables: %w", err)
 	}
@@ -168,9 +243,29 @@ func (c *Checkpointer) Checkpoint(to int, targetWriter func() (io.WriteCloser, e
 	}
 	defer writer.Close()
 
-	err = StoreCheckpoint(forestSequencing, writer)
+	return storeCheckpoint(base, baseNodes, storableNodes, storableTries, writer)
+}
 
-	return err
+// continuesChain returns whether the next checkpoint can be an incremental checkpoint on top of the given
+// checkpoint, which requires it to be a numbered checkpoint with less than maxIncrementalCheckpoints
+// incremental checkpoints below it.
+func (c *Checkpointer) continuesChain(checkpoint int) (bool, error) {
+	if checkpoint == noBaseCheckpoint || c.maxIncrementalCheckpoints < 1 {
+		return false, nil
+	}
+	length := 0
+	for checkpoint != noBaseCheckpoint {
+		header, err := readCheckpointHeader(path.Join(c.dir, numberToFilename(checkpoint)))
+		if err != nil {
+			return false, fmt.Errorf("cannot read header of checkpoint %d: %w", checkpoint, err)
+		}
+		if header.base == noBaseCheckpoint {
+			break
+		}
+		length++
+		checkpoint = header.base
+	}
+	return length < c.maxIncrementalCheckpoints, nil
 }
 
 func numberToFilenamePart(n int) string {
@@ -223,25 +318,36 @@ func CreateCheckpointWriterForFile(filename string) (io.WriteCloser, error) {
 	}, nil
 }
 
+// StoreCheckpoint writes a full checkpoint of the flattened forest
 func StoreCheckpoint(forestSequencing *flattener.FlattenedForest, writer io.WriteCloser) error {
-	storableNodes := forestSequencing.Nodes
-	storableTries := forestSequencing.Tries
-	header := make([]byte, 4+8+2)
+	// 0 element = nil, we don't need to store it
+	return storeCheckpoint(noBaseCheckpoint, 0, forestSequencing.Nodes[1:], forestSequencing.Tries, writer)
+}
+
+// storeCheckpoint writes a checkpoint with the given nodes and tries, which is incremental if it has a
+// base checkpoint. The nodes of an incremental checkpoint are numbered after the baseNodes nodes of its base.
+func storeCheckpoint(base int, baseNodes uint64, storableNodes []*flattener.StorableNode, storableTries []*flattener.StorableTrie, writer io.WriteCloser) error {
+	// the checksum covers all data written before it
+	crc := crc32.New(crc32Table)
+	w := io.MultiWriter(writer, crc)
+
+	header := make([]byte, checkpointHeaderSizeV3)
 
 	pos := writeUint16(header, 0, MagicBytes)
-	pos = writeUint16(header, pos, VersionV2)
-	pos = writeUint64(header, pos, uint64(len(storableNodes)-1)) // -1 to account for 0 node meaning nil
-	writeUint16(header, pos, uint16(len(storableTries)))
+	pos = writeUint16(header, pos, VersionV3)
+	pos = writeUint64(header, pos, uint64(len(storableNodes)))
+	pos = writeUint16(header, pos, uint16(len(storableTries)))
+	pos = writeUint64(header, pos, uint64(int64(base)))
+	writeUint64(header, pos, baseNodes)
 
-	_, err := writer.Write(header)
+	_, err := w.Write(header)
 	if err != nil {
 		return fmt.Errorf("cannot write checkpoint header: %w", err)
 	}
 
-	// 0 element = nil, we don't need to store it
-	for i := 1; i < len(storableNodes); i++ {
-		bytes := EncodeStorableNode(storableNodes[i])
-		_, err = writer.Write(bytes)
+	for _, storableNode := range storableNodes {
+		bytes := EncodeStorableNode(storableNode)
+		_, err = w.Write(bytes)
 		if err != nil {
 			return fmt.Errorf("error while writing node date: %w", err)
 		}
@@ -249,12 +355,17 @@ func StoreCheckpoint(forestSequencing *flattener.FlattenedForest, writer io.Writ
 
 	for _, storableTrie := range storableTries {
 		bytes := EncodeStorableTrie(storableTrie)
-		_, err = writer.Write(bytes)
+		_, err = w.Write(bytes)
 		if err != nil {
 			return fmt.Errorf("error while writing trie date: %w", err)
 		}
 	}
 
+	_, err = writer.Write(crc.Sum(nil))
+	if err != nil {
+		return fmt.Errorf("cannot write checkpoint checksum: %w", err)
+	}
+
 	return nil
 }
 
@@ -278,6 +389,9 @@ func (c *Checkpointer) HasRootCheckpoint() (bool, error) {
 	}
 }
 
+// LoadCheckpoint loads the checkpoint in the given file. For incremental checkpoints, the base checkpoints
+// are loaded from the same directory, and the returned forest contains the nodes of all of them.
+// Corrupted checkpoints are detected by their checksum.
 func LoadCheckpoint(filepath string) (*flattener.FlattenedForest, error) {
 	file, err := os.Open(filepath)
 	if err != nil {
@@ -287,40 +401,45 @@ func LoadCheckpoint(filepath string) (*flattener.FlattenedForest, error) {
 		_ = file.Close()
 	}()
 
-	reader := bufio.NewReader(file)
-
-	header := make([]byte, 4+8+2)
-
-	_, err = io.ReadFull(reader, header)
+	header, err := readHeader(file)
 	if err != nil {
-		return nil, fmt.Errorf("cannot read header bytes: %w", err)
+		return nil, err
 	}
 
-	magicBytes, pos := readUint16(header, 0)
-	version, pos := readUint16(header, pos)
-	nodesCount, pos := readUint64(header, pos)
-	triesCount, _ := readUint16(header, pos)
-
-	if magicBytes != MagicBytes {
-		return nil, fmt.Errorf("unknown file format. Magic constant %x does not match expected %x", magicBytes, MagicBytes)
+	if header.version == VersionV3 {
+		err = verifyChecksum(file)
+		if err != nil {
+			return nil, fmt.Errorf("cannot verify checkpoint %s: %w", filepath, err)
+		}
 	}
-	if version != VersionV1 && version != VersionV2 {
-		return nil, fmt.Errorf("unsupported file version %x ", version)
+
+	// nodes of the base checkpoints come first, the 0 index meaning nil
+	nodes := []*flattener.StorableNode{nil}
+	if header.base != noBaseCheckpoint {
+		baseForest, err := LoadCheckpoint(path.Join(path.Dir(filepath), numberToFilename(header.base)))
+		if err != nil {
+			return nil, fmt.Errorf("cannot load base checkpoint %d: %w", header.base, err)
+		}
+		if uint64(len(baseForest.Nodes)-1) != header.baseNodes {
+			return nil, fmt.Errorf("base checkpoint %d has %d nodes, expected %d", header.base, len(baseForest.Nodes)-1, header.baseNodes)
+		}
+		nodes = baseForest.Nodes
 	}
 
-	nodes := make([]*flattener.StorableNode, nodesCount+1) //+1 for 0 index meaning nil
-	tries := make([]*flattener.StorableTrie, triesCount)
+	reader := bufio.NewReader(file)
 
-	for i := uint64(1); i <= nodesCount; i++ {
+	for i := uint64(1); i <= header.nodesCount; i++ {
 		storableNode, err := ReadStorableNode(reader)
 		if err != nil {
 			return nil, fmt.Errorf("cannot read storable node %d: %w", i, err)
 		}
-		nodes[i] = storableNode
+		nodes = append(nodes, storableNode)
 	}
 
-	for i := uint16(0); i < triesCount; i++ {
-		storableTrie, err := ReadStorableTrie(reader, version)
+	tries := make([]*flattener.StorableTrie, header.triesCount)
+
+	for i := uint16(0); i < header.triesCount; i++ {
+		storableTrie, err := ReadStorableTrie(reader, header.version)
 		if err != nil {
 			return nil, fmt.Errorf("cannot read storable trie %d: %w", i, err)
 		}
@@ -333,3 +452,115 @@ func LoadCheckpoint(filepath string) (*flattener.FlattenedForest, error) {
 	}, nil
 
 }
+
+// checkpointHeader is the header of a checkpoint file
+type checkpointHeader struct {
+	version    uint16
+	nodesCount uint64
+	triesCount uint16
+	base       int    // base checkpoint of an incremental checkpoint, noBaseCheckpoint otherwise
+	baseNodes  uint64 // number of nodes up to the base checkpoint
+}
+
+// readCheckpointHeader reads the header of the checkpoint in the given file
+func readCheckpointHeader(filepath string) (*checkpointHeader, error) {
+	file, err := os.Open(filepath)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open checkpoint file %s: %w", filepath, err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	return readHeader(file)
+}
+
+// readHeader reads the header of a checkpoint, leaving the reader at the first node
+func readHeader(reader io.Reader) (*checkpointHeader, error) {
+	header := make([]byte, checkpointHeaderSize)
+
+	_, err := io.ReadFull(reader, header)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read header bytes: %w", err)
+	}
+
+	magicBytes, pos := readUint16(header, 0)
+	version, pos := readUint16(header, pos)
+	nodesCount, pos := readUint64(header, pos)
+	triesCount, _ := readUint16(header, pos)
+
+	if magicBytes != MagicBytes {
+		return nil, fmt.Errorf("unknown file format. Magic constant %x does not match expected %x", magicBytes, MagicBytes)
+	}
+	if version != VersionV1 && version != VersionV2 && version != VersionV3 {
+		return nil, fmt.Errorf("unsupported file version %x ", version)
+	}
+
+	h := &checkpointHeader{
+		version:    version,
+		nodesCount: nodesCount,
+		triesCount: triesCount,
+		base:       noBaseCheckpoint,
+	}
+	if version != VersionV3 {
+		return h, nil
+	}
+
+	extension := make([]byte, checkpointHeaderSizeV3-checkpointHeaderSize)
+	_, err = io.ReadFull(reader, extension)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read header bytes: %w", err)
+	}
+	base, pos := readUint64(extension, 0)
+	h.base = int(int64(base))
+	h.baseNodes, _ = readUint64(extension, pos)
+
+	if h.base < noBaseCheckpoint {
+		return nil, fmt.Errorf("invalid base checkpoint %d", h.base)
+	}
+
+	return h, nil
+}
+
+// verifyChecksum verifies the checksum at the end of the file against the data before it,
+// and leaves the file at the position it was at before.
+func verifyChecksum(file *os.File) error {
+	pos, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fmt.Errorf("cannot get file position: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("cannot get file size: %w", err)
+	}
+	if info.Size() < checkpointHeaderSizeV3+checksumSize {
+		return errors.New("file too short")
+	}
+
+	_, err = file.Seek(0, io.SeekStart)
+	if err != nil {
+		return fmt.Errorf("cannot seek file: %w", err)
+	}
+
+	// the checksum is read through the same buffered reader, which reads ahead of the data
+	reader := bufio.NewReader(file)
+	crc := crc32.New(crc32Table)
+	_, err = io.CopyN(crc, reader, info.Size()-checksumSize)
+	if err != nil {
+		return fmt.Errorf("cannot read file: %w", err)
+	}
+
+	checksum := make([]byte, checksumSize)
+	_, err = io.ReadFull(reader, checksum)
+	if err != nil {
+		return fmt.Errorf("cannot read checksum: %w", err)
+	}
+	if !bytes.Equal(checksum, crc.Sum(nil)) {
+		return fmt.Errorf("checksum mismatch: %x != %x", checksum, crc.Sum(nil))
+	}
+
+	_, err = file.Seek(pos, io.SeekStart)
+	if err != nil {
+		return fmt.Errorf("cannot seek file: %w", err)
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"strings"
 	"testing"
 	"time"
 
@@ -98,7 +99,8 @@ func Test_Compactor(t *testing.T) {
 		})
 
 		t.Run("remove unnecessary files", func(t *testing.T) {
-			// Remove all files apart from target checkpoint and WAL segments ahead of it
+			// Remove all files apart from checkpoints, which incremental checkpoints are based on,
+			// and WAL segments ahead of the target checkpoint
 			// We know their names, so just hardcode them
 			dirF, _ := os.Open(dir)
 			files, _ := dirF.Readdir(0)
@@ -107,7 +109,7 @@ func Test_Compactor(t *testing.T) {
 
 				name := fileInfo.Name()
 
-				if name != "checkpoint.00000009" &&
+				if !strings.HasPrefix(name, checkpointFilenamePrefix) &&
 					name != "00000010" {
 					err := os.Remove(path.Join(dir, name))
 					require.NoError(t, err)
@@ -378,7 +378,7 @@ func ReadStorableTrie(reader io.Reader, version uint16) (*flattener.StorableTrie
 				return nil, fmt.Errorf("cannot read parentRootHash data: %w", err)
 			}
 		}
-	case VersionV2:
+	case VersionV2, VersionV3:
 		{
 			// read root uint64 RootIndex
 			buf := make([]byte, 8)
@@ -1,9 +1,13 @@
 package wal
 
 import (
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"strconv"
 
 	"github.com/go-kit/kit/log"
+	"github.com/hashicorp/go-multierror"
 	"github.com/prometheus/client_golang/prometheus"
 	prometheusWAL "github.com/prometheus/tsdb/wal"
 
@@ -15,8 +19,12 @@ import (
 
 const SegmentSize = 32 * 1024 * 1024
 
+// ErrReadOnly is returned when recording changes in a LedgerWAL which was opened read-only
+var ErrReadOnly = errors.New("LedgerWAL is read-only")
+
 type LedgerWAL struct {
-	wal            *prometheusWAL.WAL
+	wal            *prometheusWAL.WAL // nil if read-only
+	dir            string
 	forestCapacity int
 	keyByteSize    int
 }
@@ -29,12 +37,28 @@ func NewWAL(logger log.Logger, reg prometheus.Registerer, dir string, forestCapa
 	}
 	return &LedgerWAL{
 		wal:            w,
+		dir:            dir,
 		forestCapacity: forestCapacity,
 		keyByteSize:    keyByteSize,
 	}, nil
 }
 
+// NewReadOnlyWAL opens the LedgerWAL in the given directory for replaying only. Unlike NewWAL, it does not
+// create a new segment or modify the directory in any other way, so that it can be used to inspect the
+// execution state of a node.
+func NewReadOnlyWAL(dir string, forestCapacity int, keyByteSize int) *LedgerWAL {
+	return &LedgerWAL{
+		dir:            dir,
+		forestCapacity: forestCapacity,
+		keyByteSize:    keyByteSize,
+	}
+}
+
 func (w *LedgerWAL) RecordUpdate(stateCommitment flow.StateCommitment, keys [][]byte, values [][]byte) error {
+	if w.wal == nil {
+		return ErrReadOnly
+	}
+
 	bytes := EncodeUpdate(stateCommitment, keys, values)
 
 	err := w.wal.Log(bytes)
@@ -46,6 +70,10 @@ func (w *LedgerWAL) RecordUpdate(stateCommitment flow.StateCommitment, keys [][]
 }
 
 func (w *LedgerWAL) RecordDelete(stateCommitment flow.StateCommitment) error {
+	if w.wal == nil {
+		return ErrReadOnly
+	}
+
 	bytes := EncodeDelete(stateCommitment)
 
 	err := w.wal.Log(bytes)
@@ -86,11 +114,15 @@ func (w *LedgerWAL) Replay(
 	updateFn func(flow.StateCommitment, [][]byte, [][]byte) error,
 	deleteFn func(flow.StateCommitment) error,
 ) error {
-	from, to, err := w.wal.Segments()
+	from, to, err := w.segments()
 	if err != nil {
 		return err
 	}
-	return w.replay(from, to, checkpointFn, updateFn, deleteFn, true)
+	return w.replay(from, to,
+		func(_ int, forestSequencing *flattener.FlattenedForest) error {
+			return checkpointFn(forestSequencing)
+		},
+		updateFn, deleteFn, true)
 }
 
 func (w *LedgerWAL) ReplayLogsOnly(
@@ -98,16 +130,23 @@ func (w *LedgerWAL) ReplayLogsOnly(
 	updateFn func(flow.StateCommitment, [][]byte, [][]byte) error,
 	deleteFn func(flow.StateCommitment) error,
 ) error {
-	from, to, err := w.wal.Segments()
+	from, to, err := w.segments()
 	if err != nil {
 		return err
 	}
-	return w.replay(from, to, checkpointFn, updateFn, deleteFn, false)
+	return w.replay(from, to,
+		func(_ int, forestSequencing *flattener.FlattenedForest) error {
+			return checkpointFn(forestSequencing)
+		},
+		updateFn, deleteFn, false)
 }
 
+// replay replays the segments in the given range. If checkpoints are used, the forest is restored from the latest
+// valid checkpoint within the range first, falling back to older checkpoints if the later ones are corrupted.
+// The checkpointFn receives the number of the loaded checkpoint, or -1 for the root checkpoint.
 func (w *LedgerWAL) replay(
 	from, to int,
-	checkpointFn func(forestSequencing *flattener.FlattenedForest) error,
+	checkpointFn func(checkpoint int, forestSequencing *flattener.FlattenedForest) error,
 	updateFn func(flow.StateCommitment, [][]byte, [][]byte) error,
 	deleteFn func(flow.StateCommitment) error,
 	useCheckpoints bool,
@@ -126,34 +165,48 @@ func (w *LedgerWAL) replay(
 	}
 
 	if useCheckpoints {
-
-		latestCheckpoint, err := checkpointer.LatestCheckpoint()
+		checkpoints, err := checkpointer.Checkpoints()
 		if err != nil {
-			return fmt.Errorf("cannot get latest checkpoint: %w", err)
+			return fmt.Errorf("cannot get checkpoints: %w", err)
 		}
 
-		if latestCheckpoint != -1 && latestCheckpoint+1 >= from { //+1 to account for connected checkpoint and segments
-			forestSequencing, err := checkpointer.LoadCheckpoint(latestCheckpoint)
+		var loadErr error
+		for i := len(checkpoints) - 1; i >= 0; i-- {
+			checkpoint := checkpoints[i]
+			if checkpoint > to {
+				continue
+			}
+			if checkpoint+1 < from { //+1 to account for connected checkpoint and segments
+				break
+			}
+
+			forestSequencing, err := checkpointer.LoadCheckpoint(checkpoint)
 			if err != nil {
-				return fmt.Errorf("cannot load checkpoint %d: %w", latestCheckpoint, err)
+				// fall back to an older checkpoint, replaying more segments
+				loadErr = multierror.Append(loadErr, fmt.Errorf("cannot load checkpoint %d: %w", checkpoint, err))
+				continue
 			}
-			err = checkpointFn(forestSequencing)
+			err = checkpointFn(checkpoint, forestSequencing)
 			if err != nil {
 				return fmt.Errorf("error while handling checkpoint: %w", err)
 			}
 			loadedCheckpoint = true
+			startSegment = checkpoint + 1
+			break
 		}
 
-		if loadedCheckpoint && to == latestCheckpoint {
-			return nil
+		// without a valid checkpoint, the forest can only be restored if all segments are available
+		if !loadedCheckpoint && loadErr != nil && from > 0 {
+			return fmt.Errorf("no valid checkpoint to replay segments %d to %d: %w", from, to, loadErr)
 		}
 
-		if loadedCheckpoint {
-			startSegment = latestCheckpoint + 1
+		if loadedCheckpoint && to < startSegment {
+			return nil
 		}
 	}
 
-	if !loadedCheckpoint && startSegment == 0 {
+	// a read-only LedgerWAL may not have any segments, which is -1
+	if !loadedCheckpoint && startSegment <= 0 {
 		hasRootCheckpoint, err := checkpointer.HasRootCheckpoint()
 		if err != nil {
 			return fmt.Errorf("cannot check root checkpoint existence: %w", err)
@@ -163,7 +216,7 @@ func (w *LedgerWAL) replay(
 			if err != nil {
 				return fmt.Errorf("cannot load root checkpoint: %w", err)
 			}
-			err = checkpointFn(flattenedForest)
+			err = checkpointFn(noBaseCheckpoint, flattenedForest)
 			if err != nil {
 				return fmt.Errorf("error while handling root checkpoint: %w", err)
 			}
@@ -172,7 +225,7 @@ func (w *LedgerWAL) replay(
 	}
 
 	sr, err := prometheusWAL.NewSegmentsRangeReader(prometheusWAL.SegmentRange{
-		Dir:   w.wal.Dir(),
+		Dir:   w.dir,
 		First: startSegment,
 		Last:  to,
 	})
@@ -217,6 +270,36 @@ func (w *LedgerWAL) NewCheckpointer() (*Checkpointer, error) {
 	return NewCheckpointer(w, w.keyByteSize, w.forestCapacity), nil
 }
 
+// segments returns the range of the existing segments, which is -1, -1 if there are none
+func (w *LedgerWAL) segments() (first, last int, err error) {
+	if w.wal != nil {
+		return w.wal.Segments()
+	}
+
+	// segment files are named by their number, as in the prometheus WAL
+	files, err := ioutil.ReadDir(w.dir)
+	if err != nil {
+		return 0, 0, fmt.Errorf("cannot list segments: %w", err)
+	}
+	first, last = -1, -1
+	for _, file := range files {
+		k, err := strconv.Atoi(file.Name())
+		if err != nil {
+			continue
+		}
+		if first == -1 || k < first {
+			first = k
+		}
+		if k > last {
+			last = k
+		}
+	}
+	return first, last, nil
+}
+
 func (w *LedgerWAL) Close() error {
+	if w.wal == nil {
+		return nil
+	}
 	return w.wal.Close()
 }
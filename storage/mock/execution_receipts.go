@@ -12,6 +12,29 @@ type ExecutionReceipts struct {
 	mock.Mock
 }
 
+// AllByBlockID provides a mock function with given fields: blockID
+func (_m *ExecutionReceipts) AllByBlockID(blockID flow.Identifier) ([]*flow.ExecutionReceipt, error) {
+	ret := _m.Called(blockID)
+
+	var r0 []*flow.ExecutionReceipt
+	if rf, ok := ret.Get(0).(func(flow.Identifier) []*flow.ExecutionReceipt); ok {
+		r0 = rf(blockID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*flow.ExecutionReceipt)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(flow.Identifier) error); ok {
+		r1 = rf(blockID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ByBlockID provides a mock function with given fields: blockID
 func (_m *ExecutionReceipts) ByBlockID(blockID flow.Identifier) (*flow.ExecutionReceipt, error) {
 	ret := _m.Called(blockID)
@@ -72,6 +95,20 @@ func (_m *ExecutionReceipts) Index(blockID flow.Identifier, resultID flow.Identi
 	return r0
 }
 
+// IndexAll provides a mock function with given fields: blockID, receiptID
+func (_m *ExecutionReceipts) IndexAll(blockID flow.Identifier, receiptID flow.Identifier) error {
+	ret := _m.Called(blockID, receiptID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(flow.Identifier, flow.Identifier) error); ok {
+		r0 = rf(blockID, receiptID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Store provides a mock function with given fields: result
 func (_m *ExecutionReceipts) Store(result *flow.ExecutionReceipt) error {
 	ret := _m.Called(result)
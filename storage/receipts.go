@@ -19,4 +19,10 @@ type ExecutionReceipts interface {
 
 	// ByBlockID retrieves an execution receipt by block ID.
 	ByBlockID(blockID flow.Identifier) (*flow.ExecutionReceipt, error)
+
+	// IndexAll adds an execution receipt to the receipts of all executors of the block.
+	IndexAll(blockID flow.Identifier, receiptID flow.Identifier) error
+
+	// AllByBlockID retrieves the execution receipts of all executors of the block.
+	AllByBlockID(blockID flow.Identifier) ([]*flow.ExecutionReceipt, error)
 }
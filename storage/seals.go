@@ -18,4 +18,10 @@ type Seals interface {
 
 	// ByBlockID retrieves the last seal in the chain of seals for the block.
 	ByBlockID(sealedID flow.Identifier) (*flow.Seal, error)
+
+	// IndexBySealedBlockID indexes the seal by the ID of the block it seals.
+	IndexBySealedBlockID(seal *flow.Seal) error
+
+	// BySealedBlockID retrieves the seal that sealed the block.
+	BySealedBlockID(sealedID flow.Identifier) (*flow.Seal, error)
 }
@@ -0,0 +1,19 @@
+package storage
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// SlashingEvidence represents persistent storage for evidence of slashable offences.
+type SlashingEvidence interface {
+
+	// Store stores the evidence. There is at most one piece of evidence per offender, view and
+	// kind of offence, and storing further evidence for them returns ErrAlreadyExists.
+	Store(evidence *flow.SlashingEvidence) error
+
+	// ByOffender returns the evidence against the given node, ordered by view.
+	ByOffender(offenderID flow.Identifier) ([]*flow.SlashingEvidence, error)
+
+	// All returns all evidence, ordered by offender and view.
+	All() ([]*flow.SlashingEvidence, error)
+}
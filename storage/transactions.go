@@ -13,3 +13,24 @@ type Transactions interface {
 	// ByID returns the transaction for the given fingerprint.
 	ByID(txID flow.Identifier) (*flow.TransactionBody, error)
 }
+
+// AccountTransaction references a transaction that involves an account, together with the height of
+// the block that included it.
+type AccountTransaction struct {
+	Height        uint64
+	TransactionID flow.Identifier
+}
+
+// AccountTransactions represents persistent storage for an index of the transactions of finalized
+// blocks by the accounts involved in them as payer, proposer or authorizer.
+type AccountTransactions interface {
+
+	// Index indexes the given transactions, included by the block at the given height, by each
+	// account involved in them
+	Index(height uint64, txs []*flow.TransactionBody) error
+
+	// ByAddress returns the transactions involving the given account that were included by blocks
+	// within the given height range (inclusive), ordered by height. The first offset transactions
+	// are skipped and at most limit transactions are returned, a limit of 0 returns all of them.
+	ByAddress(address flow.Address, startHeight, endHeight uint64, offset, limit uint) ([]AccountTransaction, error)
+}